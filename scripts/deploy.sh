@@ -148,6 +148,9 @@ fi
 if [ -n "$ROOT_FOLDER_ID" ]; then
     ENV_VARS="${ENV_VARS},ROOT_FOLDER_ID=${ROOT_FOLDER_ID}"
 fi
+if [ -n "$HOSTED_DOMAIN" ]; then
+    ENV_VARS="${ENV_VARS},HOSTED_DOMAIN=${HOSTED_DOMAIN}"
+fi
 
 # Deploy to Cloud Run
 echo "Deploying to Cloud Run..."
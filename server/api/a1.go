@@ -0,0 +1,147 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// a1ColumnsPattern matches the column letters of an A1 range such as "C5" or "C5:E9"
+var a1ColumnsPattern = regexp.MustCompile(`^([A-Za-z]+)\d*(?::([A-Za-z]+)\d*)?$`)
+
+// a1Columns returns the zero-based first and last column indices covered by an A1 range
+func a1Columns(rangeStr string) (int, int, bool) {
+	m := a1ColumnsPattern.FindStringSubmatch(rangeStr)
+	if m == nil {
+		return 0, 0, false
+	}
+	first := columnIndex(m[1])
+	last := first
+	if m[2] != "" {
+		last = columnIndex(m[2])
+	}
+	return first, last, true
+}
+
+// a1Start returns the zero-based row and column of the top-left cell of an A1
+// range such as "B3:F" (a missing row number means row 1)
+func a1Start(rangeStr string) (int, int, bool) {
+	m := a1StartPattern.FindStringSubmatch(rangeStr)
+	if m == nil {
+		return 0, 0, false
+	}
+	row := 0
+	if m[2] != "" {
+		n, err := strconv.Atoi(m[2])
+		if err != nil || n < 1 {
+			return 0, 0, false
+		}
+		row = n - 1
+	}
+	return row, columnIndex(m[1]), true
+}
+
+// a1StartPattern matches the leading cell of an A1 range
+var a1StartPattern = regexp.MustCompile(`^([A-Za-z]+)(\d*)(?::[A-Za-z]*\d*)?$`)
+
+// columnIndex converts column letters ("A", "AB") to a zero-based index
+func columnIndex(letters string) int {
+	idx := 0
+	for _, c := range strings.ToUpper(letters) {
+		idx = idx*26 + int(c-'A'+1)
+	}
+	return idx - 1
+}
+
+// columnLetter converts a zero-based column index to its A1 letters (0 = "A", 26 = "AA")
+func columnLetter(idx int) string {
+	letters := ""
+	for idx >= 0 {
+		letters = string(rune('A'+idx%26)) + letters
+		idx = idx/26 - 1
+	}
+	return letters
+}
+
+// maxColumnLetters bounds column references to what Sheets supports (ZZZ)
+const maxColumnLetters = 3
+
+// a1Bounds is the area covered by an A1 range as zero-based inclusive indices.
+// -1 marks an open side, e.g. the rows of "A:C" or the end row of "A2:C".
+type a1Bounds struct {
+	startRow, startCol int
+	endRow, endCol     int
+}
+
+// a1PartPattern matches one side of an A1 range: column letters, row number, or both
+var a1PartPattern = regexp.MustCompile(`^([A-Za-z]*)(\d*)$`)
+
+// parseA1Part returns the zero-based row and column of one side of a range (-1 when absent)
+func parseA1Part(part string) (row, col int, err error) {
+	m := a1PartPattern.FindStringSubmatch(part)
+	if part == "" || m == nil {
+		return 0, 0, fmt.Errorf("%q is not a cell, column, or row reference", part)
+	}
+	row, col = -1, -1
+	if m[1] != "" {
+		if len(m[1]) > maxColumnLetters {
+			return 0, 0, fmt.Errorf("column %q is out of range", m[1])
+		}
+		col = columnIndex(m[1])
+	}
+	if m[2] != "" {
+		n, err := strconv.Atoi(m[2])
+		if err != nil || n < 1 {
+			return 0, 0, fmt.Errorf("row %q is invalid (rows start at 1)", m[2])
+		}
+		row = n - 1
+	}
+	return row, col, nil
+}
+
+// parseA1Range checks the syntax of an A1 range without a sheet name, such as
+// "B2", "A2:C9", "A:C", "2:5", or "A2:C", and returns the area it covers
+func parseA1Range(rangeStr string) (a1Bounds, error) {
+	if rangeStr == "" {
+		return a1Bounds{}, fmt.Errorf("range is empty")
+	}
+	if strings.Contains(rangeStr, "!") {
+		return a1Bounds{}, fmt.Errorf("range %q must not include a sheet name", rangeStr)
+	}
+
+	start, end, isRange := strings.Cut(rangeStr, ":")
+	startRow, startCol, err := parseA1Part(start)
+	if err != nil {
+		return a1Bounds{}, fmt.Errorf("invalid range %q: %w", rangeStr, err)
+	}
+	if !isRange {
+		if startRow == -1 || startCol == -1 {
+			return a1Bounds{}, fmt.Errorf("invalid range %q: a single cell needs a column and a row, e.g. A1", rangeStr)
+		}
+		return a1Bounds{startRow, startCol, startRow, startCol}, nil
+	}
+
+	endRow, endCol, err := parseA1Part(end)
+	if err != nil {
+		return a1Bounds{}, fmt.Errorf("invalid range %q: %w", rangeStr, err)
+	}
+	if startCol == -1 && endCol != -1 || startRow == -1 && endRow != -1 && endCol == -1 {
+		return a1Bounds{}, fmt.Errorf("invalid range %q: both sides must use the same kind of reference", rangeStr)
+	}
+	if endCol != -1 && endCol < startCol || endRow != -1 && endRow < startRow {
+		return a1Bounds{}, fmt.Errorf("invalid range %q: end is before start", rangeStr)
+	}
+	return a1Bounds{startRow, startCol, endRow, endCol}, nil
+}
+
+// checkWithin reports an error when the range reaches past a sheet's grid
+func (b a1Bounds) checkWithin(rangeStr string, rowCount, colCount int) error {
+	if b.startRow >= rowCount || b.endRow >= rowCount {
+		return fmt.Errorf("range %q is past the last row of the sheet (%d)", rangeStr, rowCount)
+	}
+	if b.startCol >= colCount || b.endCol >= colCount {
+		return fmt.Errorf("range %q is past the last column of the sheet (%s)", rangeStr, columnLetter(colCount-1))
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestColumnLetter(t *testing.T) {
+	tests := []struct {
+		idx  int
+		want string
+	}{
+		{0, "A"},
+		{25, "Z"},
+		{26, "AA"},
+		{51, "AZ"},
+		{52, "BA"},
+		{701, "ZZ"},
+		{702, "AAA"},
+	}
+	for _, tt := range tests {
+		if got := columnLetter(tt.idx); got != tt.want {
+			t.Errorf("columnLetter(%d) = %q, want %q", tt.idx, got, tt.want)
+		}
+		if got := columnIndex(tt.want); got != tt.idx {
+			t.Errorf("columnIndex(%q) = %d, want %d", tt.want, got, tt.idx)
+		}
+	}
+}
+
+func TestParseA1Range(t *testing.T) {
+	valid := []struct {
+		in   string
+		want a1Bounds
+	}{
+		{"B2", a1Bounds{1, 1, 1, 1}},
+		{"A2:C9", a1Bounds{1, 0, 8, 2}},
+		{"a2:c9", a1Bounds{1, 0, 8, 2}},
+		{"A:C", a1Bounds{-1, 0, -1, 2}},
+		{"2:5", a1Bounds{1, -1, 4, -1}},
+		{"A2:C", a1Bounds{1, 0, -1, 2}},
+		{"ZZZ1", a1Bounds{0, 18277, 0, 18277}},
+	}
+	for _, tt := range valid {
+		got, err := parseA1Range(tt.in)
+		if err != nil {
+			t.Errorf("parseA1Range(%q) = %v, want it accepted", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseA1Range(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+
+	invalid := []struct {
+		in      string
+		wantMsg string
+	}{
+		{"", "empty"},
+		{"Grants!A1", "must not include a sheet name"},
+		{"A", "needs a column and a row"},
+		{"A0", "rows start at 1"},
+		{"AAAA1", "out of range"},
+		{"A1:", "not a cell, column, or row reference"},
+		{"A-1", "not a cell, column, or row reference"},
+		{"1A", "not a cell, column, or row reference"},
+		{"C2:A2", "end is before start"},
+		{"A5:A2", "end is before start"},
+		{"2:C", "same kind of reference"},
+	}
+	for _, tt := range invalid {
+		_, err := parseA1Range(tt.in)
+		if err == nil {
+			t.Errorf("parseA1Range(%q) accepted a malformed range", tt.in)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.wantMsg) {
+			t.Errorf("parseA1Range(%q) = %q, want it to mention %q", tt.in, err, tt.wantMsg)
+		}
+	}
+}
+
+func TestCheckWithin(t *testing.T) {
+	tests := []struct {
+		in      string
+		wantErr bool
+	}{
+		{"A1", false},
+		{"Z100", false},
+		{"A:Z", false},
+		{"A101", true},
+		{"AA1", true},
+		{"A2:A", false},
+	}
+	for _, tt := range tests {
+		b, err := parseA1Range(tt.in)
+		if err != nil {
+			t.Fatalf("parseA1Range(%q): %v", tt.in, err)
+		}
+		if err := b.checkWithin(tt.in, 100, 26); (err != nil) != tt.wantErr {
+			t.Errorf("checkWithin(%q) on 100x26 = %v, wantErr %v", tt.in, err, tt.wantErr)
+		}
+	}
+}
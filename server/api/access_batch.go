@@ -0,0 +1,124 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+)
+
+const (
+	// defaultAccessCheckConcurrency bounds concurrent permission listings so a
+	// view spanning many folders doesn't spike Drive quota
+	defaultAccessCheckConcurrency = 4
+
+	// maxAccessCheckFolders caps how many folders one CheckFolderAccess call may ask about
+	maxAccessCheckFolders = 100
+)
+
+// checkFoldersAccess returns the user's role on each folder ("" for no access).
+// Duplicate IDs are checked once, cached results are reused, and the rest are
+// verified at most s.accessCheckConcurrency at a time. The first Drive error
+// cancels the remaining checks.
+func (s *Server) checkFoldersAccess(ctx context.Context, email string, folderIDs []string) (map[string]string, error) {
+	roles := make(map[string]string, len(folderIDs))
+	var pending []string
+	for _, id := range folderIDs {
+		if _, seen := roles[id]; seen {
+			continue
+		}
+		if entry, ok := s.checkAuthCache(email, id); ok {
+			roles[id] = entry.role
+			continue
+		}
+		roles[id] = ""
+		pending = append(pending, id)
+	}
+	if len(pending) == 0 {
+		return roles, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	sem := make(chan struct{}, s.accessCheckConcurrency)
+	for _, id := range pending {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if ctx.Err() != nil {
+				return
+			}
+
+			role, err := s.verifyDriveAccessWithServiceAccount(ctx, email, id)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("folder %s: %w", id, err)
+					cancel()
+				}
+				return
+			}
+			roles[id] = role
+			s.setAuthCache(email, id, role != "", role)
+		}(id)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return roles, nil
+}
+
+// CheckFolderAccess reports which of the given folders the user can access
+func (s *Server) CheckFolderAccess(w http.ResponseWriter, r *http.Request) {
+	var req CheckFolderAccessRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if len(req.FolderIds) == 0 {
+		writeError(w, "folderIds is required", http.StatusBadRequest)
+		return
+	}
+	if len(req.FolderIds) > maxAccessCheckFolders {
+		writeError(w, fmt.Sprintf("At most %d folders can be checked at once", maxAccessCheckFolders), http.StatusBadRequest)
+		return
+	}
+
+	roles, err := s.checkFoldersAccess(r.Context(), r.Header.Get("X-User-Email"), req.FolderIds)
+	if isCancelled(err) {
+		writeCancelled(w, "CheckFolderAccess")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to check folder access: %v", err)
+		writeError(w, "Failed to verify access permissions", http.StatusInternalServerError)
+		return
+	}
+
+	if role, ok := roles[s.discoveredGrantsFolderID()]; ok && role == "" {
+		noteAccessLost(w)
+	}
+
+	access := make(map[string]FolderAccess, len(roles))
+	for id, role := range roles {
+		fa := FolderAccess{HasAccess: role != ""}
+		if role != "" {
+			fa.Role = &role
+		}
+		access[id] = fa
+	}
+	writeJSON(w, CheckFolderAccessResponse{Access: access})
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+)
+
+// servePermissionLists fakes the permission lists of folder-1..folder-n, where
+// po@example.org can write the odd folders only. It returns the most listings
+// that were in flight at once.
+func servePermissionLists(f *fakeGoogle, n int) func() int {
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	for i := 1; i <= n; i++ {
+		perms := []*drive.Permission{{Type: "user", EmailAddress: "other@example.org", Role: "owner"}}
+		if i%2 == 1 {
+			perms = append(perms, &drive.Permission{Type: "user", EmailAddress: "po@example.org", Role: "writer"})
+		}
+		f.handle(http.MethodGet, fmt.Sprintf("/files/folder-%d/permissions", i), func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			inFlight++
+			if inFlight > peak {
+				peak = inFlight
+			}
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			defer func() {
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+			}()
+			writeFakeJSON(w, &drive.PermissionList{Permissions: perms})
+		})
+	}
+	return func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return peak
+	}
+}
+
+func TestCheckFolderAccess(t *testing.T) {
+	t.Setenv("ACCESS_CHECK_CONCURRENCY", "2")
+	f := newFakeGoogle(t)
+	peak := servePermissionLists(f, 6)
+	s := newTestServer(t, f)
+
+	folderIDs := []string{"folder-1", "folder-2", "folder-3", "folder-4", "folder-5", "folder-6", "folder-1"}
+	w := callHandler(t, s.CheckFolderAccess, "po@example.org", CheckFolderAccessRequest{FolderIds: folderIDs})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var resp CheckFolderAccessResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.Access) != 6 {
+		t.Errorf("got access for %d folders, want 6", len(resp.Access))
+	}
+	for i := 1; i <= 6; i++ {
+		id := fmt.Sprintf("folder-%d", i)
+		got := resp.Access[id]
+		if want := i%2 == 1; got.HasAccess != want {
+			t.Errorf("%s hasAccess = %v, want %v", id, got.HasAccess, want)
+		}
+		if got.HasAccess && (got.Role == nil || *got.Role != "writer") {
+			t.Errorf("%s role = %v, want writer", id, got.Role)
+		}
+	}
+	if got := len(f.calls(http.MethodGet, "/files/folder-1/permissions")); got != 1 {
+		t.Errorf("checked a duplicated folder %d times, want once", got)
+	}
+	if got := peak(); got > 2 {
+		t.Errorf("%d permission listings in flight at once, want at most 2", got)
+	}
+
+	// A second check is answered from the cache
+	if w := callHandler(t, s.CheckFolderAccess, "po@example.org", CheckFolderAccessRequest{FolderIds: folderIDs}); w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	for i := 1; i <= 6; i++ {
+		path := fmt.Sprintf("/files/folder-%d/permissions", i)
+		if got := len(f.calls(http.MethodGet, path)); got != 1 {
+			t.Errorf("listed %s %d times, want once across both checks", path, got)
+		}
+	}
+}
+
+func TestCheckFolderAccessRejects(t *testing.T) {
+	tooMany := make([]string, maxAccessCheckFolders+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("folder-%d", i)
+	}
+	tests := []struct {
+		name      string
+		folderIDs []string
+	}{
+		{name: "no folders"},
+		{name: "too many folders", folderIDs: tooMany},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t, newFakeGoogle(t))
+			w := callHandler(t, s.CheckFolderAccess, "po@example.org", CheckFolderAccessRequest{FolderIds: tt.folderIDs})
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400: %s", w.Code, w.Body)
+			}
+		})
+	}
+}
+
+func TestCheckFolderAccessDriveError(t *testing.T) {
+	f := newFakeGoogle(t)
+	servePermissionLists(f, 1)
+	f.handle(http.MethodGet, "/files/folder-2/permissions", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": {"code": 500, "message": "Backend error"}}`, http.StatusInternalServerError)
+	})
+	s := newTestServer(t, f)
+
+	w := callHandler(t, s.CheckFolderAccess, "po@example.org", CheckFolderAccessRequest{FolderIds: []string{"folder-1", "folder-2"}})
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500: %s", w.Code, w.Body)
+	}
+	if _, ok := s.checkAuthCache("po@example.org", "folder-2"); ok {
+		t.Errorf("cached an access decision for a folder that failed to check")
+	}
+}
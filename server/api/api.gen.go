@@ -24,26 +24,164 @@ const (
 	SessionCookieScopes = "sessionCookie.Scopes"
 )
 
-// Defines values for CreateDocRequestMimeType.
+// Defines values for AuditListResponseSource.
 const (
-	ApplicationvndGoogleAppsDocument     CreateDocRequestMimeType = "application/vnd.google-apps.document"
-	ApplicationvndGoogleAppsPresentation CreateDocRequestMimeType = "application/vnd.google-apps.presentation"
-	ApplicationvndGoogleAppsSpreadsheet  CreateDocRequestMimeType = "application/vnd.google-apps.spreadsheet"
+	Memory AuditListResponseSource = "memory"
+	Sheet  AuditListResponseSource = "sheet"
+)
+
+// Defines values for ImportRowPreviewAction.
+const (
+	Insert    ImportRowPreviewAction = "insert"
+	Unchanged ImportRowPreviewAction = "unchanged"
+	Update    ImportRowPreviewAction = "update"
+)
+
+// Defines values for PivotRequestAgg.
+const (
+	Avg   PivotRequestAgg = "avg"
+	Count PivotRequestAgg = "count"
+	Max   PivotRequestAgg = "max"
+	Min   PivotRequestAgg = "min"
+	Sum   PivotRequestAgg = "sum"
+)
+
+// Defines values for ReadSheetRequestDateTimeRendering.
+const (
+	FORMATTEDSTRING ReadSheetRequestDateTimeRendering = "FORMATTED_STRING"
+	SERIALNUMBER    ReadSheetRequestDateTimeRendering = "SERIAL_NUMBER"
+)
+
+// Defines values for ReadSheetRequestRendering.
+const (
+	FORMATTED   ReadSheetRequestRendering = "FORMATTED"
+	FORMULA     ReadSheetRequestRendering = "FORMULA"
+	UNFORMATTED ReadSheetRequestRendering = "UNFORMATTED"
+)
+
+// Defines values for RowFilterOp.
+const (
+	Contains RowFilterOp = "contains"
+	Eq       RowFilterOp = "eq"
+	Gt       RowFilterOp = "gt"
+)
+
+// Defines values for ShareFileRequestRole.
+const (
+	Reader ShareFileRequestRole = "reader"
+	Writer ShareFileRequestRole = "writer"
 )
 
 // AppendRowRequest defines model for AppendRowRequest.
 type AppendRowRequest struct {
-	// Row Row data as key-value pairs where keys match column headers
+	// Row Row data as key-value pairs where keys match column headers. Writing to a header
+	// that appears more than once in the sheet is rejected with 400; when the server sets
+	// DUPLICATE_HEADERS=index, address a specific occurrence as "Name#N" (1-based, left to right).
 	Row map[string]interface{} `json:"row"`
 
 	// Sheet Sheet name
 	Sheet string `json:"sheet"`
+
+	// Verify Read the written row back and fail with 422 (code VERIFY_FAILED) if Sheets coerced
+	// or dropped any value. The write itself is not undone.
+	Verify *bool `json:"verify,omitempty"`
+}
+
+// AppendRowResponse defines model for AppendRowResponse.
+type AppendRowResponse struct {
+	// RowNumber 1-based sheet row of the new row (the first row, if Sheets reports several)
+	RowNumber *int `json:"rowNumber,omitempty"`
+	Success   bool `json:"success"`
+
+	// UpdatedRange A1 range Sheets wrote the row to
+	UpdatedRange *string `json:"updatedRange,omitempty"`
+}
+
+// AuditEntry defines model for AuditEntry.
+type AuditEntry struct {
+	Action string `json:"action"`
+
+	// Detail Human-readable summary
+	Detail *string `json:"detail,omitempty"`
+
+	// OperationId The X-Operation-ID the request carried, shared by every step of one client action.
+	// Letters, digits, and -_.: only, up to 128 characters; other values are ignored.
+	OperationId *string `json:"operationId,omitempty"`
+
+	// Resource Sheet name or Drive file/folder ID
+	Resource string `json:"resource"`
+
+	// Target Row ID or other object acted on
+	Target *string   `json:"target,omitempty"`
+	Time   time.Time `json:"time"`
+
+	// User Email of the user who made the change
+	User string `json:"user"`
+}
+
+// AuditListRequest defines model for AuditListRequest.
+type AuditListRequest struct {
+	// Limit Maximum number of events to return
+	Limit *int `json:"limit,omitempty"`
+
+	// Since Only events at or after this time
+	Since *time.Time `json:"since,omitempty"`
+
+	// Until Only events before this time
+	Until *time.Time `json:"until,omitempty"`
+
+	// User Only events by this email (case-insensitive)
+	User *string `json:"user,omitempty"`
+}
+
+// AuditListResponse defines model for AuditListResponse.
+type AuditListResponse struct {
+	// Entries Matching events, newest first
+	Entries []AuditEntry `json:"entries"`
+
+	// Source Where the events were read from (the AuditLog tab or this instance's memory)
+	Source AuditListResponseSource `json:"source"`
+}
+
+// AuditListResponseSource Where the events were read from (the AuditLog tab or this instance's memory)
+type AuditListResponseSource string
+
+// AutoResizeRequest defines model for AutoResizeRequest.
+type AutoResizeRequest struct {
+	// Sheet Sheet name
+	Sheet string `json:"sheet"`
+}
+
+// BatchAppendRowsRequest defines model for BatchAppendRowsRequest.
+type BatchAppendRowsRequest struct {
+	// Rows Rows in the order they should appear, each as key-value pairs where keys match
+	// column headers (duplicate headers are handled as for appendRow)
+	Rows []map[string]interface{} `json:"rows"`
+
+	// Sheet Sheet name
+	Sheet string `json:"sheet"`
+}
+
+// BatchAppendRowsResponse defines model for BatchAppendRowsResponse.
+type BatchAppendRowsResponse struct {
+	// Appended Number of rows appended
+	Appended int `json:"appended"`
+
+	// UpdatedRange A1 range Sheets wrote the rows to
+	UpdatedRange *string `json:"updatedRange,omitempty"`
 }
 
 // BatchUpdateRequest defines model for BatchUpdateRequest.
 type BatchUpdateRequest struct {
+	// AutoResize Fit the sheet's column widths to their contents after writing (useful for bulk imports)
+	AutoResize *bool `json:"autoResize,omitempty"`
+
 	// Sheet Sheet name
-	Sheet   string `json:"sheet"`
+	Sheet string `json:"sheet"`
+
+	// Updates Cells to write. Batches larger than the server's BATCH_UPDATE_MAX_RANGES (default 500)
+	// are split into several Sheets calls, or rejected with 400 (code BATCH_TOO_LARGE and
+	// the accepted `limit`) when the server sets BATCH_UPDATE_AUTO_SPLIT=false.
 	Updates []struct {
 		// Range Cell range (e.g., 'A2:C2')
 		Range string `json:"range"`
@@ -53,25 +191,144 @@ type BatchUpdateRequest struct {
 	} `json:"updates"`
 }
 
+// BatchUpdateResponse defines model for BatchUpdateResponse.
+type BatchUpdateResponse struct {
+	// Batches Number of Sheets BatchUpdate calls made
+	Batches int `json:"batches"`
+
+	// Error Error from the failed batch
+	Error *string `json:"error,omitempty"`
+
+	// FailedRanges Ranges that were not written (failed batch and everything after it)
+	FailedRanges *[]string `json:"failedRanges,omitempty"`
+
+	// Success True when every range was written
+	Success bool `json:"success"`
+
+	// UpdatedRanges Number of ranges written
+	UpdatedRanges int `json:"updatedRanges"`
+}
+
+// BootstrapResponse defines model for BootstrapResponse.
+type BootstrapResponse struct {
+	// Created Tabs that were created
+	Created []string `json:"created"`
+
+	// Existing Tabs that already existed and were left untouched
+	Existing []string `json:"existing"`
+}
+
+// Branding Instance branding for white-labeled deployments, from the INSTANCE_NAME,
+// INSTANCE_LOGO_URL, and INSTANCE_THEME_COLOR env vars, overridden by the
+// Config tab's instance_* keys. Omitted from the config when none are set.
+type Branding struct {
+	// LogoUrl http(s) URL of the instance logo
+	LogoUrl string `json:"logoUrl,omitempty"`
+
+	// Name Instance name to show in place of "Grant Tracker"
+	Name string `json:"name,omitempty"`
+
+	// ThemeColor Theme color as a CSS hex code with 3 or 6 digits
+	ThemeColor string `json:"themeColor,omitempty"`
+}
+
+// Breadcrumb defines model for Breadcrumb.
+type Breadcrumb struct {
+	// Id Folder ID
+	Id string `json:"id"`
+
+	// Name Folder name
+	Name string `json:"name"`
+}
+
+// CheckFolderAccessRequest defines model for CheckFolderAccessRequest.
+type CheckFolderAccessRequest struct {
+	// FolderIds Folders to check
+	FolderIds []string `json:"folderIds"`
+}
+
+// CheckFolderAccessResponse defines model for CheckFolderAccessResponse.
+type CheckFolderAccessResponse struct {
+	// Access Access keyed by folder ID
+	Access map[string]FolderAccess `json:"access"`
+}
+
+// CompletenessRequest defines model for CompletenessRequest.
+type CompletenessRequest struct {
+	// IdColumn Column identifying each row in the response
+	IdColumn string `json:"idColumn"`
+
+	// RequiredColumns Columns every row should fill (defaults to the configured set)
+	RequiredColumns *[]string `json:"requiredColumns,omitempty"`
+
+	// Sheet Sheet name (defaults to Grants)
+	Sheet *string `json:"sheet,omitempty"`
+}
+
+// CompletenessResponse defines model for CompletenessResponse.
+type CompletenessResponse struct {
+	// RequiredColumns The columns each row was scored against
+	RequiredColumns []string `json:"requiredColumns"`
+
+	// Rows One entry per data row, least complete first
+	Rows []RowCompleteness `json:"rows"`
+}
+
+// ConditionalUpdateRequest defines model for ConditionalUpdateRequest.
+type ConditionalUpdateRequest struct {
+	Condition UpdateCondition `json:"condition"`
+
+	// Data Fields to update as key-value pairs
+	Data map[string]interface{} `json:"data"`
+
+	// Id Value of the ID to match
+	Id string `json:"id"`
+
+	// IdColumn Column name containing the unique ID
+	IdColumn string `json:"idColumn"`
+
+	// Sheet Sheet name
+	Sheet string `json:"sheet"`
+}
+
 // Config defines model for Config.
 type Config struct {
+	// Branding Instance branding for white-labeled deployments, from the INSTANCE_NAME,
+	// INSTANCE_LOGO_URL, and INSTANCE_THEME_COLOR env vars, overridden by the
+	// Config tab's instance_* keys. Omitted from the config when none are set.
+	Branding *Branding `json:"branding,omitempty"`
+
 	// ClientId Google OAuth client ID
 	ClientId string `json:"clientId"`
 
+	// Discovering True while the server is still locating the spreadsheet and Grants folder.
+	// The response also carries a Retry-After header; clients should wait and
+	// fetch the config again.
+	Discovering *bool `json:"discovering,omitempty"`
+
 	// GrantsFolderId ID of the grants root folder (only when service account enabled)
 	GrantsFolderId *string `json:"grantsFolderId,omitempty"`
 
+	// SafeMode True when the server runs with SAFE_MODE=true. Deleting rows, moving files, and
+	// transferring ownership are then refused with 403, so clients should hide those actions.
+	SafeMode *bool `json:"safeMode,omitempty"`
+
 	// ServiceAccountEnabled Whether service account API is available
 	ServiceAccountEnabled bool `json:"serviceAccountEnabled"`
 
+	// SharedDriveId ID of the Shared Drive holding the root folder (only when service account
+	// enabled). Masked unless the server sets EXPOSE_SHARED_DRIVE_ID=true.
+	SharedDriveId *string `json:"sharedDriveId,omitempty"`
+
 	// SpreadsheetId ID of the Grant Tracker spreadsheet (only when service account enabled)
 	SpreadsheetId *string `json:"spreadsheetId,omitempty"`
 }
 
 // CreateDocRequest defines model for CreateDocRequest.
 type CreateDocRequest struct {
-	// MimeType Document type
-	MimeType CreateDocRequestMimeType `json:"mimeType"`
+	// MimeType Document type. Must be in the server's CREATE_DOC_MIME_TYPES allowlist,
+	// which defaults to Google Docs, Sheets, and Slides.
+	MimeType string `json:"mimeType"`
 
 	// Name Document name
 	Name string `json:"name"`
@@ -80,9 +337,6 @@ type CreateDocRequest struct {
 	ParentId *string `json:"parentId,omitempty"`
 }
 
-// CreateDocRequestMimeType Document type
-type CreateDocRequestMimeType string
-
 // CreateDocResponse defines model for CreateDocResponse.
 type CreateDocResponse struct {
 	// Id Created document ID
@@ -94,6 +348,9 @@ type CreateDocResponse struct {
 
 // CreateFolderRequest defines model for CreateFolderRequest.
 type CreateFolderRequest struct {
+	// GrantId Tag the folder as this grant's folder so it can be found even if renamed
+	GrantId *string `json:"grantId,omitempty"`
+
 	// Name Folder name
 	Name string `json:"name"`
 
@@ -110,6 +367,25 @@ type CreateFolderResponse struct {
 	Url string `json:"url"`
 }
 
+// CreateGrantWorkspaceRequest defines model for CreateGrantWorkspaceRequest.
+type CreateGrantWorkspaceRequest struct {
+	// GrantId Grant ID, used as the folder name
+	GrantId string `json:"grantId"`
+
+	// ParentId Parent folder ID (defaults to the Grants folder)
+	ParentId *string `json:"parentId,omitempty"`
+}
+
+// CreateGrantWorkspaceResponse defines model for CreateGrantWorkspaceResponse.
+type CreateGrantWorkspaceResponse struct {
+	// Id Grant folder ID
+	Id         string            `json:"id"`
+	Subfolders []WorkspaceFolder `json:"subfolders"`
+
+	// Url URL to view the grant folder
+	Url string `json:"url"`
+}
+
 // CreateShortcutRequest defines model for CreateShortcutRequest.
 type CreateShortcutRequest struct {
 	// Name Optional shortcut name (defaults to target's name)
@@ -128,6 +404,36 @@ type CreateShortcutResponse struct {
 	Id string `json:"id"`
 }
 
+// CreateSpreadsheetTabRequest defines model for CreateSpreadsheetTabRequest.
+type CreateSpreadsheetTabRequest struct {
+	// Headers Column headers written into row 1
+	Headers []string `json:"headers"`
+
+	// Title Name of the new tab
+	Title string `json:"title"`
+}
+
+// CreateSpreadsheetTabResponse defines model for CreateSpreadsheetTabResponse.
+type CreateSpreadsheetTabResponse struct {
+	// SheetId Numeric ID of the new tab (the gid in its URL)
+	SheetId int64 `json:"sheetId"`
+
+	// Title Name of the new tab
+	Title string `json:"title"`
+}
+
+// DashboardResponse defines model for DashboardResponse.
+type DashboardResponse struct {
+	Config Config         `json:"config"`
+	Grants *GrantsSummary `json:"grants,omitempty"`
+
+	// RecentFiles Most recently modified files in the Shared Drive
+	RecentFiles *[]FileInfo `json:"recentFiles,omitempty"`
+
+	// Warnings Sections that could not be loaded, and why
+	Warnings []string `json:"warnings"`
+}
+
 // DeleteRowRequest defines model for DeleteRowRequest.
 type DeleteRowRequest struct {
 	// Id Value of the ID to match
@@ -136,216 +442,1812 @@ type DeleteRowRequest struct {
 	// IdColumn Column name containing the unique ID
 	IdColumn string `json:"idColumn"`
 
+	// MatchAll Delete every row with this ID rather than only the first
+	MatchAll bool `json:"matchAll,omitempty"`
+
+	// Sheet Sheet name
+	Sheet string `json:"sheet"`
+}
+
+// DeleteRowResponse defines model for DeleteRowResponse.
+type DeleteRowResponse struct {
+	// Deleted Number of rows deleted
+	Deleted int  `json:"deleted"`
+	Success bool `json:"success"`
+}
+
+// DeleteRowsResponse defines model for DeleteRowsResponse.
+type DeleteRowsResponse struct {
+	// BackupSheet Tab the deleted rows were copied to first (only when DELETE_BACKUP=true)
+	BackupSheet *string `json:"backupSheet,omitempty"`
+
+	// Deleted Number of rows deleted
+	Deleted int `json:"deleted"`
+}
+
+// DeleteRowsWhereRequest defines model for DeleteRowsWhereRequest.
+type DeleteRowsWhereRequest struct {
 	// Sheet Sheet name
 	Sheet string `json:"sheet"`
+
+	// Where Column values a row must match (all must be equal) to be deleted
+	Where map[string]interface{} `json:"where"`
+}
+
+// DownloadFileRequest defines model for DownloadFileRequest.
+type DownloadFileRequest struct {
+	// FileId ID of the file to download
+	FileId string `json:"fileId"`
+
+	// Inline Ask for the file to be shown in the browser rather than saved, when its type allows
+	Inline bool `json:"inline,omitempty"`
+
+	// MimeType Format to export a Google Docs, Sheets, or Slides file as (required for those, ignored otherwise)
+	MimeType string `json:"mimeType,omitempty"`
+}
+
+// DriveChange defines model for DriveChange.
+type DriveChange struct {
+	File   *FileInfo `json:"file,omitempty"`
+	FileId string    `json:"fileId"`
+
+	// Removed The file was trashed, deleted, moved out of the Grants and root folder trees, or is
+	// no longer visible to the server. A removal carries no file, so it is only reported
+	// for files this server has seen inside the trees (in changes or search results).
+	// Files only seen before a server restart may go unreported; resync to catch them.
+	Removed bool `json:"removed"`
+
+	// Time When the change happened
+	Time *time.Time `json:"time,omitempty"`
+}
+
+// DuplicateGroup defines model for DuplicateGroup.
+type DuplicateGroup struct {
+	// Key The shared key column value
+	Key string `json:"key"`
+
+	// Rows 1-based sheet row numbers holding this key
+	Rows []int `json:"rows"`
 }
 
 // Error defines model for Error.
 type Error struct {
+	// Code Machine-readable reason, set for errors clients are expected to handle:
+	// OUT_OF_SCOPE means a file or folder ID is not in the Grant Tracker Shared Drive
+	// (or does not exist), or, for changes, not inside the Grants or root folder, and
+	// the server refused to touch it. VALIDATION_FAILED means
+	// the data broke the sheet's field schema; see `fields`. BATCH_TOO_LARGE means the
+	// request had more items than the server accepts at once; see `limit`.
+	// EXPORT_TOO_LARGE means Drive refused an export over its 10MB limit.
+	// CSRF_FAILED means the X-CSRF-Token header was missing or didn't match the
+	// gt_csrf cookie. RESYNC_REQUIRED means a Drive changes token has expired and
+	// the client should reload everything.
+	Code *string `json:"code,omitempty"`
+
 	// Error Error message
 	Error string `json:"error"`
+
+	// Fields Field-level problems (VALIDATION_FAILED only)
+	Fields *[]FieldError `json:"fields,omitempty"`
+
+	// Limit The largest batch the server accepts (BATCH_TOO_LARGE only)
+	Limit *int `json:"limit,omitempty"`
+}
+
+// ExportGrantRequest defines model for ExportGrantRequest.
+type ExportGrantRequest struct {
+	// Id Grant ID, also used to locate the grant's Drive folder
+	Id string `json:"id"`
+
+	// IdColumn Column name containing the unique ID
+	IdColumn string `json:"idColumn"`
+
+	// Sheet Sheet holding the grant (defaults to Grants)
+	Sheet *string `json:"sheet,omitempty"`
+}
+
+// ExportGrantResponse defines model for ExportGrantResponse.
+type ExportGrantResponse struct {
+	// Files Every file under the grant folder; path holds the subfolders between the grant folder and the file
+	Files  []FileInfo `json:"files"`
+	Folder *FileInfo  `json:"folder,omitempty"`
+
+	// Row The grant's row as key-value pairs keyed by column header
+	Row map[string]interface{} `json:"row"`
+}
+
+// FieldError defines model for FieldError.
+type FieldError struct {
+	// Field Column the problem is in, as sent
+	Field   string `json:"field"`
+	Message string `json:"message"`
+
+	// Row 1-based position of the row in the request (batch appends only)
+	Row *int `json:"row,omitempty"`
+}
+
+// FileInfo defines model for FileInfo.
+type FileInfo struct {
+	// CreatedBy Email of the user who created the file through this app. Drive itself attributes
+	// these files to the service account. Absent for files created elsewhere, or when the
+	// server sets CREATED_BY_ATTRIBUTION=false.
+	CreatedBy *string `json:"createdBy,omitempty"`
+
+	// Id File ID
+	Id string `json:"id"`
+
+	// MimeType MIME type
+	MimeType string `json:"mimeType"`
+
+	// ModifiedTime Last modified timestamp
+	ModifiedTime *time.Time `json:"modifiedTime,omitempty"`
+
+	// Name File name
+	Name string `json:"name"`
+
+	// Path Ancestor folders, outermost first (only when includePath is set)
+	Path            *[]Breadcrumb    `json:"path,omitempty"`
+	ShortcutDetails *ShortcutDetails `json:"shortcutDetails,omitempty"`
+
+	// WebViewLink URL to view the file in browser
+	WebViewLink *string `json:"webViewLink,omitempty"`
+}
+
+// FindDuplicatesRequest defines model for FindDuplicatesRequest.
+type FindDuplicatesRequest struct {
+	// KeyColumn Column whose value should be unique per row
+	KeyColumn string `json:"keyColumn"`
+
+	// Sheet Sheet name
+	Sheet string `json:"sheet"`
 }
 
-// FileInfo defines model for FileInfo.
-type FileInfo struct {
-	// Id File ID
-	Id string `json:"id"`
+// FindDuplicatesResponse defines model for FindDuplicatesResponse.
+type FindDuplicatesResponse struct {
+	// DuplicateRows Total number of rows across all groups
+	DuplicateRows int `json:"duplicateRows"`
+
+	// Groups One entry per key held by more than one row, in sheet order
+	Groups []DuplicateGroup `json:"groups"`
+}
+
+// FindRowsRequest defines model for FindRowsRequest.
+type FindRowsRequest struct {
+	// Columns Columns to return, in this order (default every column)
+	Columns *[]string `json:"columns,omitempty"`
+
+	// Filters Conditions a row must meet, all of which must hold
+	Filters []RowFilter `json:"filters"`
+
+	// Sheet Sheet name
+	Sheet string `json:"sheet"`
+}
+
+// FindRowsResponse defines model for FindRowsResponse.
+type FindRowsResponse struct {
+	// Headers The returned columns, in the order of each row's values
+	Headers []string `json:"headers"`
+
+	// Rows Matching rows in sheet order
+	Rows []FoundRow `json:"rows"`
+}
+
+// FolderAccess defines model for FolderAccess.
+type FolderAccess struct {
+	HasAccess bool `json:"hasAccess"`
+
+	// Role The caller's highest Drive role on the folder, when they have access
+	Role *string `json:"role,omitempty"`
+}
+
+// FoundRow defines model for FoundRow.
+type FoundRow struct {
+	// Row 1-based sheet row number
+	Row int `json:"row"`
+
+	// Values Cell values, one per header (missing trailing cells are null)
+	Values []interface{} `json:"values"`
+}
+
+// GetFilePathRequest defines model for GetFilePathRequest.
+type GetFilePathRequest struct {
+	// FileId ID of the file to place
+	FileId string `json:"fileId"`
+
+	// FollowShortcut If the file is a shortcut, return where its target lives instead
+	FollowShortcut bool `json:"followShortcut,omitempty"`
+}
+
+// GetFilePathResponse defines model for GetFilePathResponse.
+type GetFilePathResponse struct {
+	File Breadcrumb `json:"file"`
+
+	// Path Ancestor folders, outermost (the Grants or root folder) first
+	Path []Breadcrumb `json:"path"`
+}
+
+// GetFileRequest defines model for GetFileRequest.
+type GetFileRequest struct {
+	// FileId ID of the file to get
+	FileId string `json:"fileId"`
+
+	// IncludePath Also return the folder path from the Grants folder (or root) down to the file
+	IncludePath *bool `json:"includePath,omitempty"`
+}
+
+// GrantHistoryRequest defines model for GrantHistoryRequest.
+type GrantHistoryRequest struct {
+	// Id Grant ID
+	Id string `json:"id"`
+
+	// IdColumn Column name containing the grant ID
+	IdColumn string `json:"idColumn"`
+
+	// Limit Maximum number of entries to return
+	Limit *int `json:"limit,omitempty"`
+
+	// Sheet Sheet the grant rows live on; defaults to Grants
+	Sheet *string `json:"sheet,omitempty"`
+}
+
+// GrantHistoryResponse defines model for GrantHistoryResponse.
+type GrantHistoryResponse struct {
+	Entries []AuditEntry `json:"entries"`
+}
+
+// GrantManifest defines model for GrantManifest.
+type GrantManifest struct {
+	// Error Why the folder couldn't be walked; files is empty when set
+	Error *string `json:"error,omitempty"`
+
+	// Files Every file under the grant folder; path holds the subfolders between the grant folder and the file
+	Files  []FileInfo `json:"files"`
+	Folder FileInfo   `json:"folder"`
+
+	// GrantId The grant ID from the folder's appProperties, or the folder name for untagged folders
+	GrantId string `json:"grantId"`
+}
+
+// GrantPermalinkRequest defines model for GrantPermalinkRequest.
+type GrantPermalinkRequest struct {
+	GrantId string `json:"grantId"`
+}
+
+// GrantPermalinkResponse defines model for GrantPermalinkResponse.
+type GrantPermalinkResponse struct {
+	// FolderId The grant's Drive folder, if it has one
+	FolderId *string `json:"folderId,omitempty"`
+
+	// FolderUrl webViewLink of the grant's Drive folder, if it has one
+	FolderUrl *string `json:"folderUrl,omitempty"`
+
+	// Url Link to the grant's detail view in the app
+	Url string `json:"url"`
+}
+
+// GrantsSummary defines model for GrantsSummary.
+type GrantsSummary struct {
+	// ByStatus Grant count per status
+	ByStatus map[string]int `json:"byStatus"`
+
+	// Total Number of grants
+	Total int `json:"total"`
+}
+
+// ImportRowPreview defines model for ImportRowPreview.
+type ImportRowPreview struct {
+	// Action What importing this row would do
+	Action ImportRowPreviewAction `json:"action"`
+
+	// ChangedColumns Columns whose values would change (updates only)
+	ChangedColumns *[]string `json:"changedColumns,omitempty"`
+
+	// Key Key column value of the incoming row
+	Key string `json:"key"`
+}
+
+// ImportRowPreviewAction What importing this row would do
+type ImportRowPreviewAction string
+
+// ListChangesRequest defines model for ListChangesRequest.
+type ListChangesRequest struct {
+	// PageSize Maximum changes to read from Drive per call (defaults to the server's DRIVE_LIST_PAGE_SIZE)
+	PageSize int `json:"pageSize,omitempty"`
+
+	// PageToken Token from a previous response; omit to get a starting token without changes
+	PageToken string `json:"pageToken,omitempty"`
+}
+
+// ListChangesResponse defines model for ListChangesResponse.
+type ListChangesResponse struct {
+	Changes []DriveChange `json:"changes"`
+
+	// HasMore Whether more changes are waiting; call again with nextPageToken now
+	HasMore bool `json:"hasMore"`
+
+	// NextPageToken Token to store and pass as pageToken next time
+	NextPageToken string `json:"nextPageToken"`
+}
+
+// ListFilesRequest defines model for ListFilesRequest.
+type ListFilesRequest struct {
+	// FolderId Folder ID to list (defaults to grants folder)
+	FolderId *string `json:"folderId,omitempty"`
+
+	// MimeType Only files of this MIME type
+	MimeType *string `json:"mimeType,omitempty"`
+
+	// Name Only files with exactly this name
+	Name *string `json:"name,omitempty"`
+
+	// NameContains Only files whose name contains this text
+	NameContains *string `json:"nameContains,omitempty"`
+
+	// PageSize Maximum files to return (defaults to the server's DRIVE_LIST_PAGE_SIZE, 1000 unless set)
+	PageSize *int `json:"pageSize,omitempty"`
+
+	// PageToken nextPageToken from a previous response
+	PageToken *string `json:"pageToken,omitempty"`
+}
+
+// ListFilesResponse defines model for ListFilesResponse.
+type ListFilesResponse struct {
+	Files []FileInfo `json:"files"`
+
+	// PageInfo Pagination metadata shared by all paginated endpoints
+	PageInfo PageInfo `json:"pageInfo"`
+}
+
+// ListGrantManifestsRequest defines model for ListGrantManifestsRequest.
+type ListGrantManifestsRequest struct {
+	// PageSize Maximum grant folders to return (default 10)
+	PageSize *int `json:"pageSize,omitempty"`
+
+	// PageToken nextPageToken from a previous response
+	PageToken *string `json:"pageToken,omitempty"`
+}
+
+// ListGrantManifestsResponse defines model for ListGrantManifestsResponse.
+type ListGrantManifestsResponse struct {
+	Grants []GrantManifest `json:"grants"`
+
+	// PageInfo Pagination metadata shared by all paginated endpoints
+	PageInfo PageInfo `json:"pageInfo"`
+}
+
+// ListPermissionsRequest defines model for ListPermissionsRequest.
+type ListPermissionsRequest struct {
+	// FileId File or folder ID
+	FileId string `json:"fileId"`
+}
+
+// ListPermissionsResponse defines model for ListPermissionsResponse.
+type ListPermissionsResponse struct {
+	Permissions []Permission `json:"permissions"`
+}
+
+// MoveFileRequest defines model for MoveFileRequest.
+type MoveFileRequest struct {
+	// FileId ID of the file to move
+	FileId string `json:"fileId"`
+
+	// NewParentId ID of the new parent folder
+	NewParentId string `json:"newParentId"`
+
+	// PrevParentId ID of the previous parent folder (optional, will be detected if not provided)
+	PrevParentId *string `json:"prevParentId,omitempty"`
+}
+
+// PageInfo Pagination metadata shared by all paginated endpoints
+type PageInfo struct {
+	// HasMore Whether more items follow this page
+	HasMore bool `json:"hasMore"`
+
+	// Limit Maximum number of items in this page
+	Limit int `json:"limit"`
+
+	// NextPageToken Token to pass as pageToken to fetch the next page (absent on the last page)
+	NextPageToken *string `json:"nextPageToken,omitempty"`
+
+	// Offset Index of the first item in this page, when the source supports offsets
+	Offset *int `json:"offset,omitempty"`
+
+	// Total Total number of items, when known
+	Total *int `json:"total,omitempty"`
+}
+
+// Permission defines model for Permission.
+type Permission struct {
+	// DisplayName Grantee display name
+	DisplayName *string `json:"displayName,omitempty"`
+
+	// Domain Grantee domain for domain permissions
+	Domain *string `json:"domain,omitempty"`
+
+	// EmailAddress Grantee email for user and group permissions (masked unless exposed)
+	EmailAddress *string `json:"emailAddress,omitempty"`
+
+	// Id Drive permission ID
+	Id string `json:"id"`
+
+	// Role Drive role (reader, commenter, writer, fileOrganizer, organizer, owner)
+	Role string `json:"role"`
+
+	// Type Grantee type (user, group, domain, anyone)
+	Type string `json:"type"`
+}
+
+// PivotRequest defines model for PivotRequest.
+type PivotRequest struct {
+	// Agg Aggregation applied to each cell. Non-numeric values are skipped except by count.
+	Agg PivotRequestAgg `json:"agg"`
+
+	// Cols Columns whose values label the pivot columns (omit for a single column)
+	Cols *[]string `json:"cols,omitempty"`
+
+	// Rows Columns whose values label the pivot rows
+	Rows []string `json:"rows"`
+
+	// Sheet Sheet name
+	Sheet string `json:"sheet"`
+
+	// Value Column to aggregate (not needed for count)
+	Value *string `json:"value,omitempty"`
+}
+
+// PivotRequestAgg Aggregation applied to each cell. Non-numeric values are skipped except by count.
+type PivotRequestAgg string
+
+// PivotResponse defines model for PivotResponse.
+type PivotResponse struct {
+	// ColKeys One entry per pivot column, holding that column's values of the `cols` columns
+	ColKeys [][]string `json:"colKeys"`
+
+	// RowKeys One entry per pivot row, holding that row's values of the `rows` columns
+	RowKeys [][]string `json:"rowKeys"`
+
+	// Values values[i][j] aggregates rowKeys[i] × colKeys[j] (null when no rows match)
+	Values [][]*float64 `json:"values"`
+}
+
+// PreviewImportRequest defines model for PreviewImportRequest.
+type PreviewImportRequest struct {
+	// KeyColumn Column used to match incoming rows to existing rows
+	KeyColumn string `json:"keyColumn"`
+
+	// Rows Incoming rows as key-value pairs where keys match column headers
+	Rows []map[string]interface{} `json:"rows"`
+
+	// Sheet Sheet name
+	Sheet string `json:"sheet"`
+}
+
+// PreviewImportResponse defines model for PreviewImportResponse.
+type PreviewImportResponse struct {
+	// Inserts Number of rows that would be appended
+	Inserts int `json:"inserts"`
+
+	// Rows Classification of each incoming row, in request order
+	Rows []ImportRowPreview `json:"rows"`
+
+	// Unchanged Number of rows that already match the sheet
+	Unchanged int `json:"unchanged"`
+
+	// Updates Number of existing rows that would change
+	Updates int `json:"updates"`
+}
+
+// ProvisionGrantFolderRequest defines model for ProvisionGrantFolderRequest.
+type ProvisionGrantFolderRequest struct {
+	// Grant Metadata written into the tracker doc (ID, Title, Organization, Amount, Status, Year)
+	Grant map[string]string `json:"grant,omitempty"`
+
+	// GrantName Name of the grant folder, usually the grant ID
+	GrantName string `json:"grantName"`
+
+	// Subfolders Subfolders to create instead of the configured layout
+	Subfolders []string `json:"subfolders,omitempty"`
+}
+
+// ProvisionGrantFolderResponse defines model for ProvisionGrantFolderResponse.
+type ProvisionGrantFolderResponse struct {
+	// FolderId Grant folder ID
+	FolderId string `json:"folderId"`
+
+	// FolderUrl URL to view the grant folder
+	FolderUrl  string            `json:"folderUrl"`
+	Subfolders []WorkspaceFolder `json:"subfolders"`
+
+	// TrackerDocId Tracker doc ID
+	TrackerDocId string `json:"trackerDocId"`
+
+	// TrackerDocUrl URL to edit the tracker doc
+	TrackerDocUrl string `json:"trackerDocUrl"`
+}
+
+// PurgeAuthCacheRequest defines model for PurgeAuthCacheRequest.
+type PurgeAuthCacheRequest struct {
+	// Email User whose cached access to clear; omit to clear everyone's
+	Email string `json:"email,omitempty"`
+}
+
+// PurgeAuthCacheResponse defines model for PurgeAuthCacheResponse.
+type PurgeAuthCacheResponse struct {
+	// Purged Number of cached access decisions removed
+	Purged int `json:"purged"`
+}
+
+// QuotaResponse defines model for QuotaResponse.
+type QuotaResponse struct {
+	// Calls Google API calls made in the window, retries included
+	Calls int `json:"calls"`
+
+	// LastQuotaErrorAt When the most recent 429 was seen, however long ago
+	LastQuotaErrorAt *time.Time `json:"lastQuotaErrorAt,omitempty"`
+
+	// QuotaErrors Calls in the window rejected with 429
+	QuotaErrors int `json:"quotaErrors"`
+
+	// WindowSeconds Length of the rolling window the counts cover
+	WindowSeconds int `json:"windowSeconds"`
+}
+
+// RangeData defines model for RangeData.
+type RangeData struct {
+	// Columns Sheet column letter for each header
+	Columns map[string]string `json:"columns"`
+	Headers []string          `json:"headers"`
+	Rows    [][]interface{}   `json:"rows"`
+}
+
+// ReadSheetRequest defines model for ReadSheetRequest.
+type ReadSheetRequest struct {
+	// AsObjects Return rows in `objects`, each keyed by header, instead of as arrays in `rows`
+	// (which is then empty). Cells missing from short rows are "". Columns with an
+	// empty header are left out, and a repeated header keeps its first column's value.
+	// Not available with ranges.
+	AsObjects *bool `json:"asObjects,omitempty"`
+
+	// DateTimeRendering How dates and times are returned when rendering isn't FORMATTED: as spreadsheet
+	// serial numbers (days since 1899-12-30) or as strings in the cell's number format.
+	DateTimeRendering *ReadSheetRequestDateTimeRendering `json:"dateTimeRendering,omitempty"`
+
+	// Limit Maximum data rows to return (default all)
+	Limit *int `json:"limit,omitempty"`
+
+	// Mine Return only rows whose owner column (Owner, or the server's OWNER_COLUMN) holds the
+	// signed-in user's email, compared case-insensitively. Applied before paging, so
+	// offset, limit, and page tokens count the user's rows only. Works even when the owner column is
+	// sensitive and hidden from the caller. Not available with ranges.
+	Mine *bool `json:"mine,omitempty"`
+
+	// NormalizeMergedCells Fill every cell of a merged range with the merge's value so rows stay aligned with headers
+	NormalizeMergedCells *bool `json:"normalizeMergedCells,omitempty"`
+
+	// Offset Number of data rows to skip
+	Offset *int `json:"offset,omitempty"`
+
+	// PageToken nextPageToken from a previous response (takes precedence over offset)
+	PageToken *string `json:"pageToken,omitempty"`
+
+	// Range Optional range (e.g., 'A1:Z')
+	Range *string `json:"range,omitempty"`
+
+	// Ranges Read several ranges in one call instead of sheet/range, each naming its sheet
+	// (e.g. 'Grants', 'Orgs!A1:D'). Results are returned in `ranges`, keyed by the range
+	// as sent. Paging, normalizeMergedCells, and the replica fallback apply only to single reads.
+	Ranges *[]string `json:"ranges,omitempty"`
+
+	// Rendering How cell values are returned: raw values for computation (UNFORMATTED), strings
+	// as displayed in the sheet, such as "$1,200.00" (FORMATTED), or formulas (FORMULA).
+	Rendering *ReadSheetRequestRendering `json:"rendering,omitempty"`
+
+	// Sheet Sheet name (e.g., 'Grants', 'ActionItems'); required unless ranges is set
+	Sheet string `json:"sheet,omitempty"`
+}
+
+// ReadSheetRequestDateTimeRendering How dates and times are returned when rendering isn't FORMATTED: as spreadsheet
+// serial numbers (days since 1899-12-30) or as strings in the cell's number format.
+type ReadSheetRequestDateTimeRendering string
+
+// ReadSheetRequestRendering How cell values are returned: raw values for computation (UNFORMATTED), strings
+// as displayed in the sheet, such as "$1,200.00" (FORMATTED), or formulas (FORMULA).
+type ReadSheetRequestRendering string
+
+// ReadSheetResponse defines model for ReadSheetResponse.
+type ReadSheetResponse struct {
+	// Columns Sheet column letter for each header, for building A1 ranges
+	Columns *map[string]string `json:"columns,omitempty"`
+
+	// Headers Column headers from first row
+	Headers []string `json:"headers"`
+
+	// Objects Data rows keyed by header, when the request set asObjects
+	Objects *[]map[string]interface{} `json:"objects,omitempty"`
+
+	// PageInfo Pagination metadata shared by all paginated endpoints
+	PageInfo PageInfo `json:"pageInfo"`
+
+	// Ranges Per-range results for a multi-range read, keyed by the requested range; headers and rows are then empty
+	Ranges *map[string]RangeData `json:"ranges,omitempty"`
+
+	// Rows Data rows (excluding header row)
+	Rows [][]interface{} `json:"rows"`
+
+	// Stale True when the primary spreadsheet was unavailable and data came from the read-only replica
+	Stale *bool `json:"stale,omitempty"`
+}
+
+// ReloadCredentialsResponse defines model for ReloadCredentialsResponse.
+type ReloadCredentialsResponse struct {
+	// ClientEmail Service account the new key belongs to
+	ClientEmail string `json:"clientEmail"`
+
+	// Rediscovered Whether discovery re-ran (false when ROOT_FOLDER_ID is unset)
+	Rediscovered bool `json:"rediscovered"`
+
+	// Source Where the key was read from
+	Source string `json:"source"`
+}
+
+// RowCompleteness defines model for RowCompleteness.
+type RowCompleteness struct {
+	// Filled Required columns with a non-blank value
+	Filled int `json:"filled"`
+
+	// Id The row's value in idColumn
+	Id string `json:"id"`
+
+	// Missing Required columns left blank
+	Missing []string `json:"missing"`
+
+	// Percent filled as a percentage of required
+	Percent float64 `json:"percent"`
+
+	// Required Number of required columns
+	Required int `json:"required"`
+}
+
+// RowFilter defines model for RowFilter.
+type RowFilter struct {
+	// Column Column to test
+	Column string `json:"column"`
+
+	// Op eq matches the exact cell text, contains matches a case-insensitive substring,
+	// and gt matches numeric cells greater than value (non-numeric cells never match)
+	Op RowFilterOp `json:"op"`
+
+	// Value Value to compare against (a number for gt)
+	Value interface{} `json:"value"`
+}
+
+// RowFilterOp eq matches the exact cell text, contains matches a case-insensitive substring,
+// and gt matches numeric cells greater than value (non-numeric cells never match)
+type RowFilterOp string
+
+// SearchFilesRequest defines model for SearchFilesRequest.
+type SearchFilesRequest struct {
+	// FullText Match file content as well as names. The tracker spreadsheets are never returned.
+	FullText bool `json:"fullText,omitempty"`
+
+	// PageSize Maximum files to read from Drive per page (defaults to the server's DRIVE_LIST_PAGE_SIZE)
+	PageSize int `json:"pageSize,omitempty"`
+
+	// PageToken nextPageToken from a previous response
+	PageToken string `json:"pageToken,omitempty"`
+
+	// Query Text to look for
+	Query string `json:"query"`
+}
+
+// SearchFilesResponse defines model for SearchFilesResponse.
+type SearchFilesResponse struct {
+	// Files Matches, each with `path` set
+	Files []FileInfo `json:"files"`
+
+	// PageInfo Pagination metadata shared by all paginated endpoints
+	PageInfo PageInfo `json:"pageInfo"`
+}
+
+// ShareFileRequest defines model for ShareFileRequest.
+type ShareFileRequest struct {
+	// EmailAddress Email of the user to share with
+	EmailAddress string `json:"emailAddress"`
+
+	// FileId File or folder ID
+	FileId string `json:"fileId"`
+
+	// Role Access to grant
+	Role ShareFileRequestRole `json:"role"`
+
+	// SendNotificationEmail Have Drive email the user about the share
+	SendNotificationEmail bool `json:"sendNotificationEmail,omitempty"`
+}
+
+// ShareFileRequestRole Access to grant
+type ShareFileRequestRole string
+
+// ShareFileResponse defines model for ShareFileResponse.
+type ShareFileResponse struct {
+	// Created Whether a new permission was created, as opposed to an existing one updated or left as it was
+	Created bool `json:"created"`
+
+	// PermissionId ID of the user's permission on the file
+	PermissionId string `json:"permissionId"`
+
+	// Role The user's role now
+	Role string `json:"role"`
+}
+
+// SheetInfo defines model for SheetInfo.
+type SheetInfo struct {
+	ColumnCount    int64 `json:"columnCount"`
+	FrozenRowCount int64 `json:"frozenRowCount"`
+
+	// HeaderRow 1-based row the server reads headers from (see HEADER_ROWS)
+	HeaderRow int   `json:"headerRow"`
+	RowCount  int64 `json:"rowCount"`
+
+	// SheetId Numeric sheet ID (stable across renames)
+	SheetId int64 `json:"sheetId"`
+
+	// Title Tab name, as used in the sheet field of other requests
+	Title string `json:"title"`
+}
+
+// SheetMetadataResponse defines model for SheetMetadataResponse.
+type SheetMetadataResponse struct {
+	Sheets []SheetInfo `json:"sheets"`
+}
+
+// ShortcutDetails defines model for ShortcutDetails.
+type ShortcutDetails struct {
+	// TargetId ID of the file this shortcut points to
+	TargetId *string `json:"targetId,omitempty"`
+
+	// TargetMimeType MIME type of the target file
+	TargetMimeType *string `json:"targetMimeType,omitempty"`
+}
+
+// SuccessResponse defines model for SuccessResponse.
+type SuccessResponse struct {
+	Success bool `json:"success"`
+}
+
+// TransferOwnershipRequest defines model for TransferOwnershipRequest.
+type TransferOwnershipRequest struct {
+	// FileId File or folder ID
+	FileId string `json:"fileId"`
+
+	// NewOwnerEmail Email of the user who becomes the owner
+	NewOwnerEmail string `json:"newOwnerEmail"`
+}
+
+// TrashFileRequest defines model for TrashFileRequest.
+type TrashFileRequest struct {
+	// FileId ID of the file to remove
+	FileId string `json:"fileId"`
+
+	// Permanent Delete the file outright instead of moving it to the trash (admins only)
+	Permanent bool `json:"permanent,omitempty"`
+}
+
+// UpdateCondition defines model for UpdateCondition.
+type UpdateCondition struct {
+	// Column Column to check
+	Column string `json:"column"`
+
+	// Equals Value the column must currently hold (compared like expectedValues, so 5000 matches 5,000.00)
+	Equals string `json:"equals"`
+}
+
+// UpdateConflict defines model for UpdateConflict.
+type UpdateConflict struct {
+	// Code Always CONFLICT
+	Code *string `json:"code,omitempty"`
+
+	// Columns The expected columns whose values no longer match
+	Columns *[]string `json:"columns,omitempty"`
+
+	// Current The row as it is now, keyed by header, without the sensitive columns the caller may not read
+	Current map[string]interface{} `json:"current"`
+	Error   string                 `json:"error"`
+}
+
+// UpdateRowRequest defines model for UpdateRowRequest.
+type UpdateRowRequest struct {
+	// Data Fields to update as key-value pairs. Headers that appear more than once are handled
+	// as for appendRow: rejected, or addressed as "Name#N" when DUPLICATE_HEADERS=index.
+	Data map[string]interface{} `json:"data"`
+
+	// ExpectedValues Optimistic concurrency check. The values the client last read for some columns of
+	// the row; if any of them has changed since, nothing is written and the request fails
+	// with 409 carrying the row's current values so the client can merge and retry.
+	// Numbers match their text form (5000 matches "5000"). Sensitive columns the caller
+	// may not read are refused with 403.
+	ExpectedValues *map[string]interface{} `json:"expectedValues,omitempty"`
+
+	// Id Value of the ID to match
+	Id string `json:"id"`
+
+	// IdColumn Column name containing the unique ID
+	IdColumn string `json:"idColumn"`
+
+	// Sheet Sheet name
+	Sheet string `json:"sheet"`
+
+	// Verify Read the written row back and fail with 422 (code VERIFY_FAILED) if Sheets coerced
+	// or dropped any value. The write itself is not undone.
+	Verify *bool `json:"verify,omitempty"`
+}
+
+// VersionInfo defines model for VersionInfo.
+type VersionInfo struct {
+	// BuildTime Build timestamp
+	BuildTime string `json:"buildTime"`
+
+	// GitCommit Git commit the binary was built from
+	GitCommit string `json:"gitCommit"`
+
+	// GoVersion Go runtime version
+	GoVersion string `json:"goVersion"`
+
+	// Version Release version ("dev" for local builds)
+	Version string `json:"version"`
+}
+
+// WorkspaceFolder defines model for WorkspaceFolder.
+type WorkspaceFolder struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	Url  string `json:"url"`
+}
+
+// BadRequest defines model for BadRequest.
+type BadRequest = Error
+
+// Forbidden defines model for Forbidden.
+type Forbidden = Error
+
+// InternalError defines model for InternalError.
+type InternalError = Error
+
+// NotFound defines model for NotFound.
+type NotFound = Error
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized = Error
+
+// PurgeAuthCacheJSONRequestBody defines body for PurgeAuthCache for application/json ContentType.
+type PurgeAuthCacheJSONRequestBody = PurgeAuthCacheRequest
+
+// ListGrantManifestsJSONRequestBody defines body for ListGrantManifests for application/json ContentType.
+type ListGrantManifestsJSONRequestBody = ListGrantManifestsRequest
+
+// ListPermissionsJSONRequestBody defines body for ListPermissions for application/json ContentType.
+type ListPermissionsJSONRequestBody = ListPermissionsRequest
+
+// ShareFileJSONRequestBody defines body for ShareFile for application/json ContentType.
+type ShareFileJSONRequestBody = ShareFileRequest
+
+// CreateSpreadsheetTabJSONRequestBody defines body for CreateSpreadsheetTab for application/json ContentType.
+type CreateSpreadsheetTabJSONRequestBody = CreateSpreadsheetTabRequest
+
+// TransferOwnershipJSONRequestBody defines body for TransferOwnership for application/json ContentType.
+type TransferOwnershipJSONRequestBody = TransferOwnershipRequest
+
+// ListAuditEventsJSONRequestBody defines body for ListAuditEvents for application/json ContentType.
+type ListAuditEventsJSONRequestBody = AuditListRequest
+
+// CheckFolderAccessJSONRequestBody defines body for CheckFolderAccess for application/json ContentType.
+type CheckFolderAccessJSONRequestBody = CheckFolderAccessRequest
+
+// ListChangesJSONRequestBody defines body for ListChanges for application/json ContentType.
+type ListChangesJSONRequestBody = ListChangesRequest
+
+// CreateDocJSONRequestBody defines body for CreateDoc for application/json ContentType.
+type CreateDocJSONRequestBody = CreateDocRequest
+
+// CreateFolderJSONRequestBody defines body for CreateFolder for application/json ContentType.
+type CreateFolderJSONRequestBody = CreateFolderRequest
+
+// CreateShortcutJSONRequestBody defines body for CreateShortcut for application/json ContentType.
+type CreateShortcutJSONRequestBody = CreateShortcutRequest
+
+// DownloadFileJSONRequestBody defines body for DownloadFile for application/json ContentType.
+type DownloadFileJSONRequestBody = DownloadFileRequest
+
+// GetFileJSONRequestBody defines body for GetFile for application/json ContentType.
+type GetFileJSONRequestBody = GetFileRequest
+
+// ListFilesJSONRequestBody defines body for ListFiles for application/json ContentType.
+type ListFilesJSONRequestBody = ListFilesRequest
+
+// MoveFileJSONRequestBody defines body for MoveFile for application/json ContentType.
+type MoveFileJSONRequestBody = MoveFileRequest
+
+// GetFilePathJSONRequestBody defines body for GetFilePath for application/json ContentType.
+type GetFilePathJSONRequestBody = GetFilePathRequest
+
+// SearchFilesJSONRequestBody defines body for SearchFiles for application/json ContentType.
+type SearchFilesJSONRequestBody = SearchFilesRequest
+
+// TrashFileJSONRequestBody defines body for TrashFile for application/json ContentType.
+type TrashFileJSONRequestBody = TrashFileRequest
+
+// ExportGrantJSONRequestBody defines body for ExportGrant for application/json ContentType.
+type ExportGrantJSONRequestBody = ExportGrantRequest
+
+// GrantHistoryJSONRequestBody defines body for GrantHistory for application/json ContentType.
+type GrantHistoryJSONRequestBody = GrantHistoryRequest
+
+// GetGrantPermalinkJSONRequestBody defines body for GetGrantPermalink for application/json ContentType.
+type GetGrantPermalinkJSONRequestBody = GrantPermalinkRequest
+
+// ProvisionGrantFolderJSONRequestBody defines body for ProvisionGrantFolder for application/json ContentType.
+type ProvisionGrantFolderJSONRequestBody = ProvisionGrantFolderRequest
+
+// CreateGrantWorkspaceJSONRequestBody defines body for CreateGrantWorkspace for application/json ContentType.
+type CreateGrantWorkspaceJSONRequestBody = CreateGrantWorkspaceRequest
+
+// AppendRowJSONRequestBody defines body for AppendRow for application/json ContentType.
+type AppendRowJSONRequestBody = AppendRowRequest
+
+// AutoResizeColumnsJSONRequestBody defines body for AutoResizeColumns for application/json ContentType.
+type AutoResizeColumnsJSONRequestBody = AutoResizeRequest
+
+// BatchAppendRowsJSONRequestBody defines body for BatchAppendRows for application/json ContentType.
+type BatchAppendRowsJSONRequestBody = BatchAppendRowsRequest
+
+// BatchUpdateCellsJSONRequestBody defines body for BatchUpdateCells for application/json ContentType.
+type BatchUpdateCellsJSONRequestBody = BatchUpdateRequest
+
+// CompletenessJSONRequestBody defines body for Completeness for application/json ContentType.
+type CompletenessJSONRequestBody = CompletenessRequest
+
+// ConditionalUpdateJSONRequestBody defines body for ConditionalUpdate for application/json ContentType.
+type ConditionalUpdateJSONRequestBody = ConditionalUpdateRequest
+
+// DeleteRowJSONRequestBody defines body for DeleteRow for application/json ContentType.
+type DeleteRowJSONRequestBody = DeleteRowRequest
+
+// DeleteRowsWhereJSONRequestBody defines body for DeleteRowsWhere for application/json ContentType.
+type DeleteRowsWhereJSONRequestBody = DeleteRowsWhereRequest
+
+// FindDuplicatesJSONRequestBody defines body for FindDuplicates for application/json ContentType.
+type FindDuplicatesJSONRequestBody = FindDuplicatesRequest
+
+// FindRowsJSONRequestBody defines body for FindRows for application/json ContentType.
+type FindRowsJSONRequestBody = FindRowsRequest
+
+// PivotJSONRequestBody defines body for Pivot for application/json ContentType.
+type PivotJSONRequestBody = PivotRequest
+
+// PreviewImportJSONRequestBody defines body for PreviewImport for application/json ContentType.
+type PreviewImportJSONRequestBody = PreviewImportRequest
+
+// ReadSheetJSONRequestBody defines body for ReadSheet for application/json ContentType.
+type ReadSheetJSONRequestBody = ReadSheetRequest
+
+// UpdateRowJSONRequestBody defines body for UpdateRow for application/json ContentType.
+type UpdateRowJSONRequestBody = UpdateRowRequest
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Clear cached access decisions
+	// (POST /admin/auth-cache/purge)
+	PurgeAuthCache(w http.ResponseWriter, r *http.Request)
+	// Create missing spreadsheet tabs
+	// (POST /admin/bootstrap)
+	BootstrapSpreadsheet(w http.ResponseWriter, r *http.Request)
+	// List every grant folder's file manifest
+	// (POST /admin/grant-manifests)
+	ListGrantManifests(w http.ResponseWriter, r *http.Request)
+	// List who has access to a file
+	// (POST /admin/permissions)
+	ListPermissions(w http.ResponseWriter, r *http.Request)
+	// Reload the service account key
+	// (POST /admin/reload-credentials)
+	ReloadCredentials(w http.ResponseWriter, r *http.Request)
+	// Share a file or folder with a user
+	// (POST /admin/share)
+	ShareFile(w http.ResponseWriter, r *http.Request)
+	// Create a spreadsheet tab
+	// (POST /admin/sheets/create-tab)
+	CreateSpreadsheetTab(w http.ResponseWriter, r *http.Request)
+	// Transfer ownership of a file
+	// (POST /admin/transfer-ownership)
+	TransferOwnership(w http.ResponseWriter, r *http.Request)
+	// List recent audit events
+	// (POST /audit/list)
+	ListAuditEvents(w http.ResponseWriter, r *http.Request)
+	// Get application configuration
+	// (GET /config)
+	GetConfig(w http.ResponseWriter, r *http.Request)
+	// Get the dashboard payload
+	// (GET /dashboard)
+	GetDashboard(w http.ResponseWriter, r *http.Request)
+	// Check access to several folders
+	// (POST /drive/access)
+	CheckFolderAccess(w http.ResponseWriter, r *http.Request)
+	// List files changed since a sync token
+	// (POST /drive/changes)
+	ListChanges(w http.ResponseWriter, r *http.Request)
+	// Create a document
+	// (POST /drive/create-doc)
+	CreateDoc(w http.ResponseWriter, r *http.Request)
+	// Create a folder
+	// (POST /drive/create-folder)
+	CreateFolder(w http.ResponseWriter, r *http.Request)
+	// Create a shortcut
+	// (POST /drive/create-shortcut)
+	CreateShortcut(w http.ResponseWriter, r *http.Request)
+	// Download a file's content
+	// (POST /drive/download)
+	DownloadFile(w http.ResponseWriter, r *http.Request)
+	// Get file metadata
+	// (POST /drive/get)
+	GetFile(w http.ResponseWriter, r *http.Request)
+	// List files in a folder
+	// (POST /drive/list)
+	ListFiles(w http.ResponseWriter, r *http.Request)
+	// Move a file
+	// (POST /drive/move)
+	MoveFile(w http.ResponseWriter, r *http.Request)
+	// Get a file's breadcrumbs
+	// (POST /drive/path)
+	GetFilePath(w http.ResponseWriter, r *http.Request)
+	// Search files by name or content
+	// (POST /drive/search)
+	SearchFiles(w http.ResponseWriter, r *http.Request)
+	// Trash or delete a file
+	// (POST /drive/trash)
+	TrashFile(w http.ResponseWriter, r *http.Request)
+	// Export a grant bundle
+	// (POST /grants/export)
+	ExportGrant(w http.ResponseWriter, r *http.Request)
+	// Get a grant's recent history
+	// (POST /grants/history)
+	GrantHistory(w http.ResponseWriter, r *http.Request)
+	// Get a shareable link to a grant
+	// (POST /grants/permalink)
+	GetGrantPermalink(w http.ResponseWriter, r *http.Request)
+	// Provision a grant folder
+	// (POST /grants/provision)
+	ProvisionGrantFolder(w http.ResponseWriter, r *http.Request)
+	// Create a grant workspace
+	// (POST /grants/workspace)
+	CreateGrantWorkspace(w http.ResponseWriter, r *http.Request)
+	// Get recent Google API usage
+	// (GET /quota)
+	GetQuota(w http.ResponseWriter, r *http.Request)
+	// Append a row to a sheet
+	// (POST /sheets/append)
+	AppendRow(w http.ResponseWriter, r *http.Request)
+	// Fit column widths to their contents
+	// (POST /sheets/auto-resize)
+	AutoResizeColumns(w http.ResponseWriter, r *http.Request)
+	// Append several rows to a sheet
+	// (POST /sheets/batch-append)
+	BatchAppendRows(w http.ResponseWriter, r *http.Request)
+	// Batch update multiple cells
+	// (POST /sheets/batch-update)
+	BatchUpdateCells(w http.ResponseWriter, r *http.Request)
+	// Score how complete each record is
+	// (POST /sheets/completeness)
+	Completeness(w http.ResponseWriter, r *http.Request)
+	// Update a row only if a column has an expected value
+	// (POST /sheets/conditional-update)
+	ConditionalUpdate(w http.ResponseWriter, r *http.Request)
+	// Delete a row from a sheet
+	// (POST /sheets/delete)
+	DeleteRow(w http.ResponseWriter, r *http.Request)
+	// Delete all rows matching a filter
+	// (POST /sheets/delete-where)
+	DeleteRowsWhere(w http.ResponseWriter, r *http.Request)
+	// Find rows sharing a key
+	// (POST /sheets/duplicates)
+	FindDuplicates(w http.ResponseWriter, r *http.Request)
+	// Download the spreadsheet as Excel
+	// (GET /sheets/export-xlsx)
+	ExportSpreadsheetXlsx(w http.ResponseWriter, r *http.Request)
+	// Find rows matching filters
+	// (POST /sheets/find)
+	FindRows(w http.ResponseWriter, r *http.Request)
+	// List the spreadsheet's sheets
+	// (GET /sheets/metadata)
+	GetSheetMetadata(w http.ResponseWriter, r *http.Request)
+	// Aggregate a sheet along two sets of columns
+	// (POST /sheets/pivot)
+	Pivot(w http.ResponseWriter, r *http.Request)
+	// Preview an import without writing
+	// (POST /sheets/preview-import)
+	PreviewImport(w http.ResponseWriter, r *http.Request)
+	// Read data from a sheet
+	// (POST /sheets/read)
+	ReadSheet(w http.ResponseWriter, r *http.Request)
+	// Update a row in a sheet
+	// (POST /sheets/update)
+	UpdateRow(w http.ResponseWriter, r *http.Request)
+	// Get server version
+	// (GET /version)
+	GetVersion(w http.ResponseWriter, r *http.Request)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// PurgeAuthCache operation middleware
+func (siw *ServerInterfaceWrapper) PurgeAuthCache(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PurgeAuthCache(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// BootstrapSpreadsheet operation middleware
+func (siw *ServerInterfaceWrapper) BootstrapSpreadsheet(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BootstrapSpreadsheet(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListGrantManifests operation middleware
+func (siw *ServerInterfaceWrapper) ListGrantManifests(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListGrantManifests(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListPermissions operation middleware
+func (siw *ServerInterfaceWrapper) ListPermissions(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListPermissions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReloadCredentials operation middleware
+func (siw *ServerInterfaceWrapper) ReloadCredentials(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReloadCredentials(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ShareFile operation middleware
+func (siw *ServerInterfaceWrapper) ShareFile(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ShareFile(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateSpreadsheetTab operation middleware
+func (siw *ServerInterfaceWrapper) CreateSpreadsheetTab(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateSpreadsheetTab(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// TransferOwnership operation middleware
+func (siw *ServerInterfaceWrapper) TransferOwnership(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TransferOwnership(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListAuditEvents operation middleware
+func (siw *ServerInterfaceWrapper) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAuditEvents(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetConfig operation middleware
+func (siw *ServerInterfaceWrapper) GetConfig(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetConfig(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDashboard operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboard(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboard(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CheckFolderAccess operation middleware
+func (siw *ServerInterfaceWrapper) CheckFolderAccess(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CheckFolderAccess(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListChanges operation middleware
+func (siw *ServerInterfaceWrapper) ListChanges(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListChanges(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateDoc operation middleware
+func (siw *ServerInterfaceWrapper) CreateDoc(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateDoc(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateFolder operation middleware
+func (siw *ServerInterfaceWrapper) CreateFolder(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateFolder(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateShortcut operation middleware
+func (siw *ServerInterfaceWrapper) CreateShortcut(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateShortcut(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DownloadFile operation middleware
+func (siw *ServerInterfaceWrapper) DownloadFile(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DownloadFile(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetFile operation middleware
+func (siw *ServerInterfaceWrapper) GetFile(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFile(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListFiles operation middleware
+func (siw *ServerInterfaceWrapper) ListFiles(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListFiles(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// MoveFile operation middleware
+func (siw *ServerInterfaceWrapper) MoveFile(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MoveFile(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetFilePath operation middleware
+func (siw *ServerInterfaceWrapper) GetFilePath(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFilePath(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SearchFiles operation middleware
+func (siw *ServerInterfaceWrapper) SearchFiles(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchFiles(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// TrashFile operation middleware
+func (siw *ServerInterfaceWrapper) TrashFile(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TrashFile(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExportGrant operation middleware
+func (siw *ServerInterfaceWrapper) ExportGrant(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportGrant(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GrantHistory operation middleware
+func (siw *ServerInterfaceWrapper) GrantHistory(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GrantHistory(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetGrantPermalink operation middleware
+func (siw *ServerInterfaceWrapper) GetGrantPermalink(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetGrantPermalink(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ProvisionGrantFolder operation middleware
+func (siw *ServerInterfaceWrapper) ProvisionGrantFolder(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ProvisionGrantFolder(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateGrantWorkspace operation middleware
+func (siw *ServerInterfaceWrapper) CreateGrantWorkspace(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
 
-	// MimeType MIME type
-	MimeType string `json:"mimeType"`
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
 
-	// ModifiedTime Last modified timestamp
-	ModifiedTime *time.Time `json:"modifiedTime,omitempty"`
+	r = r.WithContext(ctx)
 
-	// Name File name
-	Name            string           `json:"name"`
-	ShortcutDetails *ShortcutDetails `json:"shortcutDetails,omitempty"`
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateGrantWorkspace(w, r)
+	}))
 
-	// WebViewLink URL to view the file in browser
-	WebViewLink *string `json:"webViewLink,omitempty"`
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// GetFileRequest defines model for GetFileRequest.
-type GetFileRequest struct {
-	// FileId ID of the file to get
-	FileId string `json:"fileId"`
+	handler.ServeHTTP(w, r)
 }
 
-// ListFilesRequest defines model for ListFilesRequest.
-type ListFilesRequest struct {
-	// FolderId Folder ID to list (defaults to grants folder)
-	FolderId *string `json:"folderId,omitempty"`
+// GetQuota operation middleware
+func (siw *ServerInterfaceWrapper) GetQuota(w http.ResponseWriter, r *http.Request) {
 
-	// Query Additional Drive API query filter
-	Query *string `json:"query,omitempty"`
-}
+	ctx := r.Context()
 
-// ListFilesResponse defines model for ListFilesResponse.
-type ListFilesResponse struct {
-	Files []FileInfo `json:"files"`
-}
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
 
-// MoveFileRequest defines model for MoveFileRequest.
-type MoveFileRequest struct {
-	// FileId ID of the file to move
-	FileId string `json:"fileId"`
+	r = r.WithContext(ctx)
 
-	// NewParentId ID of the new parent folder
-	NewParentId string `json:"newParentId"`
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetQuota(w, r)
+	}))
 
-	// PrevParentId ID of the previous parent folder (optional, will be detected if not provided)
-	PrevParentId *string `json:"prevParentId,omitempty"`
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// ReadSheetRequest defines model for ReadSheetRequest.
-type ReadSheetRequest struct {
-	// Range Optional range (e.g., 'A1:Z')
-	Range *string `json:"range,omitempty"`
+// AppendRow operation middleware
+func (siw *ServerInterfaceWrapper) AppendRow(w http.ResponseWriter, r *http.Request) {
 
-	// Sheet Sheet name (e.g., 'Grants', 'ActionItems')
-	Sheet string `json:"sheet"`
-}
+	ctx := r.Context()
 
-// ReadSheetResponse defines model for ReadSheetResponse.
-type ReadSheetResponse struct {
-	// Headers Column headers from first row
-	Headers []string `json:"headers"`
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
 
-	// Rows Data rows (excluding header row)
-	Rows [][]interface{} `json:"rows"`
-}
+	r = r.WithContext(ctx)
 
-// ShortcutDetails defines model for ShortcutDetails.
-type ShortcutDetails struct {
-	// TargetId ID of the file this shortcut points to
-	TargetId *string `json:"targetId,omitempty"`
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AppendRow(w, r)
+	}))
 
-	// TargetMimeType MIME type of the target file
-	TargetMimeType *string `json:"targetMimeType,omitempty"`
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// SuccessResponse defines model for SuccessResponse.
-type SuccessResponse struct {
-	Success bool `json:"success"`
+	handler.ServeHTTP(w, r)
 }
 
-// UpdateRowRequest defines model for UpdateRowRequest.
-type UpdateRowRequest struct {
-	// Data Fields to update as key-value pairs
-	Data map[string]interface{} `json:"data"`
+// AutoResizeColumns operation middleware
+func (siw *ServerInterfaceWrapper) AutoResizeColumns(w http.ResponseWriter, r *http.Request) {
 
-	// Id Value of the ID to match
-	Id string `json:"id"`
+	ctx := r.Context()
 
-	// IdColumn Column name containing the unique ID
-	IdColumn string `json:"idColumn"`
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
 
-	// Sheet Sheet name
-	Sheet string `json:"sheet"`
-}
+	r = r.WithContext(ctx)
 
-// BadRequest defines model for BadRequest.
-type BadRequest = Error
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AutoResizeColumns(w, r)
+	}))
 
-// Forbidden defines model for Forbidden.
-type Forbidden = Error
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// InternalError defines model for InternalError.
-type InternalError = Error
+	handler.ServeHTTP(w, r)
+}
 
-// NotFound defines model for NotFound.
-type NotFound = Error
+// BatchAppendRows operation middleware
+func (siw *ServerInterfaceWrapper) BatchAppendRows(w http.ResponseWriter, r *http.Request) {
 
-// Unauthorized defines model for Unauthorized.
-type Unauthorized = Error
+	ctx := r.Context()
 
-// CreateDocJSONRequestBody defines body for CreateDoc for application/json ContentType.
-type CreateDocJSONRequestBody = CreateDocRequest
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
 
-// CreateFolderJSONRequestBody defines body for CreateFolder for application/json ContentType.
-type CreateFolderJSONRequestBody = CreateFolderRequest
+	r = r.WithContext(ctx)
 
-// CreateShortcutJSONRequestBody defines body for CreateShortcut for application/json ContentType.
-type CreateShortcutJSONRequestBody = CreateShortcutRequest
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BatchAppendRows(w, r)
+	}))
 
-// GetFileJSONRequestBody defines body for GetFile for application/json ContentType.
-type GetFileJSONRequestBody = GetFileRequest
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// ListFilesJSONRequestBody defines body for ListFiles for application/json ContentType.
-type ListFilesJSONRequestBody = ListFilesRequest
+	handler.ServeHTTP(w, r)
+}
 
-// MoveFileJSONRequestBody defines body for MoveFile for application/json ContentType.
-type MoveFileJSONRequestBody = MoveFileRequest
+// BatchUpdateCells operation middleware
+func (siw *ServerInterfaceWrapper) BatchUpdateCells(w http.ResponseWriter, r *http.Request) {
 
-// AppendRowJSONRequestBody defines body for AppendRow for application/json ContentType.
-type AppendRowJSONRequestBody = AppendRowRequest
+	ctx := r.Context()
 
-// BatchUpdateCellsJSONRequestBody defines body for BatchUpdateCells for application/json ContentType.
-type BatchUpdateCellsJSONRequestBody = BatchUpdateRequest
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
 
-// DeleteRowJSONRequestBody defines body for DeleteRow for application/json ContentType.
-type DeleteRowJSONRequestBody = DeleteRowRequest
+	r = r.WithContext(ctx)
 
-// ReadSheetJSONRequestBody defines body for ReadSheet for application/json ContentType.
-type ReadSheetJSONRequestBody = ReadSheetRequest
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BatchUpdateCells(w, r)
+	}))
 
-// UpdateRowJSONRequestBody defines body for UpdateRow for application/json ContentType.
-type UpdateRowJSONRequestBody = UpdateRowRequest
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Get application configuration
-	// (GET /config)
-	GetConfig(w http.ResponseWriter, r *http.Request)
-	// Create a document
-	// (POST /drive/create-doc)
-	CreateDoc(w http.ResponseWriter, r *http.Request)
-	// Create a folder
-	// (POST /drive/create-folder)
-	CreateFolder(w http.ResponseWriter, r *http.Request)
-	// Create a shortcut
-	// (POST /drive/create-shortcut)
-	CreateShortcut(w http.ResponseWriter, r *http.Request)
-	// Get file metadata
-	// (POST /drive/get)
-	GetFile(w http.ResponseWriter, r *http.Request)
-	// List files in a folder
-	// (POST /drive/list)
-	ListFiles(w http.ResponseWriter, r *http.Request)
-	// Move a file
-	// (POST /drive/move)
-	MoveFile(w http.ResponseWriter, r *http.Request)
-	// Append a row to a sheet
-	// (POST /sheets/append)
-	AppendRow(w http.ResponseWriter, r *http.Request)
-	// Batch update multiple cells
-	// (POST /sheets/batch-update)
-	BatchUpdateCells(w http.ResponseWriter, r *http.Request)
-	// Delete a row from a sheet
-	// (POST /sheets/delete)
-	DeleteRow(w http.ResponseWriter, r *http.Request)
-	// Read data from a sheet
-	// (POST /sheets/read)
-	ReadSheet(w http.ResponseWriter, r *http.Request)
-	// Update a row in a sheet
-	// (POST /sheets/update)
-	UpdateRow(w http.ResponseWriter, r *http.Request)
+	handler.ServeHTTP(w, r)
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
-}
+// Completeness operation middleware
+func (siw *ServerInterfaceWrapper) Completeness(w http.ResponseWriter, r *http.Request) {
 
-type MiddlewareFunc func(http.Handler) http.Handler
+	ctx := r.Context()
 
-// GetConfig operation middleware
-func (siw *ServerInterfaceWrapper) GetConfig(w http.ResponseWriter, r *http.Request) {
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetConfig(w, r)
+		siw.Handler.Completeness(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -355,8 +2257,8 @@ func (siw *ServerInterfaceWrapper) GetConfig(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// CreateDoc operation middleware
-func (siw *ServerInterfaceWrapper) CreateDoc(w http.ResponseWriter, r *http.Request) {
+// ConditionalUpdate operation middleware
+func (siw *ServerInterfaceWrapper) ConditionalUpdate(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -365,7 +2267,7 @@ func (siw *ServerInterfaceWrapper) CreateDoc(w http.ResponseWriter, r *http.Requ
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateDoc(w, r)
+		siw.Handler.ConditionalUpdate(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -375,8 +2277,8 @@ func (siw *ServerInterfaceWrapper) CreateDoc(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// CreateFolder operation middleware
-func (siw *ServerInterfaceWrapper) CreateFolder(w http.ResponseWriter, r *http.Request) {
+// DeleteRow operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRow(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -385,7 +2287,7 @@ func (siw *ServerInterfaceWrapper) CreateFolder(w http.ResponseWriter, r *http.R
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateFolder(w, r)
+		siw.Handler.DeleteRow(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -395,8 +2297,8 @@ func (siw *ServerInterfaceWrapper) CreateFolder(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// CreateShortcut operation middleware
-func (siw *ServerInterfaceWrapper) CreateShortcut(w http.ResponseWriter, r *http.Request) {
+// DeleteRowsWhere operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRowsWhere(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -405,7 +2307,7 @@ func (siw *ServerInterfaceWrapper) CreateShortcut(w http.ResponseWriter, r *http
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateShortcut(w, r)
+		siw.Handler.DeleteRowsWhere(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -415,8 +2317,8 @@ func (siw *ServerInterfaceWrapper) CreateShortcut(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// GetFile operation middleware
-func (siw *ServerInterfaceWrapper) GetFile(w http.ResponseWriter, r *http.Request) {
+// FindDuplicates operation middleware
+func (siw *ServerInterfaceWrapper) FindDuplicates(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -425,7 +2327,7 @@ func (siw *ServerInterfaceWrapper) GetFile(w http.ResponseWriter, r *http.Reques
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetFile(w, r)
+		siw.Handler.FindDuplicates(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -435,8 +2337,8 @@ func (siw *ServerInterfaceWrapper) GetFile(w http.ResponseWriter, r *http.Reques
 	handler.ServeHTTP(w, r)
 }
 
-// ListFiles operation middleware
-func (siw *ServerInterfaceWrapper) ListFiles(w http.ResponseWriter, r *http.Request) {
+// ExportSpreadsheetXlsx operation middleware
+func (siw *ServerInterfaceWrapper) ExportSpreadsheetXlsx(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -445,7 +2347,7 @@ func (siw *ServerInterfaceWrapper) ListFiles(w http.ResponseWriter, r *http.Requ
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListFiles(w, r)
+		siw.Handler.ExportSpreadsheetXlsx(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -455,8 +2357,8 @@ func (siw *ServerInterfaceWrapper) ListFiles(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// MoveFile operation middleware
-func (siw *ServerInterfaceWrapper) MoveFile(w http.ResponseWriter, r *http.Request) {
+// FindRows operation middleware
+func (siw *ServerInterfaceWrapper) FindRows(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -465,7 +2367,7 @@ func (siw *ServerInterfaceWrapper) MoveFile(w http.ResponseWriter, r *http.Reque
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.MoveFile(w, r)
+		siw.Handler.FindRows(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -475,8 +2377,8 @@ func (siw *ServerInterfaceWrapper) MoveFile(w http.ResponseWriter, r *http.Reque
 	handler.ServeHTTP(w, r)
 }
 
-// AppendRow operation middleware
-func (siw *ServerInterfaceWrapper) AppendRow(w http.ResponseWriter, r *http.Request) {
+// GetSheetMetadata operation middleware
+func (siw *ServerInterfaceWrapper) GetSheetMetadata(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -485,7 +2387,7 @@ func (siw *ServerInterfaceWrapper) AppendRow(w http.ResponseWriter, r *http.Requ
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.AppendRow(w, r)
+		siw.Handler.GetSheetMetadata(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -495,8 +2397,8 @@ func (siw *ServerInterfaceWrapper) AppendRow(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// BatchUpdateCells operation middleware
-func (siw *ServerInterfaceWrapper) BatchUpdateCells(w http.ResponseWriter, r *http.Request) {
+// Pivot operation middleware
+func (siw *ServerInterfaceWrapper) Pivot(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -505,7 +2407,7 @@ func (siw *ServerInterfaceWrapper) BatchUpdateCells(w http.ResponseWriter, r *ht
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.BatchUpdateCells(w, r)
+		siw.Handler.Pivot(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -515,8 +2417,8 @@ func (siw *ServerInterfaceWrapper) BatchUpdateCells(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteRow operation middleware
-func (siw *ServerInterfaceWrapper) DeleteRow(w http.ResponseWriter, r *http.Request) {
+// PreviewImport operation middleware
+func (siw *ServerInterfaceWrapper) PreviewImport(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -525,7 +2427,7 @@ func (siw *ServerInterfaceWrapper) DeleteRow(w http.ResponseWriter, r *http.Requ
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteRow(w, r)
+		siw.Handler.PreviewImport(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -575,6 +2477,20 @@ func (siw *ServerInterfaceWrapper) UpdateRow(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
+// GetVersion operation middleware
+func (siw *ServerInterfaceWrapper) GetVersion(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetVersion(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -695,18 +2611,52 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	m.HandleFunc("POST "+options.BaseURL+"/admin/auth-cache/purge", wrapper.PurgeAuthCache)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/bootstrap", wrapper.BootstrapSpreadsheet)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/grant-manifests", wrapper.ListGrantManifests)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/permissions", wrapper.ListPermissions)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/reload-credentials", wrapper.ReloadCredentials)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/share", wrapper.ShareFile)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/sheets/create-tab", wrapper.CreateSpreadsheetTab)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/transfer-ownership", wrapper.TransferOwnership)
+	m.HandleFunc("POST "+options.BaseURL+"/audit/list", wrapper.ListAuditEvents)
 	m.HandleFunc("GET "+options.BaseURL+"/config", wrapper.GetConfig)
+	m.HandleFunc("GET "+options.BaseURL+"/dashboard", wrapper.GetDashboard)
+	m.HandleFunc("POST "+options.BaseURL+"/drive/access", wrapper.CheckFolderAccess)
+	m.HandleFunc("POST "+options.BaseURL+"/drive/changes", wrapper.ListChanges)
 	m.HandleFunc("POST "+options.BaseURL+"/drive/create-doc", wrapper.CreateDoc)
 	m.HandleFunc("POST "+options.BaseURL+"/drive/create-folder", wrapper.CreateFolder)
 	m.HandleFunc("POST "+options.BaseURL+"/drive/create-shortcut", wrapper.CreateShortcut)
+	m.HandleFunc("POST "+options.BaseURL+"/drive/download", wrapper.DownloadFile)
 	m.HandleFunc("POST "+options.BaseURL+"/drive/get", wrapper.GetFile)
 	m.HandleFunc("POST "+options.BaseURL+"/drive/list", wrapper.ListFiles)
 	m.HandleFunc("POST "+options.BaseURL+"/drive/move", wrapper.MoveFile)
+	m.HandleFunc("POST "+options.BaseURL+"/drive/path", wrapper.GetFilePath)
+	m.HandleFunc("POST "+options.BaseURL+"/drive/search", wrapper.SearchFiles)
+	m.HandleFunc("POST "+options.BaseURL+"/drive/trash", wrapper.TrashFile)
+	m.HandleFunc("POST "+options.BaseURL+"/grants/export", wrapper.ExportGrant)
+	m.HandleFunc("POST "+options.BaseURL+"/grants/history", wrapper.GrantHistory)
+	m.HandleFunc("POST "+options.BaseURL+"/grants/permalink", wrapper.GetGrantPermalink)
+	m.HandleFunc("POST "+options.BaseURL+"/grants/provision", wrapper.ProvisionGrantFolder)
+	m.HandleFunc("POST "+options.BaseURL+"/grants/workspace", wrapper.CreateGrantWorkspace)
+	m.HandleFunc("GET "+options.BaseURL+"/quota", wrapper.GetQuota)
 	m.HandleFunc("POST "+options.BaseURL+"/sheets/append", wrapper.AppendRow)
+	m.HandleFunc("POST "+options.BaseURL+"/sheets/auto-resize", wrapper.AutoResizeColumns)
+	m.HandleFunc("POST "+options.BaseURL+"/sheets/batch-append", wrapper.BatchAppendRows)
 	m.HandleFunc("POST "+options.BaseURL+"/sheets/batch-update", wrapper.BatchUpdateCells)
+	m.HandleFunc("POST "+options.BaseURL+"/sheets/completeness", wrapper.Completeness)
+	m.HandleFunc("POST "+options.BaseURL+"/sheets/conditional-update", wrapper.ConditionalUpdate)
 	m.HandleFunc("POST "+options.BaseURL+"/sheets/delete", wrapper.DeleteRow)
+	m.HandleFunc("POST "+options.BaseURL+"/sheets/delete-where", wrapper.DeleteRowsWhere)
+	m.HandleFunc("POST "+options.BaseURL+"/sheets/duplicates", wrapper.FindDuplicates)
+	m.HandleFunc("GET "+options.BaseURL+"/sheets/export-xlsx", wrapper.ExportSpreadsheetXlsx)
+	m.HandleFunc("POST "+options.BaseURL+"/sheets/find", wrapper.FindRows)
+	m.HandleFunc("GET "+options.BaseURL+"/sheets/metadata", wrapper.GetSheetMetadata)
+	m.HandleFunc("POST "+options.BaseURL+"/sheets/pivot", wrapper.Pivot)
+	m.HandleFunc("POST "+options.BaseURL+"/sheets/preview-import", wrapper.PreviewImport)
 	m.HandleFunc("POST "+options.BaseURL+"/sheets/read", wrapper.ReadSheet)
 	m.HandleFunc("POST "+options.BaseURL+"/sheets/update", wrapper.UpdateRow)
+	m.HandleFunc("GET "+options.BaseURL+"/version", wrapper.GetVersion)
 
 	return m
 }
@@ -714,50 +2664,298 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xbX28bNxL/KsTeAUmAta2k7T3oTbFiQ7gkNeykBzQxCno5kljvkluSK1VX6LsfOOT+",
-	"50qKL3JdNG/2kpwZzvw4HP5I/RElMsulAGF0NP4jUqBzKTTgP68pu4bfCtDG/pdIYUDgnzTPU55Qw6U4",
-	"+1VLYb/pZAkZtX/9U8E8Gkf/OKtFn7lWffZGKami7XYbRwx0onhuhUTjaCZWNOWMKK9wG0cXUt1xxkAc",
-	"X/skSUBrwkBwYOS5kCQHlXGtuRTESLJQVBhN5jJloF5Y42bCgBI0dSKPbuANqBUoAq49jt5LcyELwY6v",
-	"+Rq0LFQCREhD5qhzG0cfBS3MUir+X3gEG95LQ6w+EMZKBhbZPn6YlTrJcxDsWq4beM2VzEEZ7rCs5BqN",
-	"Y4xboTS9ajb3Zy3XhFFDCdXkHjYnK5oWQHLKlSbrJSiwXzXJqEmWJJFpkQmyBMpA6SiO4Hea5SlYhbNp",
-	"NI4uryfvP5y8Gr3618lo9DKKoxtDTaGjcTRVdG6iOPrAje0fvYc1ubRgs042m9x+k3e/QoIf9BIA59YB",
-	"h/1MBM2gqTtCOdYcL0cbxcUCXWdXGVc2dJ+80Bg9dBtQ+trO8WPOqIFB734dw+KoQDUokhvIdCCOVCyg",
-	"r+kc0pRgG3kOp4vTmDybvBqfv3r2oqUZv4UUY3x1X+5P+N2mAA2GcEHMEpyeKK5MrENFlaKbnofL/l5J",
-	"yMk7x5cRKr0TEnAuxZwv+v5KUg7CzFh/apdSLlIgP04KsySuG5lNQ85x2e8Ck19I0mxK5Bw94/Okkpgr",
-	"bH/yXIp0Y9eMIBrUiidAaJLIQhgCgt6lwF6EdPq+E9f1jevZV/2fJZglqJ7oydWMcE3oivLUDq1V3EmZ",
-	"AhWoI1dAGXp397QQsOSDosm91VUPe9jsOuGtQjQ062C8FVADU5kMrsmMZ/ABh3UnNpVJkdlwo9Q4AlFk",
-	"1pBm5l4JdrpAhJzQPNenzI+J4p3dGr7Z0zNXoEEYbLQzrBfpgWb0IIN5ZniyvTR0paT1Jnkv7aIKCMyp",
-	"Glg7V9hSQjy0ajox9sqrmOwJqSvC+jHlAVvcMEZK1wws4kKl/bEfr9/a3LbisD4Dxg2iveHjuVQZNdE4",
-	"KhTfO0duEWzVDE/O5ZBByIYj6AYFtpF6V33jP/5fQSTPGcxpkRodKPkOCvD+iT8ksDtQtj+sGFEn4Qjx",
-	"vFlKZZLCfGFEf8xdDUa0H4/BbfvfULUA80xj04svi6xHjJEkQTPRCZUuLkLSnL7d+8Ccp9CQSmuZRu51",
-	"Z6WgYfkhnn0IZCq7DkhNPGzGFFIwsKukDhmA9VLprtnUOgtr5KGF68rhXjA4O8eaOjBDV2sjXuyhg3LB",
-	"xQLVFYL/VoCbcq0svGyOWklX1sdDzq1OjW2PQvm5bRX2JhloTRew1wonJKT1gqcwE3N5WCht74GkM1xa",
-	"vJu9e1OWFf1hkvE5B/aBh5LCW6oNKbsQwzPQhmZ5M2vZ8vfEthy+/eMsfEADKHDLZAqG8lTvO5/edLpv",
-	"42gNdz9xWL/l4v6ANGxt4YLcKbnWD8zHhxQSl2DstAcXrrXjsES3ALPXLC8tZMhbrtESPWzK4KHiotqW",
-	"jSQp1+bLtuc4+q0AtenLnVQMAJkqvgI8KmBfO2uDYelPeNfUhhK0dUz7KLsLXNXi3HcadGJD7n4nV/CV",
-	"Ap/JVXiRwfpqcNutxQhYk7xZXgX3bwWrQ4TZflwWui2RPJe+iojJmqcpuQPCwEBi9z8+R7oqV3LF2SFn",
-	"MO+Z9gRDPr4GynCXGGaawgxFVfN0WIqX45+7JMXL8c8P2rMqoW6/embFJ7bXzAKwo+WL9rQ9nhhaASUj",
-	"NrSL+3YyVzIjc660IUqum2Z+cvu3o8cq3uy25l4ChVxr8SCvFbBgSg21yjR5Dr8nacFsFeHssZ9fNOid",
-	"YZ5nz0qtCUG0IeTEm/4O1Hbh4WXpkuu67sslF5goh0vdd/s38VKBG4B6DsuONwUS6sPI0K4DFj1lrI0q",
-	"oE/TdPHoB4ac6UnKHSUro4Z+CQ18wSFluN847i1AB7fp3kkmC2Gi8Q+j0WjU4HntOlxBkNT9VkYfVEbH",
-	"Lnj9uCNZmBSKm82N3Us9wAAvb86lvOcBiN+4ZkLdxY+R9yBI4jrHEbddqv9cYRktzC+u9y/Yu54Gzfm/",
-	"YePuLbgvr9vaXtPkHgTDUmMuVYdPLLR1fZc+xH6OokV3akIF8yWLhSzyY/r0s/gsJmlKQDC/5r0rmxcm",
-	"dqYrTol3ip8oClyB4vONC7wGRZZUe6d8FvYUXpO6ftvlojQLbTn9jJ7w1xftiU2uZlEcrUBp54aXp6PT",
-	"kYWNzEHQnEfj6LvT0el3eCI2S4zbWVIR2YsQtK7BFErokrB2vQvnDcJFmck7rDZOteth7RYnmuME2DRr",
-	"q2dPpsft69BXo9FXu+ryGgJ3XeetGSHmEeJFllFbz1r7SENx2wU2FnShkVZ2Km7t6DNmY3XmeIsTJhNM",
-	"jVKbIQpBE4pFXBlqmcQOhjGRikjk3FmHRm67sWIzI7e6QZvXkm2+nge7BPi2nUfsbrI9ZgR7bG0gmBX5",
-	"7DyPV6ffOyNCsitjzxpX7zjk5f4hrStZHPTd/kH1Hfs2jn44xLL2xXcz+0bjT728++l2e9sE73nJnDWJ",
-	"fA9YxGgIr/4IcSBkw3lqAJ8X5enkeBBtc95/Cko77HMAqP60/Q2mHZjWp9d9IC1L70Ng2qCNCRUEfufa",
-	"2F3LF9ghoJYHhaNCtUvn/ylg7THfoecwpQO/AbYDWF3jZBiyvrIKw/TS1poZGIrvX2wZSonOIeFznoQR",
-	"6vnGI0Gzw2Y+MiZrWi6QNO25u/TU04bg96Pv94+oHpU9DmYvPaVQu3AXZlOud4D2Ldf2hJSmKFHb3Z/6",
-	"w1KVwtugrQjcI8G2x30/MnD7BHUAwbYTkXPntL99EkVvNPBzwOaPPPkgKt/JFe73JatOCePzObR58TYs",
-	"Sw7/SKjsXhE8Mii7vOBgUpUrYMRzffMiTTd/e3DayHkoDSASeTN9RvEt7DAo3VvZ8rSk5Jp4ngcEs7nA",
-	"1hCOgGsjs3pjeyRo9t7wPj1sXss1ce79hs42Ol3wCC3xVIOoRKpDZxuqd9QkyxNHrQ8D1tH6mmRFanie",
-	"AkkgTX2K1lzYI375q4EuZhtvl8/toCNBN/BE+umBFx3g7zG+obeFXoxfecXThtkeBDN8JTWMXfeKSvuF",
-	"wRkI49633G3syX82LX88gDdKPfxWj7COBNzeI6+nmXOdl/9KoH2K5y0XbA9FvHI/LEsroDvKiWugzJ28",
-	"HF/QkBsThbc19dW6u8TCa3cNOVXUQLrpob56WXAk1PfecDwy6vsvJ4IUF4AhT59ZeATgWn/10bUHtYdW",
-	"FXN3xY/VxINydPXq4Eho7b1qeJo5+q9XWDzFHP3RPzNxUBQ7se5/JYUPrD71CDGZ0JS4dv+Mfxyd0ZxH",
-	"VqEXNvCTMP/eoH5lUD+C8Nq38cDQ7vuEeqQ7qfYHTnZcZfuh/iZ7e7v9XwAAAP//B9ey2K88AAA=",
+	"H4sIAAAAAAAC/+y9/XIbOZIv+io4nBNh6dwSLbvdO9N2TMSlJdrNO/paUu6e3mGHBFaBJFZFgA2AojkT",
+	"fo7zQPtiNzITqA8SRVJuy/bM+C9bZBFAAZmJ/Phl5j9aqZ7NtRLK2dbLf7SMsHOtrMA/XvOsL35bCOvg",
+	"r1QrJxT+l8/nuUy5k1o9/W+rFXxm06mYcfjf/zZi3HrZ+sPTcuin9K192jVGm9aHDx+SViZsauQcBmm9",
+	"bPXUPc9lxoyf8EPSeqPNSGaZUI8/eydNhbUsE0qKjB0ozebCzKS1UivmNJsYrpxlY51nwhzC4nrKCaN4",
+	"TkM++gIHwtwLwwR9n7QutHujFyp7/Jn7wuqFSQVT2rExzvkhab1TfOGm2si/i8+whgvtGMwnlIORRdaC",
+	"Z/zPYNTOfC5U1tfLCr3OjZ4L4yTRstFL+IdnmYRBeX5V/XrzrfWSZdxxxi27E6uje54vBJtzaSxbToUR",
+	"8KllM+7SKUt1vpgpNhU8E8a22c9GOqkmQDjcfzpUbsod4/O54MaymTaCuSlXTKtUMKmYmwpmp0I4Ji0z",
+	"4r9F6kTGltJN2Yvj41cwqX+IKMEKZ4fq9N3VWe+kc929+bHbOe32B3+WKhPvE8azzAhrGWd2LlI5linT",
+	"abowRsB83LJh64LPxB8uhi128OxoxK3IEpaLsYNVGzmZusP2ULWSlnjPZ/NcwOb1TlsvW2/7nYvro+fH",
+	"z//j6Pj4WStpDRx3C9t62To1fOxaSetaOni+dSGW7C0wDhCMW83hMz2CN4MP8GVh1DVCh4+Z4jNRnbuF",
+	"49hWMY51RqoJjHMvjByvaKAxX+Su9XLMcys26ZhnuIFLI50Tihm9ZCOe3jGuMjbmMve7/fw5O0h1JthP",
+	"3X7vzS83bzq9s+7pIZNjhouzLNXCpCIbKm1YZvR8LjLG1YohkbTZtZ9EMOmsyMdwosA8C5VpJWhX/WuM",
+	"tM4FV0jOIPmkAXb6m9+cBKn218jmVeid5HWU4C8Ws5Ewm3vsz9vTG+yDHuPWKLHEPw/gj7E0Fr9NKq9u",
+	"xFwbZ5kV98Lw/LB6SM++L1YqlRMTgZLKLlC2wirWXzppLeYZsHOfq4nYXGfnGTPwTZh8abQTuFBYpNMR",
+	"CvlfnWffv3zz7PtNSlnfYb+s6O4uMum6ypnV5rbylBb3j8rU9BY3cFgRAs2E4zLffLsfFzOujozgGR/l",
+	"gtnFbMbNKjYCzI/ytJdtDgPU9tejy/DIUe+UdogEIUu5MRJ42065ERkbrRgc3YpZJ+Zw7loJluZSKMfo",
+	"3dpDdSacE8YmLJMT6WyCLHJ0037JtMpXCVvMQUo8e/4nlk654Sk8/IppNxWGmMAybgSTE6WNyGoUX76V",
+	"8RfLNhnAtGGnRt4DNebiKd2/rHcaG89xM4lJFBDlvVMYiRZI5wwvKzKmo0tzcobLGmsz4671sgXne4Sf",
+	"Rp5e2BiTdWcgUjxjwSNsOdVsxjOi4HSKNL+LTP2cOEUSiK+yd43keyata7wMczmTriYyvz9el5fn/L2c",
+	"LWZMoRCBFxH3QjmL94NwCwPrmNFD8PvjpDWTiv56FhUEUsUO+1LlqzA0d3BMfOyEYW4qLfOvv+c5KCfz",
+	"7ROMxJhu3gePHT3j2tArGlbguR+k3IojqaxQVjp5Lw6jR73t7JoEu1DO+P+un5hLp6B30IoSEOfCOhLk",
+	"raQlnZjZXTpYRfaV6+PGcPy7iWN/RpUI6NrvxhL+BtnGxkbP6EKhd9MT5vgIzhm3SyrruErFE8tmYqbN",
+	"Cq8UtZhVr0H6pvVrbAur3BK2plhonD2c7gsr/y4a+ePT6CbROz22otdwcMWVbrfpsDYq32zQILXJkHXE",
+	"itmpXuSZ1zgTJng63UeZHaq6NssOsgXp86L4iBvBplxluchgyDHwbFj94VBVSa1Z397YhRl/36OfPTs+",
+	"Pi4eKGnv8Q4loa3d62ya2JJ2QERu6ItCgsIsrHiwstznUdXpd2hHdpt69N0PO/elWGTjnrzDxTXSKi+4",
+	"bLdq/ka60v55YoM9tZSZm+J946ZCGuZtTOsviKW3sg4WVowXOZLhaJHfMTlDHfUwomZ/OrODzibCjici",
+	"z3HRsD7RZrhZwrIc1BNDRl9pxz2x7HXn+uTHm3dXp2DGnXf+etPvXLztDtiB3zT2/fHx4VAB09l5Lh2T",
+	"yumggBcmCc9zm4BU3bAdvTVD01xfXt6cdfpvu6DSgVEqGE9TMYcf3KJacHsYtTXry+y8u768GVyd9a7/",
+	"jCfarnP9mtiK0y9slKfgA9GetBP2pPP85cnzJzWjooWfRQ0/VDQ3x/0JP2e4Ry4IRuO1rbDEjbttjf7D",
+	"836SGBds/X2QK4FMdrJRk1gZEflskyqeBCrDETmgstmKCRYRnFZrKit8TBc2GoBcgoTHFcQOgL5H6RS7",
+	"lvBzhm4P1AbABA6290F1bLQu0ChxqL4Qe0t3WCWpjdk3rofSzlwzkMxCEEnjHJ7kltyG1UTlRFX2bt19",
+	"Q++5MVax3Q2G5/oUSXHUUVrR2lln+LyZUlIjYLjIBvBR9RzCcxUm+1vr0kxgCUFFa/36kK0X76UFUbxt",
+	"ap4bwbMVw2fRX5LRctDntFBOL9Lp+qoK8Xui1Vg+aFVru159ab/Y6DYbrrLom/S8nspG/hG8b5ZT6cRR",
+	"zkcCqDkT81yvZqR8F2zUuxhcdy5OujcXnfNuMlTF32eXby9v3vXPyLwuPr7+sXvevTm5PLvsM6Hu2T03",
+	"INnvhTHoDSdLQwwVbQpo009KPfrm/6Ay12aXMyDIrFxHSo8jJyitBKpxVjiS3WsGop7odyZiTE2dmx/Y",
+	"Q/aufxZs2zAzgx9tyImk9f5ooo/gwyN7J+dHek764NFcA4uY1ktnFuJD0sLLt3nf4WsU6lO9BKk+z3kq",
+	"YA1DIhN2bXh6J8ywVb8+0plg6CpH9whruNH3X6abipk40XlMgF7Dd6C+gEZsGWcngwGbivcMb2C8kL+D",
+	"O/o/vGOlttI/PON//E786aOX9iFKz4JnqVnMRpvyQkZExZttDpb4AfmfeNVpu1YpgQHxyRjznUxFekfD",
+	"UTymUbkkP1Avs03Lwes/hfG23iE1k2OHBCnn3HPtjYZCcU81GEdb7fPqHK2mONadWJGrL+IwC2teV/ib",
+	"3aEnGijUCbXtSGR2glp7RM/Dz5nMhHJyvEIHBRijhviYnJV+s6r8ECfCsGYa1TZNZ8Nlr5fBEh7LPC+U",
+	"6mBWeKm4MCJjVrjD+v1DkYyk1ZnphXJlrCNpXRk9MXzGLsdjmQrzsPtypw1SXyaJrMOPMW+LU9l9ro1x",
+	"hF0bfo276Dc9HCyoVzbVsK18wuGKeJAyF/d0XCrBBLim2FwYCs9heCIX3DqW+td5mL+rr5fVfdipSKxv",
+	"xxbXwYlWgb93GMtpeHLXammcYmAUANzxh0Q230iRZ0hXpH9GnEL1qJ8N8b0rLrNoKE9mDVZYUBEgJqHJ",
+	"v1Sn4vVY4gZh7BQryC7gGeBSYcwVvO1K/rYQJPXKyTCGfyOz2DSP6lsq3iGhCxBPLKkcegPxgMa7aQ9W",
+	"NNRtlFJosh+SFoV3YsGjt1pPcsEuOws3DVGguNzNpE1BB42r+WRiyVxU3QbSMutA6OYa4AD+cOzcCJ7h",
+	"3qDe+7YKrWgP1XXlPmA8t9qHsCzjrC+cWR110D4kZ+Qrv2obpPySS0e+jbEA27Ki+aIgiodfE6IO+8Zf",
+	"8hE99DQQMz3JjEY8BDzPDiAsRpo1vLtM0asCdwYTCqJ72WGU6vhYnOtMbLNZK/tpFsqSEjnovOnenF+e",
+	"dv/sDEScT0UucINBFiVspu/hDwiZUexuqJzhyo6FgamZXiph7FTO0QRwMI0R44UtnUbfJczq9a2dSoxd",
+	"aTgXDETZps30m9ChPejSFkSjBhiRW9+zzlUPiIffc5nDT+NzYDwTg4Pbz2uAD/ow4lTnWaDE/U5wqMIR",
+	"ttk5t3ciYwuVC2s3PGTdv15dDro3gx87/e7pzWm/91P3pndKRxQPgVZ4Yfs71CycGgt9FOmtG8dBPjSd",
+	"XFRAoUV9qtPGW20mZ+Iaf7b+Yqc6XYCZzGDUNjtfWMdGJQwmeEZP+l1wNp5entyc9867N9e/XHUHjOe5",
+	"XubSumSollOZTllNWSKJdqpTm3jPGNnXg1xmwq5hWmpgpXuVtSf48yM+n9t25le5vy1UvNfGlXFlNGwc",
+	"u9BORP3Jc24aZPQVfsO2xL7XjtNPXmz/jtNrUvxitzr9LGNhaxoui0XMdQAuA6fZvRTLpyLzLv/KHheB",
+	"2IWR+1mSME3zy5Esb6ROFONRQAUn8eA3nFsKVOLzT8JFBeJROpZyBYSLsDgMfQJUxgg4gexjTeioetT1",
+	"H/4uyqmbFWuYxr2oavdufww1bYV17KKlylk9AhGh4P1Zmzs756l4ODHh71nvNGF4vXJbJa1NKfHLVY/O",
+	"+4qnd3xC7p9PduLFTbL/qYcX23+HHkIAtDtbj98uRvQ9DbKPSVcshmgyZl/uRVSTyuo+mrRqb9C8i4Op",
+	"Ni5dNEN24pLj0rsCmfW/j7gPCBf1xOJXhw+jJy+ZwJmGy/RhWj+XVM04rO36zBhNhWJUXo7p9M69LSao",
+	"rHyfnf0Y0VSsa497V25dRqmzXfNR4zF7iEWjueu/LwJpGAsGh8uzuueqaux6JxYPTixv0D/IZUWDbIbB",
+	"EKNXgkcdH1XX0XojQFkDU8Oy58fP/7j7bP1iwz7sv6FNp9uoXV8sZsLIlPVO196A0EoTmYFCKp2FYEcd",
+	"6PqnF98dP3/2w4s/VqSCVO4/XkSjrZ9r78Kbhhlje3fK7XSkucm2xBELz8M2Iev9E4XlvOt5uncGHuSK",
+	"K0+Fcm/ARo1A2bR1jJ7IV2ymMzmWIiOLNpgJVbtuX28fTNdTYx0j8SU34EGKrGYgyNylSGaKxrDSaLLk",
+	"mmciIwtjOV21Pj5ASftZWUb0+EQunNiW5/DP4YuL3/W4qE6e70YM0T5UfPzot0A9vXfKDHfTALVB87iA",
+	"s296EvYP/H1GH+GOo2/i3Awf2Y1AC89VRdoO6H7xIGzG7vQF/8OkWNLWN7Lb4C/p3WI+iG/9NR+REUmT",
+	"0MsRxkHPQV44Tcde9ZKcds+6192b152Tv7y7QudMLLLCaGJG/PDs6Pg5e/b98Yvj79vHx8dxlP9D934H",
+	"TmS/nUPg7SPjWJMWgkQfEmTwAiHkAiCLzhbW+YSpA57n9PdIMPHbgueHcFYjESNNb13drAQ3rZfPj5+/",
+	"SMqYRN+j3SJxiQZOo3eJ7qpeKpDncEk0h57hBtlLt838cFFRqnKpYphOe4e4kuo4I9S4lyrceyOgI2Fq",
+	"Us7ye7iFkMCls+hXI0eZ/T0ir9mD9wbVHlieeD/XxjEe97xp4x1v9DrcsoNwKP5FtRVJyBShDI2ltOKw",
+	"0Us3z8a/ByJRC+nTWUZpAbSKk2lALm7SwEM0jZJmIuHsmb4XDWk9uGUQSXWG2ykcsGcPdPHDdi1czUNs",
+	"UQ+p+rWdEYKOQdqhUprlWk2EYffSyhHRV+lybbMOwwXxvAi6KI3LSLzDS1q6USkPDHLg4BRJL8Prl0Zi",
+	"Uw7/RQPFSp/ygmthB1L55BcLy7KCG4gZCwv2KuQcokpIs+AIPmeDh6GNsI4bECUrNtFsocJaXsFXK5Wi",
+	"Ycl98GfWFKMIOT4bIQlVyc9hUwRCo0DaJ1EkTmDlMUdJLeDr3xq9mG9S251YxcnDZ3TdiRVLK/K2LtNB",
+	"o3txdPzsj1EwRTTMvpkeSDlAthI9kRg0rtmcz54nL/4UMSqrltA2DZjGa4ymF4nW69ZKLH52ziETRpTZ",
+	"dUZwq1XCrHAodxABa4sIFzcCBBkBp5322Q0vh+ry3fXN5ZubwcnlVZfNBFdwmyFfahN4rHcaMjylKnmx",
+	"iNZU7ZShOtCGZVrQ84hFPAT2THBVni8SP1jBOZ65tanydlJCuAvOoCAeMDXAKZl0bfZT56x32rnuXV74",
+	"TFZ6DfohYihGRt+JGgB/LEWeMRJlr5gVgt3iR/a2vYEjp01BQGLIOZzyjDKckRTWIe8ecE7ZXioVfgIC",
+	"n7eHCsJo/euNGXD/ilfkKlw8EJTGO+/Z8flrhqO0h+pk0H9Te19cwF+P4POja30ngisF5Stm+kNg1LBM",
+	"ZupJ0FPwrSbuJrVmzFKt76Ros3538MvFyU2/+5/vev1ieO4XGESbozm4hXXidVeclo+z+6iqEaAkVPDP",
+	"6yGqKgnGmHgrmHsmrOWTaNyATjRytcPnR7m4FzmbGz3K4QwPNskIJPTh/oa3yLNuqF6wbnpX8hHXhRym",
+	"TYCiGA5knYoO1gkyrKvYwe8r2L4mbZs2MSp3kMyQAx9kdJeefwQxBMZELIQo/ctPAmUXfuYHhwO+BoxM",
+	"Na6OgzRB2PbEqzWawbXjaLIax3GvUhfdBfAl5OILU1kt7f4rNuduii/jw/uF756NhFsKoTZ+gypX0Nc/",
+	"hR+Khn3ICFtrW5DBvslYgfrgfo/kBRYQ0lo64E4YKeXA0/7Hjq8iByKnJvKskYhhi704Ypg3mjDUMZWr",
+	"UXCB0Nz0LnlRWEvdD0aoVuggwxIWCeukkLKbsJNc23gg1295XHGaa4sHEXTzGtKVrsgDn/mCaXa2kFk7",
+	"pBTtUPkq8R32hNGUIPJ6tW+yuv9BxRqdGr2YeEcbn8/bXnb5OhfcOSNHCydIu7AiGAalkVHBp7RZZ2Qp",
+	"WhksiDChyK1AOx0tl4CDGqoq2obAIac3r3+56Vxf93uv38HlVE1HiwjK2G2Xi4bQY7P1C2gUtLGjP/Pe",
+	"6uuogXHGrSsd2mBFWMdn870T0RsQBPAWcQh+0gKZFnE1qFRYV2ixYCQunDAzbd2m00yqNF9k4gqko7QB",
+	"Kb2XqKukIESh0BRfO8UiGTsHG6w9Dr4pMfpJiuWZVHd74ARgn6QKPpSPiuruA695I1VW2HXNoPk7sdpx",
+	"dS8RbkeC2SuMo+LyngvDSOB+BSjX8l322ZBGF3Z4ph81Ta+143mlJAUlVKdGWwvuLjYBE9pGo3D+qx2g",
+	"cjCmp2D8jFa1Ik3CF8JR3irGRPt9WWDNwt9lCBcvUd+Mpm3dWjIg3ZUmUVTzSOiKkpberUw/piALDXS4",
+	"M8r8McHlscxdQ9DbQ6TrPmQhXILHrceMEID4OahtD4D9v8FZSdCrkAb0mcsOhDfffrZNzNIIFiAMNZyq",
+	"yPzJ2aReI0KPi2SNJ9Y76j9BikZRg8T4qhQfwS6YrdfXy52MEt5/i9eoli61uYHcdrZUpzI6F/HNhfxq",
+	"hKhO5WQqrAveCZ0LplUF45UU2suKTfk9Zdvb3QRSriz+Vn6Hmsrc7efQqwUAn8eEZlOOPebu05cJSsd5",
+	"gchnB8Gb4gyXOfwnxZII3AimFnl++JA0fLzdtiThvxUIIQDF5BOEbDCnNOor0RBLCdpHZKjKOKAaF7ih",
+	"xPOhr7OCsRmEL7Fc3gtK2xXVGNFDgzT7hzRqW7XNcn6YRvdw7fKg0aV5+LDcrW2q5dq+4CrJMN22OZ+A",
+	"hibCxSN+hQYd2S3wEHlCKWUHOSOK/O0acpMd+J07xDhjMLG8D2JHeH4bkcAkP0o4utVHubs+mwNrEptw",
+	"m/a7WfTs+T5Fz6ikVLzq2fM9qp5tUx/K98DrEkQC0+oV23Sd/a7kz0ZnWv209yg49rtLiDUU7Gpc3DlX",
+	"chwlwga398/TVZWFEKwFDv0RRFLzO4gSehSZZWI2d0XujIv7yP+tHInNOREVhqsUiMEJnqArqHQ5Jkyb",
+	"ytfEvODjWSjHJ5MC72/3Bp8Xr7LNs4jkciXMjOdS3e2D1N8f+/YAUPz6Mhov2sZMw+uG0ACWYZUOY0pa",
+	"NakpmTDRwh0VN0ktkfHhk0Sx8ziu07VxqfApOV+87cHn85okmzo3ty+fPsWf2Lb/oq3N5Okf6MOnDzyb",
+	"pnSOOv50E2e2CmWMm8sybAr32BVIuXdzYZhdc0WXi3HgxtgGFZusSfgmhzANlJTLj716D+uh9fXyygg4",
+	"jG1FbdeFKXe+nFoR9ke0JTqiMl0p1SiVFaaseAX/URQLzSJ1G5OW/25nGYeKA8z6eemn7IBmspuByJ22",
+	"axRS8Zc1HEVZ3ybVM2/S7iQ/AjH4/YwdBtT2JFxRs9NmzidiUFTOi+knZZS5UmST+BgoD8zTzdSfIq+S",
+	"UlPPeoPrm6vO2+7NoPdf3cOqXvPseIdisz+EDN4F4+0xZx6EyHHpnM2BNvXCFrnfr5ieSec1asYZgn6Q",
+	"CvFnAPjVCxd24pNWzamdUSNMflqUBtvPCVhBlEVIcsrtuTaiOUkavZHh3LkRmOku1eQVnTYmt+OuMCXe",
+	"u6uw7UzpZcQiSFq1p5oOx2lmHUwMusmcW8u4ZfNybPHesb2QUOUx1ectX7yJWRAOtrMS0JZSRhh1l9Y9",
+	"LPtxW/gHy/6SFokyQlpWjQjtl11cqDR7hngqk+Ipi/c8dbkvOrzhh+xTnfam4U/IlrLbp0HRW7W9PMbP",
+	"ifcuHmjaJbeKeKA3dh8moxIGgimk3/sg1P5Ca7s0qnNNk1Tar4pzhW53QhR+t5oP7xQCvtvGuArPxdwB",
+	"tlUZqIkVaxbZ77m+qmZPjBzYs+P1o/2KTnZ9G5qOuEyM2uuMa8M+ykEXWuXOk74qut/YB7vE3qyjI/eF",
+	"ye61mKbNLvv17L/j5cC7/YeV4WPrPNf34hM5EAEpHJXbYnnVmKhbT2KcV9PAo5LaiPt9Biu4pDYiOwjK",
+	"VMKWUOAHEzccoWflGNGrc6PvZbZP9ZECJF19wdgeX1Xofz3tfSIVXrNsJhxHVGvZ7wLUozk9ITImVIYa",
+	"oN0oermfCoaUxSggQNfhvAawrKhYDbDGTScjjSlVbLyKjNtXZdtU1CARqiiHBMPgd+yAEwLHh6xybumL",
+	"qD6kx2Mbc2X2oONQScTGOnyf2usklWJG1E3KLubUSYaGjcfsG+zkdRQAbp+f4k7p5R5VeOlotmufFfGw",
+	"iVOQdp7z1UVUVUNJLgTzDzUCczI941Jt+T1+j74z/9+qGIoMiL0nOtT6qXlYfApHRaQXqPYIOaiOzg5m",
+	"tVpHALa2DWWsYuGAwhb1AzZArOIR1kow9cBgUDGB6n4zoRz8FwurA2Je5uLSTLiSf4c/deW/S9Wg1Luo",
+	"Qh92Br7FevImoS1J/MYnjKuVVuJwP4gQvpZ/MkpZ8l43o4n5JFJirTOZGDEhGYdmBcGJMYYP8dU2u9Dq",
+	"SPmc9koTHjB+5yJj4n0q5o4QnYC+q/hu0lAYYDFrJS1+P0F4k490xL03Ot/XZ4PFkekygbcuSkUeoHkP",
+	"VMgZhItzsQ1jgsl/v/5+fMJeS8Sf1hbxMaiWT9bgDBbZDIvVjHvSENC40DElROaz6/Bg11LpQhWIB/TF",
+	"SJAktxBycymB/C9itRN0VSWMpAIl5+HDAqESrplbIMBb/20Nt7L/+UQIZv/FIhqsttIqkKZYJmzfJ15m",
+	"EySDPv+b/PVv//1rSRKW+df6m/yV/c//Zf5E4JkDAGOEcuAUgMSsl8PoOkt0ql5QST74OVbnq+eEF9CS",
+	"wv/Wetn6P+Ncc6iPsfMFNwEgeChJQUtb4SDew+093h8PuwzZGrghNQ+wpZxXqiG/ISm2BqDjEqlXG/zh",
+	"7SZ3d9upHU9T/4r95NVDoaBbkFlrR9VYnAfjCnZnXj31NwgA2UqPnU2tsuFmyLm10CiTB+i+4GtnjxC6",
+	"gOB/EKRtIwQT2f8yXrLXu4aGCrMyMcpvf1Mjoa2bWCPp6m6uN6pr0qg9K4TzKieNR4KqhKAhG1krvAL3",
+	"qdq3R5CuomTXba9gHtYKKFF2MqVrZjplB5C2hcW/E+Y1TKSKhFEl8IRRuC1hvwheVTT9W+0fHsH3iVsR",
+	"1fpAVYdZwhZ2wfN8Vfnm43A39dpqa2xffFep0+XRamFVldrpOV/phasrTXAw2vLctpKKP/qNVFylkucP",
+	"Uac+Eg1X7u7+hPfwgP0eVey2BOZ/V9W5x6qP55nhVKdRhEKFVeLvW/5+2zsXVUArvPfQlIziWKqbXNuW",
+	"tbdZX1yUMhZmIqAm9glPp80+PTSkI2/n86esYCn8PvOgX2SkXHBThjbxT0LYayWe1HUJq2dCK/H/BlRE",
+	"qmefNMy5/paNrlV4buutVH/NTKSSnAih7MHOy8NPETuL/1xox7eYGNDpqrGwOVSSLnthBRTKUqoMbnMj",
+	"CNvnsZm1qjMvonho8JDhgjCFseO2FJCYlSXM2IvnP1BHBCHQwFnCiWMpDsYnukryW3O/fitmjukv+Jq1",
+	"N1zvBff8h+obHsdekH44EFCcPjLJmVATNy3zGnPEd/vZ6EpYKOyQfV+Hl//H7hTs+tSJP9n6W8cIBPtn",
+	"nfouCI3ZLx+pL5D2S+OwXDgnDFrWqBs25cQm1cyMh3ssNoyvhxpNa3kRSbEP0e0TPMO3bPZH2Ut82u7T",
+	"ZB3jeCH545amsbe+/2iRVDz1Xr2KQsEtw7ehH6LhPFQHlN4jLVWpR8DmYZtRj8WQZ4ARPQTc08TcCDZs",
+	"DVttVnh7gPy5GiocwM/OeOg+pheOKvhxZsScUlD9M3dCzC3C9smzXfdHtIfqQruyUD1NRB3hmurOAIdD",
+	"amhfqKzSTsHvamvQ7fc6ZzcX785fd/ut9f39US8ZgZ5guZg9iq9RJPssqZi/H5pJC9jXN5f98871dff0",
+	"JexypXo8ZtTKwpcOzV7hALBTM3v2px9+OHr2/Oi740NG/ayIdAshA27HJ9b/lpEIo7cOjsX1lykWcjO4",
+	"7vcu3kZ9izuiJqHtSzRuzCm9ZHuoeFaU3tqDlKnaEUxHNzq6loNAOLhcKnI918ELlz9fdPvQwu3d+cVh",
+	"iQIeKisnSmRHEj0M8CSqEOjdnmO4ar1tdL5qs4539/pCSBjKmiTM6qGi4ElCZU8Sj9KZCMJIWY9FDDnd",
+	"lOxPeLk2Qy2QapYXsZnay0HBqGIdOPSU+s+VHeUwC6rNHswDSiM+9u/iXMC9j+y8T5vYPA/ZiIKy/zib",
+	"4QihqSNVgRT0aeBSZr17yzoO0UA8A3q2lJSbi2yKd5VqT40UQfuqkt7xo6AU2IHjd8LCF6nIBPApXLU+",
+	"ihYNeTS0Xy1KR6+1YH328r/WO7A+e/lfjQPHOn+CRA/9aekhEBlaEcVUhT5Koaf4jL8iFEdvi8S2Iyii",
+	"cGXsCTnInyTsCfSq/F+dZy9Pnxy2WZ8Kh9WlIN4gOPFtUl46RR/YofKVI9rsyjNTjCKTApFvBOK42Jjn",
+	"ORSGxAjMimSD0yF8gWJ1rXpPpYdlsezWr/v1pPs+0gTbxG+NdxeFaI3eGWmZr1fbqZfM8GX4giIGs/nC",
+	"kQvsoDLqYRKkP26ej2zSVhe+p4TZBXUZH7b+97Pk+fFx+/h42GIH1WE0XRWLnFv64t1Z57B+bdTfZv3/",
+	"78460Xtjj65qnsZLUuogUBf3+8nhK1aUJvRRz0C81ueH7AzX/B5zrKKIbYmnPL4+m/hO2pKCG6HN+FpX",
+	"sN4pSIayJd7L1kkr8Q3zXrZeR/uE7VvcHIUf6Vtr5Q3+1uqdFtMUcz8oLKerumwt6lxI8w01tbggg/PX",
+	"CsdKtbi6QL8zG1kLxTZRbZnKXpUuug+/fipf/sOhYlVx/jF9KUsrbIPmroQ5wsFDlUcf9Z0tcieLb3i2",
+	"Lqppr8Pl/qogD6px6RX90i6I2WFxV3950gfiPZj+QOc0OnwcDYDtEZmzjuc7e3nNjYQMlFoPJ3ANLFSp",
+	"QcELom6RYupUULfgB0eh+ibcR7szPTcswa3Ivz6WojsxAlt18nwb+h1r2HXjDrDBWiOqAEuD4hojAW4P",
+	"G+3zAGsPfea29QsLz8BGAP1AO/Hc+k3uX15e37y5PDvt9m+oMuNC1VWjahMxBCNFZyLawjXD+RRpFrVr",
+	"ADF7T61IjQBe4O3/tlrtjpPRrEltF9dePno+a70qYyDDaKO1vp+8AF+QQcyUVkejnKu7onLopsoqG1LU",
+	"KvFu7JNQJptGkPRoqO+xLjTHcUUPyueZC5MKFbn8aUOoF7N/CMwjCKSF86gVCkwice56XLt2lFvidGtv",
+	"ttsFijFjf4CVb8qXK/exgTZ8QZMGrWEbgsQJW1dvbGMVNT3fHEj8RlFIQbmumJlAKqcT711SZg+Ep/iG",
+	"nQsJsjRJMlSISHPF0wHUlKLXZ4KhKF/3msjvQFWgT/SUQg8r4RlquqX4jVpv4oJaSWviospkA+iGOik4",
+	"HQz20NyWHfCKH4RN1kA3/s7f7PngOUbPA6wherYDrJG8IxFmkefX4r1rKMlC6GJ4b6Ec8MMSjodT5oht",
+	"s+tKFKZyNfmqHYKqy5LN0P49FcUflCKymdhGqNWvPbHtIxMO9p/7t4UwsaLQmI6lWa41lpGvx4RXV72d",
+	"NxMNvJMGH1h385wY2Vv4ePfcQn78rTer/klSYLCQ81aQ/3b87WaRRacJpo570lR/4CNzLJrAtZ0iFklY",
+	"ilI0mhDOIIBt3MgWKrvQrsDJNGiAP/J74dkW96R8ZT7SC+ddBtyIT134Zg0D7Tdhx2k2KrlUjrJZEeWU",
+	"bVGinEFT9L/C8qR6jrBp2G2uSoyNVsJ3387gFFHr4ZZJNAWiimo5x/aEDe/nrSxJq2pRiT2J5LrqMs5F",
+	"PYm0QXbUllhgoMMexo9ACNdQqxSvxhPEqr78xz5dtsZG/10oVJH3/xHZR/1tRbOMXlYuGXLy1T0VB1YI",
+	"9mO3AxZH//LnwWET6uwhK9vZtgwfwBaP1pHVSGUQqfso1lr++N5k0FkHhkEiXtg1P5+vD6/H1K8jWOr2",
+	"oyvh9aoVBIuNSmpUsHHC1dNrpK6A99rRH25/8ExJs7uisH7g+NI2qp7WF7V/D0XsueGHY5TY1GBb05jn",
+	"u4vahgnoBw2SI+bfG1Dbpy17/XsbSsU289q3Nr8MXc0fI2MRs9NwhoYbL15BeSRSPRO2DK49KBuuMl/D",
+	"i9vpJ0o6JKRO7L1BrHMVta59F7ZiHL1wRk6mrhrh8b3oCe7ksV4WOkBlM7AI1wpfP171u3d43RYFRT/G",
+	"Sk6nIr3b00zGpla20YCchmQXqlyaLoyhVocQK2YHRTg4l3dlY5KffL1Fq9n3kAcf7OPvk2OMtKzZnHNI",
+	"wYxVL2+yQv2at+7eOJep27cLSydf8pVlJ5cXb856J9e15VU+jGUXxYvCXE/L3Sh9WdVUnrLDUehwuL8X",
+	"yZ/CQ/qceUeYV96wC8wyicQPfLES0iOCyyO8QBlKx55GSjvUMmL+7KLoWaXSA4T3CF7tgRukRuIYL1lT",
+	"Pfxo/4tyD5qJYFvzycyjsPbdPuwGgLYIacOR9Ic2+9HrWoR6n88FN7XSzKlAJwW17MkwPInxBUwB6Ovl",
+	"ywIGh8FHTqYBeQWHiLL+w8Ww5ZsDvrs66510rrs3pM8N/iwhu3UtqPuPFsHAsbUIOBTWgzuxy7HOxQ/Z",
+	"JYjUz8BySMGBQyeUrkgckefGE3+luQym8pIHRRtm9awkNz2mNjRGL19BtjZXK38bzLDsV42YsBXRlKBE",
+	"BWa+DItTNGwMSsxQEcjw+AfsHbYKdRrJT+zpKqzU6upiU64IsYEjG+HMCpBVHpNUJDhIg/5EDB+zg5oI",
+	"HLbgz2HrsM0GW/hrqKoM5iPh1FDIL/67jaMuzhZ7Q0SP9p+55+ony1IURo5X+8CqOBFPICYQn4SpUBlS",
+	"UgCrPod7MBPsp26/9+YX33XoECh2QK7JVAuTAsdrwzKjMcsViJmgeezaT1K0p/BNuhYq00rEoUl7dWdN",
+	"SM7FRORPwqABHLVpMaQe7wrxGr6qdYPY2OCJdCd6FoXGvZUO86M9mn4kFUQY4Q6AKV0IW20Oqf16I0Nq",
+	"ZhYKFsTu/TO1NDf9rP38u/aLBkqIj9kXueC2GJAdDFuZuB+2UERBX6Qc15vZuiLzrP2ifbzzAitXWW5U",
+	"Utny6tvGTm49KaKh6G1jsaamMokf3V8iXsgQOFakCyPdagAWKa3NCvS7nGCHsggz09dlGgK4pamdGWZs",
+	"tV62ir/ofVoTd0NP3zhfpytoS3P5FwHGLtY0jhXjeA1RBJUh9h5Ott4Nb4GQ3bVWMPQcQfY9c4M0IAci",
+	"HAL6GgFbNVSdPC9reIQ4G+MLNxXKhey9e8mZ3xT/ojggCanSRIPrjl5zqKrFK4sqy1IVbU1hLbiAq8vB",
+	"deHyIP0dO3zhrUdpv9U+c7eFFmhFYQRVCwwO1a1vMXdb9Jh7YzBSk4WW41w98XcWmQ74Rgewa55PGByv",
+	"IneMNnIi1eFQwb068WWGXRmLuH3bvWZPYcee4qwAovNvExRVTpdVWW4Phnlx/B0+MFQolyst9nBfgtmP",
+	"DqPg4Z0LC1k9C6zdkGH0f9lmJ77tIixQz6lvomYcLi683IW6F7mew9b8A2RtgmVdEura+OEWVGsrFMI2",
+	"bv96FCY+6vqfvWTOLMQt02aobjvYJ+4l26jFBgd95GNe7TDj/wMB/NtXVY8fLBIPWCj0s1HVgqHyzce9",
+	"DkRRjX53cHV5MejedC9+6p5dXnWxwfRtm4WlZUX4x5YEM1Tb3iKQzy3sQZtMzls2k9TBEhb64/X1la82",
+	"SpjFUBtOKwEYYXYLm3iLX93iHt7SZQhRUygdET4MTV+Fr2/svXykUywNxysWn4VmjwGRegvkcJsUPSKT",
+	"0MwxYbdeB8P/kgp4e5j4zfKTksMSfeS3vrHVrb+dPUKqLkE6GMcqbpnWs/Zx+5hC00LxuWy9bH3XPm5/",
+	"16La9Cggn6K7AWn+CJOVnmLGEXw119Y1RklQx6YgqM9xomZV94LNpFoEIP69XhRfQoENPucjmUu3Iu4h",
+	"m50PFXlZMrZRHYVSbYvux5h2wJba3GF+A6KlllOZo1YjLaWNeUg5rguf0SpEW6A4fEgqI8PmFr/AY4fc",
+	"MyeyQ69s3+s7bzoElh+q4gWk9zcUZYMCuRtx5PeGBKMHqfuQrKTGBhzhyYA2H1KU2wtycEmtZZ95yIOw",
+	"7rXOVuRSwFg1/LfKusCf8Bn5Y3cG/KKJfB8+0MXrGREGeX58/GiT0jR0Za7nbKVoBQluqIX5i+PjptGL",
+	"5T59zbPiTeAnz3b/5J0C0tdG/j3M893uH73RZoSI/5ragRjHNYXjb78CdNGG4sqtE3ijpqzAVtJyfGJB",
+	"30GubP0Kw3sOHWntrDN83syaJxhRIpIFGsu4yZjjI8sOyDzBnG2bMKxDf6YnCTvBROXDIj9AmiKCI1G8",
+	"rVim4YrFCF2bdUOgDoct8oQWyhGje56QBA3mY4KEALY95A/lq/YGxb8O7zYoARatR6TDYr5tJFg85MGZ",
+	"n42kEjDZd/+ip5wwiue+d+xDCRFppcgUq4Iu4Wi3kiIpCLNQ57GZIH/m+Z0lbEOtnn/ZGqDeusM7ORZG",
+	"hcyyXLAwT0LKBQfahSVz53g6nVGX6mqjMyyYDvkLwqdb1ib3PaRhaPsKC8INVQA3tOv4FDS+EdKknFTB",
+	"ci6ud4l5X4LPPNnz8BqgmaKOSd0VUDuVtugMj6BAZ6lyDmQ6wQEyw1FFRdfd2LcHqriS2kN1XWpeS9xY",
+	"7iiX9rxz0XvTHVzfnFxenLzr97sXJ7+Etw29pMtMsBeHsUtns3znI108zeVSP9SNPo/oeTQhsKVgaUQa",
+	"QKmjuUdIEjmV9P8VX0+fRZbATnrNuMppT9YYeKtMWatSGpcnfS8baK46lGO9+XziK1ECH6Em9sT6BEHo",
+	"pI7KYHB400VWL+tXMXOscJZB7/VB9+aq2z/vDQbQ7Lt73umdDdCMaWKoq1pRwsfipkhJ2i/ASrFatBE+",
+	"qjxWA+B84yEwlae64nkhyz+ADBo5h9rkH6VlcsI2BjoimM7by8u3Z92bQbf/U++ke9M5Obl8d3F985fu",
+	"LyFl1z/RuaK4D1D8Sb972r247nXOBrishBlBfkmKm1RLS3g/RpF36rMcEn/HH5mFsmW6gr8/jXaI/ILU",
+	"AszmHCoxHovUVdwvRmC/gzbr0GPgBcq0wHTuOTd0LxflHUJABoLHWqFajHn0Q7WwH2ObbSSCPKaa2px1",
+	"ErOYyscYkYTI/u25inYw1mObUT+SZrYiEGYjJ73FJoWcvKWED8WO3AgQ3WDg8lrCiCMVrkOXH7kqampw",
+	"wjCvF0gdah/BwJQjHNoH0WNt1lErikCK3IrEF2vxeuPamEUjcs8PFX0/IYZZC/axTonV8QXVhqoim+C/",
+	"ZC8iDNKHRl8Rlod8PwOqM00unRlXfCJMaTMOFc/RCUMzHse4rUCiPtLtuYFb/sz35ibSNsLY3uGGh/+1",
+	"s/SL4xe7f3GhHbZp/UwyADd5kw99oteCmp1vkwNCOPuU0LpHjo+2uEazDESC46Py3pvIe6EY+muRKVBA",
+	"WHbrvSy3VGYPQr3PElbUdCFAJ9h8Hm7qPa3NxTAQj1oPFMGt+Ipx/D3dk1C4wjMz3q+qsLYBnADLq2Aa",
+	"6I2zGF+S86DirLnmo0di0dhUX4hb40tpZtxrr/YAzXzlXPvDJ9ukwKObYqzKF74xT7hZyLtoP6/ri687",
+	"vbaKAeextEc6gGmb5cA5FiIJQUfKZylqyOjxpiySip2vQrzgDTXhVBAWqV2h3NAYRR54hh/TzR6CESt8",
+	"bK282fHxq6GiG9hf2v4Wx6/r97RAbNFUzgNWNSYANpDFj8T9jQjmz31Rr+G3Yw6isEQWSMV8U8ALQmEF",
+	"25QM0MRv4OB9mkvrmjksOILWywnib7F0k7MJCF8E4UljXcICUDpfMcWN0UuKrGIkuGj3wBFq5Ov2tVn3",
+	"nqLxulptIERPSKAV3Tt8FsxCBcBf591p7/pm0Lv4y59RxryqhDH9YBWT8wn892gmZtqs2JQ6Dw/VAQ3y",
+	"Y29wfdn/BdM2/esdBnUBzeHQspeP0fyoWMklmjyBKlseBotmd3Ck+XwmDhBN6VavUB2p1MShLNdQC7LJ",
+	"2UXNjXFtjyQNaOOldZ8pXFmZr5nv+0R5dCjf3FgyzowVXg/ZP8jsVCgZVjURW/g8wGF9WWU8zIq9ewkx",
+	"5fBQ7xQ5ed3eLzIS6pT7VjiKgT6mI8fPEPPa1N4I0ZO1akB94czqqDP25QvW0WtYERSkGLS8DGXwECYM",
+	"23IAXE/wiMLVJtUEQITlujeKL+AqyxN9K1wVIVQ/g8qx0uf+WDNupyPNTbb7ZPFnCeMBX+ZnDo5CpCSv",
+	"/3j5VC2Z1h6qgcBSVSSqsJeTyEo4uMtXYANZeojMIERlVwAfMNVQiffznMtQJG3JDaCG7W1jYG451Xk1",
+	"PBcjrdNiHx6RuopJtomp4iE25ytwin2tCIm3HpaXbSy4pLXiu0BuQB9PeZFC16Q0UF+tEvX+pOpqxzB1",
+	"cK1Vi+d5tAZmHMFv58Ic+WMfKl6BQxHNLlQBiPIRWCPoe5EVIdvOyUl3MLg5+bF78pdq2HaoKnFaeJoU",
+	"kqgRDkMSJpdcRI9lga/P86XM78117HSazUWwsf7tb2bcvgq5hzKUlWLwgbuAm2qcVenoHGetnkqNmAnl",
+	"eM7sSqVUehOYhUS37xkNoaE2g3rcRUDntqgWclt0tB6qekvrxLdaDoVcc+6w4onF3LKA0gh4XiqTUs/V",
+	"GSrEXZLGX8GdUME0XQBTnBFQ88Wn59GsblpWhRyq2xpE5PaVBxH6bnm3viJiUm09TZmffMmxJGZmuAwl",
+	"8/De6NBL+p0q0/Q4wnNhjXYpjGUvnh2Tvd7vDn65OLnpd//zXa/fPU3YTHBVuP29FmSnCJykSBDp+eTZ",
+	"g1fG7aUzKgNruIom5f6k6FL9WFHstdbvXyCCvd7YPKau0SNEVSV6/Ct37z07fnz33nXYC8JPByK+57nM",
+	"XrEMA2ELhAGifKAEpjVKPvycRkpETDBOwqtI7GgWiBQNyHS6G41JpVBCsoROE/LPY8oleQhDGkBokx5z",
+	"tp9iA5DH87Cf6vSLutVx/i3qa9iiUDXl3/46D07sQDz70Ou4zODag2TjmT4N9OmTwx6TROsNsL4Ila61",
+	"QooQKj3xjUzXybTsuL2LSEPNln3INDy7XkbKu5ejUcsw/KPGK/0kXzZSWSyimVzDM98IdiM4WNJJM8lm",
+	"eqnQN9FIqwNEiFsf8nhiQ6XJNns3zxGk5TURbgSzvgQlGh5ZUs3KIOsAKhENFZYiQj1e/l20K6qF9bqF",
+	"T3Yb5DITlk052RV+Zp+3JjEmQ80ybme+9tEtZW1SbNY3LqG2nnNtHFVCgOr01fzKKpnOs/Hhq6GixaZ8",
+	"bv0vEYb+7Pj8tbffzARRTcJS+uTz5/SqqBQC0rZ/fXN9eXlz1um/7baH6s3GDlXB/x6xeytVLlVhghVQ",
+	"J9ytMhtlqOaEqwqFu0ZGL60w7EDO+ETYhF2dvkkY+gKxqkIULn/qD/4RwUnVKT6ZHNGpE+6I0hbqSynS",
+	"sSlJPpKR/SGJVYcKE9Xc1if04dGptHNti7pC9Z+XZ8i0YXR6Jc0HfvFJ3xsO63JZ/1qIqBfPnz++tdYl",
+	"fhbvUyEyW+DjPZuDWKHGOZ9J6AZS35CSW4Wvjys04DOFs5gbjeXifTP1uUihPmdcPXgr3CNysx/9CykE",
+	"ZYnYBi4OO/UNXvgx8YpxbQu30ex2WAM4JiiLbVyAgPzNX+jPm765N75E72N55mrltb+AX65eWjlCwfAQ",
+	"aDW4ad9C8KV3C+lnD8sLKxw2w9k0Qd1DRUTOMjkeCyOKTC+fGKk1aV015LktFbGmKuob4PMNMoclPKJw",
+	"DsN/vegyEtJYIsFX/ARv6urfntjh5DbhZJsUPuduuh+cLFBtAfeqUTMWkKhEjA4ZmIBloodPUh4Bhadm",
+	"MQP09r2cIFVATkXhsJAWTIyUGlOaUBUmfEnJGlYW1s1Q3VI6YzDYy0iTVB4IlnKLaFBfnvaJxdJRBJWx",
+	"1RZwAdZJZpVeOCszsTMsFmVVdvnu+ubyzc3g5PKq2wCCgGmuOJZxf0TNCmb4QvxbW8F2PLhXb0v6sN80",
+	"ro/RuHhsJ7cJAItdGppFwBupMhtcMWpFTCnVbq6gIqvURU/7iqbArb7ryG0SbJnDstsLDIoNJaqNH2Qp",
+	"ZEBaFQKoyOSqSx4UPG3mO0hUuBiWUzJsUf4PgaGoSw4VyQJcx8ynXmJue8oV1dkdi2WAPN2GxiS3fncI",
+	"T1ZEvIcKBJGDDqRWRHFQlQYZj5XHtdkG5nNf4ZEmIBERcB5qh31TVVcFZXi6Gq2Ii7DX5m7rH6tl76+0",
+	"FgW2E3/hSqquOiIchjai1lIHHAbfHbOMr2yb/UwsXdT7xu4svqxxJnKBzSrWKny32SWAL+nVdud8lmtB",
+	"Je8VyKDG3E4WS+2k5lD75XZifXQYTKH7hzuWC25dUX1UUFYnDVm8Nr2r9Fn7CNnHxwAtNw6tENDLOtOZ",
+	"aEgbobrsj5cuUq/7/pUq8kiI1OHE0883LeAjMkvstNzB7UYAYYufkn9ztx3Ai1Rr7HGiJ9TUxqeMhnom",
+	"4HII9QBzEar6bHXXkN/1rW8v9BgsUJnhCzFBbQXNjIAPsNFCZbn4Rv0PpX7vwPeEGraxIcnCU79PKvro",
+	"rCrlsAQm+dJxzHqKFdTyxEeGiiqTh9woaULHFGnLUrKQqAEKM6d7516YkbbCT5aLe5EnQ1X2F9bLAASH",
+	"TKksNEbWUCZfujb7kd4OprgTVDnVJ1PNoby5T88qCnxsTayyGm73rYlVMVMX3sov47Fs3coUX8rYrS1h",
+	"d14UkcS/UAlHMj+LC4Jec1oc+zYORFUql+puPx7k8zl71z8LhWbDlBn2RmIQw04qdczZ1bvXZ72TG/gF",
+	"WaLAO9jiN/F9DWhjn1hfCBlKPHbOzi5/7p7eXPZ7b3sXLMcIhKzlKn5/fHwIAfGFrS5jqKo3HU2mlfCp",
+	"3A2uICSeq2IXHpFJikm+JJtUFrHrIoSnvhmExFtYegcrSsOukHM1tGTcot1BVx8ZCuw3oHEEeFTnNTw8",
+	"Yh3pRiJzqJJe9rIs6kilmOkaKNhgqBpKWSb1Iqx2MQp+ZanQTxNyGXgRFsl0OlRzPV/kvMjcr7J9iC+2",
+	"2aAcLSQLhX6vYEDnfIWIfjtUYWuWoYY/O8Ay7PjxTbmqW18Ilkp0Yed47MBxM3j3mjqGDw4LT3S1wUmQ",
+	"F5ahN6raLDfTKVzGnKV6HvqosOt+5+Qv3f7Ndff86gy6yfRO6cX9RY9FSsivjWoG9eCGoQp5FC2ZHI4e",
+	"D+FRsaixqb6QjIkvZZekCT7G8ON/RdAf/Oq7z5P0UPfFTLmlqq9CsbJnPVsJ92BZWJxuoeVvjeSuc/o+",
+	"8Nl6QTHWLMv4ZBJEEvB60N+TitNpm6gjwVB84ysa1aoqQK+HDaEEBf68D97LJyi5cMDZ/ze4vGDYnuww",
+	"YWOeY1KuTwQjAVV0vl6XY8wK56gKaANCGF++6HryqDjh+lRfFC28vpRmMVI89A00vA4aJsZYVmgnxqq/",
+	"LTR1g2vIjCfE7FQvwdW0qpXV5NDCn1qbrhm0M54VASvscUZtGFDJmOol1v9ZsaXYrA70/Ad2gEvyeECR",
+	"kQmcVnqRWHBbY6jbFxlAsx4iSb4/CqUU+vz4NsNGtBSBmldXSUn9Vjim1VZbWrj/xF16RLrHCfYwYReW",
+	"T8TXbI16G7RCJ7TkhuIMvqIdtQHcUs0Ovw/JQOQIReoSKqMiPqFOf/3oOqG94GNVgQnjfyFxWZl/C+lA",
+	"60t88J8KseNdnY+vNVVLr005ZozSH/UCcZ8NEB3alUJruNB/b7RwLJNZ2RWRmvFZSkLwxVVgudQ863Nl",
+	"rxL9MR5YsuTDqOspcPvC6SMjANq9pV+DzIRCoAFVyAoFonxH3pLpYd6xRKCSLxQVitZ6N+lokd8xOcNw",
+	"y4aAWDjdx5WQE/fxykWFeb7ecKDfAUYn8y0M+GBueIPFzJA8lzJzU1/LW8gCRWB3cMYIYhVH+96GyBXe",
+	"QxS6bfI8T6jDVUn1WEUiP9LmyJdUfel5CbgW6rBiZjyn1m+oURUR/7KfbFI2lIVpJxpm9lFIaNtC2VdC",
+	"ZVDaTTMhMUwJ+PHQthZL32YZxZq58r1GqnBAjlVfFZaXjGlir2F7ijvvsXh1bZYvxLAbq9h6wdtvN/yn",
+	"ueE/36UZit4gO+19d5KEoEbczRKCmoBD38vcyXkuWCry3LbZGYQ+QxtvGzDvdp5LR8Wfw6JInAwVUiGN",
+	"5g0+PS4KR73uXJ/8ePPu6hScp+edv970OxdvuwNmqDCJ4Ok0IQUFmhxog+j8HlzcVDUnxewSLIBGd7Xg",
+	"JpchGxPpGCzAJFSoCa2Ohur58R8pexNdvvg1zYleWzQslXZBdDWKEt8wH/bmMWUJTfMl5UhYwZbLHzbB",
+	"U8amDHl+/MfPvaCBngk28ohSPNFCF/Z3lKcifAbJ6Jv3h8g6MHid+3cIFlhMLpxQW+vWeV9K4Q/yoZRA",
+	"zkU3d4QSY7oj6BhjmeeUAVQ2VSva21sP+AsLCACO/vqY9cq3nlt9hIi8tmEZN6GhadVViy3OysdLr2wo",
+	"+XNzcnn27vxiEPXGVnfnkbywlSm+lPe1toRtlkL5HPrUjF5+AxCnIJWAMwpKRi4wItUmY3I3A6pMUlno",
+	"nff7CTVfOOIqO/IOTOBGInvfRdk33/SywJdfLRFMgJq4LeZs009vWVGplInfFjy3rPIMfXLrzWsrHBbH",
+	"cwv75ysuM5pCjn11W2zbUAzWmUOMT2TA1mWXh61x1JNyP+i2eDS+W5vn67XQwZPXdEX/K5Ud+IQ9ILyK",
+	"p9U4l6lrMhAKGg/2e1k1jlB9wDTi/ZxCFehlelX0J6n46T6TqPEKOV2uge14WDt2RlJry90hfAi43Cxw",
+	"TvF7n5UI9zN5KDHBp3caZg4iQ2a3lKnAbhF92cnz24R8Dh6PCftG3ocia0Gq0pOAOl3CRto5uO41c3rO",
+	"rA4GwlAF+L/1LYHtVI6dVwi1ElHMF73D4wUDivG/kACpzL9dhIQd/xcWIZ+jUEjA+qOGC2rpfhY87f4R",
+	"5uDsZrjSUUfMVijYwGqVrk7B873BRc1sYH/GJTwyM9AsX5oldvvPvjHFp2SK3Pu1CmGPKTGuhhiKMseC",
+	"jnpbgee3Ri/mtrAubWhBhCwAFuntnVideH222sY7tAXWi7lPjZ0RfICTD93oZcI43PvVame4MviOqQX2",
+	"I0JAQrlQ30FwtIJLlxzuIYMuzQVXImOLeZshkeGwXHkc/53vkCQnSpt4MxNIBT4t9+RxeLU+yRcrC1Rf",
+	"xJb6q+EpOspvWesfEagCrgCCBHgzsWe9B2mMNylj7eh9bt83AoYoF8hu5n4S74EfJvTuAVeM0YvJdL3k",
+	"F4BGsAuG9fUKJZXZU6wNc1fq7bVZc3W/V76031DVarzsUeOP/bzWR8miK3zQvRj0rns/dYOfKCHVe2Gx",
+	"sAwUxKCa7jAbc7XbesZXfn0xJqdNqzTz+yts8YN47V5lbT0X6v0sp+J59kiPxzIVoSRvu7ILs7yN//7u",
+	"onvd96nIEWI20vrud5XdewX3g1B8Jv48bCF07ciDt49++eWXX47Oz49OT/H8h609SvB9Htb+HGiQCll8",
+	"fTXy1tgc2BRpYockGctt4e0+DFegO6oXOPJb4TYudIsi8dUJUy1UOlSRu7vSem1utAcfUvhtMUJf2jiw",
+	"bZsN+D2kEAb0IWr5oc4qiU3qvFMAUeYyvWPUw2BMSDXbdK0/YgQ7DP8Fr/JdOvd5xQvw7Qb/HTf4rFLG",
+	"w9Wbp8RYr6gMuKsZVvXC3uDzAn3fO03YxEgq/0sQEeoXTOcagdJiZPm8rE/4iA2tKxNtrzuNmgG8Jh8x",
+	"DFP/C1bhWzvCJ5Z58thOL3N5r93DbbFb+OO20H8CdOg21Xn946Hik4kRE7SkbtGEgz7UFDqBuGUA882w",
+	"c95oFcIL//N/CeF+sxLctIfqRM9AcSGnINKn0qzqa/T9IxfYqG0zawvf85HStGDsL5WXRXM3Uz8+AJT/",
+	"tZc++BzInECKpfaB3gC31ISWKXWDXWxDdb2PPOy0OZCXc2vlWHq4DBhHehac42T0SGWFcYkP/GAuotJH",
+	"ek4dnTxn+0gb89YW0r3IMC6IbgZa9SbV0zJ7ARz7OEmKlTm+WHZibQ3N3EBPMH983/SShycK4sZVXGGh",
+	"qRdEyMBC2843cD/ttAzynC6cqtM98UYC8I63QhkvvBxizg13Il9R3UtU1gEpRgUChmpEVTDJLResjefH",
+	"vskZfXzrhyWfIl1wrxivDpdpgSmXOCwlHL84fhG7buBNBj5Y8BhMV4z/hRiuMv8OxYv905T6/nfDqcIZ",
+	"bjLaDgbeF5k6liLPfFVoDARnQjm4CDNGnvQyqhzi13UO8liNR4vmFuN/g4P828FB0GFUIkC4LeNLSLIB",
+	"WvETftKIBPmWLbYFtkKB6m0S5V6YUEFlq6fCV+yCZxM2wRyc2SzUNIGaRBn2LS6wqguFWgI592Muip/8",
+	"xI/I3X6Kpj4Yr3HVUpFPHj7bbIRO6w9vHk2whd/gUxaPZ61TgU55Xu7CwuStl62nfC5bH34tBtuw9ymv",
+	"17tMip2zraSFN9PLcIQfkoafUsQm9ktKS9/8YWdLx3f/U/o48ttekewNJUKldfRLduAFOVpYZflQptVm",
+	"zYnDch58MrbEYDhmWAqLCtPBQFM9E8ymRojKasuW4R9+/fD/DwDsj+Fj3FMBAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
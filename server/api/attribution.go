@@ -0,0 +1,23 @@
+package api
+
+import "net/http"
+
+// createdByProperty is the Drive appProperties key recording who asked for a
+// file to be created. Without domain-wide delegation Drive attributes every
+// file to the service account, so this is the only record of the human actor.
+const createdByProperty = "createdByUser"
+
+// withCreator adds the requesting user to appProperties for a new file, unless
+// attribution is disabled
+func (s *Server) withCreator(r *http.Request, appProperties map[string]string) map[string]string {
+	email := r.Header.Get("X-User-Email")
+	if !s.recordCreator || email == "" {
+		return appProperties
+	}
+	props := make(map[string]string, len(appProperties)+1)
+	for k, v := range appProperties {
+		props[k] = v
+	}
+	props[createdByProperty] = email
+	return props
+}
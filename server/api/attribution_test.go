@@ -0,0 +1,104 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+)
+
+func TestCreatedByAttribution(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		want string
+	}{
+		{name: "recorded by default", want: "po@example.org"},
+		{name: "CREATED_BY_ATTRIBUTION=false", env: "false"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CREATED_BY_ATTRIBUTION", tt.env)
+			f := newFakeGoogle(t)
+			f.reply(http.MethodPost, "/files", &drive.File{Id: "doc-1"})
+			s := newTestServer(t, f)
+			s.grantsFolderID = "grants"
+
+			parent := "grants"
+			w := callHandler(t, s.CreateDoc, "po@example.org", CreateDocRequest{Name: "Proposal", MimeType: "application/vnd.google-apps.document", ParentId: &parent})
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", w.Code, w.Body)
+			}
+			sent := f.sent(http.MethodPost, "/files")
+			if len(sent) != 1 {
+				t.Fatalf("created %d files, want 1", len(sent))
+			}
+			var created drive.File
+			if err := json.Unmarshal(sent[0], &created); err != nil {
+				t.Fatalf("decode create: %v", err)
+			}
+			if got := created.AppProperties[createdByProperty]; got != tt.want {
+				t.Errorf("%s = %q, want %q", createdByProperty, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateFolderKeepsGrantIDWithCreator(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodPost, "/files", &drive.File{Id: "folder-1"})
+	s := newTestServer(t, f)
+	s.grantsFolderID = "grants"
+
+	parent, grantID := "grants", "G-1"
+	w := callHandler(t, s.CreateFolder, "po@example.org", CreateFolderRequest{Name: "G-1", ParentId: &parent, GrantId: &grantID})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	sent := f.sent(http.MethodPost, "/files")
+	if len(sent) != 1 {
+		t.Fatalf("created %d folders, want 1", len(sent))
+	}
+	var created drive.File
+	if err := json.Unmarshal(sent[0], &created); err != nil {
+		t.Fatalf("decode create: %v", err)
+	}
+	if created.AppProperties[grantIDProperty] != "G-1" || created.AppProperties[createdByProperty] != "po@example.org" {
+		t.Errorf("appProperties = %v, want the grant ID and the creator", created.AppProperties)
+	}
+}
+
+func TestGetFileCreatedBy(t *testing.T) {
+	tests := []struct {
+		name  string
+		props map[string]string
+		want  string
+	}{
+		{name: "created through the app", props: map[string]string{createdByProperty: "po@example.org"}, want: "po@example.org"},
+		{name: "created elsewhere"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeGoogle(t)
+			f.reply(http.MethodGet, "/files/doc-1", &drive.File{Id: "doc-1", Name: "Proposal", AppProperties: tt.props})
+			s := newTestServer(t, f)
+
+			w := callHandler(t, s.GetFile, "other@example.org", GetFileRequest{FileId: "doc-1"})
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", w.Code, w.Body)
+			}
+			var fi FileInfo
+			if err := json.Unmarshal(w.Body.Bytes(), &fi); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			got := ""
+			if fi.CreatedBy != nil {
+				got = *fi.CreatedBy
+			}
+			if got != tt.want {
+				t.Errorf("createdBy = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AuditEvent records who did what to which resource
+type AuditEvent struct {
+	Time     time.Time
+	User     string
+	Action   string // e.g. "append_row", "read_sheet"
+	Resource string // Sheet name or Drive file/folder ID
+	Target   string // Row ID or other object acted on (optional)
+	Detail   string // Human-readable summary, without the user
+
+	// Client-supplied X-Operation-ID grouping the requests of one multi-step
+	// action (e.g. create folder, create doc, append row)
+	OperationID string
+
+	// Full payloads, kept only at the verbose detail level
+	Before map[string]interface{}
+	After  map[string]interface{}
+}
+
+// AuditLogger receives audit events
+type AuditLogger interface {
+	Log(event AuditEvent)
+}
+
+// logAuditLogger writes audit events to the standard logger
+type logAuditLogger struct{}
+
+func (logAuditLogger) Log(event AuditEvent) {
+	prefix := "AUDIT:"
+	if event.OperationID != "" {
+		prefix = fmt.Sprintf("AUDIT: op=%s", event.OperationID)
+	}
+	if event.Detail == "" {
+		log.Printf("%s %s %s %s", prefix, event.User, event.Action, event.Resource)
+		return
+	}
+	if event.Before == nil && event.After == nil {
+		log.Printf("%s %s %s", prefix, event.User, event.Detail)
+		return
+	}
+	before, _ := json.Marshal(event.Before)
+	after, _ := json.Marshal(event.After)
+	log.Printf("%s %s %s before=%s after=%s", prefix, event.User, event.Detail, before, after)
+}
+
+// AuditLevel controls how much of each audit event is recorded
+type AuditLevel string
+
+const (
+	AuditMinimal AuditLevel = "minimal" // Action and resource only
+	AuditNormal  AuditLevel = "normal"  // Plus target and summary
+	AuditVerbose AuditLevel = "verbose" // Plus before/after payloads
+)
+
+// parseAuditLevel validates an audit level name
+func parseAuditLevel(s string) (AuditLevel, error) {
+	switch level := AuditLevel(s); level {
+	case AuditMinimal, AuditNormal, AuditVerbose:
+		return level, nil
+	}
+	return "", fmt.Errorf("unknown audit level %q (want minimal, normal, or verbose)", s)
+}
+
+// parseAuditLevels parses per-action overrides in the form "action=level,..."
+func parseAuditLevels(spec string) (map[string]AuditLevel, error) {
+	levels := make(map[string]AuditLevel)
+	for _, entry := range strings.Split(spec, ",") {
+		action, name, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || action == "" {
+			return nil, fmt.Errorf("invalid entry %q (want action=level)", entry)
+		}
+		level, err := parseAuditLevel(name)
+		if err != nil {
+			return nil, err
+		}
+		levels[action] = level
+	}
+	return levels, nil
+}
+
+// auditLevelFor returns the detail level for an action
+func (s *Server) auditLevelFor(action string) AuditLevel {
+	if level, ok := s.auditLevels[action]; ok {
+		return level
+	}
+	return s.auditLevel
+}
+
+// trimToLevel drops the parts of an event not recorded at the given level
+func (event AuditEvent) trimToLevel(level AuditLevel) AuditEvent {
+	switch level {
+	case AuditMinimal:
+		event.Target = ""
+		event.Detail = ""
+		event.Before = nil
+		event.After = nil
+	case AuditNormal:
+		event.Before = nil
+		event.After = nil
+	}
+	return event
+}
+
+// operationIDHeader carries a client-chosen ID shared by every request of one logical operation
+const operationIDHeader = "X-Operation-ID"
+
+// maxOperationIDLength bounds how much of a client-supplied operation ID is kept
+const maxOperationIDLength = 128
+
+// operationID returns the request's X-Operation-ID, or "" if it is missing or
+// not a plain token. Only letters, digits, and -_.: are accepted so the ID
+// can't forge fields in the log line.
+func operationID(r *http.Request) string {
+	id := strings.TrimSpace(r.Header.Get(operationIDHeader))
+	if id == "" || len(id) > maxOperationIDLength {
+		return ""
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-' || c == '_' || c == '.' || c == ':':
+		default:
+			return ""
+		}
+	}
+	return id
+}
+
+// audit stamps an event with the time, requesting user, and operation ID and
+// sends it to the audit logger
+func (s *Server) audit(r *http.Request, event AuditEvent) {
+	event.Time = time.Now()
+	event.User = r.Header.Get("X-User-Email")
+	event.OperationID = operationID(r)
+	s.auditLogger.Log(event.trimToLevel(s.auditLevelFor(event.Action)))
+}
+
+// auditRead records a read operation when read auditing is enabled
+func (s *Server) auditRead(r *http.Request, event AuditEvent) {
+	if s.auditReads {
+		s.audit(r, event)
+	}
+}
+
+// rowPayload pairs a row's values with its headers for audit payloads
+func rowPayload(headers []interface{}, row []interface{}) map[string]interface{} {
+	payload := make(map[string]interface{}, len(headers))
+	for i, h := range headers {
+		if i < len(row) {
+			payload[cellString(h)] = row[i]
+		} else {
+			payload[cellString(h)] = ""
+		}
+	}
+	return payload
+}
+
+// rowsPayload keys each row's payload by its 1-based sheet row number, for
+// events that touch several rows at once
+func rowsPayload(headers []interface{}, rows [][]interface{}, rowIndices []int) map[string]interface{} {
+	payload := make(map[string]interface{}, len(rows))
+	for n, row := range rows {
+		payload[strconv.Itoa(rowIndices[n]+1)] = rowPayload(headers, row)
+	}
+	return payload
+}
@@ -0,0 +1,164 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// defaultAuditHistorySize is how many recent audit events each instance keeps in memory
+const defaultAuditHistorySize = 1000
+
+// defaultGrantHistoryLimit and maxGrantHistoryLimit bound GrantHistory responses
+const (
+	defaultGrantHistoryLimit = 20
+	maxGrantHistoryLimit     = 200
+)
+
+// auditHistory is an AuditLogger that keeps the most recent events in a ring buffer
+type auditHistory struct {
+	mu     sync.Mutex
+	events []AuditEvent
+	next   int // Slot the next event is written to
+	full   bool
+}
+
+func newAuditHistory(size int) *auditHistory {
+	return &auditHistory{events: make([]AuditEvent, size)}
+}
+
+func (h *auditHistory) Log(event AuditEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events[h.next] = event
+	h.next = (h.next + 1) % len(h.events)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// recent returns up to limit events accepted by match, newest first
+func (h *auditHistory) recent(match func(AuditEvent) bool, limit int) []AuditEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	count := h.next
+	if h.full {
+		count = len(h.events)
+	}
+	var found []AuditEvent
+	for i := 1; i <= count && len(found) < limit; i++ {
+		event := h.events[(h.next-i+len(h.events))%len(h.events)]
+		if match(event) {
+			found = append(found, event)
+		}
+	}
+	return found
+}
+
+// multiAuditLogger sends each event to several loggers
+type multiAuditLogger []AuditLogger
+
+func (m multiAuditLogger) Log(event AuditEvent) {
+	for _, l := range m {
+		l.Log(event)
+	}
+}
+
+// GrantHistory returns the most recent audit entries for one grant
+func (s *Server) GrantHistory(w http.ResponseWriter, r *http.Request) {
+	var req GrantHistoryRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.IdColumn == "" || req.Id == "" {
+		writeError(w, "idColumn and id are required", http.StatusBadRequest)
+		return
+	}
+
+	limit := defaultGrantHistoryLimit
+	if req.Limit != nil {
+		if *req.Limit < 1 || *req.Limit > maxGrantHistoryLimit {
+			writeError(w, fmt.Sprintf("limit must be between 1 and %d", maxGrantHistoryLimit), http.StatusBadRequest)
+			return
+		}
+		limit = *req.Limit
+	}
+
+	// Matching on a hidden column would reveal which grants hold a value in it
+	if s.sensitiveColumns[req.IdColumn] && !s.canSeeSensitive(r) {
+		writeError(w, fmt.Sprintf("insufficient permission to read column %s", req.IdColumn), http.StatusForbidden)
+		return
+	}
+
+	sheet := "Grants"
+	if req.Sheet != nil && *req.Sheet != "" {
+		sheet = *req.Sheet
+	}
+
+	// Row payloads (verbose level) identify appends, which have no target.
+	// Targets are only IDs on the grant sheet; elsewhere they name files,
+	// users and tabs that may share the text.
+	events := s.auditHistory.recent(func(e AuditEvent) bool {
+		if e.Resource != sheet {
+			return false
+		}
+		if e.Target == req.Id {
+			return true
+		}
+		// Appended IDs carry the leading ' that stores them as text
+		for _, payload := range []map[string]interface{}{e.After, e.Before} {
+			if v, ok := payload[req.IdColumn]; ok && strings.TrimPrefix(cellString(v), "'") == req.Id {
+				return true
+			}
+		}
+		return false
+	}, limit)
+
+	result := GrantHistoryResponse{Entries: make([]AuditEntry, 0, len(events))}
+	for _, e := range events {
+		entry := auditEntry(e)
+		if entry.Detail != nil && s.sensitiveDetail(r, *entry.Detail) {
+			entry.Detail = nil
+		}
+		result.Entries = append(result.Entries, entry)
+	}
+
+	writeJSON(w, result)
+}
+
+// auditEntry converts an event to its API form
+func auditEntry(e AuditEvent) AuditEntry {
+	entry := AuditEntry{Time: e.Time, User: e.User, Action: e.Action, Resource: e.Resource}
+	if e.Target != "" {
+		target := e.Target
+		entry.Target = &target
+	}
+	if e.Detail != "" {
+		detail := e.Detail
+		entry.Detail = &detail
+	}
+	if e.OperationID != "" {
+		op := e.OperationID
+		entry.OperationId = &op
+	}
+	return entry
+}
+
+// sensitiveDetail reports whether detail quotes a condition on a column the
+// requesting user may not see, as conditional updates and deletes record it
+// ("where Budget=5000", "with Budget=5000", "where map[Budget:5000]")
+func (s *Server) sensitiveDetail(r *http.Request, detail string) bool {
+	if len(s.sensitiveColumns) == 0 || s.canSeeSensitive(r) {
+		return false
+	}
+	for column := range s.sensitiveColumns {
+		if strings.Contains(detail, column+"=") || strings.Contains(detail, column+":") {
+			return true
+		}
+	}
+	return false
+}
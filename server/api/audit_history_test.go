@@ -0,0 +1,103 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// grantHistory calls GrantHistory as po@example.org with role and returns the entries
+func grantHistory(t *testing.T, s *Server, role string, req GrantHistoryRequest) ([]AuditEntry, int) {
+	t.Helper()
+	w := callHandlerAs(t, s.GrantHistory, "po@example.org", role, req)
+	if w.Code != http.StatusOK {
+		return nil, w.Code
+	}
+	var resp GrantHistoryResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return resp.Entries, w.Code
+}
+
+func TestGrantHistoryMatchesGrantSheetOnly(t *testing.T) {
+	s := newTestServer(t, nil)
+	s.auditHistory.Log(AuditEvent{Action: "update_row", Resource: "Grants", Target: "G-1", Detail: "updated G-1 in Grants (row 2)"})
+	s.auditHistory.Log(AuditEvent{Action: "append_row", Resource: "Grants", After: map[string]interface{}{"ID": "G-1"}})
+	s.auditHistory.Log(AuditEvent{Action: "update_row", Resource: "Grants", Target: "G-2"})
+	s.auditHistory.Log(AuditEvent{Action: "download_file", Resource: "file-1", Target: "G-1"})
+	s.auditHistory.Log(AuditEvent{Action: "update_row", Resource: "Reports", Target: "G-1"})
+
+	entries, status := grantHistory(t, s, "", GrantHistoryRequest{IdColumn: "ID", Id: "G-1"})
+	if status != http.StatusOK {
+		t.Fatalf("status = %d", status)
+	}
+	if len(entries) != 2 || entries[0].Action != "append_row" || entries[1].Action != "update_row" {
+		t.Errorf("entries = %+v, want the append and update on Grants", entries)
+	}
+
+	reports := "Reports"
+	entries, _ = grantHistory(t, s, "", GrantHistoryRequest{IdColumn: "ID", Id: "G-1", Sheet: &reports})
+	if len(entries) != 1 || entries[0].Resource != "Reports" {
+		t.Errorf("entries = %+v, want the Reports update", entries)
+	}
+}
+
+func TestGrantHistorySensitiveColumns(t *testing.T) {
+	s := newTestServer(t, nil)
+	s.sensitiveColumns = map[string]bool{"Budget": true}
+	s.sensitiveMinRole = "organizer"
+	s.auditHistory.Log(AuditEvent{Action: "conditional_update", Resource: "Grants", Target: "G-1", Detail: "updated G-1 in Grants (row 2) where Budget=5000"})
+	s.auditHistory.Log(AuditEvent{Action: "update_row", Resource: "Grants", Target: "G-1", Detail: "updated G-1 in Grants (row 2) where Status=Active"})
+
+	t.Run("hidden id column is refused", func(t *testing.T) {
+		if _, status := grantHistory(t, s, "writer", GrantHistoryRequest{IdColumn: "Budget", Id: "5000"}); status != http.StatusForbidden {
+			t.Errorf("status = %d, want 403", status)
+		}
+	})
+
+	t.Run("sensitive condition is redacted", func(t *testing.T) {
+		entries, status := grantHistory(t, s, "writer", GrantHistoryRequest{IdColumn: "ID", Id: "G-1"})
+		if status != http.StatusOK || len(entries) != 2 {
+			t.Fatalf("status = %d, entries = %+v", status, entries)
+		}
+		if entries[0].Detail == nil || *entries[0].Detail != "updated G-1 in Grants (row 2) where Status=Active" {
+			t.Errorf("plain detail = %v, want it kept", entries[0].Detail)
+		}
+		if entries[1].Detail != nil {
+			t.Errorf("sensitive detail = %q, want it removed", *entries[1].Detail)
+		}
+	})
+
+	t.Run("privileged caller sees everything", func(t *testing.T) {
+		if _, status := grantHistory(t, s, "organizer", GrantHistoryRequest{IdColumn: "Budget", Id: "5000"}); status != http.StatusOK {
+			t.Errorf("status = %d, want 200", status)
+		}
+		entries, _ := grantHistory(t, s, "organizer", GrantHistoryRequest{IdColumn: "ID", Id: "G-1"})
+		if len(entries) != 2 || entries[1].Detail == nil {
+			t.Errorf("entries = %+v, want both details", entries)
+		}
+	})
+}
+
+func TestGrantHistoryFindsAppendedRow(t *testing.T) {
+	t.Setenv("AUDIT_DETAIL_LEVEL", "verbose")
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Config!A:B", &sheets.ValueRange{})
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants!1:1", &sheets.ValueRange{Values: [][]interface{}{{"ID", "Title"}}})
+	f.reply(http.MethodPost, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants!A1:append", &sheets.AppendValuesResponse{})
+	s := newTestServer(t, f)
+
+	if w := callHandler(t, s.AppendRow, "po@example.org", AppendRowRequest{Sheet: "Grants", Row: map[string]interface{}{"ID": "G-1", "Title": "Packaging"}}); w.Code != http.StatusOK {
+		t.Fatalf("append status = %d: %s", w.Code, w.Body)
+	}
+	entries, status := grantHistory(t, s, "", GrantHistoryRequest{IdColumn: "ID", Id: "G-1"})
+	if status != http.StatusOK {
+		t.Fatalf("status = %d", status)
+	}
+	if len(entries) != 1 || entries[0].Action != "append_row" {
+		t.Errorf("entries = %+v, want the append of G-1", entries)
+	}
+}
@@ -0,0 +1,157 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// defaultAuditListLimit and maxAuditListLimit bound ListAuditEvents responses
+const (
+	defaultAuditListLimit = 50
+	maxAuditListLimit     = 500
+)
+
+// auditFilter narrows ListAuditEvents to one user and a time window
+type auditFilter struct {
+	user         string // Lowercased; "" matches everyone
+	since, until time.Time
+}
+
+func (f auditFilter) match(e AuditEvent) bool {
+	if f.user != "" && strings.ToLower(e.User) != f.user {
+		return false
+	}
+	if !f.since.IsZero() && e.Time.Before(f.since) {
+		return false
+	}
+	if !f.until.IsZero() && !e.Time.Before(f.until) {
+		return false
+	}
+	return true
+}
+
+// auditFilterFor validates a ListAuditEvents request
+func auditFilterFor(req AuditListRequest) (auditFilter, int, error) {
+	var f auditFilter
+	if req.User != nil {
+		f.user = strings.ToLower(strings.TrimSpace(*req.User))
+	}
+	if req.Since != nil {
+		f.since = *req.Since
+	}
+	if req.Until != nil {
+		f.until = *req.Until
+	}
+
+	limit := defaultAuditListLimit
+	if req.Limit != nil {
+		if *req.Limit < 1 || *req.Limit > maxAuditListLimit {
+			return auditFilter{}, 0, fmt.Errorf("limit must be between 1 and %d", maxAuditListLimit)
+		}
+		limit = *req.Limit
+	}
+	return f, limit, nil
+}
+
+// auditEventFromRow reads an AuditLog row written by sheetAuditLogger. Rows
+// whose timestamp doesn't parse are skipped.
+func auditEventFromRow(table sheetTable, row []interface{}) (AuditEvent, bool) {
+	cell := func(column string) string {
+		if idx := table.indexOf(column); idx != -1 && idx < len(row) {
+			return cellString(row[idx])
+		}
+		return ""
+	}
+
+	t, err := time.Parse(time.RFC3339, cell("timestamp"))
+	if err != nil {
+		return AuditEvent{}, false
+	}
+	e := AuditEvent{
+		Time:     t,
+		User:     cell("user"),
+		Action:   cell("action"),
+		Resource: cell("resource"),
+		Target:   cell("target"),
+		Detail:   cell("detail"),
+	}
+	if rest, ok := strings.CutPrefix(e.Detail, "[op="); ok {
+		if op, detail, ok := strings.Cut(rest, "] "); ok {
+			e.OperationID, e.Detail = op, detail
+		}
+	}
+	// Rows written before auditRow stopped recording values carry the whole
+	// row as before=/after= JSON; never hand those back
+	if summary, _, ok := strings.Cut(e.Detail, " before="); ok {
+		e.Detail = summary
+	}
+	return e, true
+}
+
+// ListAuditEvents returns recent audit events, newest first, from the AuditLog
+// tab when AUDIT_SINK=sheet and from in-memory history otherwise
+func (s *Server) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	var req AuditListRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	filter, limit, err := auditFilterFor(req)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result := AuditListResponse{Entries: []AuditEntry{}, Source: Memory}
+	if !s.auditToSheet {
+		for _, e := range s.auditHistory.recent(filter.match, limit) {
+			result.Entries = append(result.Entries, auditEntry(e))
+		}
+		writeJSON(w, result)
+		return
+	}
+
+	// The audit trail lives in the instance's own spreadsheet, whichever one the request selects
+	spreadsheetID := s.discoveredSpreadsheetID()
+	if spreadsheetID == "" {
+		writeError(w, "Spreadsheet not yet discovered", http.StatusServiceUnavailable)
+		return
+	}
+
+	srv, err := s.sheetsService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Sheets service: %v", err)
+		writeError(w, "Failed to connect to Google Sheets", http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := withRetry(r.Context(), s.retryAttempts, true, func() (*sheets.ValueRange, error) {
+		return srv.Spreadsheets.Values.Get(spreadsheetID, auditSheet).Context(r.Context()).Do()
+	})
+	if isCancelled(err) {
+		writeCancelled(w, "ListAuditEvents")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to read %s: %v", auditSheet, err)
+		writeError(w, fmt.Sprintf("Failed to read %s", auditSheet), http.StatusInternalServerError)
+		return
+	}
+
+	// Rows are appended in time order, so walk up from the bottom
+	table := splitTable(resp.Values, 1)
+	result.Source = Sheet
+	for i := len(table.rows) - 1; i >= 0 && len(result.Entries) < limit; i-- {
+		if e, ok := auditEventFromRow(table, table.rows[i]); ok && filter.match(e) {
+			result.Entries = append(result.Entries, auditEntry(e))
+		}
+	}
+
+	writeJSON(w, result)
+}
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/sheets/v4"
+)
+
+// auditSheet is the tab audit events are appended to (see docs/SCHEMA.md)
+const auditSheet = "AuditLog"
+
+// refuseInternalSheet writes a 403 and returns true for tabs only the server
+// itself may touch. The audit trail is only worth keeping if API callers can't
+// read, rewrite, or clear it through the generic sheet endpoints.
+func refuseInternalSheet(w http.ResponseWriter, sheet string) bool {
+	name, _ := splitSheetRange(strings.TrimSpace(sheet))
+	if !strings.EqualFold(name, auditSheet) {
+		return false
+	}
+	writeError(w, fmt.Sprintf("Sheet %s is maintained by the server and can't be accessed directly", auditSheet), http.StatusForbidden)
+	return true
+}
+
+// Tuning for the AuditLog sink. Events are appended in batches so a busy
+// instance doesn't spend a Sheets call per write.
+const (
+	auditFlushInterval = 5 * time.Second
+	auditFlushSize     = 100   // Flush early once this many events are waiting
+	auditQueueSize     = 1000  // Events buffered before Log starts dropping
+	auditMaxBacklog    = 10000 // Unwritten events kept while the sheet is unreachable
+	auditFlushTimeout  = 30 * time.Second
+)
+
+// sheetAuditLogger appends audit events to the AuditLog tab of the discovered
+// spreadsheet, so the trail outlives the container. Log never blocks a request;
+// a background goroutine batches the writes.
+type sheetAuditLogger struct {
+	s      *Server
+	events chan AuditEvent
+}
+
+func newSheetAuditLogger(s *Server) *sheetAuditLogger {
+	l := &sheetAuditLogger{s: s, events: make(chan AuditEvent, auditQueueSize)}
+	go l.run()
+	return l
+}
+
+func (l *sheetAuditLogger) Log(event AuditEvent) {
+	select {
+	case l.events <- event:
+	default:
+		log.Printf("[API] Audit sheet: queue full, dropping %s by %s", event.Action, event.User)
+	}
+}
+
+// run batches queued events and flushes them on a timer or when enough pile up
+func (l *sheetAuditLogger) run() {
+	ticker := time.NewTicker(auditFlushInterval)
+	defer ticker.Stop()
+
+	var pending [][]interface{}
+	for {
+		select {
+		case event := <-l.events:
+			pending = append(pending, auditRow(event))
+			if len(pending) >= auditFlushSize {
+				pending = l.flush(pending)
+			}
+		case <-ticker.C:
+			pending = l.flush(pending)
+		}
+	}
+}
+
+// flush appends rows to the AuditLog tab and returns whatever must be tried
+// again: everything while discovery is unfinished or Google is unreachable,
+// nothing once the write succeeds or is rejected outright
+func (l *sheetAuditLogger) flush(rows [][]interface{}) [][]interface{} {
+	if len(rows) == 0 {
+		return rows
+	}
+	spreadsheetID := l.s.discoveredSpreadsheetID()
+	if spreadsheetID == "" {
+		return trimAuditBacklog(rows)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), auditFlushTimeout)
+	defer cancel()
+
+	srv, err := l.s.sheetsService(ctx)
+	if err != nil {
+		log.Printf("[API] Audit sheet: %v", err)
+		return trimAuditBacklog(rows)
+	}
+	// RAW so user-supplied text like "=HYPERLINK(...)" is stored, not evaluated
+	_, err = withRetry(ctx, l.s.retryAttempts, false, func() (*sheets.AppendValuesResponse, error) {
+		return srv.Spreadsheets.Values.Append(spreadsheetID, auditSheet+"!A1", &sheets.ValueRange{Values: rows}).
+			ValueInputOption("RAW").
+			InsertDataOption("INSERT_ROWS").
+			Context(ctx).
+			Do()
+	})
+	if err != nil {
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) && apiErr.Code < http.StatusInternalServerError && apiErr.Code != http.StatusTooManyRequests {
+			// A missing tab or bad request won't fix itself by retrying
+			log.Printf("[API] Audit sheet: dropping %d events: %v", len(rows), err)
+			return nil
+		}
+		log.Printf("[API] Audit sheet: will retry %d events: %v", len(rows), err)
+		return trimAuditBacklog(rows)
+	}
+	return nil
+}
+
+// payloadColumns returns the sorted column names present in any of the payloads
+func payloadColumns(payloads ...map[string]interface{}) []string {
+	seen := make(map[string]bool)
+	var columns []string
+	for _, p := range payloads {
+		for col := range p {
+			if !seen[col] {
+				seen[col] = true
+				columns = append(columns, col)
+			}
+		}
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// trimAuditBacklog drops the oldest rows once the backlog passes auditMaxBacklog
+func trimAuditBacklog(rows [][]interface{}) [][]interface{} {
+	if over := len(rows) - auditMaxBacklog; over > 0 {
+		log.Printf("[API] Audit sheet: backlog full, dropping %d oldest events", over)
+		rows = rows[over:]
+	}
+	return rows
+}
+
+// auditRow lays an event out as the AuditLog columns: timestamp, user, action,
+// resource, target, detail. Of the before/after payloads kept at the verbose
+// level only the column names are recorded; the values may include sensitive
+// columns, and the sheet is readable by anyone with access to the spreadsheet.
+func auditRow(event AuditEvent) []interface{} {
+	detail := event.Detail
+	if event.OperationID != "" {
+		detail = fmt.Sprintf("[op=%s] %s", event.OperationID, detail)
+	}
+	if columns := payloadColumns(event.Before, event.After); len(columns) > 0 {
+		detail = fmt.Sprintf("%s columns=%s", detail, strings.Join(columns, ","))
+	}
+	return []interface{}{
+		event.Time.UTC().Format(time.RFC3339),
+		event.User,
+		event.Action,
+		event.Resource,
+		event.Target,
+		detail,
+	}
+}
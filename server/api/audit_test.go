@@ -0,0 +1,175 @@
+package api
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestAuditReads(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     string
+		audited bool
+	}{
+		{name: "off by default", audited: false},
+		{name: "AUDIT_READS=true", env: "true", audited: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("AUDIT_READS", tt.env)
+			f := newFakeGoogle(t)
+			f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: envelopeGrants})
+			s := newTestServer(t, f)
+
+			if w := callHandler(t, s.ReadSheet, "po@example.org", ReadSheetRequest{Sheet: "Grants"}); w.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", w.Code, w.Body)
+			}
+			events := s.auditHistory.recent(func(e AuditEvent) bool { return e.Action == "read_sheet" }, 10)
+			if !tt.audited {
+				if len(events) != 0 {
+					t.Errorf("read audited with AUDIT_READS off: %+v", events)
+				}
+				return
+			}
+			if len(events) != 1 || events[0].User != "po@example.org" || events[0].Resource != "Grants" {
+				t.Errorf("audited %+v, want one read of Grants by po@example.org", events)
+			}
+		})
+	}
+}
+
+func TestAuditDetailLevels(t *testing.T) {
+	tests := []struct {
+		name       string
+		level      string
+		overrides  string
+		wantDetail bool
+		wantDiff   bool
+	}{
+		{name: "normal by default", wantDetail: true},
+		{name: "minimal", level: "minimal"},
+		{name: "verbose", level: "verbose", wantDetail: true, wantDiff: true},
+		{name: "per-action override", level: "minimal", overrides: "update_row=verbose", wantDetail: true, wantDiff: true},
+		{name: "override for another action", level: "minimal", overrides: "delete_row=verbose"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("AUDIT_DETAIL_LEVEL", tt.level)
+			t.Setenv("AUDIT_DETAIL_LEVELS", tt.overrides)
+			s := newTestServer(t, nil)
+
+			r, _ := http.NewRequest(http.MethodPost, "/api/sheets/update", nil)
+			r.Header.Set("X-User-Email", "po@example.org")
+			s.audit(r, AuditEvent{
+				Action:   "update_row",
+				Resource: "Grants",
+				Target:   "G-1",
+				Detail:   "updated G-1 in Grants",
+				Before:   map[string]interface{}{"Status": "Open"},
+				After:    map[string]interface{}{"Status": "Closed"},
+			})
+
+			events := s.auditHistory.recent(func(AuditEvent) bool { return true }, 10)
+			if len(events) != 1 {
+				t.Fatalf("recorded %d events, want 1", len(events))
+			}
+			e := events[0]
+			if e.Action != "update_row" || e.Resource != "Grants" || e.User != "po@example.org" {
+				t.Errorf("event = %+v, want action, resource, and user at every level", e)
+			}
+			if got := e.Target != "" && e.Detail != ""; got != tt.wantDetail {
+				t.Errorf("target %q, detail %q, want them recorded %v", e.Target, e.Detail, tt.wantDetail)
+			}
+			if got := e.Before != nil && e.After != nil; got != tt.wantDiff {
+				t.Errorf("before %v, after %v, want the diff recorded %v", e.Before, e.After, tt.wantDiff)
+			}
+		})
+	}
+}
+
+func TestParseAuditLevels(t *testing.T) {
+	levels, err := parseAuditLevels("update_row=verbose, read_sheet=minimal")
+	if err != nil {
+		t.Fatalf("parseAuditLevels: %v", err)
+	}
+	if levels["update_row"] != AuditVerbose || levels["read_sheet"] != AuditMinimal {
+		t.Errorf("levels = %v", levels)
+	}
+	for _, spec := range []string{"update_row", "=verbose", "update_row=loud"} {
+		if _, err := parseAuditLevels(spec); err == nil {
+			t.Errorf("parseAuditLevels(%q) succeeded, want an error", spec)
+		}
+	}
+}
+
+func TestAuditOperationID(t *testing.T) {
+	// Verbose keeps the appended rows, which is how history finds them
+	t.Setenv("AUDIT_DETAIL_LEVEL", "verbose")
+	f := newFakeGoogle(t)
+	f.reply(http.MethodPost, "/files", &drive.File{Id: "folder-1"})
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Config!A:B", &sheets.ValueRange{})
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants!1:1", &sheets.ValueRange{Values: [][]interface{}{{"ID", "Title"}}})
+	f.reply(http.MethodPost, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants!A1:append", &sheets.AppendValuesResponse{})
+	s := newTestServer(t, f)
+	s.grantsFolderID = "grants"
+
+	withOp := http.Header{}
+	withOp.Set("X-User-Email", "po@example.org")
+	withOp.Set(operationIDHeader, "new-grant-7f3a")
+	parent := "grants"
+	if w := callHandlerWith(t, s.CreateFolder, withOp, CreateFolderRequest{Name: "G-1", ParentId: &parent}); w.Code != http.StatusOK {
+		t.Fatalf("create folder: status = %d: %s", w.Code, w.Body)
+	}
+	if w := callHandlerWith(t, s.AppendRow, withOp, AppendRowRequest{Sheet: "Grants", Row: map[string]interface{}{"ID": "G-1", "Title": "Packaging"}}); w.Code != http.StatusOK {
+		t.Fatalf("append row: status = %d: %s", w.Code, w.Body)
+	}
+	if w := callHandler(t, s.AppendRow, "po@example.org", AppendRowRequest{Sheet: "Grants", Row: map[string]interface{}{"ID": "G-2", "Title": "Docs"}}); w.Code != http.StatusOK {
+		t.Fatalf("append row: status = %d: %s", w.Code, w.Body)
+	}
+
+	ops := map[string]string{}
+	for _, e := range s.auditHistory.recent(func(AuditEvent) bool { return true }, 10) {
+		ops[e.Action+" "+e.Target+cellString(e.After["ID"])] = e.OperationID
+	}
+	want := map[string]string{
+		"create_folder G-1": "new-grant-7f3a",
+		"append_row 'G-1":   "new-grant-7f3a",
+		"append_row 'G-2":   "",
+	}
+	if !reflect.DeepEqual(ops, want) {
+		t.Errorf("operation IDs by event = %v, want %v", ops, want)
+	}
+
+	entries, status := grantHistory(t, s, "", GrantHistoryRequest{IdColumn: "ID", Id: "G-1"})
+	if status != http.StatusOK {
+		t.Fatalf("history status = %d", status)
+	}
+	if len(entries) != 1 || entries[0].OperationId == nil || *entries[0].OperationId != "new-grant-7f3a" {
+		t.Errorf("history = %+v, want the append carrying its operation ID", entries)
+	}
+}
+
+func TestOperationID(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: "new-grant-7f3a", want: "new-grant-7f3a"},
+		{header: "  op:42.retry_1 ", want: "op:42.retry_1"},
+		{header: ""},
+		{header: "op 1 user=admin@example.org"},
+		{header: strings.Repeat("a", maxOperationIDLength+1)},
+	}
+	for _, tt := range tests {
+		r, _ := http.NewRequest(http.MethodPost, "/api/sheets/append", nil)
+		r.Header.Set(operationIDHeader, tt.header)
+		if got := operationID(r); got != tt.want {
+			t.Errorf("operationID(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
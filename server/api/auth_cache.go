@@ -0,0 +1,114 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// evictAuthCache drops one cached access decision
+func (s *Server) evictAuthCache(email, folderId string) {
+	s.authCacheMu.Lock()
+	delete(s.authCache, email+":"+folderId)
+	s.authCacheMu.Unlock()
+}
+
+// PurgeAuthCache drops cached access decisions for email, or for everyone when
+// email is empty, and revokes the matching capabilities, so the next request
+// re-checks Drive and a revocation takes effect immediately. It returns the
+// number of cache entries removed.
+func (s *Server) PurgeAuthCache(email string) int {
+	s.authCacheMu.Lock()
+	purged := 0
+	for key := range s.authCache {
+		if email == "" || strings.HasPrefix(key, email+":") {
+			delete(s.authCache, key)
+			purged++
+		}
+	}
+	s.authCacheMu.Unlock()
+
+	s.revokeCapabilities(email, time.Now())
+	return purged
+}
+
+// revokeCapabilities rejects capabilities for email (everyone when empty)
+// issued at or before now. Milliseconds, so one issued just after a revocation
+// in the same second still works.
+func (s *Server) revokeCapabilities(email string, now time.Time) {
+	s.capabilityRevokedMu.Lock()
+	defer s.capabilityRevokedMu.Unlock()
+	if s.capabilityRevoked == nil {
+		s.capabilityRevoked = make(map[string]int64)
+	}
+	s.capabilityRevoked[email] = now.UnixMilli()
+}
+
+// capabilityRevokedFor reports whether a capability issued at issued has been
+// revoked for email, individually or by a purge of everyone
+func (s *Server) capabilityRevokedFor(email string, issued int64) bool {
+	s.capabilityRevokedMu.Lock()
+	defer s.capabilityRevokedMu.Unlock()
+	for _, key := range []string{email, ""} {
+		if at, ok := s.capabilityRevoked[key]; ok && issued <= at {
+			return true
+		}
+	}
+	return false
+}
+
+// accessWatcher notes whether a handler found the user's Drive access gone
+type accessWatcher struct {
+	http.ResponseWriter
+	lost bool
+}
+
+func (a *accessWatcher) accessLost() {
+	a.lost = true
+}
+
+// Flush keeps streaming handlers working behind the watcher
+func (a *accessWatcher) Flush() {
+	if flusher, ok := a.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// noteAccessLost tells RequireAccess that a Drive check made while handling
+// the request found the user without access to the Grants folder, so their
+// cached access and capabilities are dropped once the handler returns
+func noteAccessLost(w http.ResponseWriter) {
+	if a, ok := w.(interface{ accessLost() }); ok {
+		a.accessLost()
+	}
+}
+
+// PurgeAuthCacheHandler clears cached access decisions for one user or everyone
+func (s *Server) PurgeAuthCacheHandler(w http.ResponseWriter, r *http.Request) {
+	// An empty body purges everyone
+	var req PurgeAuthCacheRequest
+	if err := decodeBody(r, &req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	email := strings.TrimSpace(req.Email)
+	purged := s.PurgeAuthCache(email)
+
+	target := email
+	if target == "" {
+		target = "all users"
+	}
+	log.Printf("[API] PurgeAuthCache: %s (%d entries)", target, purged)
+	s.audit(r, AuditEvent{
+		Action:   "purge_auth_cache",
+		Resource: "auth_cache",
+		Target:   email,
+		Detail:   "purged cached access for " + target,
+	})
+
+	writeJSON(w, PurgeAuthCacheResponse{Purged: purged})
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAuthCacheTTLIsPerServer(t *testing.T) {
+	t.Setenv("AUTH_CACHE_TTL", "1ns")
+	short := newTestServer(t, nil)
+	t.Setenv("AUTH_CACHE_TTL", "")
+	long := newTestServer(t, nil)
+
+	if short.authCacheTTL != time.Nanosecond || long.authCacheTTL != defaultAuthCacheTTL {
+		t.Fatalf("TTLs = %s and %s, want 1ns and %s", short.authCacheTTL, long.authCacheTTL, defaultAuthCacheTTL)
+	}
+
+	short.setAuthCache("po@example.org", "folder-1", true, "writer")
+	long.setAuthCache("po@example.org", "folder-1", true, "writer")
+	time.Sleep(time.Millisecond)
+
+	if _, ok := short.checkAuthCache("po@example.org", "folder-1"); ok {
+		t.Error("entry outlived a 1ns TTL")
+	}
+	entry, ok := long.checkAuthCache("po@example.org", "folder-1")
+	if !ok || !entry.hasAccess || entry.role != "writer" {
+		t.Errorf("entry = %+v, %v; want cached writer access", entry, ok)
+	}
+}
+
+func TestPurgeAuthCache(t *testing.T) {
+	s := newTestServer(t, nil)
+	other := newTestServer(t, nil)
+	s.setAuthCache("a@example.org", "folder-1", true, "writer")
+	s.setAuthCache("a@example.org", "folder-2", true, "reader")
+	s.setAuthCache("b@example.org", "folder-1", true, "writer")
+	other.setAuthCache("a@example.org", "folder-1", true, "writer")
+
+	if n := s.PurgeAuthCache("a@example.org"); n != 2 {
+		t.Errorf("purged %d entries for a@, want 2", n)
+	}
+	if _, ok := s.checkAuthCache("b@example.org", "folder-1"); !ok {
+		t.Error("purging a@ dropped b@'s entry")
+	}
+	if _, ok := other.checkAuthCache("a@example.org", "folder-1"); !ok {
+		t.Error("purging one server's cache dropped another's")
+	}
+	if n := s.PurgeAuthCache(""); n != 1 {
+		t.Errorf("purged %d remaining entries, want 1", n)
+	}
+}
+
+func TestPurgeAuthCacheHandlerEmptyBody(t *testing.T) {
+	s := newTestServer(t, nil)
+	s.setAuthCache("a@example.org", "folder-1", true, "writer")
+	s.setAuthCache("b@example.org", "folder-1", true, "writer")
+
+	r := httptest.NewRequest(http.MethodPost, "/api/admin/auth-cache/purge", nil)
+	r.Header.Set("X-User-Email", "admin@example.org")
+	w := httptest.NewRecorder()
+	s.PurgeAuthCacheHandler(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var resp PurgeAuthCacheResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Purged != 2 {
+		t.Errorf("purged %d entries, want everyone's 2", resp.Purged)
+	}
+
+	// The spec has to agree, or generated clients insist on a body
+	swagger, err := GetSwagger()
+	if err != nil {
+		t.Fatalf("load spec: %v", err)
+	}
+	if body := swagger.Paths.Find("/admin/auth-cache/purge").Post.RequestBody.Value; body.Required {
+		t.Error("spec requires a request body")
+	}
+}
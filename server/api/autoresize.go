@@ -0,0 +1,97 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// errSheetNotFound is returned by autoResizeColumns for an unknown sheet title
+var errSheetNotFound = errors.New("sheet not found")
+
+// autoResizeRequest fits every column of a sheet to its contents
+func autoResizeRequest(props *sheets.SheetProperties) *sheets.Request {
+	columns := int64(0)
+	if props.GridProperties != nil {
+		columns = props.GridProperties.ColumnCount
+	}
+	return &sheets.Request{
+		AutoResizeDimensions: &sheets.AutoResizeDimensionsRequest{
+			Dimensions: &sheets.DimensionRange{
+				SheetId:    props.SheetId,
+				Dimension:  "COLUMNS",
+				StartIndex: 0,
+				EndIndex:   columns,
+				// The first sheet's ID and the start index are both 0, which
+				// would otherwise be dropped as empty
+				ForceSendFields: []string{"SheetId", "StartIndex"},
+			},
+		},
+	}
+}
+
+// autoResizeColumns widens a sheet's columns to fit their contents
+func autoResizeColumns(ctx context.Context, srv *sheets.Service, spreadsheetID, sheet string) error {
+	props, err := sheetProperties(ctx, srv, spreadsheetID, sheet)
+	if err != nil {
+		return err
+	}
+	if props == nil {
+		return errSheetNotFound
+	}
+	_, err = srv.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{autoResizeRequest(props)},
+	}).Context(ctx).Do()
+	return err
+}
+
+// AutoResizeColumns fits a sheet's column widths to their contents, e.g. after a bulk import
+func (s *Server) AutoResizeColumns(w http.ResponseWriter, r *http.Request) {
+	var req AutoResizeRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.Sheet == "" {
+		writeError(w, "Sheet is required", http.StatusBadRequest)
+		return
+	}
+	if refuseInternalSheet(w, req.Sheet) {
+		return
+	}
+
+	spreadsheetID, err := s.spreadsheetFor(r)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	srv, err := s.sheetsService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Sheets service: %v", err)
+		writeError(w, "Failed to connect to Google Sheets", http.StatusInternalServerError)
+		return
+	}
+
+	err = autoResizeColumns(r.Context(), srv, spreadsheetID, req.Sheet)
+	if isCancelled(err) {
+		writeCancelled(w, "AutoResizeColumns")
+		return
+	}
+	if errors.Is(err, errSheetNotFound) {
+		writeError(w, fmt.Sprintf("Sheet %s not found", req.Sheet), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to resize columns: %v", err)
+		writeError(w, fmt.Sprintf("Failed to resize columns: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, SuccessResponse{Success: true})
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+const spreadsheetBatchUpdatePath = "/v4/spreadsheets/" + testSpreadsheetID + ":batchUpdate"
+
+// replySheetGrids fakes spreadsheet metadata with Grants as the first sheet
+// (ID 0) and a narrower Budget sheet
+func (f *fakeGoogle) replySheetGrids() {
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID, &sheets.Spreadsheet{Sheets: []*sheets.Sheet{
+		{Properties: &sheets.SheetProperties{SheetId: 0, Title: "Grants", GridProperties: &sheets.GridProperties{RowCount: 100, ColumnCount: 12}}},
+		{Properties: &sheets.SheetProperties{SheetId: 9, Title: "Budget", GridProperties: &sheets.GridProperties{RowCount: 50, ColumnCount: 4}}},
+	}})
+}
+
+// sentResizes decodes the column ranges of every AutoResizeDimensions request sent
+func sentResizes(t *testing.T, f *fakeGoogle) []map[string]interface{} {
+	t.Helper()
+	var ranges []map[string]interface{}
+	for _, body := range f.sent(http.MethodPost, spreadsheetBatchUpdatePath) {
+		var req struct {
+			Requests []struct {
+				AutoResizeDimensions *struct {
+					Dimensions map[string]interface{} `json:"dimensions"`
+				} `json:"autoResizeDimensions"`
+			} `json:"requests"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("decode batch update: %v", err)
+		}
+		for _, r := range req.Requests {
+			if r.AutoResizeDimensions != nil {
+				ranges = append(ranges, r.AutoResizeDimensions.Dimensions)
+			}
+		}
+	}
+	return ranges
+}
+
+func TestAutoResizeColumns(t *testing.T) {
+	tests := []struct {
+		sheet       string
+		wantSheetID float64
+		wantEnd     float64
+	}{
+		{sheet: "Grants", wantSheetID: 0, wantEnd: 12},
+		{sheet: "Budget", wantSheetID: 9, wantEnd: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sheet, func(t *testing.T) {
+			f := newFakeGoogle(t)
+			f.replySheetGrids()
+			f.reply(http.MethodPost, spreadsheetBatchUpdatePath, &sheets.BatchUpdateSpreadsheetResponse{})
+			s := newTestServer(t, f)
+
+			w := callHandler(t, s.AutoResizeColumns, "po@example.org", AutoResizeRequest{Sheet: tt.sheet})
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", w.Code, w.Body)
+			}
+			resizes := sentResizes(t, f)
+			if len(resizes) != 1 {
+				t.Fatalf("sent %d resizes, want 1", len(resizes))
+			}
+			dims := resizes[0]
+			// sheetId and startIndex are 0 for the first sheet and must still be sent
+			sheetID, hasSheetID := dims["sheetId"]
+			start, hasStart := dims["startIndex"]
+			if !hasSheetID || sheetID != tt.wantSheetID || dims["dimension"] != "COLUMNS" || !hasStart || start != float64(0) || dims["endIndex"] != tt.wantEnd {
+				t.Errorf("resized %v, want columns 0-%v of sheet %v", dims, tt.wantEnd, tt.wantSheetID)
+			}
+		})
+	}
+}
+
+func TestAutoResizeColumnsUnknownSheet(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.replySheetGrids()
+	s := newTestServer(t, f)
+
+	w := callHandler(t, s.AutoResizeColumns, "po@example.org", AutoResizeRequest{Sheet: "Missing"})
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404: %s", w.Code, w.Body)
+	}
+	if calls := f.calls(http.MethodPost, spreadsheetBatchUpdatePath); len(calls) != 0 {
+		t.Errorf("sent %d batch updates for an unknown sheet", len(calls))
+	}
+}
+
+func TestBatchUpdateCellsAutoResize(t *testing.T) {
+	on := true
+	tests := []struct {
+		name       string
+		autoResize *bool
+		want       int
+	}{
+		{name: "off by default"},
+		{name: "opted in", autoResize: &on, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeGoogle(t)
+			f.replySheetGrids()
+			f.reply(http.MethodPost, valuesBatchUpdatePath, &sheets.BatchUpdateValuesResponse{})
+			f.reply(http.MethodPost, spreadsheetBatchUpdatePath, &sheets.BatchUpdateSpreadsheetResponse{})
+			s := newTestServer(t, f)
+			s.checkRangeBounds = false
+
+			req := cellUpdates(2)
+			req.AutoResize = tt.autoResize
+			if w := callHandler(t, s.BatchUpdateCells, "po@example.org", req); w.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", w.Code, w.Body)
+			}
+			if got := len(sentResizes(t, f)); got != tt.want {
+				t.Errorf("sent %d resizes, want %d", got, tt.want)
+			}
+		})
+	}
+}
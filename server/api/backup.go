@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// backupTitleLayout timestamps backup tabs; milliseconds keep two deletes in
+// the same second from colliding
+const backupTitleLayout = "2006-01-02 150405.000"
+
+// addSheetRequest creates a tab with its header row frozen
+func addSheetRequest(title string, headerRow int) *sheets.Request {
+	return &sheets.Request{
+		AddSheet: &sheets.AddSheetRequest{
+			Properties: &sheets.SheetProperties{
+				Title:          title,
+				GridProperties: &sheets.GridProperties{FrozenRowCount: int64(headerRow)},
+			},
+		},
+	}
+}
+
+// backupRows copies rows about to be deleted from sheet, under its headers, to a
+// new timestamped tab such as "Grants backup 2026-01-02 150405.000", and returns
+// the tab's title. Values are written RAW so they come back exactly as read.
+func backupRows(ctx context.Context, srv *sheets.Service, spreadsheetID, sheet string, headers []interface{}, rows [][]interface{}, now time.Time) (string, error) {
+	title := fmt.Sprintf("%s backup %s", sheet, now.UTC().Format(backupTitleLayout))
+
+	_, err := srv.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{addSheetRequest(title, 1)},
+	}).Context(ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("create backup tab: %w", err)
+	}
+
+	values := append([][]interface{}{headers}, rows...)
+	_, err = srv.Spreadsheets.Values.Update(spreadsheetID, "'"+strings.ReplaceAll(title, "'", "''")+"'!A1", &sheets.ValueRange{Values: values}).
+		ValueInputOption("RAW").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return "", fmt.Errorf("write backup tab %s: %w", title, err)
+	}
+	return title, nil
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// backupGrants is a Grants tab with two closed grants and an open one
+var backupGrants = [][]interface{}{
+	{"ID", "Status"},
+	{"G-1", "Closed"},
+	{"G-2", "Open"},
+	{"G-3", "Closed"},
+}
+
+// serveBackups fakes Grants (sheet ID 7) holding backupGrants and accepts new
+// backup tabs, failing writes to them when failWrite is set. It returns the
+// steps taken in order (one "delete" per batch of row deletions) and the rows
+// written to backups.
+func serveBackups(f *fakeGoogle, failWrite bool) (steps func() []string, backedUp func() [][]interface{}) {
+	var mu sync.Mutex
+	var order []string
+	var written [][]interface{}
+	step := func(s string) {
+		mu.Lock()
+		order = append(order, s)
+		mu.Unlock()
+	}
+
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID, &sheets.Spreadsheet{
+		Sheets: []*sheets.Sheet{{Properties: &sheets.SheetProperties{SheetId: 7, Title: "Grants"}}},
+	})
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: backupGrants})
+	f.handle(http.MethodPost, "/v4/spreadsheets/"+testSpreadsheetID+":batchUpdate", func(w http.ResponseWriter, r *http.Request) {
+		var req sheets.BatchUpdateSpreadsheetRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			f.t.Errorf("decode batch update: %v", err)
+		}
+		deletes := false
+		for _, sr := range req.Requests {
+			switch {
+			case sr.AddSheet != nil:
+				title := sr.AddSheet.Properties.Title
+				step("add " + title)
+				// The tab is named for the time of the delete, so its path is only known now
+				f.handle(http.MethodPut, "/v4/spreadsheets/"+testSpreadsheetID+"/values/'"+title+"'!A1", func(w http.ResponseWriter, r *http.Request) {
+					step("write " + title)
+					if failWrite {
+						http.Error(w, `{"error": {"code": 500, "message": "Backend error"}}`, http.StatusInternalServerError)
+						return
+					}
+					var vr sheets.ValueRange
+					if err := json.NewDecoder(r.Body).Decode(&vr); err != nil {
+						f.t.Errorf("decode backup write: %v", err)
+					}
+					mu.Lock()
+					written = append(written, vr.Values...)
+					mu.Unlock()
+					writeFakeJSON(w, &sheets.UpdateValuesResponse{})
+				})
+			case sr.DeleteDimension != nil:
+				deletes = true
+			}
+		}
+		if deletes {
+			step("delete")
+		}
+		writeFakeJSON(w, &sheets.BatchUpdateSpreadsheetResponse{})
+	})
+
+	steps = func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), order...)
+	}
+	backedUp = func() [][]interface{} {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([][]interface{}(nil), written...)
+	}
+	return steps, backedUp
+}
+
+// backupStepsOK checks a backup tab named for sheet was created and written
+// before the delete, returning the tab's title
+func backupStepsOK(t *testing.T, steps []string, sheet string) string {
+	t.Helper()
+	if len(steps) != 3 || !strings.HasPrefix(steps[0], "add "+sheet+" backup ") || steps[1] != "write "+strings.TrimPrefix(steps[0], "add ") || steps[2] != "delete" {
+		t.Fatalf("steps = %q, want a backup tab created and written before the delete", steps)
+	}
+	return strings.TrimPrefix(steps[0], "add ")
+}
+
+func TestDeleteRowBackup(t *testing.T) {
+	t.Setenv("DELETE_BACKUP", "true")
+	f := newFakeGoogle(t)
+	steps, backedUp := serveBackups(f, false)
+	s := newTestServer(t, f)
+
+	w := callHandler(t, s.DeleteRow, "po@example.org", DeleteRowRequest{Sheet: "Grants", IdColumn: "ID", Id: "G-2"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	backupStepsOK(t, steps(), "Grants")
+	if want := [][]interface{}{{"ID", "Status"}, {"G-2", "Open"}}; !reflect.DeepEqual(backedUp(), want) {
+		t.Errorf("backed up %v, want %v", backedUp(), want)
+	}
+}
+
+func TestDeleteRowsWhereBackup(t *testing.T) {
+	t.Setenv("DELETE_BACKUP", "true")
+	f := newFakeGoogle(t)
+	steps, backedUp := serveBackups(f, false)
+	s := newTestServer(t, f)
+
+	w := callHandler(t, s.DeleteRowsWhere, "po@example.org", DeleteRowsWhereRequest{Sheet: "Grants", Where: map[string]interface{}{"Status": "Closed"}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	title := backupStepsOK(t, steps(), "Grants")
+	if want := [][]interface{}{{"ID", "Status"}, {"G-1", "Closed"}, {"G-3", "Closed"}}; !reflect.DeepEqual(backedUp(), want) {
+		t.Errorf("backed up %v, want %v", backedUp(), want)
+	}
+	var resp DeleteRowsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Deleted != 2 || resp.BackupSheet == nil || *resp.BackupSheet != title {
+		t.Errorf("response = %+v, want 2 deleted and backup sheet %q", resp, title)
+	}
+}
+
+func TestDeleteRowBackupFailureKeepsRow(t *testing.T) {
+	t.Setenv("DELETE_BACKUP", "true")
+	f := newFakeGoogle(t)
+	steps, _ := serveBackups(f, true)
+	s := newTestServer(t, f)
+
+	w := callHandler(t, s.DeleteRow, "po@example.org", DeleteRowRequest{Sheet: "Grants", IdColumn: "ID", Id: "G-2"})
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500: %s", w.Code, w.Body)
+	}
+	for _, step := range steps() {
+		if step == "delete" {
+			t.Errorf("deleted the row after its backup failed (steps %q)", steps())
+		}
+	}
+}
+
+func TestDeleteRowWithoutBackup(t *testing.T) {
+	f := newFakeGoogle(t)
+	steps, _ := serveBackups(f, false)
+	s := newTestServer(t, f)
+
+	w := callHandler(t, s.DeleteRow, "po@example.org", DeleteRowRequest{Sheet: "Grants", IdColumn: "ID", Id: "G-2"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	if got := steps(); !reflect.DeepEqual(got, []string{"delete"}) {
+		t.Errorf("steps = %q, want only the delete", got)
+	}
+}
+
+func TestBackupRowsTitle(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodPost, "/v4/spreadsheets/"+testSpreadsheetID+":batchUpdate", &sheets.BatchUpdateSpreadsheetResponse{})
+	f.reply(http.MethodPut, "/v4/spreadsheets/"+testSpreadsheetID+"/values/'Grants backup 2026-01-02 150405.123'!A1", &sheets.UpdateValuesResponse{})
+	s := newTestServer(t, f)
+	srv, err := s.sheetsService(context.Background())
+	if err != nil {
+		t.Fatalf("sheets client: %v", err)
+	}
+
+	now := time.Date(2026, time.January, 2, 15, 4, 5, 123e6, time.UTC)
+	title, err := backupRows(context.Background(), srv, testSpreadsheetID, "Grants", backupGrants[0], backupGrants[1:2], now)
+	if err != nil {
+		t.Fatalf("backupRows: %v", err)
+	}
+	if title != "Grants backup 2026-01-02 150405.123" {
+		t.Errorf("title = %q", title)
+	}
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// maxBatchAppendRows caps one BatchAppendRows request, keeping it well under
+// the Sheets request size limit
+const maxBatchAppendRows = 1000
+
+// BatchAppendRows appends several rows with one Values.Append. Every row is
+// checked before anything is written, so a bad row fails the whole batch.
+func (s *Server) BatchAppendRows(w http.ResponseWriter, r *http.Request) {
+	var req BatchAppendRowsRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.Sheet == "" {
+		writeError(w, "Sheet name is required", http.StatusBadRequest)
+		return
+	}
+	if refuseInternalSheet(w, req.Sheet) {
+		return
+	}
+	if len(req.Rows) == 0 {
+		writeError(w, "At least one row is required", http.StatusBadRequest)
+		return
+	}
+	if len(req.Rows) > maxBatchAppendRows {
+		writeError(w, fmt.Sprintf("At most %d rows can be appended at once", maxBatchAppendRows), http.StatusBadRequest)
+		return
+	}
+
+	for _, row := range req.Rows {
+		if err := s.checkSensitiveWrite(r, row); err != nil {
+			writeError(w, err.Error(), http.StatusForbidden)
+			return
+		}
+	}
+
+	spreadsheetID, err := s.spreadsheetFor(r)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	srv, err := s.sheetsService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Sheets service: %v", err)
+		writeError(w, "Failed to connect to Google Sheets", http.StatusInternalServerError)
+		return
+	}
+
+	rules, ok := s.fieldRules(w, r, srv, spreadsheetID, req.Sheet, "BatchAppendRows")
+	if !ok {
+		return
+	}
+	var problems []FieldError
+	for n, row := range req.Rows {
+		for _, problem := range validateFields(rules, row, false) {
+			rowNum := n + 1
+			problem.Row = &rowNum
+			problems = append(problems, problem)
+		}
+	}
+	if len(problems) > 0 {
+		writeValidationError(w, problems)
+		return
+	}
+
+	headersResp, err := srv.Spreadsheets.Values.Get(spreadsheetID, s.headerRange(req.Sheet)).
+		Context(r.Context()).
+		Do()
+	if err != nil {
+		if isCancelled(err) {
+			writeCancelled(w, "BatchAppendRows")
+			return
+		}
+		if sheetMissing(r.Context(), srv, spreadsheetID, req.Sheet, err) {
+			writeError(w, fmt.Sprintf("Sheet %s not found", req.Sheet), http.StatusNotFound)
+			return
+		}
+		log.Printf("Failed to get headers: %v", err)
+		writeError(w, "Failed to get sheet headers", http.StatusInternalServerError)
+		return
+	}
+
+	if len(headersResp.Values) == 0 || len(headersResp.Values[0]) == 0 {
+		writeError(w, "Sheet has no headers", http.StatusBadRequest)
+		return
+	}
+	headers := headersResp.Values[0]
+
+	// Build every row in header order before writing any of them
+	values := make([][]interface{}, 0, len(req.Rows))
+	for n, row := range req.Rows {
+		columns, err := s.columnsForData(req.Sheet, headers, row)
+		if err != nil {
+			writeError(w, fmt.Sprintf("Row %d: %v", n+1, err), http.StatusBadRequest)
+			return
+		}
+		rowValues := make([]interface{}, len(headers))
+		for colIdx := range headers {
+			if val, ok := columns[colIdx]; ok {
+				rowValues[colIdx] = val
+			} else {
+				rowValues[colIdx] = ""
+			}
+		}
+		values = append(values, rowValues)
+	}
+
+	// Anchor table detection at the header so banner rows are not mistaken for the table
+	appendRange := fmt.Sprintf("%s!A%d", req.Sheet, s.headerRow(req.Sheet))
+	appendResp, err := withRetry(r.Context(), s.retryAttempts, false, func() (*sheets.AppendValuesResponse, error) {
+		return srv.Spreadsheets.Values.Append(spreadsheetID, appendRange, &sheets.ValueRange{Values: values}).
+			ValueInputOption("USER_ENTERED").
+			InsertDataOption("INSERT_ROWS").
+			Context(r.Context()).
+			Do()
+	})
+	if err != nil {
+		if isCancelled(err) {
+			writeCancelled(w, "BatchAppendRows")
+			return
+		}
+		log.Printf("Failed to append rows: %v", err)
+		writeError(w, fmt.Sprintf("Failed to append rows: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("[API] BatchAppendRows %s: %d rows", req.Sheet, len(values))
+	for _, rowValues := range values {
+		s.audit(r, AuditEvent{
+			Action:   "append_row",
+			Resource: req.Sheet,
+			Detail:   fmt.Sprintf("appended row to %s (batch of %d)", req.Sheet, len(values)),
+			After:    rowPayload(headers, rowValues),
+		})
+	}
+
+	result := BatchAppendRowsResponse{Appended: len(values)}
+	if appendResp.Updates != nil && appendResp.Updates.UpdatedRange != "" {
+		updated := appendResp.Updates.UpdatedRange
+		result.UpdatedRange = &updated
+	}
+	writeJSON(w, result)
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// standardTabs lists the tabs a new instance needs, with their header rows (see docs/SCHEMA.md)
+var standardTabs = []struct {
+	name    string
+	headers []string
+}{
+	{"Grants", []string{
+		"grant_id", "title", "organization", "contact_name", "contact_email", "type",
+		"category_a_pct", "category_b_pct", "category_c_pct", "category_d_pct",
+		"ecosystem", "amount", "grant_year", "status", "proposal_doc_url",
+		"internal_notes_url", "drive_folder_url", "github_repo", "created_at",
+		"updated_at", "status_changed_at", "notes",
+	}},
+	{"Orgs", []string{"org_id", "name", "website", "contact_name", "contact_email", "notes", "created_at"}},
+	{"AuditLog", []string{"timestamp", "user", "action", "resource", "target", "detail"}},
+	{"Config", []string{"key", "value"}},
+}
+
+// BootstrapSpreadsheet creates any missing standard tabs with their headers.
+// Existing tabs are never modified, so repeated calls are no-ops.
+func (s *Server) BootstrapSpreadsheet(w http.ResponseWriter, r *http.Request) {
+	spreadsheetID, err := s.spreadsheetFor(r)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	srv, err := s.sheetsService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Sheets service: %v", err)
+		writeError(w, "Failed to connect to Google Sheets", http.StatusInternalServerError)
+		return
+	}
+
+	spreadsheet, err := srv.Spreadsheets.Get(spreadsheetID).
+		Fields("sheets.properties.title").
+		Context(r.Context()).
+		Do()
+	if err != nil {
+		log.Printf("Failed to get spreadsheet: %v", err)
+		writeError(w, "Failed to get spreadsheet", http.StatusInternalServerError)
+		return
+	}
+
+	existingTitles := make(map[string]bool)
+	for _, sh := range spreadsheet.Sheets {
+		existingTitles[sh.Properties.Title] = true
+	}
+
+	result := BootstrapResponse{Created: []string{}, Existing: []string{}}
+	var addRequests []*sheets.Request
+	var headerData []*sheets.ValueRange
+	for _, tab := range standardTabs {
+		if existingTitles[tab.name] {
+			result.Existing = append(result.Existing, tab.name)
+			continue
+		}
+		result.Created = append(result.Created, tab.name)
+
+		headerRow := s.headerRow(tab.name)
+		addRequests = append(addRequests, addSheetRequest(tab.name, headerRow))
+
+		values := make([]interface{}, len(tab.headers))
+		for i, h := range tab.headers {
+			values[i] = h
+		}
+		headerData = append(headerData, &sheets.ValueRange{
+			Range:  fmt.Sprintf("%s!A%d", tab.name, headerRow),
+			Values: [][]interface{}{values},
+		})
+	}
+
+	if len(addRequests) > 0 {
+		_, err = srv.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: addRequests,
+		}).Context(r.Context()).Do()
+		if err != nil {
+			log.Printf("Failed to add tabs: %v", err)
+			writeError(w, fmt.Sprintf("Failed to add tabs: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		_, err = srv.Spreadsheets.Values.BatchUpdate(spreadsheetID, &sheets.BatchUpdateValuesRequest{
+			ValueInputOption: "RAW",
+			Data:             headerData,
+		}).Context(r.Context()).Do()
+		if err != nil {
+			log.Printf("Failed to write headers: %v", err)
+			writeError(w, fmt.Sprintf("Failed to write headers: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		s.audit(r, AuditEvent{
+			Action:   "bootstrap_spreadsheet",
+			Resource: spreadsheetID,
+			Detail:   fmt.Sprintf("created tabs %v", result.Created),
+		})
+	}
+
+	writeJSON(w, result)
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestBootstrapSpreadsheet(t *testing.T) {
+	t.Setenv("HEADER_ROWS", "Grants=3")
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID, &sheets.Spreadsheet{Sheets: []*sheets.Sheet{
+		{Properties: &sheets.SheetProperties{Title: "Orgs"}},
+		{Properties: &sheets.SheetProperties{Title: "Notes"}},
+	}})
+	batchPath := "/v4/spreadsheets/" + testSpreadsheetID + ":batchUpdate"
+	valuesPath := "/v4/spreadsheets/" + testSpreadsheetID + "/values:batchUpdate"
+	f.reply(http.MethodPost, batchPath, &sheets.BatchUpdateSpreadsheetResponse{})
+	f.reply(http.MethodPost, valuesPath, &sheets.BatchUpdateValuesResponse{})
+	s := newTestServer(t, f)
+
+	w := callHandler(t, s.BootstrapSpreadsheet, "admin@example.org", struct{}{})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var resp BootstrapResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	want := BootstrapResponse{Created: []string{"Grants", "AuditLog", "Config"}, Existing: []string{"Orgs"}}
+	if !reflect.DeepEqual(resp, want) {
+		t.Errorf("got %+v, want %+v", resp, want)
+	}
+
+	var added sheets.BatchUpdateSpreadsheetRequest
+	if err := json.Unmarshal(f.sent(http.MethodPost, batchPath)[0], &added); err != nil {
+		t.Fatalf("decode batch update: %v", err)
+	}
+	var titles []string
+	for _, r := range added.Requests {
+		titles = append(titles, r.AddSheet.Properties.Title)
+	}
+	if !reflect.DeepEqual(titles, want.Created) {
+		t.Errorf("added tabs %v, want %v", titles, want.Created)
+	}
+
+	var headers sheets.BatchUpdateValuesRequest
+	if err := json.Unmarshal(f.sent(http.MethodPost, valuesPath)[0], &headers); err != nil {
+		t.Fatalf("decode header write: %v", err)
+	}
+	if len(headers.Data) != 3 || headers.Data[0].Range != "Grants!A3" || headers.Data[2].Range != "Config!A1" {
+		t.Errorf("header ranges = %+v", headers.Data)
+	}
+}
+
+func TestBootstrapSpreadsheetComplete(t *testing.T) {
+	f := newFakeGoogle(t)
+	var existing []*sheets.Sheet
+	for _, tab := range standardTabs {
+		existing = append(existing, &sheets.Sheet{Properties: &sheets.SheetProperties{Title: tab.name}})
+	}
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID, &sheets.Spreadsheet{Sheets: existing})
+	s := newTestServer(t, f)
+
+	// The fake has no write handlers, so any change would also fail the test
+	w := callHandler(t, s.BootstrapSpreadsheet, "admin@example.org", struct{}{})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var resp BootstrapResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.Created) != 0 || len(resp.Existing) != len(standardTabs) {
+		t.Errorf("got %+v, want every tab existing", resp)
+	}
+}
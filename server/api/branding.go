@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"regexp"
+)
+
+// Config tab keys that override the INSTANCE_* branding environment variables
+const (
+	instanceNameKey       = "instance_name"
+	instanceLogoURLKey    = "instance_logo_url"
+	instanceThemeColorKey = "instance_theme_color"
+)
+
+// themeColorPattern accepts CSS hex colors: #RGB or #RRGGBB
+var themeColorPattern = regexp.MustCompile(`^#([0-9A-Fa-f]{3}|[0-9A-Fa-f]{6})$`)
+
+// validateBranding rejects logo URLs that aren't http(s) and colors that aren't hex
+func validateBranding(b Branding) error {
+	if b.LogoUrl != "" {
+		u, err := url.Parse(b.LogoUrl)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("logo URL %q must be an http(s) URL", b.LogoUrl)
+		}
+	}
+	if b.ThemeColor != "" && !themeColorPattern.MatchString(b.ThemeColor) {
+		return fmt.Errorf("theme color %q must be a hex color like #1a73e8", b.ThemeColor)
+	}
+	return nil
+}
+
+// brandingFromEnv reads INSTANCE_NAME, INSTANCE_LOGO_URL, and INSTANCE_THEME_COLOR
+func brandingFromEnv() (Branding, error) {
+	b := Branding{
+		Name:       os.Getenv("INSTANCE_NAME"),
+		LogoUrl:    os.Getenv("INSTANCE_LOGO_URL"),
+		ThemeColor: os.Getenv("INSTANCE_THEME_COLOR"),
+	}
+	if err := validateBranding(b); err != nil {
+		return Branding{}, fmt.Errorf("invalid INSTANCE_* branding: %w", err)
+	}
+	return b, nil
+}
+
+// loadBranding layers the Config tab's instance_* keys over the environment
+// branding. It runs after discovery, so edits to the tab show up on the next
+// discovery (startup or credential reload). A bad or unreadable tab keeps the
+// environment values.
+func (s *Server) loadBranding(ctx context.Context, spreadsheetID string) Branding {
+	b := s.envBranding
+
+	srv, err := s.sheetsService(ctx)
+	if err != nil {
+		log.Printf("[API] Branding: using environment values (%v)", err)
+		return b
+	}
+	values, err := configValues(ctx, srv, spreadsheetID)
+	if err != nil {
+		log.Printf("[API] Branding: could not read Config tab, using environment values (%v)", err)
+		return b
+	}
+
+	tab := b
+	if v, ok := values[instanceNameKey]; ok && v != "" {
+		tab.Name = v
+	}
+	if v, ok := values[instanceLogoURLKey]; ok && v != "" {
+		tab.LogoUrl = v
+	}
+	if v, ok := values[instanceThemeColorKey]; ok && v != "" {
+		tab.ThemeColor = v
+	}
+	if err := validateBranding(tab); err != nil {
+		log.Printf("[API] Branding: ignoring Config tab values (%v)", err)
+		return b
+	}
+	return tab
+}
+
+// currentBranding returns the branding for GetConfig, or nil when none is set
+func (s *Server) currentBranding() *Branding {
+	s.discoveryMu.RLock()
+	b := s.branding
+	s.discoveryMu.RUnlock()
+
+	if b == (Branding{}) {
+		return nil
+	}
+	return &b
+}
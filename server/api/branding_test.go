@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// setBrandingEnv sets the INSTANCE_* variables for one test
+func setBrandingEnv(t *testing.T, name, logoURL, themeColor string) {
+	t.Helper()
+	t.Setenv("INSTANCE_NAME", name)
+	t.Setenv("INSTANCE_LOGO_URL", logoURL)
+	t.Setenv("INSTANCE_THEME_COLOR", themeColor)
+}
+
+// getConfig calls GetConfig and returns the raw JSON object
+func getConfig(t *testing.T, s *Server) map[string]json.RawMessage {
+	t.Helper()
+	w := callHandler(t, s.GetConfig, "", struct{}{})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var config map[string]json.RawMessage
+	if err := json.Unmarshal(w.Body.Bytes(), &config); err != nil {
+		t.Fatalf("decode config: %v", err)
+	}
+	return config
+}
+
+func TestGetConfigBranding(t *testing.T) {
+	setBrandingEnv(t, "Acme Grants", "https://acme.example/logo.png", "#1a73e8")
+	s := newTestServer(t, nil)
+
+	raw, ok := getConfig(t, s)["branding"]
+	if !ok {
+		t.Fatal("config has no branding")
+	}
+	var b Branding
+	if err := json.Unmarshal(raw, &b); err != nil {
+		t.Fatalf("decode branding: %v", err)
+	}
+	want := Branding{Name: "Acme Grants", LogoUrl: "https://acme.example/logo.png", ThemeColor: "#1a73e8"}
+	if b != want {
+		t.Errorf("branding = %+v, want %+v", b, want)
+	}
+}
+
+func TestGetConfigWithoutBranding(t *testing.T) {
+	setBrandingEnv(t, "", "", "")
+	s := newTestServer(t, nil)
+
+	if raw, ok := getConfig(t, s)["branding"]; ok {
+		t.Errorf("config has branding %s, want it omitted", raw)
+	}
+}
+
+func TestBrandingFromEnvRejectsInvalidValues(t *testing.T) {
+	tests := []struct {
+		name, logoURL, themeColor string
+	}{
+		{"script logo", "javascript:alert(1)", ""},
+		{"relative logo", "/logo.png", ""},
+		{"named color", "", "red"},
+		{"long hex color", "", "#1a73e8ff"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setBrandingEnv(t, "Acme", tt.logoURL, tt.themeColor)
+			if _, err := brandingFromEnv(); err == nil {
+				t.Error("invalid branding accepted")
+			}
+		})
+	}
+}
+
+func TestLoadBrandingConfigTab(t *testing.T) {
+	tests := []struct {
+		name string
+		tab  [][]interface{}
+		want Branding
+	}{
+		{
+			name: "tab overrides the environment",
+			tab:  [][]interface{}{{"key", "value"}, {"instance_name", "Tab Name"}, {"instance_theme_color", "#abc"}},
+			want: Branding{Name: "Tab Name", LogoUrl: "https://env.example/logo.png", ThemeColor: "#abc"},
+		},
+		{
+			name: "invalid tab values keep the environment",
+			tab:  [][]interface{}{{"key", "value"}, {"instance_name", "Tab Name"}, {"instance_logo_url", "ftp://x/logo.png"}},
+			want: Branding{Name: "Env Name", LogoUrl: "https://env.example/logo.png"},
+		},
+		{
+			name: "empty tab values are ignored",
+			tab:  [][]interface{}{{"key", "value"}, {"instance_name", ""}},
+			want: Branding{Name: "Env Name", LogoUrl: "https://env.example/logo.png"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setBrandingEnv(t, "Env Name", "https://env.example/logo.png", "")
+			f := newFakeGoogle(t)
+			f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Config!A:B", &sheets.ValueRange{Values: tt.tab})
+			s := newTestServer(t, f)
+
+			if got := s.loadBranding(context.Background(), testSpreadsheetID); got != tt.want {
+				t.Errorf("branding = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewServerRejectsInvalidBranding(t *testing.T) {
+	setBrandingEnv(t, "Acme", "", "blue")
+	t.Setenv("ROOT_FOLDER_ID", "")
+	t.Setenv("GOOGLE_SERVICE_ACCOUNT_KEY", "")
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+
+	_, err := NewServer("test-client")
+	if err == nil || !strings.Contains(err.Error(), "INSTANCE_") {
+		t.Errorf("NewServer error = %v, want an INSTANCE_* branding error", err)
+	}
+}
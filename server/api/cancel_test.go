@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestIsCancelled(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"client went away", context.Canceled, true},
+		{"wrapped cancellation", fmt.Errorf("read Grants: %w", context.Canceled), true},
+		{"deadline exceeded", context.DeadlineExceeded, false},
+		{"other failure", fmt.Errorf("backend error"), false},
+		{"no error", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isCancelled(tt.err); got != tt.want {
+				t.Errorf("isCancelled(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteRowsWhereStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID, &sheets.Spreadsheet{
+		Sheets: []*sheets.Sheet{{Properties: &sheets.SheetProperties{SheetId: 7, Title: "Grants"}}},
+	})
+	// The client disconnects while the rows to delete are being read
+	f.handle(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		writeFakeJSON(w, &sheets.ValueRange{Values: [][]interface{}{{"ID", "Status"}, {"G-1", "Closed"}}})
+	})
+	s := newTestServer(t, f)
+
+	body, _ := json.Marshal(DeleteRowsWhereRequest{Sheet: "Grants", Where: map[string]interface{}{"Status": "Closed"}})
+	r := httptest.NewRequest(http.MethodPost, "/api/test", strings.NewReader(string(body))).WithContext(ctx)
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("X-User-Email", "admin@example.org")
+	w := httptest.NewRecorder()
+	s.DeleteRowsWhere(w, r)
+
+	if w.Code != statusClientClosedRequest {
+		t.Errorf("status = %d, want %d: %s", w.Code, statusClientClosedRequest, w.Body)
+	}
+	if calls := f.calls(http.MethodPost, "/v4/spreadsheets/"+testSpreadsheetID+":batchUpdate"); len(calls) != 0 {
+		t.Errorf("deleted rows %d times after the client went away", len(calls))
+	}
+}
+
+func TestPivotDeadlineIsNotACancellation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	f := newFakeGoogle(t)
+	s := newTestServer(t, f)
+
+	body, _ := json.Marshal(PivotRequest{Sheet: "Grants", Rows: []string{"Status"}, Agg: Count})
+	r := httptest.NewRequest(http.MethodPost, "/api/test", strings.NewReader(string(body))).WithContext(ctx)
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.Pivot(w, r)
+
+	if w.Code == statusClientClosedRequest {
+		t.Errorf("timed-out read reported as a client disconnect")
+	}
+	if w.Code < 500 {
+		t.Errorf("status = %d, want a server error", w.Code)
+	}
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	capabilityCookie     = "gt_access_capability"
+	capabilityHeader     = "X-Access-Capability"
+	defaultCapabilityTTL = 10 * time.Minute
+)
+
+// capability records a successful Drive access check so later requests can
+// skip it, even on another instance or after a restart when the secret is shared
+type capability struct {
+	Email   string `json:"e"`
+	Folder  string `json:"f"`
+	Role    string `json:"r"`
+	Expires int64  `json:"x"`
+	Issued  int64  `json:"i,omitempty"` // Unix milliseconds, checked against revocations
+}
+
+// randomCapabilitySecret is used when CAPABILITY_SECRET is unset; capabilities
+// then only survive as long as the process
+func randomCapabilitySecret() []byte {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic(err)
+	}
+	return secret
+}
+
+func (s *Server) signCapability(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.capabilitySecret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// issueCapability returns a token of the form base64(payload).base64(hmac)
+func (s *Server) issueCapability(c capability) string {
+	payload, _ := json.Marshal(c)
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(s.signCapability(payload))
+}
+
+// verifyCapability checks a token's signature, expiry, and that it was issued
+// for this user and folder, returning the role it grants
+func (s *Server) verifyCapability(token, email, folderID string, now time.Time) (string, error) {
+	payloadPart, sigPart, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", errors.New("malformed capability")
+	}
+	enc := base64.RawURLEncoding
+	payload, err := enc.DecodeString(payloadPart)
+	if err != nil {
+		return "", errors.New("malformed capability")
+	}
+	sig, err := enc.DecodeString(sigPart)
+	if err != nil || !hmac.Equal(sig, s.signCapability(payload)) {
+		return "", errors.New("invalid capability signature")
+	}
+
+	var c capability
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return "", errors.New("malformed capability")
+	}
+	if now.Unix() >= c.Expires {
+		return "", errors.New("capability expired")
+	}
+	if c.Email != email || c.Folder != folderID || c.Role == "" {
+		return "", errors.New("capability issued for another user or folder")
+	}
+	if s.capabilityRevokedFor(email, c.Issued) {
+		return "", errors.New("capability revoked")
+	}
+	return c.Role, nil
+}
+
+// capabilityFromRequest returns the token presented by the client, preferring
+// the header so non-browser clients can hold it themselves
+func capabilityFromRequest(r *http.Request) string {
+	if token := r.Header.Get(capabilityHeader); token != "" {
+		return token
+	}
+	if cookie, err := r.Cookie(capabilityCookie); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+// grantCapability hands the client a capability after a successful Drive check
+func (s *Server) grantCapability(w http.ResponseWriter, r *http.Request, email, folderID, role string) {
+	now := time.Now()
+	expires := now.Add(s.capabilityTTL)
+	token := s.issueCapability(capability{Email: email, Folder: folderID, Role: role, Expires: expires.Unix(), Issued: now.UnixMilli()})
+
+	w.Header().Set(capabilityHeader, token)
+	http.SetCookie(w, &http.Cookie{
+		Name:     capabilityCookie,
+		Value:    token,
+		Path:     "/api/",
+		Expires:  expires,
+		Secure:   s.production || r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
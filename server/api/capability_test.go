@@ -0,0 +1,139 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+)
+
+func TestCapabilityCookieSecureInProduction(t *testing.T) {
+	for _, prod := range []bool{false, true} {
+		old := Production
+		Production = prod
+		s := newTestServer(t, nil)
+		Production = old
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/sheets/read", nil)
+		s.grantCapability(w, r, "po@example.org", "folder-1", "writer")
+
+		cookies := w.Result().Cookies()
+		if len(cookies) != 1 || cookies[0].Name != capabilityCookie {
+			t.Fatalf("production=%v: got cookies %v", prod, cookies)
+		}
+		if cookies[0].Secure != prod {
+			t.Errorf("production=%v: Secure = %v", prod, cookies[0].Secure)
+		}
+		if !cookies[0].HttpOnly {
+			t.Errorf("production=%v: capability cookie is readable from script", prod)
+		}
+	}
+}
+
+func TestVerifyCapability(t *testing.T) {
+	s := newTestServer(t, nil)
+	now := time.Now()
+	valid := capability{Email: "po@example.org", Folder: "grants", Role: "writer", Expires: now.Add(time.Minute).Unix()}
+	token := s.issueCapability(valid)
+
+	// A token whose payload was edited after signing
+	payload, _ := json.Marshal(capability{Email: "po@example.org", Folder: "grants", Role: "owner", Expires: valid.Expires})
+	_, sig, _ := strings.Cut(token, ".")
+	tampered := base64.RawURLEncoding.EncodeToString(payload) + "." + sig
+
+	other := newTestServer(t, nil)
+	tests := []struct {
+		name   string
+		token  string
+		email  string
+		folder string
+		now    time.Time
+		want   string
+	}{
+		{name: "valid", token: token, email: "po@example.org", folder: "grants", now: now, want: "writer"},
+		{name: "expired", token: token, email: "po@example.org", folder: "grants", now: now.Add(2 * time.Minute)},
+		{name: "tampered payload", token: tampered, email: "po@example.org", folder: "grants", now: now},
+		{name: "signed with another secret", token: other.issueCapability(valid), email: "po@example.org", folder: "grants", now: now},
+		{name: "another user", token: token, email: "other@example.org", folder: "grants", now: now},
+		{name: "another folder", token: token, email: "po@example.org", folder: "other-folder", now: now},
+		{name: "malformed", token: "not-a-token", email: "po@example.org", folder: "grants", now: now},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, err := s.verifyCapability(tt.token, tt.email, tt.folder, tt.now)
+			if role != tt.want || (err == nil) != (tt.want != "") {
+				t.Errorf("verifyCapability() = %q, %v, want %q", role, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireAccessWithCapability(t *testing.T) {
+	fakeTokenInfo(t, "po@example.org")
+	t.Setenv("CAPABILITY_SECRET", strings.Repeat("s", 32))
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/files/grants/permissions", &drive.PermissionList{Permissions: []*drive.Permission{
+		{Id: "p-1", Role: "writer", Type: "user", EmailAddress: "po@example.org"},
+	}})
+	permissionChecks := func() int { return len(f.calls(http.MethodGet, "/files/grants/permissions")) }
+
+	// newInstance stands in for a freshly started server sharing the secret
+	newInstance := func() *Server {
+		s := newTestServer(t, f)
+		s.grantsFolderID = "grants"
+		return s
+	}
+	var role string
+	handler := func(s *Server) http.HandlerFunc {
+		return s.RequireAccess(func(w http.ResponseWriter, r *http.Request) {
+			role = r.Header.Get("X-User-Role")
+		})
+	}
+	call := func(s *Server, token string) *httptest.ResponseRecorder {
+		r := authRequest(t, "access-token")
+		if token != "" {
+			r.Header.Set(capabilityHeader, token)
+		}
+		w := httptest.NewRecorder()
+		handler(s)(w, r)
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d: %s", w.Code, w.Body)
+		}
+		return w
+	}
+
+	w := call(newInstance(), "")
+	token := w.Header().Get(capabilityHeader)
+	if token == "" || permissionChecks() != 1 {
+		t.Fatalf("first request: capability %q after %d Drive checks, want one check and a capability", token, permissionChecks())
+	}
+
+	role = ""
+	call(newInstance(), token)
+	if permissionChecks() != 1 {
+		t.Errorf("valid capability still checked Drive (%d checks)", permissionChecks())
+	}
+	if role != "writer" {
+		t.Errorf("role from capability = %q, want writer", role)
+	}
+
+	s := newInstance()
+	expired := s.issueCapability(capability{Email: "po@example.org", Folder: "grants", Role: "owner", Expires: time.Now().Add(-time.Minute).Unix()})
+	call(s, expired)
+	if permissionChecks() != 2 || role != "writer" {
+		t.Errorf("expired capability: %d Drive checks, role %q, want it verified again as writer", permissionChecks(), role)
+	}
+
+	payloadPart, sig, _ := strings.Cut(token, ".")
+	tampered := payloadPart + "x." + sig
+	call(newInstance(), tampered)
+	if permissionChecks() != 3 {
+		t.Errorf("tampered capability: %d Drive checks, want it verified again", permissionChecks())
+	}
+}
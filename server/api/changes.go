@@ -0,0 +1,190 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/googleapi"
+)
+
+// resyncRequiredCode tells the client its changes token is dead and it has to
+// reload everything
+const resyncRequiredCode = "RESYNC_REQUIRED"
+
+// invalidChangesToken reports whether Drive rejected a changes page token,
+// which happens once it is malformed or too old to replay from
+func invalidChangesToken(err error) bool {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.Code {
+	case http.StatusBadRequest, http.StatusNotFound, http.StatusGone:
+		return true
+	}
+	return false
+}
+
+// changeInGrantsTree reports whether a changed file is in the Grants or root
+// folder tree, starting from the parents the change already carries
+func (s *Server) changeInGrantsTree(ctx context.Context, srv *drive.Service, f *drive.File) (bool, error) {
+	if f.Id == s.discoveredGrantsFolderID() || f.Id == s.rootFolderID {
+		return true, nil
+	}
+	place, err := s.locateInTree(ctx, srv, f.Parents)
+	return place.inTree(), err
+}
+
+// noteTreeFile records that a file was seen inside the Grants or root tree
+func (s *Server) noteTreeFile(fileID string) {
+	s.treeFilesMu.Lock()
+	s.treeFiles[fileID] = true
+	s.treeFilesMu.Unlock()
+}
+
+// seenInTree reports whether a file was ever seen inside the Grants or root
+// tree by this instance
+func (s *Server) seenInTree(fileID string) bool {
+	s.treeFilesMu.Lock()
+	defer s.treeFilesMu.Unlock()
+	return s.treeFiles[fileID]
+}
+
+// ListChanges returns the files changed since a token from the Drive Changes
+// API, so clients can refresh only what changed instead of relisting folders
+func (s *Server) ListChanges(w http.ResponseWriter, r *http.Request) {
+	var req ListChangesRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	pageSize := s.listPageSize
+	if req.PageSize != 0 {
+		if req.PageSize < 1 || req.PageSize > maxListPageSize {
+			writeError(w, fmt.Sprintf("pageSize must be between 1 and %d", maxListPageSize), http.StatusBadRequest)
+			return
+		}
+		pageSize = req.PageSize
+	}
+
+	srv, err := s.driveService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Drive service: %v", err)
+		writeError(w, "Failed to connect to Google Drive", http.StatusInternalServerError)
+		return
+	}
+
+	// Only follow the Shared Drive the grants live on, when there is one
+	driveID := s.discoveredSharedDriveID()
+
+	if req.PageToken == "" {
+		call := srv.Changes.GetStartPageToken().SupportsAllDrives(true)
+		if driveID != "" {
+			call = call.DriveId(driveID)
+		}
+		start, err := withRetry(r.Context(), s.retryAttempts, true, func() (*drive.StartPageToken, error) {
+			return call.Context(r.Context()).Do()
+		})
+		if isCancelled(err) {
+			writeCancelled(w, "ListChanges")
+			return
+		}
+		if err != nil {
+			log.Printf("Failed to get changes start token: %v", err)
+			writeError(w, fmt.Sprintf("Failed to get changes start token: %v", err), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, ListChangesResponse{Changes: []DriveChange{}, NextPageToken: start.StartPageToken})
+		return
+	}
+
+	call := srv.Changes.List(req.PageToken).
+		Fields("nextPageToken, newStartPageToken, changes(fileId, removed, time, file(id, name, mimeType, modifiedTime, webViewLink, shortcutDetails, appProperties, parents, trashed))").
+		PageSize(int64(pageSize)).
+		IncludeRemoved(true).
+		SupportsAllDrives(true).
+		IncludeItemsFromAllDrives(true)
+	if driveID != "" {
+		call = call.DriveId(driveID)
+	}
+	resp, err := withRetry(r.Context(), s.retryAttempts, true, func() (*drive.ChangeList, error) {
+		return call.Context(r.Context()).Do()
+	})
+	switch {
+	case isCancelled(err):
+		writeCancelled(w, "ListChanges")
+		return
+	case invalidChangesToken(err):
+		log.Printf("Changes token rejected, client must resync: %v", err)
+		writeErrorCode(w, "Changes token is no longer valid; reload everything and start over without a token",
+			resyncRequiredCode, http.StatusGone)
+		return
+	case err != nil:
+		log.Printf("Failed to list changes: %v", err)
+		writeError(w, fmt.Sprintf("Failed to list changes: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	changes := []DriveChange{}
+	for _, c := range resp.Changes {
+		change := DriveChange{FileId: c.FileId, Removed: c.Removed}
+		if t, err := time.Parse(time.RFC3339, c.Time); err == nil {
+			change.Time = &t
+		}
+		// A change without a file is a removal, or a file the service account
+		// can no longer see. Its id alone would reveal activity outside the
+		// tree, so it is only passed on for files known to have been inside.
+		if c.File == nil {
+			if !s.seenInTree(c.FileId) {
+				continue
+			}
+			change.Removed = true
+		} else {
+			in, err := s.changeInGrantsTree(r.Context(), srv, c.File)
+			if isCancelled(err) {
+				writeCancelled(w, "ListChanges")
+				return
+			}
+			if err != nil {
+				log.Printf("Failed to place changed file %s: %v", c.FileId, err)
+				writeError(w, fmt.Sprintf("Failed to list changes: %v", err), http.StatusInternalServerError)
+				return
+			}
+			if !in {
+				// Moved out of the tree: to the client that is a removal
+				if !s.seenInTree(c.FileId) {
+					continue
+				}
+				change.Removed = true
+				changes = append(changes, change)
+				continue
+			}
+			// Moves change parents, so don't answer later scope checks from the cache
+			s.forgetParents(c.FileId)
+			s.noteTreeFile(c.FileId)
+			fi := fileInfoFromDrive(c.File)
+			change.File = &fi
+			change.Removed = c.File.Trashed
+		}
+		changes = append(changes, change)
+	}
+
+	result := ListChangesResponse{Changes: changes, NextPageToken: resp.NewStartPageToken}
+	if resp.NextPageToken != "" {
+		result.NextPageToken = resp.NextPageToken
+		result.HasMore = true
+	}
+
+	s.auditRead(r, AuditEvent{
+		Action: "list_changes",
+		Detail: fmt.Sprintf("listed %d changes (%d read from Drive)", len(changes), len(resp.Changes)),
+	})
+
+	writeJSON(w, result)
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// requiredColumnsKey is the Config tab key holding a JSON array of the columns
+// every grant record should fill in
+const requiredColumnsKey = "required_columns"
+
+// Completeness scores each row by how many of the required columns it fills,
+// least complete first, so program officers can see which records need work
+func (s *Server) Completeness(w http.ResponseWriter, r *http.Request) {
+	var req CompletenessRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.IdColumn == "" {
+		writeError(w, "idColumn is required", http.StatusBadRequest)
+		return
+	}
+	sheet := "Grants"
+	if req.Sheet != nil && *req.Sheet != "" {
+		sheet = *req.Sheet
+	}
+	if refuseInternalSheet(w, sheet) {
+		return
+	}
+
+	spreadsheetID, err := s.spreadsheetFor(r)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	srv, err := s.sheetsService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Sheets service: %v", err)
+		writeError(w, "Failed to connect to Google Sheets", http.StatusInternalServerError)
+		return
+	}
+
+	// Explicit columns win, then the Config tab, then REQUIRED_COLUMNS
+	var required []string
+	if req.RequiredColumns != nil {
+		required = *req.RequiredColumns
+	} else {
+		value, found, err := configValue(r.Context(), srv, spreadsheetID, requiredColumnsKey)
+		if isCancelled(err) {
+			writeCancelled(w, "Completeness")
+			return
+		}
+		if err != nil {
+			log.Printf("[API] Completeness: could not read Config tab (%v)", err)
+		}
+		if found && value != "" {
+			if err := json.Unmarshal([]byte(value), &required); err != nil {
+				writeError(w, fmt.Sprintf("Invalid %s in Config tab: %v", requiredColumnsKey, err), http.StatusInternalServerError)
+				return
+			}
+		} else {
+			required = s.requiredColumns
+		}
+	}
+	if len(required) == 0 {
+		writeError(w, fmt.Sprintf("No required columns configured; pass requiredColumns or set %s in the Config tab", requiredColumnsKey), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := withRetry(r.Context(), s.retryAttempts, true, func() (*sheets.ValueRange, error) {
+		return srv.Spreadsheets.Values.Get(spreadsheetID, sheet).
+			ValueRenderOption("UNFORMATTED_VALUE").Context(r.Context()).Do()
+	})
+	if isCancelled(err) {
+		writeCancelled(w, "Completeness")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to read sheet: %v", err)
+		writeError(w, "Failed to read sheet", http.StatusInternalServerError)
+		return
+	}
+	table := splitTable(resp.Values, s.headerRow(sheet))
+
+	idIdx, status, err := s.readableColumns(r, table, []string{req.IdColumn})
+	if err != nil {
+		writeError(w, err.Error(), status)
+		return
+	}
+	requiredIdx, status, err := s.readableColumns(r, table, required)
+	if err != nil {
+		writeError(w, err.Error(), status)
+		return
+	}
+
+	s.auditRead(r, AuditEvent{
+		Action:   "completeness",
+		Resource: sheet,
+		Detail:   fmt.Sprintf("scored %d rows of %s against %d required columns", len(table.rows), sheet, len(required)),
+	})
+
+	writeJSON(w, CompletenessResponse{
+		RequiredColumns: required,
+		Rows:            completenessScores(table.rows, idIdx[0], required, requiredIdx),
+	})
+}
+
+// completenessScores scores every non-empty row, least complete first with
+// ties broken by ID
+func completenessScores(rows [][]interface{}, idIdx int, required []string, requiredIdx []int) []RowCompleteness {
+	cell := func(row []interface{}, idx int) string {
+		if idx < len(row) {
+			return strings.TrimSpace(cellString(row[idx]))
+		}
+		return ""
+	}
+
+	scores := []RowCompleteness{}
+	for _, row := range rows {
+		if len(row) == 0 {
+			continue
+		}
+		score := RowCompleteness{Id: cell(row, idIdx), Required: len(required), Missing: []string{}}
+		for i, idx := range requiredIdx {
+			if cell(row, idx) != "" {
+				score.Filled++
+			} else {
+				score.Missing = append(score.Missing, required[i])
+			}
+		}
+		score.Percent = float64(score.Filled) * 100 / float64(score.Required)
+		scores = append(scores, score)
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Filled != scores[j].Filled {
+			return scores[i].Filled < scores[j].Filled
+		}
+		return scores[i].Id < scores[j].Id
+	})
+	return scores
+}
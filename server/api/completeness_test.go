@@ -0,0 +1,68 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// completenessGrants has G-2 missing two of its four required columns
+var completenessGrants = [][]interface{}{
+	{"grant_id", "title", "amount", "program_officer", "start_date"},
+	{"G-1", "Packaging", float64(5000), "po@example.org", "2026-01-01"},
+	{"G-2", "Docs", "", "  ", "2026-02-01"},
+	{"G-3", "Security", float64(1000), "po@example.org"},
+}
+
+func TestCompleteness(t *testing.T) {
+	tests := []struct {
+		name     string
+		required *[]string
+		config   [][]interface{}
+		env      string
+	}{
+		{name: "requiredColumns in the request", required: &[]string{"title", "amount", "program_officer", "start_date"}},
+		{name: "Config tab", config: [][]interface{}{{"key", "value"}, {requiredColumnsKey, `["title", "amount", "program_officer", "start_date"]`}}},
+		{name: "REQUIRED_COLUMNS", env: "title, amount, program_officer, start_date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("REQUIRED_COLUMNS", tt.env)
+			f := newFakeGoogle(t)
+			f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Config!A:B", &sheets.ValueRange{Values: tt.config})
+			f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: completenessGrants})
+			s := newTestServer(t, f)
+
+			w := callHandler(t, s.Completeness, "po@example.org", CompletenessRequest{IdColumn: "grant_id", RequiredColumns: tt.required})
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", w.Code, w.Body)
+			}
+			var resp CompletenessResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			want := []RowCompleteness{
+				{Id: "G-2", Filled: 2, Required: 4, Percent: 50, Missing: []string{"amount", "program_officer"}},
+				{Id: "G-3", Filled: 3, Required: 4, Percent: 75, Missing: []string{"start_date"}},
+				{Id: "G-1", Filled: 4, Required: 4, Percent: 100, Missing: []string{}},
+			}
+			if !reflect.DeepEqual(resp.Rows, want) {
+				t.Errorf("rows = %+v, want %+v", resp.Rows, want)
+			}
+		})
+	}
+}
+
+func TestCompletenessWithoutRequiredColumns(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Config!A:B", &sheets.ValueRange{})
+	s := newTestServer(t, f)
+
+	w := callHandler(t, s.Completeness, "po@example.org", CompletenessRequest{IdColumn: "grant_id"})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400: %s", w.Code, w.Body)
+	}
+}
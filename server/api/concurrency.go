@@ -0,0 +1,58 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// conflictCode marks an update rejected because the row changed since the client read it
+const conflictCode = "CONFLICT"
+
+// sameExpected reports whether a cell still holds the value the client read.
+// Reads are unformatted, so a number the client holds as text still matches.
+func sameExpected(expected, current interface{}) bool {
+	want, have := strings.TrimSpace(cellString(expected)), strings.TrimSpace(cellString(current))
+	if want == have {
+		return true
+	}
+	if w, ok := numericValue(expected); ok {
+		h, ok := numericValue(current)
+		return ok && w == h
+	}
+	return false
+}
+
+// expectedMismatches returns the columns in expected whose current value in row
+// differs, sorted. A column that isn't in the sheet is a client error.
+func expectedMismatches(table sheetTable, row []interface{}, expected map[string]interface{}) ([]string, error) {
+	var changed []string
+	for column, want := range expected {
+		idx := table.indexOf(column)
+		if idx == -1 {
+			return nil, fmt.Errorf("Column %s not found", column)
+		}
+		var current interface{} = ""
+		if idx < len(row) {
+			current = row[idx]
+		}
+		if !sameExpected(want, current) {
+			changed = append(changed, column)
+		}
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// writeConflict rejects an update with the row as it is now, so the client can
+// merge its edits and retry with fresh expected values
+func writeConflict(w http.ResponseWriter, changed []string, current map[string]interface{}) {
+	code := conflictCode
+	writeJSONStatus(w, UpdateConflict{
+		Error:   "Row changed since it was read: " + strings.Join(changed, ", "),
+		Code:    &code,
+		Columns: &changed,
+		Current: current,
+	}, http.StatusConflict)
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestConditionalUpdate(t *testing.T) {
+	tests := []struct {
+		name   string
+		equals string
+		status int
+	}{
+		{name: "same text", equals: "5000", status: http.StatusOK},
+		{name: "same number formatted differently", equals: "5,000.00", status: http.StatusOK},
+		{name: "surrounding whitespace", equals: " 5000 ", status: http.StatusOK},
+		{name: "different value", equals: "4000", status: http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeGoogle(t)
+			f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Config!A:B", &sheets.ValueRange{})
+			f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: [][]interface{}{
+				{"ID", "Status", "Budget"},
+				{"G-1", "Approved", float64(5000)},
+			}})
+			f.reply(http.MethodPost, "/v4/spreadsheets/"+testSpreadsheetID+"/values:batchUpdate", &sheets.BatchUpdateValuesResponse{})
+			s := newTestServer(t, f)
+
+			w := callHandler(t, s.ConditionalUpdate, "po@example.org", ConditionalUpdateRequest{
+				Sheet:     "Grants",
+				IdColumn:  "ID",
+				Id:        "G-1",
+				Data:      map[string]interface{}{"Status": "Paid"},
+				Condition: UpdateCondition{Column: "Budget", Equals: tt.equals},
+			})
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+			writes := len(f.calls(http.MethodPost, "/v4/spreadsheets/"+testSpreadsheetID+"/values:batchUpdate"))
+			if tt.status == http.StatusOK {
+				if writes != 1 {
+					t.Errorf("%d writes, want 1", writes)
+				}
+				return
+			}
+
+			if writes != 0 {
+				t.Errorf("%d writes after a failed condition", writes)
+			}
+			var conflict UpdateConflict
+			if err := json.Unmarshal(w.Body.Bytes(), &conflict); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if conflict.Code == nil || *conflict.Code != conflictCode {
+				t.Errorf("code = %v, want %s", conflict.Code, conflictCode)
+			}
+			if conflict.Columns == nil || len(*conflict.Columns) != 1 || (*conflict.Columns)[0] != "Budget" {
+				t.Errorf("columns = %v, want [Budget]", conflict.Columns)
+			}
+			if conflict.Current["Status"] != "Approved" {
+				t.Errorf("current = %v, want the row as it is now", conflict.Current)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// configSheet is the key-value tab for app settings (see docs/SCHEMA.md)
+const configSheet = "Config"
+
+// configValue returns the value stored under key in the Config tab.
+// found is false when the key is absent.
+func configValue(ctx context.Context, srv *sheets.Service, spreadsheetID, key string) (value string, found bool, err error) {
+	resp, err := srv.Spreadsheets.Values.Get(spreadsheetID, configSheet+"!A:B").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return "", false, err
+	}
+
+	// Skip the key/value header row
+	for i, row := range resp.Values {
+		if i == 0 || len(row) == 0 || cellString(row[0]) != key {
+			continue
+		}
+		if len(row) > 1 {
+			return cellString(row[1]), true, nil
+		}
+		return "", true, nil
+	}
+	return "", false, nil
+}
+
+// configValues returns every key in the Config tab with its value, for callers
+// that need several keys from one read. Later rows win over earlier duplicates.
+func configValues(ctx context.Context, srv *sheets.Service, spreadsheetID string) (map[string]string, error) {
+	resp, err := srv.Spreadsheets.Values.Get(spreadsheetID, configSheet+"!A:B").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	for i, row := range resp.Values {
+		if i == 0 || len(row) == 0 {
+			continue
+		}
+		value := ""
+		if len(row) > 1 {
+			value = cellString(row[1])
+		}
+		values[cellString(row[0])] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/sheets/v4"
+)
+
+// tabExists reports whether Sheets refused an AddSheet because the title is
+// taken, which can happen if another request adds the tab after our check
+func tabExists(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusBadRequest &&
+		strings.Contains(apiErr.Message, "already exists")
+}
+
+// CreateSpreadsheetTab adds a tab with a header row in row 1, so admins can set
+// up a new tracking sheet without editing the spreadsheet by hand
+func (s *Server) CreateSpreadsheetTab(w http.ResponseWriter, r *http.Request) {
+	var req CreateSpreadsheetTabRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req.Title = strings.TrimSpace(req.Title)
+	if req.Title == "" {
+		writeError(w, "title is required", http.StatusBadRequest)
+		return
+	}
+	if len(req.Headers) == 0 {
+		writeError(w, "headers must name at least one column", http.StatusBadRequest)
+		return
+	}
+
+	spreadsheetID, err := s.spreadsheetFor(r)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	srv, err := s.sheetsService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Sheets service: %v", err)
+		writeError(w, "Failed to connect to Google Sheets", http.StatusInternalServerError)
+		return
+	}
+
+	spreadsheet, err := srv.Spreadsheets.Get(spreadsheetID).
+		Fields("sheets.properties.title").
+		Context(r.Context()).
+		Do()
+	if err != nil {
+		log.Printf("Failed to get spreadsheet: %v", err)
+		writeError(w, "Failed to get spreadsheet", http.StatusInternalServerError)
+		return
+	}
+	// Sheets treats tab names case-insensitively
+	for _, sh := range spreadsheet.Sheets {
+		if strings.EqualFold(sh.Properties.Title, req.Title) {
+			writeError(w, fmt.Sprintf("A tab named %q already exists", sh.Properties.Title), http.StatusConflict)
+			return
+		}
+	}
+
+	resp, err := srv.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{addSheetRequest(req.Title, 1)},
+	}).Context(r.Context()).Do()
+	if tabExists(err) {
+		writeError(w, fmt.Sprintf("A tab named %q already exists", req.Title), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to add tab %s: %v", req.Title, err)
+		writeError(w, fmt.Sprintf("Failed to add tab: %v", err), http.StatusInternalServerError)
+		return
+	}
+	sheetID := resp.Replies[0].AddSheet.Properties.SheetId
+
+	headers := make([]interface{}, len(req.Headers))
+	for i, h := range req.Headers {
+		headers[i] = h
+	}
+	_, err = withRetry(r.Context(), s.retryAttempts, true, func() (*sheets.UpdateValuesResponse, error) {
+		return srv.Spreadsheets.Values.Update(spreadsheetID, "'"+strings.ReplaceAll(req.Title, "'", "''")+"'!A1", &sheets.ValueRange{
+			Values: [][]interface{}{headers},
+		}).ValueInputOption("RAW").Context(r.Context()).Do()
+	})
+	if err != nil {
+		// The tab exists now, so say so rather than leaving the caller to retry into a 409
+		log.Printf("Failed to write headers to new tab %s: %v", req.Title, err)
+		writeError(w, fmt.Sprintf("Created tab %q but failed to write its headers: %v", req.Title, err), http.StatusInternalServerError)
+		return
+	}
+
+	s.audit(r, AuditEvent{
+		Action:   "create_tab",
+		Resource: spreadsheetID,
+		Target:   req.Title,
+		Detail:   fmt.Sprintf("headers %v", req.Headers),
+	})
+
+	writeJSON(w, CreateSpreadsheetTabResponse{SheetId: sheetID, Title: req.Title})
+}
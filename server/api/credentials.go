@@ -0,0 +1,97 @@
+package api
+
+import (
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"golang.org/x/oauth2/google"
+	"golang.org/x/oauth2/jwt"
+	"google.golang.org/api/drive/v3"
+)
+
+// loadCredentials reads the service account key from GOOGLE_SERVICE_ACCOUNT_KEY
+// or the GOOGLE_APPLICATION_CREDENTIALS file. It returns nil credentials when
+// neither is set, and a description of where the key came from.
+func loadCredentials() ([]byte, string, error) {
+	if keyJSON := os.Getenv("GOOGLE_SERVICE_ACCOUNT_KEY"); keyJSON != "" {
+		return []byte(keyJSON), "GOOGLE_SERVICE_ACCOUNT_KEY", nil
+	}
+	if keyPath := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"); keyPath != "" {
+		data, err := os.ReadFile(keyPath)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read service account key file: %w", err)
+		}
+		return data, "file " + keyPath, nil
+	}
+	return nil, "", nil
+}
+
+// checkServiceAccountKey catches keys JWTConfigFromJSON accepts but that can
+// never mint a token
+func checkServiceAccountKey(config *jwt.Config) error {
+	if config.Email == "" {
+		return errors.New("client_email is missing")
+	}
+	if block, _ := pem.Decode(config.PrivateKey); block == nil {
+		return errors.New("private_key is not a PEM key")
+	}
+	return nil
+}
+
+// ReloadCredentials re-reads the service account key, rebuilds the Google API
+// clients with it, and re-runs discovery, so a rotated key takes effect without
+// a restart. A key that doesn't parse is rejected and the old one stays in use.
+func (s *Server) ReloadCredentials(w http.ResponseWriter, r *http.Request) {
+	creds, source, err := loadCredentials()
+	if err != nil {
+		writeError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if creds == nil {
+		writeError(w, "No service account key configured", http.StatusBadRequest)
+		return
+	}
+	config, err := google.JWTConfigFromJSON(creds, drive.DriveScope)
+	if err == nil {
+		err = checkServiceAccountKey(config)
+	}
+	if err != nil {
+		writeError(w, fmt.Sprintf("New service account key is invalid: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	// Drop the cached clients so the next call builds them with the new key
+	s.clientMu.Lock()
+	s.credentials = creds
+	s.sheetsClient = nil
+	s.driveClient = nil
+	s.docsClient = nil
+	// Start new write queues; writes already pending still flush on their own
+	s.writeQueuesMu.Lock()
+	s.writeQueues = make(map[string]*writeQueue)
+	s.writeQueuesMu.Unlock()
+	s.clientMu.Unlock()
+	log.Printf("[API] ReloadCredentials: loaded %s from %s", config.Email, source)
+
+	result := ReloadCredentialsResponse{Source: source, ClientEmail: config.Email}
+	if s.rootFolderID != "" {
+		if err := s.discoverResources(); err != nil {
+			log.Printf("[API] ReloadCredentials: discovery failed: %v", err)
+			writeError(w, fmt.Sprintf("Credentials reloaded but discovery failed: %v", err), http.StatusInternalServerError)
+			return
+		}
+		result.Rediscovered = true
+	}
+
+	s.audit(r, AuditEvent{
+		Action:   "reload_credentials",
+		Resource: "service_account",
+		Detail:   fmt.Sprintf("reloaded service account %s from %s", config.Email, source),
+	})
+
+	writeJSON(w, result)
+}
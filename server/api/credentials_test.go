@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// fakeServiceAccountKey returns a service account key for email whose tokens
+// are minted by f
+func fakeServiceAccountKey(t *testing.T, f *fakeGoogle, email string) string {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	data, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"client_email":   email,
+		"client_id":      "1234567890",
+		"private_key_id": "key-" + email,
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"token_uri":      f.server.URL + "/token",
+	})
+	if err != nil {
+		t.Fatalf("marshal key file: %v", err)
+	}
+	return string(data)
+}
+
+// handleTokens mints "token-for-<issuer>" for every JWT assertion f receives
+func handleTokens(t *testing.T, f *fakeGoogle) {
+	f.handle(http.MethodPost, "/token", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.FormValue("assertion"), ".")
+		if len(parts) != 3 {
+			t.Errorf("token request without a JWT assertion")
+			http.Error(w, "bad assertion", http.StatusBadRequest)
+			return
+		}
+		payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+		if err != nil {
+			t.Errorf("decode assertion: %v", err)
+		}
+		var claims struct {
+			Iss string `json:"iss"`
+		}
+		json.Unmarshal(payload, &claims)
+		writeFakeJSON(w, map[string]interface{}{
+			"access_token": "token-for-" + claims.Iss,
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	})
+}
+
+// sheetsCallToken makes one Sheets call with s's client and returns the bearer token it carried
+func sheetsCallToken(t *testing.T, s *Server, f *fakeGoogle) string {
+	t.Helper()
+	srv, err := s.sheetsService(context.Background())
+	if err != nil {
+		t.Fatalf("sheetsService: %v", err)
+	}
+	srv.BasePath = f.server.URL + "/"
+	if _, err := srv.Spreadsheets.Get(testSpreadsheetID).Do(); err != nil {
+		t.Fatalf("Sheets call: %v", err)
+	}
+	calls := f.calls(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID)
+	return strings.TrimPrefix(calls[len(calls)-1].header.Get("Authorization"), "Bearer ")
+}
+
+func TestReloadCredentialsRebuildsClients(t *testing.T) {
+	f := newFakeGoogle(t)
+	handleTokens(t, f)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID, &sheets.Spreadsheet{SpreadsheetId: testSpreadsheetID})
+
+	s := newTestServer(t, nil)
+	t.Setenv("GOOGLE_SERVICE_ACCOUNT_KEY", fakeServiceAccountKey(t, f, "old@example.iam.gserviceaccount.com"))
+	if w := callHandler(t, s.ReloadCredentials, "admin@example.org", struct{}{}); w.Code != http.StatusOK {
+		t.Fatalf("first reload: status %d: %s", w.Code, w.Body)
+	}
+	if got := sheetsCallToken(t, s, f); got != "token-for-old@example.iam.gserviceaccount.com" {
+		t.Fatalf("before rotation the call used %q", got)
+	}
+	oldQueue := &writeQueue{}
+	s.writeQueues[testSpreadsheetID] = oldQueue
+
+	t.Setenv("GOOGLE_SERVICE_ACCOUNT_KEY", fakeServiceAccountKey(t, f, "new@example.iam.gserviceaccount.com"))
+	w := callHandler(t, s.ReloadCredentials, "admin@example.org", struct{}{})
+	if w.Code != http.StatusOK {
+		t.Fatalf("reload: status %d: %s", w.Code, w.Body)
+	}
+	var resp ReloadCredentialsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.ClientEmail != "new@example.iam.gserviceaccount.com" || resp.Source != "GOOGLE_SERVICE_ACCOUNT_KEY" {
+		t.Errorf("got %+v", resp)
+	}
+
+	if got := sheetsCallToken(t, s, f); got != "token-for-new@example.iam.gserviceaccount.com" {
+		t.Errorf("after rotation the call used %q", got)
+	}
+	if s.writeQueues[testSpreadsheetID] == oldQueue {
+		t.Error("write queue from before the reload is still in use")
+	}
+}
+
+func TestReloadCredentialsKeepsOldKeyWhenNewIsInvalid(t *testing.T) {
+	f := newFakeGoogle(t)
+	s := newTestServer(t, nil)
+	oldKey := fakeServiceAccountKey(t, f, "old@example.iam.gserviceaccount.com")
+	s.credentials = []byte(oldKey)
+
+	t.Setenv("GOOGLE_SERVICE_ACCOUNT_KEY", `{"type": "service_account"}`)
+	if w := callHandler(t, s.ReloadCredentials, "admin@example.org", struct{}{}); w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400: %s", w.Code, w.Body)
+	}
+	if string(s.credentials) != oldKey {
+		t.Error("invalid key replaced the working one")
+	}
+}
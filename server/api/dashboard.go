@@ -0,0 +1,145 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/sheets/v4"
+)
+
+// dashboardRecentFiles is how many recently modified files the dashboard shows
+const dashboardRecentFiles = 10
+
+// GetDashboard returns config, a grants summary, and recent activity in one
+// response. Sections load concurrently; a failed section becomes a warning.
+func (s *Server) GetDashboard(w http.ResponseWriter, r *http.Request) {
+	spreadsheetID, err := s.spreadsheetFor(r)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	ctx := r.Context()
+	result := DashboardResponse{Config: s.buildConfig(), Warnings: []string{}}
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex // Guards result
+	)
+	warn := func(section string, err error) {
+		log.Printf("[API] Dashboard: %s failed: %v", section, err)
+		mu.Lock()
+		result.Warnings = append(result.Warnings, fmt.Sprintf("%s unavailable: %v", section, err))
+		mu.Unlock()
+	}
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		summary, err := s.grantsSummary(ctx, spreadsheetID)
+		if err != nil {
+			warn("grants", err)
+			return
+		}
+		mu.Lock()
+		result.Grants = summary
+		mu.Unlock()
+	}()
+	go func() {
+		defer wg.Done()
+		files, err := s.recentFiles(ctx, dashboardRecentFiles)
+		if err != nil {
+			warn("recent files", err)
+			return
+		}
+		mu.Lock()
+		result.RecentFiles = &files
+		mu.Unlock()
+	}()
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		writeCancelled(w, "GetDashboard")
+		return
+	}
+
+	writeJSON(w, result)
+}
+
+// grantsSummary counts grants in the Grants tab, in total and per status
+func (s *Server) grantsSummary(ctx context.Context, spreadsheetID string) (*GrantsSummary, error) {
+	srv, err := s.sheetsService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := withRetry(ctx, s.retryAttempts, true, func() (*sheets.ValueRange, error) {
+		return srv.Spreadsheets.Values.Get(spreadsheetID, "Grants").
+			ValueRenderOption("UNFORMATTED_VALUE").
+			Context(ctx).
+			Do()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	table := splitTable(resp.Values, s.headerRow("Grants"))
+	statusIdx := table.indexOf("status")
+	summary := &GrantsSummary{ByStatus: map[string]int{}}
+	for _, row := range table.rows {
+		if len(row) == 0 {
+			continue
+		}
+		summary.Total++
+		status := ""
+		if statusIdx != -1 && statusIdx < len(row) {
+			status = cellString(row[statusIdx])
+		}
+		summary.ByStatus[status]++
+	}
+	return summary, nil
+}
+
+// recentFiles lists the most recently modified files in the Shared Drive, or
+// directly in the Grants folder before the drive is discovered
+func (s *Server) recentFiles(ctx context.Context, limit int) ([]FileInfo, error) {
+	srv, err := s.driveService(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	call := srv.Files.List().
+		Fields("files(id, name, mimeType, modifiedTime, webViewLink, shortcutDetails, appProperties)").
+		OrderBy("modifiedTime desc").
+		PageSize(int64(limit)).
+		SupportsAllDrives(true).
+		IncludeItemsFromAllDrives(true).
+		Context(ctx)
+	if driveID := s.discoveredSharedDriveID(); driveID != "" {
+		call = call.Corpora("drive").DriveId(driveID).Q("trashed = false")
+	} else if folderID := s.discoveredGrantsFolderID(); folderID != "" {
+		call = call.Q(fmt.Sprintf("'%s' in parents and trashed = false", folderID))
+	} else {
+		return nil, fmt.Errorf("Grants folder not discovered")
+	}
+
+	resp, err := withRetry(ctx, s.retryAttempts, true, func() (*drive.FileList, error) {
+		return call.Do()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return filesFromDrive(resp.Files), nil
+}
+
+// filesFromDrive converts a Drive listing to FileInfo
+func filesFromDrive(files []*drive.File) []FileInfo {
+	infos := make([]FileInfo, 0, len(files))
+	for _, f := range files {
+		infos = append(infos, fileInfoFromDrive(f))
+	}
+	return infos
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/sheets/v4"
+)
+
+// getDashboard calls GetDashboard and decodes the response
+func getDashboard(t *testing.T, s *Server) DashboardResponse {
+	t.Helper()
+	w := callHandler(t, s.GetDashboard, "po@example.org", struct{}{})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var resp DashboardResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return resp
+}
+
+func TestGetDashboard(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: [][]interface{}{
+		{"grant_id", "status"},
+		{"G-1", "Active"},
+		{"G-2", "Active"},
+		{"G-3", "Closed"},
+		{"G-4"},
+		{},
+	}})
+	f.reply(http.MethodGet, "/files", &drive.FileList{Files: []*drive.File{{Id: "doc-1", Name: "Proposal"}, {Id: "doc-2", Name: "Report"}}})
+	s := newTestServer(t, f)
+	s.sharedDriveID = "drive-1"
+
+	resp := getDashboard(t, s)
+	if len(resp.Warnings) != 0 {
+		t.Errorf("warnings = %v, want none", resp.Warnings)
+	}
+	want := &GrantsSummary{Total: 4, ByStatus: map[string]int{"Active": 2, "Closed": 1, "": 1}}
+	if !reflect.DeepEqual(resp.Grants, want) {
+		t.Errorf("grants = %+v, want %+v", resp.Grants, want)
+	}
+	if resp.RecentFiles == nil || len(*resp.RecentFiles) != 2 || (*resp.RecentFiles)[0].Id != "doc-1" {
+		t.Errorf("recent files = %v, want doc-1 and doc-2", resp.RecentFiles)
+	}
+	if resp.Config.ClientId != "test-client" {
+		t.Errorf("config = %+v, want the server config", resp.Config)
+	}
+}
+
+func TestGetDashboardSectionFails(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.handle(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": {"code": 403, "message": "The caller does not have permission"}}`, http.StatusForbidden)
+	})
+	f.reply(http.MethodGet, "/files", &drive.FileList{Files: []*drive.File{{Id: "doc-1", Name: "Proposal"}}})
+	s := newTestServer(t, f)
+	s.grantsFolderID = "grants"
+
+	resp := getDashboard(t, s)
+	if resp.Grants != nil {
+		t.Errorf("grants = %+v, want the failed section left out", resp.Grants)
+	}
+	if len(resp.Warnings) != 1 || !strings.HasPrefix(resp.Warnings[0], "grants unavailable") {
+		t.Errorf("warnings = %v, want one for grants", resp.Warnings)
+	}
+	if resp.RecentFiles == nil || len(*resp.RecentFiles) != 1 {
+		t.Errorf("recent files = %v, want them despite the grants failure", resp.RecentFiles)
+	}
+}
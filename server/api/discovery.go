@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+)
+
+// discoveryRetryAfterSeconds is the Retry-After hint sent while discovery runs
+const discoveryRetryAfterSeconds = 2
+
+// defaultDiscoveryWaitTimeout is how long a request waits for discovery to finish
+const defaultDiscoveryWaitTimeout = 10 * time.Second
+
+// runDiscovery discovers resources in the background and clears the in-progress flag when done
+func (s *Server) runDiscovery() {
+	err := s.discoverResources()
+
+	s.discoveryMu.Lock()
+	s.discovering = false
+	close(s.discoveryDone)
+	s.discoveryMu.Unlock()
+
+	if err != nil {
+		// Don't fail the server - just log the error
+		log.Printf("[API] Discovery failed: %v", err)
+		return
+	}
+	log.Printf("[API] Discovery complete")
+}
+
+// isDiscovering reports whether background discovery is still running
+func (s *Server) isDiscovering() bool {
+	s.discoveryMu.RLock()
+	defer s.discoveryMu.RUnlock()
+	return s.discovering
+}
+
+// awaitDiscovery blocks until a running discovery finishes, so requests that
+// arrive during startup are served instead of failing on missing IDs. It gives
+// up after s.discoveryWaitTimeout or when ctx ends.
+func (s *Server) awaitDiscovery(ctx context.Context) error {
+	s.discoveryMu.RLock()
+	discovering, done := s.discovering, s.discoveryDone
+	s.discoveryMu.RUnlock()
+	if !discovering {
+		return nil
+	}
+	if s.discoveryWaitTimeout <= 0 {
+		return fmt.Errorf("Server is still starting up; retry in %d seconds", discoveryRetryAfterSeconds)
+	}
+
+	timer := time.NewTimer(s.discoveryWaitTimeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("Server is still starting up; retry in %d seconds", discoveryRetryAfterSeconds)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// discoveredSpreadsheetID returns the spreadsheet found in the root folder ("" until discovered)
+func (s *Server) discoveredSpreadsheetID() string {
+	s.discoveryMu.RLock()
+	defer s.discoveryMu.RUnlock()
+	return s.spreadsheetID
+}
+
+// discoveredGrantsFolderID returns the Grants folder found in the root folder ("" until discovered)
+func (s *Server) discoveredGrantsFolderID() string {
+	s.discoveryMu.RLock()
+	defer s.discoveryMu.RUnlock()
+	return s.grantsFolderID
+}
+
+// discoveredSharedDriveID returns the Shared Drive containing the root folder ("" until discovered)
+func (s *Server) discoveredSharedDriveID() string {
+	s.discoveryMu.RLock()
+	defer s.discoveryMu.RUnlock()
+	return s.sharedDriveID
+}
+
+// findGrantsFolder returns the Grants folder in the root folder ("" if there is
+// none). If duplicates exist, the oldest wins so every instance agrees.
+func (s *Server) findGrantsFolder(ctx context.Context, srv *drive.Service) (string, error) {
+	query := fmt.Sprintf("'%s' in parents and mimeType = '%s' and name = 'Grants' and trashed = false", s.rootFolderID, folderMimeType)
+	resp, err := srv.Files.List().
+		Q(query).
+		SupportsAllDrives(true).
+		IncludeItemsFromAllDrives(true).
+		Fields("files(id, name)").
+		OrderBy("createdTime").
+		PageSize(1).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return "", fmt.Errorf("failed to search for Grants folder: %w", err)
+	}
+	if len(resp.Files) == 0 {
+		return "", nil
+	}
+	return resp.Files[0].Id, nil
+}
+
+// createGrantsFolder creates the Grants folder unless one appeared since the
+// last check. Another server instance can still race us between the check and
+// the create, so afterwards we adopt the oldest folder rather than ours.
+func (s *Server) createGrantsFolder(ctx context.Context, srv *drive.Service) (string, error) {
+	id, err := s.findGrantsFolder(ctx, srv)
+	if err != nil || id != "" {
+		return id, err
+	}
+
+	created, err := s.createFolder(ctx, srv, "Grants", s.rootFolderID, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create Grants folder: %w", err)
+	}
+	log.Printf("[API]   Created Grants folder: %s", maskString(created.Id))
+
+	if id, err = s.findGrantsFolder(ctx, srv); err == nil && id != "" && id != created.Id {
+		log.Printf("[API]   Another instance created the Grants folder first; using %s", maskString(id))
+		return id, nil
+	}
+	return created.Id, nil
+}
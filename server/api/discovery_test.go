@@ -0,0 +1,252 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/sheets/v4"
+)
+
+// testSharedDriveID is the Shared Drive serveDiscovery puts the root folder on
+const testSharedDriveID = "0AExampleSharedDrive"
+
+// serveDiscovery fakes a root folder with ID "root" on a Shared Drive, holding
+// the tracker spreadsheet and an existing Grants folder
+func serveDiscovery(f *fakeGoogle) {
+	f.reply(http.MethodGet, "/files/root", &drive.File{Id: "root", Name: "Grant Tracker", DriveId: testSharedDriveID, MimeType: folderMimeType})
+	f.handle(http.MethodGet, "/files", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		switch {
+		case strings.Contains(q, "google-apps.spreadsheet"):
+			writeFakeJSON(w, &drive.FileList{Files: []*drive.File{{Id: testSpreadsheetID, Name: "Grants"}}})
+		case strings.Contains(q, "name = 'Grants'"):
+			writeFakeJSON(w, &drive.FileList{Files: []*drive.File{{Id: "grants", Name: "Grants"}}})
+		default:
+			f.t.Errorf("unexpected Drive query %q", q)
+			writeFakeJSON(w, &drive.FileList{})
+		}
+	})
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Config!A:B", &sheets.ValueRange{})
+}
+
+func TestGetConfigDuringDiscovery(t *testing.T) {
+	tests := []struct {
+		name           string
+		discovering    bool
+		wantRetryAfter string
+	}{
+		{name: "in progress", discovering: true, wantRetryAfter: "2"},
+		{name: "complete", discovering: false, wantRetryAfter: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t, nil)
+			s.discovering = tt.discovering
+			s.discoveryDone = make(chan struct{})
+
+			w := callHandler(t, s.GetConfig, "", nil)
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", w.Code, w.Body)
+			}
+			if got := w.Header().Get("Retry-After"); got != tt.wantRetryAfter {
+				t.Errorf("Retry-After = %q, want %q", got, tt.wantRetryAfter)
+			}
+			var config Config
+			if err := json.Unmarshal(w.Body.Bytes(), &config); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if got := config.Discovering != nil && *config.Discovering; got != tt.discovering {
+				t.Errorf("discovering = %v, want %v", got, tt.discovering)
+			}
+		})
+	}
+}
+
+func TestGetConfigSharedDriveID(t *testing.T) {
+	tests := []struct {
+		name   string
+		expose string
+		want   string
+	}{
+		{name: "masked by default", want: maskString(testSharedDriveID)},
+		{name: "EXPOSE_SHARED_DRIVE_ID=true", expose: "true", want: testSharedDriveID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("EXPOSE_SHARED_DRIVE_ID", tt.expose)
+			f := newFakeGoogle(t)
+			serveDiscovery(f)
+			s := newTestServer(t, f)
+			s.rootFolderID = "root"
+			// Config only reports discovered IDs once credentials are set
+			t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "/dev/null")
+
+			if config := getConfig(t, s); config["sharedDriveId"] != nil {
+				t.Errorf("sharedDriveId = %s before discovery", config["sharedDriveId"])
+			}
+			if err := s.discoverResources(); err != nil {
+				t.Fatalf("discoverResources: %v", err)
+			}
+			var got string
+			if err := json.Unmarshal(getConfig(t, s)["sharedDriveId"], &got); err != nil {
+				t.Fatalf("decode sharedDriveId: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("sharedDriveId = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// serveEmptyRoot fakes a root folder that starts without a Grants folder and
+// records Grants folders as they are created. existing is returned ahead of
+// ours by the oldest-first search, standing in for another instance's folder.
+func serveEmptyRoot(f *fakeGoogle, existing func() []*drive.File) {
+	var mu sync.Mutex
+	var created []*drive.File
+	f.reply(http.MethodGet, "/files/root", &drive.File{Id: "root", Name: "Grant Tracker", DriveId: testSharedDriveID, MimeType: folderMimeType})
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Config!A:B", &sheets.ValueRange{})
+	f.handle(http.MethodGet, "/files", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		if strings.Contains(q, "google-apps.spreadsheet") {
+			writeFakeJSON(w, &drive.FileList{Files: []*drive.File{{Id: testSpreadsheetID, Name: "Grants"}}})
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		folders := append(existing(), created...)
+		if len(folders) > 1 {
+			folders = folders[:1]
+		}
+		writeFakeJSON(w, &drive.FileList{Files: folders})
+	})
+	f.handle(http.MethodPost, "/files", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		folder := &drive.File{Id: fmt.Sprintf("grants-%d", len(created)+1), Name: "Grants"}
+		created = append(created, folder)
+		writeFakeJSON(w, folder)
+	})
+}
+
+func TestConcurrentDiscoveryCreatesOneGrantsFolder(t *testing.T) {
+	f := newFakeGoogle(t)
+	serveEmptyRoot(f, func() []*drive.File { return nil })
+	s := newTestServer(t, f)
+	s.rootFolderID = "root"
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.discoverResources(); err != nil {
+				t.Errorf("discoverResources: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if creates := f.calls(http.MethodPost, "/files"); len(creates) != 1 {
+		t.Errorf("created the Grants folder %d times, want once", len(creates))
+	}
+	if got := s.discoveredGrantsFolderID(); got != "grants-1" {
+		t.Errorf("Grants folder = %q, want grants-1", got)
+	}
+}
+
+func TestCreateGrantsFolderAdoptsOlderFolder(t *testing.T) {
+	f := newFakeGoogle(t)
+	// Another instance's folder only shows up once ours has been created
+	var raced bool
+	var mu sync.Mutex
+	serveEmptyRoot(f, func() []*drive.File {
+		mu.Lock()
+		defer mu.Unlock()
+		if !raced {
+			return nil
+		}
+		return []*drive.File{{Id: "grants-other", Name: "Grants"}}
+	})
+	f.handle(http.MethodPost, "/files", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		raced = true
+		mu.Unlock()
+		writeFakeJSON(w, &drive.File{Id: "grants-ours", Name: "Grants"})
+	})
+	s := newTestServer(t, f)
+	s.rootFolderID = "root"
+
+	id, err := s.createGrantsFolder(context.Background(), s.driveClient)
+	if err != nil {
+		t.Fatalf("createGrantsFolder: %v", err)
+	}
+	if id != "grants-other" {
+		t.Errorf("Grants folder = %q, want the older grants-other", id)
+	}
+}
+
+func TestRequireAccessWaitsForDiscovery(t *testing.T) {
+	tests := []struct {
+		name     string
+		wait     time.Duration
+		finishIn time.Duration
+		wantCode int
+	}{
+		{name: "discovery finishes within the timeout", wait: time.Second, finishIn: 20 * time.Millisecond, wantCode: http.StatusOK},
+		{name: "discovery outlasts the timeout", wait: 20 * time.Millisecond, finishIn: time.Second, wantCode: http.StatusServiceUnavailable},
+		{name: "waiting disabled", wait: 0, finishIn: 20 * time.Millisecond, wantCode: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeTokenInfo(t, "po@example.org")
+			f := newFakeGoogle(t)
+			f.reply(http.MethodGet, "/files/grants/permissions", &drive.PermissionList{Permissions: []*drive.Permission{
+				{Id: "p-1", Role: "writer", Type: "user", EmailAddress: "po@example.org"},
+			}})
+			s := newTestServer(t, f)
+			s.discoveryWaitTimeout = tt.wait
+			s.discovering = true
+			s.discoveryDone = make(chan struct{})
+
+			// Stands in for runDiscovery finding the Grants folder
+			finished := time.AfterFunc(tt.finishIn, func() {
+				s.discoveryMu.Lock()
+				s.grantsFolderID = "grants"
+				s.discovering = false
+				close(s.discoveryDone)
+				s.discoveryMu.Unlock()
+			})
+			t.Cleanup(func() { finished.Stop() })
+
+			served := false
+			w := httptest.NewRecorder()
+			s.RequireAccess(func(w http.ResponseWriter, r *http.Request) { served = true })(w, authRequest(t, "access-token"))
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body)
+			}
+			if served != (tt.wantCode == http.StatusOK) {
+				t.Errorf("handler ran = %v with status %d", served, w.Code)
+			}
+			if tt.wantCode == http.StatusServiceUnavailable && w.Header().Get("Retry-After") == "" {
+				t.Errorf("503 without a Retry-After hint")
+			}
+		})
+	}
+}
+
+func TestAwaitDiscoveryWhenIdle(t *testing.T) {
+	s := newTestServer(t, nil)
+	s.discoveryWaitTimeout = 0
+	if err := s.awaitDiscovery(context.Background()); err != nil {
+		t.Errorf("awaitDiscovery with no discovery running = %v, want nil", err)
+	}
+}
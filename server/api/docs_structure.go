@@ -0,0 +1,167 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+)
+
+// DocStructureRequest is the request body for reading a doc's structure
+type DocStructureRequest struct {
+	DocumentId string `json:"documentId"`
+}
+
+// DocBlock is one top-level element of a doc: a title, heading, paragraph,
+// list item, or table
+type DocBlock struct {
+	Type  string     `json:"type"`
+	Level int        `json:"level,omitempty"` // Heading level (1-6) or list nesting depth (0-based)
+	Text  string     `json:"text,omitempty"`
+	Rows  [][]string `json:"rows,omitempty"` // Table cells by row, then column
+}
+
+// DocStructureResponse is a doc's content as structured blocks in reading order
+type DocStructureResponse struct {
+	DocumentId string     `json:"documentId"`
+	Title      string     `json:"title"`
+	Blocks     []DocBlock `json:"blocks"`
+}
+
+// paragraphText joins a paragraph's text runs, dropping the trailing newline
+func paragraphText(p *docs.Paragraph) string {
+	var b strings.Builder
+	for _, el := range p.Elements {
+		if el.TextRun != nil {
+			b.WriteString(el.TextRun.Content)
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// plainText flattens content to text, one line per paragraph. Used for table
+// cells, where nested tables and lists can't keep their structure.
+func plainText(content []*docs.StructuralElement) string {
+	var lines []string
+	for _, el := range content {
+		switch {
+		case el.Paragraph != nil:
+			if text := paragraphText(el.Paragraph); text != "" {
+				lines = append(lines, text)
+			}
+		case el.Table != nil:
+			for _, row := range el.Table.TableRows {
+				for _, cell := range row.TableCells {
+					if text := plainText(cell.Content); text != "" {
+						lines = append(lines, text)
+					}
+				}
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// docBlocks converts body content to blocks, skipping empty paragraphs and
+// descending into tables of contents
+func docBlocks(content []*docs.StructuralElement) []DocBlock {
+	blocks := []DocBlock{}
+	for _, el := range content {
+		switch {
+		case el.Paragraph != nil:
+			text := paragraphText(el.Paragraph)
+			if strings.TrimSpace(text) == "" {
+				continue
+			}
+			block := DocBlock{Type: "paragraph", Text: text}
+			style := ""
+			if el.Paragraph.ParagraphStyle != nil {
+				style = el.Paragraph.ParagraphStyle.NamedStyleType
+			}
+			switch {
+			case style == "TITLE":
+				block.Type = "title"
+			case style == "SUBTITLE":
+				block.Type = "subtitle"
+			case strings.HasPrefix(style, "HEADING_"):
+				block.Type = "heading"
+				fmt.Sscanf(style, "HEADING_%d", &block.Level)
+			case el.Paragraph.Bullet != nil:
+				block.Type = "listItem"
+				block.Level = int(el.Paragraph.Bullet.NestingLevel)
+			}
+			blocks = append(blocks, block)
+
+		case el.Table != nil:
+			rows := make([][]string, len(el.Table.TableRows))
+			for i, row := range el.Table.TableRows {
+				rows[i] = make([]string, len(row.TableCells))
+				for j, cell := range row.TableCells {
+					rows[i][j] = plainText(cell.Content)
+				}
+			}
+			blocks = append(blocks, DocBlock{Type: "table", Rows: rows})
+
+		case el.TableOfContents != nil:
+			blocks = append(blocks, docBlocks(el.TableOfContents.Content)...)
+		}
+	}
+	return blocks
+}
+
+// GetDocStructure returns a tracker doc's headings, paragraphs, and tables as JSON
+func (s *Server) GetDocStructure(w http.ResponseWriter, r *http.Request) {
+	var req DocStructureRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.DocumentId == "" {
+		writeError(w, "documentId is required", http.StatusBadRequest)
+		return
+	}
+
+	driveSrv, err := s.driveService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Drive service: %v", err)
+		writeError(w, "Failed to connect to Google Drive", http.StatusInternalServerError)
+		return
+	}
+	if !s.requireInScope(w, r, driveSrv, "GetDocStructure", req.DocumentId) {
+		return
+	}
+
+	srv, err := s.docsService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Docs service: %v", err)
+		writeError(w, "Failed to connect to Google Docs", http.StatusInternalServerError)
+		return
+	}
+
+	doc, err := srv.Documents.Get(req.DocumentId).Context(r.Context()).Do()
+	if isCancelled(err) {
+		writeCancelled(w, "GetDocStructure")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to read document: %v", err)
+		writeError(w, fmt.Sprintf("Failed to read document: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	result := DocStructureResponse{DocumentId: doc.DocumentId, Title: doc.Title, Blocks: []DocBlock{}}
+	if doc.Body != nil {
+		result.Blocks = docBlocks(doc.Body.Content)
+	}
+
+	s.auditRead(r, AuditEvent{
+		Action:   "read_doc_structure",
+		Resource: req.DocumentId,
+		Detail:   fmt.Sprintf("read structure of %s (%d blocks)", req.DocumentId, len(result.Blocks)),
+	})
+
+	writeJSON(w, result)
+}
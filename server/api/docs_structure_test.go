@@ -0,0 +1,89 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+// docParagraph is a paragraph of text with a named style
+func docParagraph(text, style string) *docs.StructuralElement {
+	return &docs.StructuralElement{Paragraph: &docs.Paragraph{
+		Elements:       []*docs.ParagraphElement{{TextRun: &docs.TextRun{Content: text + "\n"}}},
+		ParagraphStyle: &docs.ParagraphStyle{NamedStyleType: style},
+	}}
+}
+
+// docCell is a table cell holding one paragraph per line
+func docCell(lines ...string) *docs.TableCell {
+	cell := &docs.TableCell{}
+	for _, line := range lines {
+		cell.Content = append(cell.Content, docParagraph(line, "NORMAL_TEXT"))
+	}
+	return cell
+}
+
+func TestGetDocStructure(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, testDocPath, &docs.Document{
+		DocumentId: testDocID,
+		Title:      "PYPI-2026 Tracker",
+		Body: &docs.Body{Content: []*docs.StructuralElement{
+			{SectionBreak: &docs.SectionBreak{}},
+			docParagraph("Milestones", "HEADING_2"),
+			docParagraph("", "NORMAL_TEXT"),
+			docParagraph("Due dates agreed with the funder.", "NORMAL_TEXT"),
+			{Table: &docs.Table{TableRows: []*docs.TableRow{
+				{TableCells: []*docs.TableCell{docCell("Milestone"), docCell("Due")}},
+				{TableCells: []*docs.TableCell{docCell("Interim report", "(draft)"), docCell("2026-06-30")}},
+			}}},
+		}},
+	})
+	s := newTestServer(t, f)
+
+	w := callHandler(t, s.GetDocStructure, "po@example.org", DocStructureRequest{DocumentId: testDocID})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var resp DocStructureResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	want := DocStructureResponse{
+		DocumentId: testDocID,
+		Title:      "PYPI-2026 Tracker",
+		Blocks: []DocBlock{
+			{Type: "heading", Level: 2, Text: "Milestones"},
+			{Type: "paragraph", Text: "Due dates agreed with the funder."},
+			{Type: "table", Rows: [][]string{{"Milestone", "Due"}, {"Interim report\n(draft)", "2026-06-30"}}},
+		},
+	}
+	if !reflect.DeepEqual(resp, want) {
+		t.Errorf("structure = %+v, want %+v", resp, want)
+	}
+}
+
+func TestDocBlocksListsAndNestedTables(t *testing.T) {
+	item := docParagraph("Submit receipts", "NORMAL_TEXT")
+	item.Paragraph.Bullet = &docs.Bullet{NestingLevel: 1}
+	nested := &docs.TableCell{Content: []*docs.StructuralElement{{Table: &docs.Table{TableRows: []*docs.TableRow{
+		{TableCells: []*docs.TableCell{docCell("a"), docCell("b")}},
+	}}}}}
+
+	got := docBlocks([]*docs.StructuralElement{
+		docParagraph("Report", "TITLE"),
+		item,
+		{Table: &docs.Table{TableRows: []*docs.TableRow{{TableCells: []*docs.TableCell{nested}}}}},
+	})
+	want := []DocBlock{
+		{Type: "title", Text: "Report"},
+		{Type: "listItem", Level: 1, Text: "Submit receipts"},
+		{Type: "table", Rows: [][]string{{"a\nb"}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("docBlocks = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+
+	"google.golang.org/api/googleapi"
+)
+
+// googleAppsMimePrefix marks Google-native files, which must be exported rather than downloaded
+const googleAppsMimePrefix = "application/vnd.google-apps."
+
+// previewable reports whether a type is safe to show inline: it can't run
+// script in our origin the way HTML or SVG could
+func previewable(mimeType string) bool {
+	switch {
+	case mimeType == "image/svg+xml":
+		return false
+	case strings.HasPrefix(mimeType, "image/"):
+		return true
+	}
+	return mimeType == "application/pdf" || mimeType == "text/plain"
+}
+
+// DownloadFile streams a Drive file's content: uploaded files as stored, and
+// Google Docs, Sheets, and Slides exported to the requested format
+func (s *Server) DownloadFile(w http.ResponseWriter, r *http.Request) {
+	var req DownloadFileRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.FileId == "" {
+		writeError(w, "fileId is required", http.StatusBadRequest)
+		return
+	}
+
+	srv, err := s.driveService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Drive service: %v", err)
+		writeError(w, "Failed to connect to Google Drive", http.StatusInternalServerError)
+		return
+	}
+
+	file, err := srv.Files.Get(req.FileId).
+		Fields("id, name, mimeType, size, driveId").
+		SupportsAllDrives(true).
+		Context(r.Context()).
+		Do()
+
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound && s.discoveredSharedDriveID() != "" {
+		err = &outOfScopeError{fileID: req.FileId}
+	} else if err == nil && !s.inScope(file.DriveId) {
+		err = &outOfScopeError{fileID: req.FileId}
+	}
+	var scopeErr *outOfScopeError
+	switch {
+	case errors.As(err, &scopeErr):
+		writeErrorCode(w, scopeErr.Error(), outOfScopeCode, http.StatusForbidden)
+		return
+	case isCancelled(err):
+		writeCancelled(w, "DownloadFile")
+		return
+	case errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound:
+		writeError(w, fmt.Sprintf("File %s not found", req.FileId), http.StatusNotFound)
+		return
+	case err != nil:
+		log.Printf("Failed to get file: %v", err)
+		writeError(w, fmt.Sprintf("Failed to get file: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	// A download can't hide columns, so the tracker spreadsheets are held to the
+	// same rule as ExportSpreadsheetXlsx
+	if s.trackerSpreadsheet(file.Id) && len(s.sensitiveColumns) > 0 && !s.canSeeSensitive(r) {
+		writeError(w, "insufficient permission to download sensitive columns", http.StatusForbidden)
+		return
+	}
+
+	native := strings.HasPrefix(file.MimeType, googleAppsMimePrefix)
+	if native && req.MimeType == "" {
+		writeError(w, fmt.Sprintf("%s is a Google file (%s); set mimeType to the format to export it as", file.Name, file.MimeType), http.StatusBadRequest)
+		return
+	}
+
+	var resp *http.Response
+	contentType := file.MimeType
+	var size int64
+	if native {
+		contentType = req.MimeType
+		resp, err = srv.Files.Export(file.Id, req.MimeType).Context(r.Context()).Download()
+	} else {
+		size = file.Size
+		resp, err = srv.Files.Get(file.Id).SupportsAllDrives(true).Context(r.Context()).Download()
+	}
+	switch {
+	case isCancelled(err):
+		writeCancelled(w, "DownloadFile")
+		return
+	case exportTooLarge(err):
+		writeErrorCode(w, fmt.Sprintf("%s is too large to export (Drive limits exports to 10MB)", file.Name),
+			exportTooLargeCode, http.StatusUnprocessableEntity)
+		return
+	case native && errors.As(err, &apiErr) && apiErr.Code == http.StatusBadRequest:
+		writeError(w, fmt.Sprintf("%s can't be exported as %s: %v", file.Name, req.MimeType, apiErr.Message), http.StatusBadRequest)
+		return
+	case err != nil:
+		log.Printf("Failed to download file %s: %v", file.Id, err)
+		writeError(w, fmt.Sprintf("Failed to download file: %v", err), http.StatusInternalServerError)
+		return
+	}
+	defer resp.Body.Close()
+
+	// Exports have no size in the metadata, so fall back to what Drive sends
+	if size <= 0 {
+		size = resp.ContentLength
+	}
+
+	disposition := "attachment"
+	if req.Inline && previewable(contentType) {
+		disposition = "inline"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": file.Name}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if size > 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(size))
+	}
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		// Headers are already sent, so all we can do is log it
+		log.Printf("Failed to stream file %s after %d bytes: %v", file.Id, n, err)
+		return
+	}
+
+	s.auditRead(r, AuditEvent{
+		Action:   "download_file",
+		Resource: file.Id,
+		Target:   file.Name,
+		Detail:   fmt.Sprintf("downloaded %s (%s) as %s (%d bytes)", file.Name, file.Id, contentType, n),
+	})
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/googleapi"
+)
+
+// maxPathDepth caps how many parent hops we follow when walking up the folder tree
+const maxPathDepth = 32
+
+// treePlace is where a walk up the folder tree from a file ended
+type treePlace struct {
+	path   []Breadcrumb // Ancestors outermost first, from top down; nil when outside
+	top    string       // The Grants or root folder reached; "" when outside both trees
+	grants bool         // top is the Grants folder rather than the root folder
+}
+
+// inTree reports whether the walk reached the Grants or root folder
+func (p treePlace) inTree() bool {
+	return p.top != ""
+}
+
+// locateInTree walks up from a file's parents to the Grants or root folder,
+// whichever it meets first. Every check that a file belongs to the instance
+// goes through here, so they all follow the same rules: a parent the service
+// account can't see, a cycle, or a chain deeper than maxPathDepth puts the
+// file outside, and the Grants folder only counts as reached when it is the
+// file's ancestor (placing the Grants folder itself needs no walk). Folders
+// come from the parent cache, so resolving many files fetches each shared
+// ancestor once.
+func (s *Server) locateInTree(ctx context.Context, srv *drive.Service, parents []string) (treePlace, error) {
+	grantsFolderID := s.discoveredGrantsFolderID()
+	var path []Breadcrumb
+	visited := make(map[string]bool)
+
+	for depth := 0; len(parents) > 0; depth++ {
+		// Stop walking as soon as the client goes away
+		if err := ctx.Err(); err != nil {
+			return treePlace{}, err
+		}
+		if depth >= maxPathDepth {
+			log.Printf("[API] locateInTree: depth cap (%d) reached", maxPathDepth)
+			return treePlace{}, nil
+		}
+
+		folderID := parents[0]
+		if visited[folderID] {
+			log.Printf("[API] locateInTree: cycle detected at %s", maskString(folderID))
+			return treePlace{}, nil
+		}
+		visited[folderID] = true
+
+		folder, err := s.treeFolder(ctx, srv, folderID)
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound {
+			return treePlace{}, nil
+		}
+		if err != nil {
+			return treePlace{}, fmt.Errorf("failed to get folder %s: %w", folderID, err)
+		}
+		path = append(path, Breadcrumb{Id: folder.Id, Name: folder.Name})
+
+		isGrants := grantsFolderID != "" && folderID == grantsFolderID
+		if isGrants || (s.rootFolderID != "" && folderID == s.rootFolderID) {
+			return treePlace{path: reversePath(path), top: folderID, grants: isGrants}, nil
+		}
+		parents = folder.Parents
+	}
+	return treePlace{}, nil
+}
+
+// fileInTree reports whether a file is the Grants or root folder or is placed
+// inside either tree by locateInTree. A file the service account can't see is
+// outside.
+func (s *Server) fileInTree(ctx context.Context, srv *drive.Service, fileID string) (bool, error) {
+	if fileID != "" && (fileID == s.discoveredGrantsFolderID() || fileID == s.rootFolderID) {
+		return true, nil
+	}
+	file, err := s.treeFolder(ctx, srv, fileID)
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	place, err := s.locateInTree(ctx, srv, file.Parents)
+	return place.inTree(), err
+}
+
+// reversePath flips a child-first path into outermost-first order
+func reversePath(path []Breadcrumb) []Breadcrumb {
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// parentCacheTTL is how long looked-up folders are reused. Moves made through
+// the API evict the file right away; ones made in Drive, and renames, can take
+// this long to be noticed.
+const parentCacheTTL = time.Minute
+
+// parentCacheEntry is a file's name and parents as of a lookup
+type parentCacheEntry struct {
+	file    *drive.File
+	expires time.Time
+}
+
+// treeFolder returns a file's id, name, and parents, from the cache when
+// looked up recently
+func (s *Server) treeFolder(ctx context.Context, srv *drive.Service, fileID string) (*drive.File, error) {
+	now := time.Now()
+	s.parentCacheMu.Lock()
+	entry, ok := s.parentCache[fileID]
+	s.parentCacheMu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.file, nil
+	}
+
+	file, err := srv.Files.Get(fileID).
+		Fields("id, name, parents").
+		SupportsAllDrives(true).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, err
+	}
+
+	s.parentCacheMu.Lock()
+	for id, e := range s.parentCache {
+		if !now.Before(e.expires) {
+			delete(s.parentCache, id)
+		}
+	}
+	s.parentCache[fileID] = parentCacheEntry{file: file, expires: now.Add(parentCacheTTL)}
+	s.parentCacheMu.Unlock()
+	return file, nil
+}
+
+// forgetParents drops a file's cached parents after it moves
+func (s *Server) forgetParents(fileID string) {
+	s.parentCacheMu.Lock()
+	delete(s.parentCache, fileID)
+	s.parentCacheMu.Unlock()
+}
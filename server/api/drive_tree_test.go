@@ -0,0 +1,78 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+)
+
+// replyFolder fakes a Drive file with a name and parents
+func (f *fakeGoogle) replyFolder(id, name string, parents ...string) {
+	f.reply(http.MethodGet, "/files/"+id, &drive.File{Id: id, Name: name, Parents: parents})
+}
+
+func TestGetFileIncludePath(t *testing.T) {
+	tests := []struct {
+		name   string
+		fileID string
+		want   []Breadcrumb
+	}{
+		{name: "file in a grant folder", fileID: "doc-1", want: []Breadcrumb{{Id: "grants", Name: "Grants"}, {Id: "grant-1", Name: "PYPI-2026"}}},
+		{name: "file directly in Grants", fileID: "doc-2", want: []Breadcrumb{{Id: "grants", Name: "Grants"}}},
+		{name: "file outside the tree", fileID: "doc-3", want: []Breadcrumb{}},
+		{name: "parents that form a cycle", fileID: "doc-4", want: []Breadcrumb{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeGoogle(t)
+			f.replyFolder("grants", "Grants", "root")
+			f.replyFolder("grant-1", "PYPI-2026", "grants")
+			f.replyFolder("elsewhere", "Elsewhere")
+			f.replyFolder("loop-a", "A", "loop-b")
+			f.replyFolder("loop-b", "B", "loop-a")
+			f.replyFolder("doc-1", "Proposal", "grant-1")
+			f.replyFolder("doc-2", "Index", "grants")
+			f.replyFolder("doc-3", "Notes", "elsewhere")
+			f.replyFolder("doc-4", "Stray", "loop-a")
+			s := newTestServer(t, f)
+			s.grantsFolderID = "grants"
+
+			includePath := true
+			w := callHandler(t, s.GetFile, "po@example.org", GetFileRequest{FileId: tt.fileID, IncludePath: &includePath})
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", w.Code, w.Body)
+			}
+			var fi FileInfo
+			if err := json.Unmarshal(w.Body.Bytes(), &fi); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if fi.Path == nil || !reflect.DeepEqual(*fi.Path, tt.want) {
+				t.Errorf("path = %v, want %v", fi.Path, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetFileWithoutPath(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.replyFolder("doc-1", "Proposal", "grant-1")
+	s := newTestServer(t, f)
+
+	w := callHandler(t, s.GetFile, "po@example.org", GetFileRequest{FileId: "doc-1"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var fi FileInfo
+	if err := json.Unmarshal(w.Body.Bytes(), &fi); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if fi.Path != nil {
+		t.Errorf("path = %v without includePath", *fi.Path)
+	}
+	if calls := f.calls(http.MethodGet, "/files/grant-1"); len(calls) != 0 {
+		t.Errorf("walked up to the parent %d times", len(calls))
+	}
+}
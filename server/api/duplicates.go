@@ -0,0 +1,108 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// FindDuplicates reports rows that share a key column value, such as grant
+// rows imported twice, so admins can see which sheet rows to clean up
+func (s *Server) FindDuplicates(w http.ResponseWriter, r *http.Request) {
+	var req FindDuplicatesRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.Sheet == "" || req.KeyColumn == "" {
+		writeError(w, "Sheet and keyColumn are required", http.StatusBadRequest)
+		return
+	}
+	if refuseInternalSheet(w, req.Sheet) {
+		return
+	}
+
+	spreadsheetID, err := s.spreadsheetFor(r)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	srv, err := s.sheetsService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Sheets service: %v", err)
+		writeError(w, "Failed to connect to Google Sheets", http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := withRetry(r.Context(), s.retryAttempts, true, func() (*sheets.ValueRange, error) {
+		return srv.Spreadsheets.Values.Get(spreadsheetID, req.Sheet).
+			ValueRenderOption("UNFORMATTED_VALUE").Context(r.Context()).Do()
+	})
+	if isCancelled(err) {
+		writeCancelled(w, "FindDuplicates")
+		return
+	}
+	if err != nil {
+		if sheetMissing(r.Context(), srv, spreadsheetID, req.Sheet, err) {
+			writeError(w, fmt.Sprintf("Sheet %s not found", req.Sheet), http.StatusNotFound)
+			return
+		}
+		log.Printf("Failed to read sheet: %v", err)
+		writeError(w, "Failed to read sheet", http.StatusInternalServerError)
+		return
+	}
+	table := splitTable(resp.Values, s.headerRow(req.Sheet))
+
+	keyIdx, status, err := s.readableColumns(r, table, []string{req.KeyColumn})
+	if err != nil {
+		writeError(w, err.Error(), status)
+		return
+	}
+
+	result := FindDuplicatesResponse{Groups: duplicateGroups(table, keyIdx[0])}
+	for _, group := range result.Groups {
+		result.DuplicateRows += len(group.Rows)
+	}
+
+	s.auditRead(r, AuditEvent{
+		Action:   "find_duplicates",
+		Resource: req.Sheet,
+		Detail:   fmt.Sprintf("found %d duplicate keys in %s across %d rows", len(result.Groups), req.Sheet, result.DuplicateRows),
+	})
+
+	writeJSON(w, result)
+}
+
+// duplicateGroups groups the table's rows by the keyIdx column and returns the
+// keys held by more than one row, ordered by their first occurrence. Keys are
+// compared after trimming whitespace; rows with an empty key are skipped.
+func duplicateGroups(table sheetTable, keyIdx int) []DuplicateGroup {
+	var order []string
+	rowsByKey := make(map[string][]int)
+	for i, row := range table.rows {
+		if keyIdx >= len(row) {
+			continue
+		}
+		key := strings.TrimSpace(cellString(row[keyIdx]))
+		if key == "" {
+			continue
+		}
+		if _, seen := rowsByKey[key]; !seen {
+			order = append(order, key)
+		}
+		rowsByKey[key] = append(rowsByKey[key], table.sheetRow(i))
+	}
+
+	groups := []DuplicateGroup{}
+	for _, key := range order {
+		if rows := rowsByKey[key]; len(rows) > 1 {
+			groups = append(groups, DuplicateGroup{Key: key, Rows: rows})
+		}
+	}
+	return groups
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestDuplicateGroups(t *testing.T) {
+	tests := []struct {
+		name      string
+		headerRow int
+		rows      [][]interface{}
+		want      []DuplicateGroup
+	}{
+		{
+			name:      "key repeated across two rows",
+			headerRow: 1,
+			rows:      [][]interface{}{{"G-1"}, {"G-2"}, {"G-1"}},
+			want:      []DuplicateGroup{{Key: "G-1", Rows: []int{2, 4}}},
+		},
+		{
+			name:      "groups ordered by first occurrence",
+			headerRow: 1,
+			rows:      [][]interface{}{{"B"}, {"A"}, {"A"}, {"B"}, {"B"}},
+			want:      []DuplicateGroup{{Key: "B", Rows: []int{2, 5, 6}}, {Key: "A", Rows: []int{3, 4}}},
+		},
+		{
+			name:      "whitespace and numbers",
+			headerRow: 1,
+			rows:      [][]interface{}{{" 42 "}, {float64(42)}},
+			want:      []DuplicateGroup{{Key: "42", Rows: []int{2, 3}}},
+		},
+		{
+			name:      "empty and missing keys are skipped",
+			headerRow: 1,
+			rows:      [][]interface{}{{""}, {}, {""}},
+			want:      []DuplicateGroup{},
+		},
+		{
+			name:      "rows numbered below a banner",
+			headerRow: 3,
+			rows:      [][]interface{}{{"G-1"}, {"G-1"}},
+			want:      []DuplicateGroup{{Key: "G-1", Rows: []int{4, 5}}},
+		},
+		{
+			name:      "no duplicates",
+			headerRow: 1,
+			rows:      [][]interface{}{{"G-1"}, {"G-2"}},
+			want:      []DuplicateGroup{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := sheetTable{headerRow: tt.headerRow, headers: []interface{}{"ID"}, rows: tt.rows}
+			if got := duplicateGroups(table, 0); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: [][]interface{}{
+		{"ID", "Title"},
+		{"G-1", "Original"},
+		{"G-2", "Other"},
+		{"G-1", "Imported again"},
+	}})
+	s := newTestServer(t, f)
+
+	w := callHandler(t, s.FindDuplicates, "admin@example.org", FindDuplicatesRequest{Sheet: "Grants", KeyColumn: "ID"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var resp FindDuplicatesResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	want := FindDuplicatesResponse{DuplicateRows: 2, Groups: []DuplicateGroup{{Key: "G-1", Rows: []int{2, 4}}}}
+	if !reflect.DeepEqual(resp, want) {
+		t.Errorf("got %+v, want %+v", resp, want)
+	}
+}
+
+func TestFindDuplicatesUnknownColumn(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: [][]interface{}{{"ID"}, {"G-1"}}})
+	s := newTestServer(t, f)
+
+	w := callHandler(t, s.FindDuplicates, "admin@example.org", FindDuplicatesRequest{Sheet: "Grants", KeyColumn: "Key"})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400: %s", w.Code, w.Body)
+	}
+}
+
+func TestFindDuplicatesRetriesRead(t *testing.T) {
+	f := newFakeGoogle(t)
+	path := "/v4/spreadsheets/" + testSpreadsheetID + "/values/Grants"
+	f.replyAfterOutage(http.MethodGet, path, &sheets.ValueRange{Values: [][]interface{}{{"ID"}, {"G-1"}, {"G-1"}}})
+	s := newTestServer(t, f)
+
+	w := callHandler(t, s.FindDuplicates, "admin@example.org", FindDuplicatesRequest{Sheet: "Grants", KeyColumn: "ID"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	if got := len(f.calls(http.MethodGet, path)); got != 2 {
+		t.Errorf("%d reads, want the failed one retried once", got)
+	}
+}
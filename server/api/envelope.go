@@ -0,0 +1,152 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// envelopeMediaType is the Accept value that opts a request into the envelope
+const envelopeMediaType = "application/vnd.grant-tracker.envelope+json"
+
+// responseEnvelope is the uniform response shape: exactly one of data and error is set
+type responseEnvelope struct {
+	Data  json.RawMessage `json:"data"`
+	Meta  envelopeMeta    `json:"meta"`
+	Error envelopeError   `json:"error"`
+}
+
+type envelopeMeta struct {
+	Status int `json:"status"`
+}
+
+// envelopeError is the handler's error object with its "error" text moved to
+// "message", so code, fields, limit, and conflict details still reach the client
+type envelopeError map[string]json.RawMessage
+
+// newEnvelopeError rewraps an error body, falling back to the status text
+// when the handler didn't write a JSON object with a message
+func newEnvelopeError(body []byte, status int) envelopeError {
+	e := envelopeError{}
+	if err := json.Unmarshal(body, &e); err != nil || e == nil {
+		e = envelopeError{}
+	}
+	var message string
+	if err := json.Unmarshal(e["error"], &message); err != nil || message == "" {
+		message = http.StatusText(status)
+	}
+	delete(e, "error")
+	e["message"], _ = json.Marshal(message)
+	return e
+}
+
+// wantsEnvelope reports whether a response should be wrapped, either because
+// the server enables it globally or the client asked via Accept or X-Response-Envelope
+func (s *Server) wantsEnvelope(r *http.Request) bool {
+	if s.responseEnvelope || r.Header.Get("X-Response-Envelope") == "true" {
+		return true
+	}
+	return strings.Contains(r.Header.Get("Accept"), envelopeMediaType)
+}
+
+// bufferedResponse holds a handler's status and body so they can be rewrapped.
+// Headers go straight to the underlying writer so cookies still get through.
+// Responses that aren't JSON (downloads, exports) or that the handler flushes
+// are passed straight through as they are written instead.
+type bufferedResponse struct {
+	http.ResponseWriter
+	status      int
+	body        bytes.Buffer
+	passthrough bool
+}
+
+// start records the status on the handler's first write and decides whether
+// the response can be wrapped
+func (b *bufferedResponse) start(status int) {
+	if b.status != 0 {
+		return
+	}
+	b.status = status
+	if !strings.HasPrefix(b.Header().Get("Content-Type"), "application/json") {
+		b.passthrough = true
+		b.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (b *bufferedResponse) WriteHeader(status int) {
+	b.start(status)
+}
+
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	b.start(http.StatusOK)
+	if b.passthrough {
+		return b.ResponseWriter.Write(p)
+	}
+	return b.body.Write(p)
+}
+
+// Flush switches to passing the response through unwrapped, since an envelope
+// can only be written once the whole body is known
+func (b *bufferedResponse) Flush() {
+	flusher, ok := b.ResponseWriter.(http.Flusher)
+	if !ok {
+		return
+	}
+	b.start(http.StatusOK)
+	if !b.passthrough {
+		b.passthrough = true
+		b.ResponseWriter.WriteHeader(b.status)
+		b.ResponseWriter.Write(b.body.Bytes())
+		b.body.Reset()
+	}
+	flusher.Flush()
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (b *bufferedResponse) Unwrap() http.ResponseWriter {
+	return b.ResponseWriter
+}
+
+// Envelope wraps JSON API responses in {data, meta, error} for clients that opt
+// in. Everything else, including non-JSON and streamed responses, passes
+// through unchanged.
+func (s *Server) Envelope(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/api/") || !s.wantsEnvelope(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		buf := &bufferedResponse{ResponseWriter: w}
+		next.ServeHTTP(buf, r)
+		if buf.passthrough {
+			return
+		}
+		if buf.status == 0 {
+			buf.status = http.StatusOK
+		}
+
+		if !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+			w.WriteHeader(buf.status)
+			w.Write(buf.body.Bytes())
+			return
+		}
+
+		env := responseEnvelope{Meta: envelopeMeta{Status: buf.status}}
+		if buf.status >= 400 {
+			env.Data = json.RawMessage("null")
+			env.Error = newEnvelopeError(buf.body.Bytes(), buf.status)
+		} else {
+			env.Data = json.RawMessage(bytes.TrimSpace(buf.body.Bytes()))
+			if len(env.Data) == 0 {
+				env.Data = json.RawMessage("null")
+			}
+		}
+
+		w.Header().Del("Content-Length")
+		w.Header().Set("X-Response-Envelope", "true")
+		w.WriteHeader(buf.status)
+		json.NewEncoder(w).Encode(env)
+	})
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+var envelopeGrants = [][]interface{}{
+	{"ID", "Title"},
+	{"G-1", "First"},
+}
+
+// serveEnveloped sends body through Envelope to handler as po@example.org with the given request headers
+func serveEnveloped(t *testing.T, s *Server, handler http.HandlerFunc, header http.Header, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	header = header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("X-User-Email", "po@example.org")
+	return callHandlerWith(t, s.Envelope(handler).ServeHTTP, header, body)
+}
+
+func TestReadSheetLegacyShape(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: envelopeGrants})
+	s := newTestServer(t, f)
+
+	w := serveEnveloped(t, s, s.ReadSheet, nil, ReadSheetRequest{Sheet: "Grants"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	if w.Header().Get("X-Response-Envelope") != "" {
+		t.Error("legacy response marked as enveloped")
+	}
+	var resp ReadSheetResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if want := []string{"ID", "Title"}; !reflect.DeepEqual(resp.Headers, want) || len(resp.Rows) != 1 {
+		t.Errorf("got headers %v and %d rows, want %v and 1 row", resp.Headers, len(resp.Rows), want)
+	}
+}
+
+func TestReadSheetEnveloped(t *testing.T) {
+	tests := []struct {
+		name   string
+		global bool
+		header http.Header
+	}{
+		{name: "X-Response-Envelope header", header: http.Header{"X-Response-Envelope": {"true"}}},
+		{name: "Accept media type", header: http.Header{"Accept": {envelopeMediaType}}},
+		{name: "RESPONSE_ENVELOPE", global: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeGoogle(t)
+			f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: envelopeGrants})
+			s := newTestServer(t, f)
+			s.responseEnvelope = tt.global
+
+			w := serveEnveloped(t, s, s.ReadSheet, tt.header, ReadSheetRequest{Sheet: "Grants"})
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", w.Code, w.Body)
+			}
+			if w.Header().Get("X-Response-Envelope") != "true" {
+				t.Error("enveloped response missing X-Response-Envelope")
+			}
+			var env struct {
+				Data  *ReadSheetResponse `json:"data"`
+				Meta  envelopeMeta       `json:"meta"`
+				Error *json.RawMessage   `json:"error"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &env); err != nil {
+				t.Fatalf("decode envelope: %v", err)
+			}
+			if env.Meta.Status != http.StatusOK || env.Error != nil || env.Data == nil {
+				t.Fatalf("got %s, want data with status 200 and no error", w.Body)
+			}
+			if want := []string{"ID", "Title"}; !reflect.DeepEqual(env.Data.Headers, want) || len(env.Data.Rows) != 1 {
+				t.Errorf("got headers %v and %d rows, want %v and 1 row", env.Data.Headers, len(env.Data.Rows), want)
+			}
+		})
+	}
+}
+
+func TestEnvelopeKeepsErrorDetails(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		status  int
+		want    map[string]interface{}
+	}{
+		{
+			name: "validation error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				writeValidationError(w, []FieldError{{Field: "Amount", Message: "must be a number"}})
+			},
+			status: http.StatusBadRequest,
+			want: map[string]interface{}{
+				"message": "1 field(s) failed validation",
+				"code":    validationFailedCode,
+				"fields":  []interface{}{map[string]interface{}{"field": "Amount", "message": "must be a number"}},
+			},
+		},
+		{
+			name: "batch too large",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				writeBatchTooLarge(w, 12, 10)
+			},
+			status: http.StatusBadRequest,
+			want: map[string]interface{}{
+				"message": "Batch of 12 updates exceeds the limit of 10; split it into batches of at most 10",
+				"code":    batchTooLargeCode,
+				"limit":   float64(10),
+			},
+		},
+		{
+			name: "conflict",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				writeConflict(w, []string{"Status"}, map[string]interface{}{"Status": "Closed"})
+			},
+			status: http.StatusConflict,
+			want: map[string]interface{}{
+				"message": "Row changed since it was read: Status",
+				"code":    conflictCode,
+				"columns": []interface{}{"Status"},
+				"current": map[string]interface{}{"Status": "Closed"},
+			},
+		},
+		{
+			name: "scope error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				writeErrorCode(w, "File is not in the Grant Tracker Shared Drive", outOfScopeCode, http.StatusForbidden)
+			},
+			status: http.StatusForbidden,
+			want: map[string]interface{}{
+				"message": "File is not in the Grant Tracker Shared Drive",
+				"code":    outOfScopeCode,
+			},
+		},
+		{
+			name: "body that isn't JSON",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadGateway)
+			},
+			status: http.StatusBadGateway,
+			want:   map[string]interface{}{"message": "Bad Gateway"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t, nil)
+			w := serveEnveloped(t, s, tt.handler, http.Header{"X-Response-Envelope": {"true"}}, struct{}{})
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d", w.Code, tt.status)
+			}
+			var env struct {
+				Data  json.RawMessage        `json:"data"`
+				Meta  envelopeMeta           `json:"meta"`
+				Error map[string]interface{} `json:"error"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &env); err != nil {
+				t.Fatalf("decode envelope: %v", err)
+			}
+			if string(env.Data) != "null" || env.Meta.Status != tt.status {
+				t.Errorf("data = %s, meta.status = %d, want null and %d", env.Data, env.Meta.Status, tt.status)
+			}
+			if !reflect.DeepEqual(env.Error, tt.want) {
+				t.Errorf("error = %v, want %v", env.Error, tt.want)
+			}
+		})
+	}
+}
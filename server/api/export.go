@@ -0,0 +1,239 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/sheets/v4"
+)
+
+// grantIDProperty is the Drive appProperties key that tags a folder with its grant
+const grantIDProperty = "grantId"
+
+const folderMimeType = "application/vnd.google-apps.folder"
+
+// driveQuoted escapes a value for use inside a single-quoted Drive query string
+func driveQuoted(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(v)
+}
+
+// findGrantFolder locates a grant's folder under the Grants folder, preferring
+// one tagged with the grant ID in appProperties and falling back to the folder
+// named after the grant. Returns nil if neither exists.
+func (s *Server) findGrantFolder(ctx context.Context, srv *drive.Service, grantID string) (*drive.File, error) {
+	base := fmt.Sprintf("'%s' in parents and mimeType = '%s' and trashed = false", s.discoveredGrantsFolderID(), folderMimeType)
+	queries := []string{
+		fmt.Sprintf("%s and appProperties has { key='%s' and value='%s' }", base, grantIDProperty, driveQuoted(grantID)),
+		fmt.Sprintf("%s and name = '%s'", base, driveQuoted(grantID)),
+	}
+
+	for _, q := range queries {
+		call := srv.Files.List().
+			Q(q).
+			Fields("files(id, name, mimeType, modifiedTime, webViewLink, appProperties)").
+			PageSize(1).
+			SupportsAllDrives(true).
+			IncludeItemsFromAllDrives(true).
+			Context(ctx)
+		resp, err := withRetry(ctx, s.retryAttempts, true, func() (*drive.FileList, error) {
+			return call.Do()
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Files) > 0 {
+			return resp.Files[0], nil
+		}
+	}
+	return nil, nil
+}
+
+// folderManifest lists every file under a folder, descending into subfolders up
+// to maxPathDepth. Each entry's Path holds the subfolders between root and the file.
+func (s *Server) folderManifest(ctx context.Context, srv *drive.Service, rootID string) ([]FileInfo, error) {
+	type pending struct {
+		id   string
+		path []Breadcrumb
+	}
+
+	files := []FileInfo{}
+	queue := []pending{{id: rootID}}
+	for len(queue) > 0 {
+		folder := queue[0]
+		queue = queue[1:]
+
+		pageToken := ""
+		for {
+			// Stop between Drive calls as soon as the client goes away
+			if err := ctx.Err(); err != nil {
+				return files, err
+			}
+
+			call := srv.Files.List().
+				Q(fmt.Sprintf("'%s' in parents and trashed = false", folder.id)).
+				Fields("nextPageToken, files(id, name, mimeType, modifiedTime, webViewLink, shortcutDetails, appProperties)").
+				OrderBy("name").
+				PageSize(maxListPageSize).
+				SupportsAllDrives(true).
+				IncludeItemsFromAllDrives(true).
+				Context(ctx)
+			if pageToken != "" {
+				call = call.PageToken(pageToken)
+			}
+			resp, err := withRetry(ctx, s.retryAttempts, true, func() (*drive.FileList, error) {
+				return call.Do()
+			})
+			if err != nil {
+				return files, err
+			}
+
+			for _, f := range resp.Files {
+				fi := fileInfoFromDrive(f)
+				if len(folder.path) > 0 {
+					path := folder.path
+					fi.Path = &path
+				}
+				files = append(files, fi)
+
+				if f.MimeType == folderMimeType && len(folder.path) < maxPathDepth {
+					childPath := append(append([]Breadcrumb{}, folder.path...), Breadcrumb{Id: f.Id, Name: f.Name})
+					queue = append(queue, pending{id: f.Id, path: childPath})
+				}
+			}
+
+			if resp.NextPageToken == "" {
+				break
+			}
+			pageToken = resp.NextPageToken
+		}
+	}
+	return files, nil
+}
+
+// ExportGrant returns a grant's row plus a manifest of its Drive folder
+func (s *Server) ExportGrant(w http.ResponseWriter, r *http.Request) {
+	var req ExportGrantRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.IdColumn == "" || req.Id == "" {
+		writeError(w, "idColumn and id are required", http.StatusBadRequest)
+		return
+	}
+
+	sheet := "Grants"
+	if req.Sheet != nil && *req.Sheet != "" {
+		sheet = *req.Sheet
+	}
+	if refuseInternalSheet(w, sheet) {
+		return
+	}
+
+	spreadsheetID, err := s.spreadsheetFor(r)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	sheetsSrv, err := s.sheetsService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Sheets service: %v", err)
+		writeError(w, "Failed to connect to Google Sheets", http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := withRetry(r.Context(), s.retryAttempts, true, func() (*sheets.ValueRange, error) {
+		return sheetsSrv.Spreadsheets.Values.Get(spreadsheetID, sheet).
+			ValueRenderOption("UNFORMATTED_VALUE").Context(r.Context()).Do()
+	})
+	if isCancelled(err) {
+		writeCancelled(w, "ExportGrant")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to read sheet: %v", err)
+		writeError(w, "Failed to read sheet", http.StatusInternalServerError)
+		return
+	}
+
+	table := splitTable(resp.Values, s.headerRow(sheet))
+	idColIdx := table.indexOf(req.IdColumn)
+	if idColIdx == -1 {
+		writeError(w, fmt.Sprintf("Column %s not found", req.IdColumn), http.StatusBadRequest)
+		return
+	}
+	rowIdx := table.findRow(idColIdx, req.Id)
+	if rowIdx == -1 {
+		writeError(w, fmt.Sprintf("Row with %s=%s not found", req.IdColumn, req.Id), http.StatusNotFound)
+		return
+	}
+
+	// Apply the same ID formatting and redaction as ReadSheet
+	var headers []string
+	for _, h := range table.headers {
+		headers = append(headers, cellString(h))
+	}
+	rows := [][]interface{}{table.rows[rowIdx]}
+	s.stringifyIDColumns(headers, rows)
+	headers, rows = s.redactColumns(r, headers, rows)
+
+	row := make(map[string]interface{}, len(headers))
+	for i, h := range headers {
+		if i < len(rows[0]) {
+			row[h] = rows[0][i]
+		} else {
+			row[h] = ""
+		}
+	}
+
+	result := ExportGrantResponse{Row: row, Files: []FileInfo{}}
+
+	driveSrv, err := s.driveService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Drive service: %v", err)
+		writeError(w, "Failed to connect to Google Drive", http.StatusInternalServerError)
+		return
+	}
+
+	folder, err := s.findGrantFolder(r.Context(), driveSrv, req.Id)
+	if isCancelled(err) {
+		writeCancelled(w, "ExportGrant")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to find grant folder: %v", err)
+		writeError(w, fmt.Sprintf("Failed to find grant folder: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if folder != nil {
+		fi := fileInfoFromDrive(folder)
+		result.Folder = &fi
+
+		result.Files, err = s.folderManifest(r.Context(), driveSrv, folder.Id)
+		if isCancelled(err) {
+			writeCancelled(w, "ExportGrant")
+			return
+		}
+		if err != nil {
+			log.Printf("Failed to list grant folder: %v", err)
+			writeError(w, fmt.Sprintf("Failed to list grant folder: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	s.auditRead(r, AuditEvent{
+		Action:   "export_grant",
+		Resource: sheet,
+		Target:   req.Id,
+		Detail:   fmt.Sprintf("exported %s (%d files)", req.Id, len(result.Files)),
+	})
+
+	writeJSON(w, result)
+}
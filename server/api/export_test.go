@@ -0,0 +1,95 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/sheets/v4"
+)
+
+// serveGrantFolder fakes Drive listings for a grant folder named G-1 that holds
+// a proposal and a Reports subfolder with one report in it
+func serveGrantFolder(f *fakeGoogle) {
+	f.handle(http.MethodGet, "/files", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		switch {
+		case strings.Contains(q, "appProperties"):
+			// Not tagged, so the lookup falls back to the folder name
+			writeFakeJSON(w, &drive.FileList{})
+		case strings.Contains(q, "name = 'G-1'"):
+			writeFakeJSON(w, &drive.FileList{Files: []*drive.File{{Id: "folder-g1", Name: "G-1", MimeType: folderMimeType}}})
+		case strings.HasPrefix(q, "'folder-g1' in parents"):
+			writeFakeJSON(w, &drive.FileList{Files: []*drive.File{
+				{Id: "doc-1", Name: "Proposal", WebViewLink: "https://docs.example/doc-1"},
+				{Id: "reports", Name: "Reports", MimeType: folderMimeType},
+			}})
+		case strings.HasPrefix(q, "'reports' in parents"):
+			writeFakeJSON(w, &drive.FileList{Files: []*drive.File{{Id: "doc-2", Name: "Q1 report"}}})
+		default:
+			writeFakeJSON(w, &drive.FileList{})
+		}
+	})
+}
+
+func TestExportGrant(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: [][]interface{}{
+		{"ID", "Title", "Budget"},
+		{"G-1", "Packaging", float64(5000)},
+		{"G-2", "Docs", float64(1000)},
+	}})
+	serveGrantFolder(f)
+	s := newTestServer(t, f)
+	s.grantsFolderID = "grants"
+
+	w := callHandler(t, s.ExportGrant, "po@example.org", ExportGrantRequest{IdColumn: "ID", Id: "G-1"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var resp ExportGrantResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+
+	wantRow := map[string]interface{}{"ID": "G-1", "Title": "Packaging", "Budget": float64(5000)}
+	if !reflect.DeepEqual(resp.Row, wantRow) {
+		t.Errorf("row = %v, want %v", resp.Row, wantRow)
+	}
+	if resp.Folder == nil || resp.Folder.Id != "folder-g1" {
+		t.Errorf("folder = %+v, want folder-g1", resp.Folder)
+	}
+	var names []string
+	for _, fi := range resp.Files {
+		names = append(names, fi.Name)
+	}
+	if want := []string{"Proposal", "Reports", "Q1 report"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("files = %v, want %v", names, want)
+	}
+	if resp.Files[0].WebViewLink == nil || *resp.Files[0].WebViewLink != "https://docs.example/doc-1" {
+		t.Errorf("proposal link = %v", resp.Files[0].WebViewLink)
+	}
+	if path := resp.Files[2].Path; path == nil || !reflect.DeepEqual(*path, []Breadcrumb{{Id: "reports", Name: "Reports"}}) {
+		t.Errorf("report path = %v, want it under Reports", path)
+	}
+}
+
+func TestExportGrantNotFound(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: [][]interface{}{
+		{"ID", "Title"},
+		{"G-1", "Packaging"},
+	}})
+	s := newTestServer(t, f)
+
+	w := callHandler(t, s.ExportGrant, "po@example.org", ExportGrantRequest{IdColumn: "ID", Id: "G-9"})
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404: %s", w.Code, w.Body)
+	}
+	if calls := f.calls(http.MethodGet, "/files"); len(calls) != 0 {
+		t.Errorf("listed Drive %d times for a missing grant", len(calls))
+	}
+}
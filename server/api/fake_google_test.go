@@ -0,0 +1,195 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
+)
+
+// testSpreadsheetID is the spreadsheet newTestServer has already discovered
+const testSpreadsheetID = "sheet-1"
+
+// fakeRequest is one call a Google API client made to fakeGoogle
+type fakeRequest struct {
+	method string
+	path   string
+	header http.Header
+	body   []byte
+}
+
+// fakeGoogle stands in for the Sheets, Drive, and Docs APIs. Handlers are
+// registered by "METHOD /path"; a request nothing handles fails the test.
+type fakeGoogle struct {
+	t        *testing.T
+	server   *httptest.Server
+	mu       sync.Mutex
+	handlers map[string]http.HandlerFunc
+	requests []fakeRequest
+}
+
+func newFakeGoogle(t *testing.T) *fakeGoogle {
+	t.Helper()
+	f := &fakeGoogle{t: t, handlers: make(map[string]http.HandlerFunc)}
+	f.server = httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeGoogle) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	key := r.Method + " " + r.URL.Path
+	f.mu.Lock()
+	f.requests = append(f.requests, fakeRequest{method: r.Method, path: r.URL.Path, header: r.Header.Clone(), body: body})
+	handler := f.handlers[key]
+	f.mu.Unlock()
+
+	if handler == nil {
+		f.t.Errorf("unexpected Google API call: %s", key)
+		http.Error(w, "not faked", http.StatusNotImplemented)
+		return
+	}
+	handler(w, r)
+}
+
+// handle registers fn for one method and path
+func (f *fakeGoogle) handle(method, path string, fn http.HandlerFunc) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.handlers[method+" "+path] = fn
+}
+
+// reply registers a canned JSON response for one method and path
+func (f *fakeGoogle) reply(method, path string, v interface{}) {
+	f.handle(method, path, func(w http.ResponseWriter, r *http.Request) {
+		writeFakeJSON(w, v)
+	})
+}
+
+// sent returns the bodies of every call made to one method and path
+func (f *fakeGoogle) sent(method, path string) [][]byte {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var bodies [][]byte
+	for _, req := range f.requests {
+		if req.method == method && req.path == path {
+			bodies = append(bodies, req.body)
+		}
+	}
+	return bodies
+}
+
+// calls returns every call made to one method and path
+func (f *fakeGoogle) calls(method, path string) []fakeRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var calls []fakeRequest
+	for _, req := range f.requests {
+		if req.method == method && req.path == path {
+			calls = append(calls, req)
+		}
+	}
+	return calls
+}
+
+// options points a Google API client at the fake, without authentication
+func (f *fakeGoogle) options() []option.ClientOption {
+	return []option.ClientOption{
+		option.WithEndpoint(f.server.URL + "/"),
+		option.WithHTTPClient(f.server.Client()),
+	}
+}
+
+func writeFakeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// replySheet fakes a spreadsheet holding one tab with the given values
+func (f *fakeGoogle) replySheet(title string, sheetID int64, values [][]interface{}) {
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID, &sheets.Spreadsheet{
+		Sheets: []*sheets.Sheet{{Properties: &sheets.SheetProperties{SheetId: sheetID, Title: title}}},
+	})
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/"+title, &sheets.ValueRange{
+		Range:  title,
+		Values: values,
+	})
+}
+
+// newTestServer builds a Server from an empty environment with its Google API
+// clients pointed at f and the spreadsheet already discovered
+func newTestServer(t *testing.T, f *fakeGoogle) *Server {
+	t.Helper()
+	for _, name := range []string{"ROOT_FOLDER_ID", "GOOGLE_SERVICE_ACCOUNT_KEY", "GOOGLE_APPLICATION_CREDENTIALS", "AUDIT_SINK"} {
+		t.Setenv(name, "")
+	}
+
+	s, err := NewServer("test-client")
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	s.spreadsheetID = testSpreadsheetID
+	if f == nil {
+		return s
+	}
+
+	ctx := context.Background()
+	if s.sheetsClient, err = sheets.NewService(ctx, f.options()...); err != nil {
+		t.Fatalf("sheets client: %v", err)
+	}
+	if s.driveClient, err = drive.NewService(ctx, f.options()...); err != nil {
+		t.Fatalf("drive client: %v", err)
+	}
+	if s.docsClient, err = docs.NewService(ctx, f.options()...); err != nil {
+		t.Fatalf("docs client: %v", err)
+	}
+	return s
+}
+
+// callHandler sends body as JSON to a handler as user and returns the recorded response
+func callHandler(t *testing.T, handler http.HandlerFunc, user string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	return callHandlerAs(t, handler, user, "", body)
+}
+
+// callHandlerAs is callHandler for a user holding the given Drive role
+func callHandlerAs(t *testing.T, handler http.HandlerFunc, user, role string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	header := http.Header{}
+	if user != "" {
+		header.Set("X-User-Email", user)
+	}
+	if role != "" {
+		header.Set("X-User-Role", role)
+	}
+	return callHandlerWith(t, handler, header, body)
+}
+
+// callHandlerWith sends body as JSON to a handler with the given request headers
+func callHandlerWith(t *testing.T, handler http.HandlerFunc, header http.Header, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+	r := httptest.NewRequest(http.MethodPost, "/api/test", strings.NewReader(string(data)))
+	r.Header.Set("Content-Type", "application/json")
+	for name, values := range header {
+		r.Header[name] = values
+	}
+	w := httptest.NewRecorder()
+	handler(w, r)
+	return w
+}
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// fieldSchemaKey is the Config tab key holding per-sheet field rules as JSON,
+// in the same shape as the FIELD_SCHEMA environment variable
+const fieldSchemaKey = "field_schema"
+
+// validationFailedCode marks a write rejected by the field schema
+const validationFailedCode = "VALIDATION_FAILED"
+
+// Field types a schema rule can require
+const (
+	fieldString = "string"
+	fieldNumber = "number"
+	fieldDate   = "date"
+	fieldEnum   = "enum"
+)
+
+// fieldRule constrains one column's values
+type fieldRule struct {
+	Type     string   `json:"type"`
+	Values   []string `json:"values,omitempty"` // Allowed values for enum
+	Required bool     `json:"required,omitempty"`
+}
+
+// fieldSchemas maps sheet name to column name to rule, e.g.
+// {"Grants": {"Status": {"type": "enum", "values": ["Draft", "Active"]}}}
+type fieldSchemas map[string]map[string]fieldRule
+
+// parseFieldSchemas parses and checks a field schema
+func parseFieldSchemas(spec string) (fieldSchemas, error) {
+	var schemas fieldSchemas
+	if err := json.Unmarshal([]byte(spec), &schemas); err != nil {
+		return nil, err
+	}
+	for sheet, rules := range schemas {
+		for column, rule := range rules {
+			switch rule.Type {
+			case fieldString, fieldNumber, fieldDate:
+			case fieldEnum:
+				if len(rule.Values) == 0 {
+					return nil, fmt.Errorf("%s.%s: enum needs values", sheet, column)
+				}
+			default:
+				return nil, fmt.Errorf("%s.%s: unknown type %q (want string, number, date, or enum)", sheet, column, rule.Type)
+			}
+		}
+	}
+	return schemas, nil
+}
+
+// checkFieldValue returns why value doesn't satisfy rule, or "" if it does.
+// Blank values are only checked for required columns.
+func checkFieldValue(rule fieldRule, value interface{}) string {
+	str := strings.TrimSpace(cellString(value))
+	if str == "" {
+		if rule.Required {
+			return "is required"
+		}
+		return ""
+	}
+
+	switch rule.Type {
+	case fieldString:
+		if _, ok := value.(string); !ok {
+			return "must be a string"
+		}
+	case fieldNumber:
+		if _, ok := numericValue(value); !ok {
+			return "must be a number"
+		}
+	case fieldDate:
+		if _, err := time.Parse(time.RFC3339, str); err == nil {
+			return ""
+		}
+		if _, err := time.Parse("2006-01-02", str); err == nil {
+			return ""
+		}
+		return "must be an RFC 3339 date (2006-01-02 or 2006-01-02T15:04:05Z)"
+	case fieldEnum:
+		for _, allowed := range rule.Values {
+			if str == allowed {
+				return ""
+			}
+		}
+		return fmt.Sprintf("must be one of %s", strings.Join(rule.Values, ", "))
+	}
+	return ""
+}
+
+// validateFields checks data against a sheet's rules. A full row (append)
+// must also fill every required column; a partial one (update) only has the
+// columns it sends checked.
+func validateFields(rules map[string]fieldRule, data map[string]interface{}, partial bool) []FieldError {
+	var problems []FieldError
+	for key, value := range data {
+		column, _ := splitColumnKey(key)
+		rule, ok := rules[column]
+		if !ok {
+			continue
+		}
+		if msg := checkFieldValue(rule, value); msg != "" {
+			problems = append(problems, FieldError{Field: key, Message: msg})
+		}
+	}
+	if !partial {
+		for column, rule := range rules {
+			if _, sent := data[column]; rule.Required && !sent {
+				problems = append(problems, FieldError{Field: column, Message: "is required"})
+			}
+		}
+	}
+	sort.Slice(problems, func(i, j int) bool { return problems[i].Field < problems[j].Field })
+	return problems
+}
+
+// schemaCacheTTL is how long a spreadsheet's field_schema is reused, so every
+// write doesn't read the Config tab. Edits to it take this long to apply.
+const schemaCacheTTL = time.Minute
+
+// schemaCacheEntry is a spreadsheet's field_schema as of a read
+type schemaCacheEntry struct {
+	value   string
+	found   bool
+	expires time.Time
+}
+
+// configFieldSchema returns the field_schema value from the spreadsheet's
+// Config tab, from the cache when read recently. Failed reads aren't cached.
+func (s *Server) configFieldSchema(ctx context.Context, srv *sheets.Service, spreadsheetID string) (string, bool, error) {
+	now := time.Now()
+	s.schemaCacheMu.Lock()
+	entry, ok := s.schemaCache[spreadsheetID]
+	s.schemaCacheMu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.value, entry.found, nil
+	}
+
+	value, found, err := configValue(ctx, srv, spreadsheetID, fieldSchemaKey)
+	if err != nil {
+		return "", false, err
+	}
+	s.schemaCacheMu.Lock()
+	s.schemaCache[spreadsheetID] = schemaCacheEntry{value: value, found: found, expires: now.Add(schemaCacheTTL)}
+	s.schemaCacheMu.Unlock()
+	return value, found, nil
+}
+
+// fieldRulesFor returns the rules for sheet, or nil if it has none. A
+// field_schema entry in the Config tab wins over FIELD_SCHEMA, so rules can
+// change without a redeploy; an unreadable Config tab falls back to the env.
+func (s *Server) fieldRulesFor(ctx context.Context, srv *sheets.Service, spreadsheetID, sheet string) (map[string]fieldRule, error) {
+	value, found, err := s.configFieldSchema(ctx, srv, spreadsheetID)
+	if isCancelled(err) {
+		return nil, err
+	}
+	if err != nil {
+		log.Printf("[API] Field schema: could not read Config tab, using FIELD_SCHEMA (%v)", err)
+	}
+	if !found || value == "" {
+		return s.fieldSchemas[sheet], nil
+	}
+
+	schemas, err := parseFieldSchemas(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s in Config tab: %v", fieldSchemaKey, err)
+	}
+	return schemas[sheet], nil
+}
+
+// writeValidationError rejects a write with the fields that failed validation
+func writeValidationError(w http.ResponseWriter, problems []FieldError) {
+	code := validationFailedCode
+	writeJSONStatus(w, Error{
+		Error:  fmt.Sprintf("%d field(s) failed validation", len(problems)),
+		Code:   &code,
+		Fields: &problems,
+	}, http.StatusBadRequest)
+}
+
+// fieldRules loads the rules for sheet for a write handler, writing the error
+// response itself when they can't be loaded
+func (s *Server) fieldRules(w http.ResponseWriter, r *http.Request, srv *sheets.Service, spreadsheetID, sheet, op string) (map[string]fieldRule, bool) {
+	rules, err := s.fieldRulesFor(r.Context(), srv, spreadsheetID, sheet)
+	if isCancelled(err) {
+		writeCancelled(w, op)
+		return nil, false
+	}
+	if err != nil {
+		log.Printf("[API] %s: %v", op, err)
+		writeError(w, err.Error(), http.StatusInternalServerError)
+		return nil, false
+	}
+	return rules, true
+}
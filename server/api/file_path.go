@@ -0,0 +1,93 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/googleapi"
+)
+
+// shortcutMimeType is the Drive MIME type of a shortcut
+const shortcutMimeType = "application/vnd.google-apps.shortcut"
+
+// GetFilePath returns the breadcrumbs from the Grants or root folder down to a
+// file, optionally following a shortcut to where its target lives
+func (s *Server) GetFilePath(w http.ResponseWriter, r *http.Request) {
+	var req GetFilePathRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.FileId == "" {
+		writeError(w, "fileId is required", http.StatusBadRequest)
+		return
+	}
+
+	srv, err := s.driveService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Drive service: %v", err)
+		writeError(w, "Failed to connect to Google Drive", http.StatusInternalServerError)
+		return
+	}
+
+	getFile := func(id string) (*drive.File, error) {
+		return withRetry(r.Context(), s.retryAttempts, true, func() (*drive.File, error) {
+			return srv.Files.Get(id).
+				Fields("id, name, mimeType, parents, shortcutDetails").
+				SupportsAllDrives(true).
+				Context(r.Context()).
+				Do()
+		})
+	}
+
+	file, err := getFile(req.FileId)
+	if err == nil && req.FollowShortcut && file.MimeType == shortcutMimeType && file.ShortcutDetails != nil {
+		file, err = getFile(file.ShortcutDetails.TargetId)
+	}
+	var apiErr *googleapi.Error
+	switch {
+	case isCancelled(err):
+		writeCancelled(w, "GetFilePath")
+		return
+	case errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound:
+		writeError(w, fmt.Sprintf("File %s not found", req.FileId), http.StatusNotFound)
+		return
+	case err != nil:
+		log.Printf("Failed to get file: %v", err)
+		writeError(w, fmt.Sprintf("Failed to get file: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	// The Grants and root folders are where paths start, so they have none
+	path := []Breadcrumb{}
+	if file.Id != s.discoveredGrantsFolderID() && file.Id != s.rootFolderID {
+		place, err := s.locateInTree(r.Context(), srv, file.Parents)
+		if isCancelled(err) {
+			writeCancelled(w, "GetFilePath")
+			return
+		}
+		if err != nil {
+			log.Printf("Failed to resolve file path: %v", err)
+			writeError(w, fmt.Sprintf("Failed to resolve file path: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if !place.inTree() {
+			writeErrorCode(w, fmt.Sprintf("File %s is not inside the Grant Tracker folder", file.Id), outOfScopeCode, http.StatusForbidden)
+			return
+		}
+		path = place.path
+	}
+
+	s.auditRead(r, AuditEvent{
+		Action:   "get_file_path",
+		Resource: file.Id,
+		Target:   file.Name,
+		Detail:   fmt.Sprintf("resolved path of %s (%s), %d folders deep", file.Name, file.Id, len(path)),
+	})
+
+	writeJSON(w, GetFilePathResponse{Path: path, File: Breadcrumb{Id: file.Id, Name: file.Name}})
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// rowMatcher tests one cell against a resolved RowFilter
+type rowMatcher struct {
+	colIdx int
+	op     RowFilterOp
+	text   string  // eq and contains (lowercased for contains)
+	number float64 // gt
+}
+
+// newRowMatcher checks a filter's operator and value
+func newRowMatcher(f RowFilter, colIdx int) (rowMatcher, error) {
+	m := rowMatcher{colIdx: colIdx, op: f.Op}
+	switch f.Op {
+	case Eq:
+		m.text = cellString(f.Value)
+	case Contains:
+		m.text = strings.ToLower(cellString(f.Value))
+	case Gt:
+		n, ok := numericValue(f.Value)
+		if !ok {
+			return rowMatcher{}, fmt.Errorf("Filter on %s: gt needs a numeric value", f.Column)
+		}
+		m.number = n
+	default:
+		return rowMatcher{}, fmt.Errorf("Unknown op %q (want eq, contains, or gt)", f.Op)
+	}
+	return m, nil
+}
+
+// matches reports whether the row's cell satisfies the filter. Missing cells
+// read as empty.
+func (m rowMatcher) matches(row []interface{}) bool {
+	var cell interface{}
+	if m.colIdx < len(row) {
+		cell = row[m.colIdx]
+	}
+	switch m.op {
+	case Eq:
+		return cellString(cell) == m.text
+	case Contains:
+		return strings.Contains(strings.ToLower(cellString(cell)), m.text)
+	case Gt:
+		n, ok := numericValue(cell)
+		return ok && n > m.number
+	}
+	return false
+}
+
+// FindRows returns the rows of a sheet matching every filter, with their sheet
+// row numbers, so clients don't have to download the whole sheet to filter it
+func (s *Server) FindRows(w http.ResponseWriter, r *http.Request) {
+	var req FindRowsRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.Sheet == "" || len(req.Filters) == 0 {
+		writeError(w, "Sheet and filters are required", http.StatusBadRequest)
+		return
+	}
+	if refuseInternalSheet(w, req.Sheet) {
+		return
+	}
+
+	spreadsheetID, err := s.spreadsheetFor(r)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	srv, err := s.sheetsService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Sheets service: %v", err)
+		writeError(w, "Failed to connect to Google Sheets", http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := withRetry(r.Context(), s.retryAttempts, true, func() (*sheets.ValueRange, error) {
+		return srv.Spreadsheets.Values.Get(spreadsheetID, req.Sheet).
+			ValueRenderOption("UNFORMATTED_VALUE").Context(r.Context()).Do()
+	})
+	if isCancelled(err) {
+		writeCancelled(w, "FindRows")
+		return
+	}
+	if err != nil {
+		if sheetMissing(r.Context(), srv, spreadsheetID, req.Sheet, err) {
+			writeError(w, fmt.Sprintf("Sheet %s not found", req.Sheet), http.StatusNotFound)
+			return
+		}
+		log.Printf("Failed to read sheet: %v", err)
+		writeError(w, "Failed to read sheet", http.StatusInternalServerError)
+		return
+	}
+	table := splitTable(resp.Values, s.headerRow(req.Sheet))
+
+	headers := make([]string, len(table.headers))
+	for i, h := range table.headers {
+		headers[i] = cellString(h)
+	}
+	s.stringifyIDColumns(headers, table.rows)
+
+	// Filtering on a hidden column would reveal its values, same as returning it
+	filterColumns := make([]string, len(req.Filters))
+	for i, f := range req.Filters {
+		filterColumns[i] = f.Column
+	}
+	filterIdx, status, err := s.readableColumns(r, table, filterColumns)
+	if err != nil {
+		writeError(w, err.Error(), status)
+		return
+	}
+	matchers := make([]rowMatcher, len(req.Filters))
+	for i, f := range req.Filters {
+		if matchers[i], err = newRowMatcher(f, filterIdx[i]); err != nil {
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Project to the requested columns, or every column the caller may see
+	var projection []int
+	if req.Columns != nil && len(*req.Columns) > 0 {
+		if projection, status, err = s.readableColumns(r, table, *req.Columns); err != nil {
+			writeError(w, err.Error(), status)
+			return
+		}
+	} else {
+		hidden := s.hiddenColumns(r, headers)
+		for i := range headers {
+			if !hidden[i] {
+				projection = append(projection, i)
+			}
+		}
+	}
+
+	result := FindRowsResponse{Headers: make([]string, len(projection)), Rows: []FoundRow{}}
+	for n, idx := range projection {
+		result.Headers[n] = headers[idx]
+	}
+	for i, row := range table.rows {
+		matched := true
+		for _, m := range matchers {
+			if !m.matches(row) {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		values := make([]interface{}, len(projection))
+		for n, idx := range projection {
+			if idx < len(row) {
+				values[n] = row[idx]
+			}
+		}
+		result.Rows = append(result.Rows, FoundRow{Row: table.sheetRow(i), Values: values})
+	}
+
+	log.Printf("[API] FindRows %s: %d of %d rows matched", req.Sheet, len(result.Rows), len(table.rows))
+	s.auditRead(r, AuditEvent{
+		Action:   "find_rows",
+		Resource: req.Sheet,
+		Detail:   fmt.Sprintf("found %d rows in %s matching %d filters", len(result.Rows), req.Sheet, len(req.Filters)),
+	})
+
+	writeJSON(w, result)
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/docs/v1"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/sheets/v4"
+)
+
+// impersonationScopes are the scopes the service account must be delegated for
+// the Workspace user it impersonates
+var impersonationScopes = []string{drive.DriveScope, sheets.SpreadsheetsScope, docs.DocumentsScope}
+
+// impersonationTokenSource explains token failures caused by missing
+// domain-wide delegation, which Google otherwise reports as a bare
+// unauthorized_client
+type impersonationTokenSource struct {
+	base     oauth2.TokenSource
+	subject  string
+	clientID string
+	scope    string
+}
+
+func (ts impersonationTokenSource) Token() (*oauth2.Token, error) {
+	token, err := ts.base.Token()
+	if err != nil {
+		return nil, impersonationError(err, ts.subject, ts.clientID, ts.scope)
+	}
+	return token, nil
+}
+
+// impersonationError rewords Google's refusal to issue a delegated token
+func impersonationError(err error, subject, clientID, scope string) error {
+	var retrieveErr *oauth2.RetrieveError
+	if !errors.As(err, &retrieveErr) {
+		return err
+	}
+	switch retrieveErr.ErrorCode {
+	case "unauthorized_client", "access_denied", "invalid_grant":
+		return fmt.Errorf("Google rejected impersonation of %s: grant client ID %s domain-wide delegation for %s in the Workspace admin console (%s)",
+			subject, clientID, scope, retrieveErr.ErrorCode)
+	}
+	return err
+}
+
+// serviceAccountClientID returns the numeric client ID from a service account
+// key, which is what the Workspace admin console asks for when granting delegation
+func serviceAccountClientID(credentials []byte) string {
+	var key struct {
+		ClientID string `json:"client_id"`
+	}
+	json.Unmarshal(credentials, &key)
+	return key.ClientID
+}
+
+// checkImpersonation fetches a delegated token for each scope the server uses,
+// so missing delegation is reported once at discovery rather than on every call
+func (s *Server) checkImpersonation(ctx context.Context) error {
+	if s.impersonateSubject == "" {
+		return nil
+	}
+	s.clientMu.Lock()
+	credentials := s.credentials
+	s.clientMu.Unlock()
+
+	clientID := serviceAccountClientID(credentials)
+	for _, scope := range impersonationScopes {
+		config, err := google.JWTConfigFromJSON(credentials, scope)
+		if err != nil {
+			return fmt.Errorf("failed to parse service account credentials: %w", err)
+		}
+		config.Subject = s.impersonateSubject
+		if _, err := config.TokenSource(ctx).Token(); err != nil {
+			return impersonationError(err, s.impersonateSubject, clientID, scope)
+		}
+	}
+	return nil
+}
+
+// validSubject does a light check that an impersonation subject is an email address
+func validSubject(subject string) bool {
+	user, domain, ok := strings.Cut(subject, "@")
+	return ok && user != "" && strings.Contains(domain, ".") && !strings.ContainsAny(subject, " ,")
+}
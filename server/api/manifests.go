@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"google.golang.org/api/drive/v3"
+)
+
+const (
+	// defaultManifestPageSize is how many grant folders one ListGrantManifests page covers
+	defaultManifestPageSize = 10
+	maxManifestPageSize     = 100
+
+	// defaultManifestConcurrency bounds how many folders are walked at once so a
+	// full audit stays inside the Drive per-user quota
+	defaultManifestConcurrency = 4
+)
+
+// grantFolderID returns the grant ID a folder is tagged with, or its name for
+// folders created before tagging
+func grantFolderID(f *drive.File) string {
+	if id := f.AppProperties[grantIDProperty]; id != "" {
+		return id
+	}
+	return f.Name
+}
+
+// grantManifest walks one grant folder. Failures are reported on the entry so
+// one unreadable folder doesn't abort the whole stream.
+func (s *Server) grantManifest(ctx context.Context, srv *drive.Service, f *drive.File) GrantManifest {
+	m := GrantManifest{GrantId: grantFolderID(f), Folder: fileInfoFromDrive(f), Files: []FileInfo{}}
+	files, err := s.folderManifest(ctx, srv, f.Id)
+	if err != nil {
+		msg := err.Error()
+		m.Error = &msg
+		return m
+	}
+	m.Files = files
+	return m
+}
+
+// ListGrantManifests streams the file manifest of every grant folder under the
+// Grants folder, one page of grant folders at a time
+func (s *Server) ListGrantManifests(w http.ResponseWriter, r *http.Request) {
+	var req ListGrantManifestsRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	pageSize := defaultManifestPageSize
+	if req.PageSize != nil {
+		if *req.PageSize < 1 || *req.PageSize > maxManifestPageSize {
+			writeError(w, fmt.Sprintf("pageSize must be between 1 and %d", maxManifestPageSize), http.StatusBadRequest)
+			return
+		}
+		pageSize = *req.PageSize
+	}
+
+	srv, err := s.driveService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Drive service: %v", err)
+		writeError(w, "Failed to connect to Google Drive", http.StatusInternalServerError)
+		return
+	}
+
+	call := srv.Files.List().
+		Q(fmt.Sprintf("'%s' in parents and mimeType = '%s' and trashed = false", s.discoveredGrantsFolderID(), folderMimeType)).
+		Fields("nextPageToken, files(id, name, mimeType, modifiedTime, webViewLink, appProperties)").
+		OrderBy("name").
+		PageSize(int64(pageSize)).
+		SupportsAllDrives(true).
+		IncludeItemsFromAllDrives(true).
+		Context(r.Context())
+	if req.PageToken != nil && *req.PageToken != "" {
+		call = call.PageToken(*req.PageToken)
+	}
+	resp, err := call.Do()
+	if isCancelled(err) {
+		writeCancelled(w, "ListGrantManifests")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to list grant folders: %v", err)
+		writeError(w, fmt.Sprintf("Failed to list grant folders: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	// Walk folders concurrently but emit them in listing order, each as soon
+	// as it and everything before it is done
+	results := make([]chan GrantManifest, len(resp.Files))
+	sem := make(chan struct{}, s.manifestConcurrency)
+	for i, f := range resp.Files {
+		results[i] = make(chan GrantManifest, 1)
+		go func(out chan<- GrantManifest, f *drive.File) {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			out <- s.grantManifest(r.Context(), srv, f)
+		}(results[i], f)
+	}
+
+	// The body is written incrementally, so it has to be assembled by hand
+	// rather than with writeJSON
+	pageInfo, _ := json.Marshal(tokenPageInfo(pageSize, resp.NextPageToken))
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"pageInfo":%s,"grants":[`, pageInfo)
+	flusher, _ := w.(http.Flusher)
+
+	fileCount := 0
+	for i, ch := range results {
+		m := <-ch
+		fileCount += len(m.Files)
+		entry, _ := json.Marshal(m)
+		if i > 0 {
+			w.Write([]byte(","))
+		}
+		w.Write(entry)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	w.Write([]byte("]}\n"))
+
+	s.auditRead(r, AuditEvent{
+		Action:   "list_grant_manifests",
+		Resource: s.discoveredGrantsFolderID(),
+		Detail:   fmt.Sprintf("listed manifests for %d grant folders (%d files)", len(resp.Files), fileCount),
+	})
+}
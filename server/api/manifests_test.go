@@ -0,0 +1,115 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+)
+
+// serveGrantFolders fakes a Grants folder holding two grant folders, one tagged
+// with its grant ID and one found by name, plus a third that can't be read.
+// It returns the most folder listings that were in flight at once.
+func serveGrantFolders(f *fakeGoogle) func() int {
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	f.handle(http.MethodGet, "/files", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		if strings.HasPrefix(q, "'grants' in parents") {
+			writeFakeJSON(w, &drive.FileList{
+				Files: []*drive.File{
+					{Id: "folder-1", Name: "Packaging", MimeType: folderMimeType, AppProperties: map[string]string{grantIDProperty: "G-1"}},
+					{Id: "folder-2", Name: "G-2", MimeType: folderMimeType},
+					{Id: "folder-3", Name: "G-3", MimeType: folderMimeType},
+				},
+				NextPageToken: "next-grants",
+			})
+			return
+		}
+
+		mu.Lock()
+		inFlight++
+		if inFlight > peak {
+			peak = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		switch {
+		case strings.HasPrefix(q, "'folder-1' in parents"):
+			writeFakeJSON(w, &drive.FileList{Files: []*drive.File{{Id: "doc-1", Name: "Proposal"}, {Id: "doc-2", Name: "Budget"}}})
+		case strings.HasPrefix(q, "'folder-2' in parents"):
+			writeFakeJSON(w, &drive.FileList{Files: []*drive.File{{Id: "doc-3", Name: "Report"}}})
+		default:
+			http.Error(w, `{"error": {"code": 403, "message": "Insufficient permissions"}}`, http.StatusForbidden)
+		}
+	})
+	return func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return peak
+	}
+}
+
+func TestListGrantManifests(t *testing.T) {
+	f := newFakeGoogle(t)
+	peak := serveGrantFolders(f)
+	s := newTestServer(t, f)
+	s.grantsFolderID = "grants"
+	s.manifestConcurrency = 2
+
+	w := callHandler(t, s.ListGrantManifests, "admin@example.org", ListGrantManifestsRequest{})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var resp ListGrantManifestsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode streamed response: %v\n%s", err, w.Body)
+	}
+
+	if len(resp.Grants) != 3 {
+		t.Fatalf("grants = %+v, want three", resp.Grants)
+	}
+	files := func(m GrantManifest) []string {
+		var names []string
+		for _, fi := range m.Files {
+			names = append(names, fi.Name)
+		}
+		return names
+	}
+	if g := resp.Grants[0]; g.GrantId != "G-1" || g.Folder.Id != "folder-1" || !reflect.DeepEqual(files(g), []string{"Proposal", "Budget"}) {
+		t.Errorf("first grant = %+v, want G-1 from appProperties with two files", g)
+	}
+	if g := resp.Grants[1]; g.GrantId != "G-2" || !reflect.DeepEqual(files(g), []string{"Report"}) {
+		t.Errorf("second grant = %+v, want G-2 by name with one file", g)
+	}
+	if g := resp.Grants[2]; g.GrantId != "G-3" || g.Error == nil || len(g.Files) != 0 {
+		t.Errorf("third grant = %+v, want its error reported on the entry", g)
+	}
+	if !resp.PageInfo.HasMore || resp.PageInfo.NextPageToken == nil || *resp.PageInfo.NextPageToken != "next-grants" {
+		t.Errorf("pageInfo = %+v, want the next page of grant folders", resp.PageInfo)
+	}
+	if p := peak(); p > 2 {
+		t.Errorf("%d folder listings in flight at once, want at most 2", p)
+	}
+}
+
+func TestListGrantManifestsPageSize(t *testing.T) {
+	s := newTestServer(t, nil)
+	for _, size := range []int{0, maxManifestPageSize + 1} {
+		w := callHandler(t, s.ListGrantManifests, "admin@example.org", ListGrantManifestsRequest{PageSize: &size})
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("pageSize %d: status = %d, want 400", size, w.Code)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ownerNotifyTimeout bounds one webhook delivery
+const ownerNotifyTimeout = 10 * time.Second
+
+// FieldChange is one column's value before and after an update
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// OwnerNotification tells a row's owner that someone else changed it
+type OwnerNotification struct {
+	Event      string                 `json:"event"` // Always "row_updated"
+	Owner      string                 `json:"owner"`
+	ModifiedBy string                 `json:"modifiedBy"`
+	Sheet      string                 `json:"sheet"`
+	ID         string                 `json:"id"`
+	Changes    map[string]FieldChange `json:"changes"`
+	Time       time.Time              `json:"time"`
+}
+
+// Notifier delivers owner notifications
+type Notifier interface {
+	Notify(ctx context.Context, n OwnerNotification) error
+}
+
+// webhookNotifier POSTs each notification as JSON, e.g. to a chat or email relay
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func (wh webhookNotifier) Notify(ctx context.Context, n OwnerNotification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wh.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := wh.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// rowChanges returns the columns whose value differs between two row payloads
+func rowChanges(before, after map[string]interface{}) map[string]FieldChange {
+	changes := make(map[string]FieldChange)
+	for column, now := range after {
+		if was := before[column]; cellString(was) != cellString(now) {
+			changes[column] = FieldChange{Before: was, After: now}
+		}
+	}
+	return changes
+}
+
+// notifyOwner tells the row's owner about an update made by someone else. It
+// runs in the background so a slow webhook never delays the write.
+func (s *Server) notifyOwner(r *http.Request, sheet, id string, before, after map[string]interface{}) {
+	if s.ownerNotifier == nil {
+		return
+	}
+	owner := strings.TrimSpace(cellString(before[s.ownerColumn]))
+	modifiedBy := r.Header.Get("X-User-Email")
+	if owner == "" || strings.EqualFold(owner, modifiedBy) {
+		return
+	}
+	changes := rowChanges(before, after)
+	if len(changes) == 0 {
+		return
+	}
+
+	n := OwnerNotification{
+		Event:      "row_updated",
+		Owner:      owner,
+		ModifiedBy: modifiedBy,
+		Sheet:      sheet,
+		ID:         id,
+		Changes:    changes,
+		Time:       time.Now().UTC(),
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), ownerNotifyTimeout)
+		defer cancel()
+		if err := s.ownerNotifier.Notify(ctx, n); err != nil {
+			log.Printf("[API] Failed to notify %s of update to %s in %s: %v", owner, id, sheet, err)
+		}
+	}()
+}
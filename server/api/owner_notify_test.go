@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// recordingNotifier hands each notification to a channel
+type recordingNotifier chan OwnerNotification
+
+func (n recordingNotifier) Notify(ctx context.Context, note OwnerNotification) error {
+	n <- note
+	return nil
+}
+
+// newNotifyServer fakes a Grants tab owned row by row and returns a server
+// notifying through the returned channel
+func newNotifyServer(t *testing.T) (*Server, recordingNotifier) {
+	t.Helper()
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Config!A:B", &sheets.ValueRange{})
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: [][]interface{}{
+		{"ID", "Status", "Owner"},
+		{"G-1", "Draft", "owner@example.org"},
+		{"G-2", "Draft"},
+	}})
+	f.reply(http.MethodPost, "/v4/spreadsheets/"+testSpreadsheetID+"/values:batchUpdate", &sheets.BatchUpdateValuesResponse{})
+
+	s := newTestServer(t, f)
+	notes := make(recordingNotifier, 1)
+	s.ownerNotifier = notes
+	return s, notes
+}
+
+func TestUpdateRowNotifiesOwner(t *testing.T) {
+	s, notes := newNotifyServer(t)
+
+	w := callHandler(t, s.UpdateRow, "editor@example.org", UpdateRowRequest{
+		Sheet: "Grants", IdColumn: "ID", Id: "G-1", Data: map[string]interface{}{"Status": "Approved"},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+
+	select {
+	case note := <-notes:
+		if note.Owner != "owner@example.org" || note.ModifiedBy != "editor@example.org" || note.Sheet != "Grants" || note.ID != "G-1" {
+			t.Errorf("got %+v", note)
+		}
+		want := map[string]FieldChange{"Status": {Before: "Draft", After: "Approved"}}
+		if !reflect.DeepEqual(note.Changes, want) {
+			t.Errorf("changes = %v, want %v", note.Changes, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("owner was not notified")
+	}
+}
+
+func TestUpdateRowSkipsNotification(t *testing.T) {
+	tests := []struct {
+		name string
+		user string
+		id   string
+		data map[string]interface{}
+	}{
+		{"owner updates their own row", "Owner@Example.org", "G-1", map[string]interface{}{"Status": "Approved"}},
+		{"row has no owner", "editor@example.org", "G-2", map[string]interface{}{"Status": "Approved"}},
+		{"nothing changed", "editor@example.org", "G-1", map[string]interface{}{"Status": "Draft"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, notes := newNotifyServer(t)
+
+			w := callHandler(t, s.UpdateRow, tt.user, UpdateRowRequest{Sheet: "Grants", IdColumn: "ID", Id: tt.id, Data: tt.data})
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", w.Code, w.Body)
+			}
+			select {
+			case note := <-notes:
+				t.Errorf("unexpected notification %+v", note)
+			case <-time.After(100 * time.Millisecond):
+			}
+		})
+	}
+}
+
+func TestUpdateRowWithoutNotifier(t *testing.T) {
+	s, _ := newNotifyServer(t)
+	s.ownerNotifier = nil
+
+	w := callHandler(t, s.UpdateRow, "editor@example.org", UpdateRowRequest{
+		Sheet: "Grants", IdColumn: "ID", Id: "G-1", Data: map[string]interface{}{"Status": "Approved"},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// defaultOwnerColumn holds the email of the program officer who owns a row
+const defaultOwnerColumn = "Owner"
+
+// ownedValues keeps the header (and anything above it) and the rows whose
+// owner column holds email. It runs on the values as read, before sensitive
+// columns are dropped, so the mine filter works even when the owner column is
+// hidden from the caller.
+func ownedValues(values [][]interface{}, headerRow int, column, email string) ([][]interface{}, error) {
+	table := splitTable(values, headerRow)
+	if table.headers == nil {
+		return values, nil
+	}
+	idx := table.indexOf(column)
+	if idx == -1 {
+		return nil, fmt.Errorf("Column %s not found", column)
+	}
+
+	owned := append([][]interface{}{}, values[:headerRow]...)
+	for _, row := range table.rows {
+		if idx < len(row) && strings.EqualFold(strings.TrimSpace(cellString(row[idx])), email) {
+			owned = append(owned, row)
+		}
+	}
+	return owned, nil
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+var ownedGrants = [][]interface{}{
+	{"ID", "Title", "Owner"},
+	{"G-1", "Mine", "po@example.org"},
+	{"G-2", "Theirs", "other@example.org"},
+	{"G-3", "Mine too", " PO@Example.org "},
+	{"G-4", "Unowned"},
+}
+
+func TestOwnedValues(t *testing.T) {
+	banner := append([][]interface{}{{"Grants 2026"}}, ownedGrants...)
+
+	tests := []struct {
+		name      string
+		values    [][]interface{}
+		headerRow int
+		column    string
+		want      [][]interface{}
+		wantErr   bool
+	}{
+		{
+			name: "keeps the header and the user's rows", values: ownedGrants, headerRow: 1, column: "Owner",
+			want: [][]interface{}{ownedGrants[0], ownedGrants[1], ownedGrants[3]},
+		},
+		{
+			name: "keeps rows above a lower header", values: banner, headerRow: 2, column: "Owner",
+			want: [][]interface{}{banner[0], banner[1], banner[2], banner[4]},
+		},
+		{name: "empty sheet", values: nil, headerRow: 1, column: "Owner", want: nil},
+		{name: "missing owner column", values: ownedGrants, headerRow: 1, column: "Officer", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ownedValues(tt.values, tt.headerRow, tt.column, "po@example.org")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// readMine reads Grants with mine=true as po@example.org holding role
+func readMine(t *testing.T, s *Server, role string) ReadSheetResponse {
+	t.Helper()
+	mine := true
+	w := callHandlerAs(t, s.ReadSheet, "po@example.org", role, ReadSheetRequest{Sheet: "Grants", Mine: &mine})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var resp ReadSheetResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return resp
+}
+
+func TestReadSheetMine(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: ownedGrants})
+	s := newTestServer(t, f)
+
+	resp := readMine(t, s, "writer")
+	if want := []string{"ID", "Title", "Owner"}; !reflect.DeepEqual(resp.Headers, want) {
+		t.Errorf("headers = %v, want %v", resp.Headers, want)
+	}
+	var ids []interface{}
+	for _, row := range resp.Rows {
+		ids = append(ids, row[0])
+	}
+	if want := []interface{}{"G-1", "G-3"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("rows %v, want %v", ids, want)
+	}
+}
+
+func TestReadSheetMineWithHiddenOwner(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: ownedGrants})
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants!1:1", &sheets.ValueRange{Values: ownedGrants[:1]})
+	s := newTestServer(t, f)
+	s.sensitiveColumns = map[string]bool{"Owner": true}
+	s.sensitiveMinRole = "organizer"
+
+	resp := readMine(t, s, "writer")
+	if want := []string{"ID", "Title"}; !reflect.DeepEqual(resp.Headers, want) {
+		t.Errorf("headers = %v, want %v", resp.Headers, want)
+	}
+	if want := [][]interface{}{{"G-1", "Mine"}, {"G-3", "Mine too"}}; !reflect.DeepEqual(resp.Rows, want) {
+		t.Errorf("rows = %v, want %v", resp.Rows, want)
+	}
+}
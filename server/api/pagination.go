@@ -0,0 +1,75 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	// maxListPageSize is the largest page Drive returns from Files.List
+	maxListPageSize = 1000
+	// defaultListPageSize is the Drive page size used when the client doesn't
+	// ask for one (see DRIVE_LIST_PAGE_SIZE)
+	defaultListPageSize = maxListPageSize
+)
+
+// pageWindow resolves the offset and limit for an offset-paginated read. A page
+// token (the next offset, as issued by pageRows) takes precedence over offset;
+// a zero limit means "no limit".
+func pageWindow(offset, limit *int, pageToken *string) (int, int, error) {
+	start := 0
+	if offset != nil {
+		start = *offset
+	}
+	if pageToken != nil && *pageToken != "" {
+		n, err := strconv.Atoi(*pageToken)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid pageToken")
+		}
+		start = n
+	}
+	if start < 0 {
+		return 0, 0, fmt.Errorf("offset must not be negative")
+	}
+
+	size := 0
+	if limit != nil {
+		if *limit < 1 {
+			return 0, 0, fmt.Errorf("limit must be positive")
+		}
+		size = *limit
+	}
+	return start, size, nil
+}
+
+// pageRows slices rows to the requested window and describes the page
+func pageRows(rows [][]interface{}, offset, limit int) ([][]interface{}, PageInfo) {
+	total := len(rows)
+	if limit == 0 {
+		limit = total
+	}
+
+	start := min(offset, total)
+	end := min(start+limit, total)
+
+	info := PageInfo{
+		Total:   &total,
+		Offset:  &start,
+		Limit:   limit,
+		HasMore: end < total,
+	}
+	if info.HasMore {
+		next := strconv.Itoa(end)
+		info.NextPageToken = &next
+	}
+	return rows[start:end], info
+}
+
+// tokenPageInfo describes a page from a cursor-paginated source such as Drive
+func tokenPageInfo(limit int, nextPageToken string) PageInfo {
+	info := PageInfo{Limit: limit, HasMore: nextPageToken != ""}
+	if info.HasMore {
+		info.NextPageToken = &nextPageToken
+	}
+	return info
+}
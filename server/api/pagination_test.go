@@ -0,0 +1,148 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestPageWindow(t *testing.T) {
+	intp := func(n int) *int { return &n }
+	strp := func(s string) *string { return &s }
+	tests := []struct {
+		name       string
+		offset     *int
+		limit      *int
+		token      *string
+		wantOffset int
+		wantLimit  int
+		wantErr    bool
+	}{
+		{name: "defaults"},
+		{name: "offset and limit", offset: intp(10), limit: intp(5), wantOffset: 10, wantLimit: 5},
+		{name: "token wins over offset", offset: intp(10), limit: intp(5), token: strp("20"), wantOffset: 20, wantLimit: 5},
+		{name: "empty token", offset: intp(3), token: strp(""), wantOffset: 3},
+		{name: "garbled token", token: strp("abc"), wantErr: true},
+		{name: "negative offset", offset: intp(-1), wantErr: true},
+		{name: "zero limit", limit: intp(0), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset, limit, err := pageWindow(tt.offset, tt.limit, tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("pageWindow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if offset != tt.wantOffset || limit != tt.wantLimit {
+				t.Errorf("pageWindow() = %d, %d, want %d, %d", offset, limit, tt.wantOffset, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestPageRows(t *testing.T) {
+	rows := [][]interface{}{{"G-1"}, {"G-2"}, {"G-3"}, {"G-4"}, {"G-5"}}
+	tests := []struct {
+		name      string
+		offset    int
+		limit     int
+		want      [][]interface{}
+		wantMore  bool
+		wantToken string
+	}{
+		{name: "everything", want: rows},
+		{name: "first page", limit: 2, want: rows[:2], wantMore: true, wantToken: "2"},
+		{name: "middle page", offset: 2, limit: 2, want: rows[2:4], wantMore: true, wantToken: "4"},
+		{name: "last page", offset: 4, limit: 2, want: rows[4:]},
+		{name: "past the end", offset: 9, limit: 2, want: [][]interface{}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, info := pageRows(rows, tt.offset, tt.limit)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rows = %v, want %v", got, tt.want)
+			}
+			if info.HasMore != tt.wantMore || *info.Total != len(rows) {
+				t.Errorf("info = %+v", info)
+			}
+			token := ""
+			if info.NextPageToken != nil {
+				token = *info.NextPageToken
+			}
+			if token != tt.wantToken {
+				t.Errorf("next page token = %q, want %q", token, tt.wantToken)
+			}
+		})
+	}
+}
+
+func TestTokenPageInfo(t *testing.T) {
+	if info := tokenPageInfo(100, "next"); !info.HasMore || *info.NextPageToken != "next" || info.Limit != 100 {
+		t.Errorf("with a token: %+v", info)
+	}
+	if info := tokenPageInfo(100, ""); info.HasMore || info.NextPageToken != nil {
+		t.Errorf("last page: %+v", info)
+	}
+}
+
+func TestReadSheetPageInfo(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: [][]interface{}{
+		{"ID"}, {"G-1"}, {"G-2"}, {"G-3"},
+	}})
+	s := newTestServer(t, f)
+
+	limit := 2
+	w := callHandler(t, s.ReadSheet, "po@example.org", ReadSheetRequest{Sheet: "Grants", Limit: &limit})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var resp ReadSheetResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.Rows) != 2 {
+		t.Errorf("rows = %v, want the first two", resp.Rows)
+	}
+	info := resp.PageInfo
+	if info.Total == nil || *info.Total != 3 || info.Limit != 2 || !info.HasMore || info.NextPageToken == nil || *info.NextPageToken != "2" {
+		t.Fatalf("pageInfo = %+v", info)
+	}
+
+	// The token picks up where the first page stopped
+	w = callHandler(t, s.ReadSheet, "po@example.org", ReadSheetRequest{Sheet: "Grants", Limit: &limit, PageToken: info.NextPageToken})
+	resp = ReadSheetResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if !reflect.DeepEqual(resp.Rows, [][]interface{}{{"G-3"}}) || resp.PageInfo.HasMore || resp.PageInfo.NextPageToken != nil {
+		t.Errorf("second page = %v, %+v", resp.Rows, resp.PageInfo)
+	}
+}
+
+func TestListFilesPageInfo(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/files", &drive.FileList{
+		Files:         []*drive.File{{Id: "doc-1", Name: "Proposal"}},
+		NextPageToken: "cursor-2",
+	})
+	s := newTestServer(t, f)
+	s.grantsFolderID = "grants"
+
+	pageSize := 1
+	w := callHandler(t, s.ListFiles, "po@example.org", ListFilesRequest{PageSize: &pageSize})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var resp ListFilesResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	info := resp.PageInfo
+	if info.Limit != 1 || !info.HasMore || info.NextPageToken == nil || *info.NextPageToken != "cursor-2" {
+		t.Errorf("pageInfo = %+v, want the same shape ReadSheet returns", info)
+	}
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// Sources a create handler can take its parent folder from
+const (
+	parentFromRequest = "request" // parentId in the request body
+	parentFromUser    = "user"    // default_parent.<email> in the Config tab
+	parentFromGrants  = "grants"  // discovered Grants folder
+	parentFromRoot    = "root"    // ROOT_FOLDER_ID
+)
+
+// defaultParentOrder is where un-parented creations land unless PARENT_RESOLUTION_ORDER says otherwise
+var defaultParentOrder = []string{parentFromRequest, parentFromGrants}
+
+// userParentKeyPrefix prefixes a user's email to form their Config tab default parent key
+const userParentKeyPrefix = "default_parent."
+
+// parseParentOrder parses a comma-separated list of parent sources
+func parseParentOrder(spec string) ([]string, error) {
+	var order []string
+	for _, src := range strings.Split(spec, ",") {
+		switch src = strings.TrimSpace(src); src {
+		case parentFromRequest, parentFromUser, parentFromGrants, parentFromRoot:
+			order = append(order, src)
+		default:
+			return nil, fmt.Errorf("unknown parent source %q (want request, user, grants, or root)", src)
+		}
+	}
+	return order, nil
+}
+
+// resolveParent picks the parent folder for a create request by walking the
+// configured sources in order and returning the first one that is set
+func (s *Server) resolveParent(r *http.Request, requested *string) (string, error) {
+	for _, src := range s.parentOrder {
+		switch src {
+		case parentFromRequest:
+			if requested != nil && *requested != "" {
+				return *requested, nil
+			}
+		case parentFromUser:
+			if id := s.userDefaultParent(r); id != "" {
+				return id, nil
+			}
+		case parentFromGrants:
+			if id := s.discoveredGrantsFolderID(); id != "" {
+				return id, nil
+			}
+		case parentFromRoot:
+			if s.rootFolderID != "" {
+				return s.rootFolderID, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no parent folder: set parentId (tried %s)", strings.Join(s.parentOrder, ", "))
+}
+
+// userDefaultParent returns the requesting user's default parent folder from the Config tab, if any
+func (s *Server) userDefaultParent(r *http.Request) string {
+	email := r.Header.Get("X-User-Email")
+	if email == "" {
+		return ""
+	}
+	spreadsheetID, err := s.spreadsheetFor(r)
+	if err != nil {
+		return ""
+	}
+	srv, err := s.sheetsService(r.Context())
+	if err != nil {
+		log.Printf("[API] Default parent: %v", err)
+		return ""
+	}
+	value, _, err := configValue(r.Context(), srv, spreadsheetID, userParentKeyPrefix+email)
+	if err != nil {
+		log.Printf("[API] Default parent: could not read Config tab: %v", err)
+		return ""
+	}
+	return strings.TrimSpace(value)
+}
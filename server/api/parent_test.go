@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestResolveParent(t *testing.T) {
+	tests := []struct {
+		name      string
+		order     string
+		requested string
+		user      string
+		want      string
+		wantErr   bool
+	}{
+		{name: "default order uses the request", requested: "req-folder", want: "req-folder"},
+		{name: "default order falls back to Grants", want: "grants"},
+		{name: "default order skips the user default", user: "po@example.org", want: "grants"},
+		{name: "user default before Grants", order: "request, user, grants", user: "po@example.org", want: "user-folder"},
+		{name: "user without a default", order: "user, grants", user: "other@example.org", want: "grants"},
+		{name: "anonymous caller", order: "user, grants", want: "grants"},
+		{name: "root before Grants", order: "root, grants", want: "root"},
+		{name: "request ignored when not listed", order: "grants", requested: "req-folder", want: "grants"},
+		{name: "nothing set", order: "request", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PARENT_RESOLUTION_ORDER", tt.order)
+			f := newFakeGoogle(t)
+			f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Config!A:B", &sheets.ValueRange{Values: [][]interface{}{
+				{"key", "value"},
+				{userParentKeyPrefix + "po@example.org", " user-folder "},
+			}})
+			s := newTestServer(t, f)
+			s.grantsFolderID = "grants"
+			s.rootFolderID = "root"
+
+			r, _ := http.NewRequest(http.MethodPost, "/api/drive/create-folder", nil)
+			if tt.user != "" {
+				r.Header.Set("X-User-Email", tt.user)
+			}
+			var requested *string
+			if tt.requested != "" {
+				requested = &tt.requested
+			}
+			got, err := s.resolveParent(r, requested)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveParent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveParent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseParentOrder(t *testing.T) {
+	if _, err := parseParentOrder("request, home"); err == nil {
+		t.Error("parseParentOrder accepted an unknown source")
+	}
+	t.Setenv("PARENT_RESOLUTION_ORDER", "request, nowhere")
+	if _, err := NewServer("test-client"); err == nil {
+		t.Error("NewServer accepted an invalid PARENT_RESOLUTION_ORDER")
+	}
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+)
+
+// appBaseURL returns the app's public origin: PUBLIC_URL when set, otherwise
+// the origin this request came in on if ALLOWED_ORIGIN lists it. The Host
+// header is the client's to choose, so links are never built from it alone.
+func (s *Server) appBaseURL(r *http.Request) (string, error) {
+	if s.publicURL != "" {
+		return s.publicURL, nil
+	}
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	origin := scheme + "://" + r.Host
+	if !s.linkOrigins[origin] {
+		return "", errors.New("Server configuration error: PUBLIC_URL not set, and the request's origin isn't in ALLOWED_ORIGIN")
+	}
+	return origin, nil
+}
+
+// grantPermalink is the app URL of a grant's detail view (see web/src/lib/router.svelte.js)
+func grantPermalink(base, grantID string) string {
+	return base + "/#/grant/" + url.PathEscape(grantID)
+}
+
+// GetGrantPermalink returns a shareable link to a grant plus its Drive folder
+func (s *Server) GetGrantPermalink(w http.ResponseWriter, r *http.Request) {
+	var req GrantPermalinkRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.GrantId == "" {
+		writeError(w, "grantId is required", http.StatusBadRequest)
+		return
+	}
+
+	base, err := s.appBaseURL(r)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	result := GrantPermalinkResponse{Url: grantPermalink(base, req.GrantId)}
+
+	srv, err := s.driveService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Drive service: %v", err)
+		writeError(w, "Failed to connect to Google Drive", http.StatusInternalServerError)
+		return
+	}
+
+	folder, err := s.findGrantFolder(r.Context(), srv, req.GrantId)
+	if isCancelled(err) {
+		writeCancelled(w, "GetGrantPermalink")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to find grant folder: %v", err)
+		writeError(w, fmt.Sprintf("Failed to find grant folder: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if folder != nil {
+		result.FolderId = &folder.Id
+		result.FolderUrl = &folder.WebViewLink
+	}
+
+	writeJSON(w, result)
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+)
+
+// serveTaggedGrantFolder fakes Drive holding a grant folder tagged with G-1
+func serveTaggedGrantFolder(f *fakeGoogle) {
+	f.handle(http.MethodGet, "/files", func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Query().Get("q"), "'G-1'") {
+			writeFakeJSON(w, &drive.FileList{Files: []*drive.File{{
+				Id:            "folder-g1",
+				Name:          "Packaging",
+				MimeType:      folderMimeType,
+				WebViewLink:   "https://drive.example/folder-g1",
+				AppProperties: map[string]string{grantIDProperty: "G-1"},
+			}}})
+			return
+		}
+		writeFakeJSON(w, &drive.FileList{})
+	})
+}
+
+func TestGetGrantPermalink(t *testing.T) {
+	tests := []struct {
+		name          string
+		grantID       string
+		wantURL       string
+		wantFolderURL string
+	}{
+		{name: "grant with a folder", grantID: "G-1", wantURL: "https://grants.example.org/#/grant/G-1", wantFolderURL: "https://drive.example/folder-g1"},
+		{name: "grant without a folder", grantID: "G-2", wantURL: "https://grants.example.org/#/grant/G-2"},
+		{name: "ID needing escaping", grantID: "G 3/a", wantURL: "https://grants.example.org/#/grant/G%203%2Fa"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PUBLIC_URL", "https://grants.example.org/")
+			f := newFakeGoogle(t)
+			serveTaggedGrantFolder(f)
+			s := newTestServer(t, f)
+			s.grantsFolderID = "grants"
+
+			w := callHandler(t, s.GetGrantPermalink, "po@example.org", GrantPermalinkRequest{GrantId: tt.grantID})
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", w.Code, w.Body)
+			}
+			var resp GrantPermalinkResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if resp.Url != tt.wantURL {
+				t.Errorf("url = %q, want %q", resp.Url, tt.wantURL)
+			}
+			folderURL := ""
+			if resp.FolderUrl != nil {
+				folderURL = *resp.FolderUrl
+			}
+			if folderURL != tt.wantFolderURL {
+				t.Errorf("folderUrl = %q, want %q", folderURL, tt.wantFolderURL)
+			}
+		})
+	}
+}
+
+func TestGetGrantPermalinkWithoutPublicURL(t *testing.T) {
+	tests := []struct {
+		name          string
+		allowedOrigin string
+		wantCode      int
+		wantURL       string
+	}{
+		{name: "request origin allowed", allowedOrigin: "http://example.com", wantCode: http.StatusOK, wantURL: "http://example.com/#/grant/G-1"},
+		{name: "request origin not allowed", allowedOrigin: "https://grants.example.org", wantCode: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PUBLIC_URL", "")
+			t.Setenv("ALLOWED_ORIGIN", tt.allowedOrigin)
+			f := newFakeGoogle(t)
+			serveTaggedGrantFolder(f)
+			s := newTestServer(t, f)
+			s.grantsFolderID = "grants"
+
+			// httptest requests arrive on example.com
+			w := callHandler(t, s.GetGrantPermalink, "po@example.org", GrantPermalinkRequest{GrantId: "G-1"})
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body)
+			}
+			if tt.wantURL == "" {
+				return
+			}
+			var resp GrantPermalinkResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if resp.Url != tt.wantURL {
+				t.Errorf("url = %q, want %q", resp.Url, tt.wantURL)
+			}
+		})
+	}
+}
@@ -0,0 +1,352 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/mail"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/googleapi"
+)
+
+// permissionFields are the permission attributes read from Drive
+const permissionFields = "id,emailAddress,domain,role,type,displayName"
+
+// listAllPermissions returns every permission on a file, following nextPageToken
+func listAllPermissions(ctx context.Context, srv *drive.Service, fileID string) ([]*drive.Permission, error) {
+	var perms []*drive.Permission
+	pageToken := ""
+	for {
+		call := srv.Permissions.List(fileID).
+			SupportsAllDrives(true).
+			Fields("nextPageToken, permissions(" + permissionFields + ")").
+			PageSize(100).
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, err
+		}
+		perms = append(perms, resp.Permissions...)
+
+		if resp.NextPageToken == "" {
+			return perms, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+// maskEmail keeps the first character of the local part and the domain ("j***@example.com")
+func maskEmail(email string) string {
+	parts := splitEmail(email)
+	if len(parts) != 2 || parts[0] == "" {
+		return maskString(email)
+	}
+	return parts[0][:1] + "***@" + parts[1]
+}
+
+// ListPermissions returns who has access to a file or folder
+func (s *Server) ListPermissions(w http.ResponseWriter, r *http.Request) {
+	var req ListPermissionsRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if strings.TrimSpace(req.FileId) == "" {
+		writeError(w, "fileId is required", http.StatusBadRequest)
+		return
+	}
+
+	srv, err := s.driveService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Drive service: %v", err)
+		writeError(w, "Failed to connect to Google Drive", http.StatusInternalServerError)
+		return
+	}
+
+	if !s.requireInScope(w, r, srv, "ListPermissions", req.FileId) {
+		return
+	}
+
+	perms, err := listAllPermissions(r.Context(), srv, req.FileId)
+	if isCancelled(err) {
+		writeCancelled(w, "ListPermissions")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to list permissions: %v", err)
+		writeError(w, fmt.Sprintf("Failed to list permissions: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	result := ListPermissionsResponse{Permissions: make([]Permission, 0, len(perms))}
+	for _, p := range perms {
+		perm := Permission{Id: p.Id, Role: p.Role, Type: p.Type}
+		if p.EmailAddress != "" {
+			email := p.EmailAddress
+			if !s.exposePermissionEmails {
+				email = maskEmail(email)
+			}
+			perm.EmailAddress = &email
+		}
+		if p.Domain != "" {
+			perm.Domain = &p.Domain
+		}
+		if p.DisplayName != "" {
+			perm.DisplayName = &p.DisplayName
+		}
+		result.Permissions = append(result.Permissions, perm)
+	}
+
+	s.auditRead(r, AuditEvent{
+		Action:   "list_permissions",
+		Resource: req.FileId,
+		Detail:   fmt.Sprintf("listed %d permissions on %s", len(result.Permissions), req.FileId),
+	})
+
+	writeJSON(w, result)
+}
+
+// TransferOwnership makes another user the owner of a My Drive file or folder
+func (s *Server) TransferOwnership(w http.ResponseWriter, r *http.Request) {
+	var req TransferOwnershipRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if strings.TrimSpace(req.FileId) == "" {
+		writeError(w, "fileId is required", http.StatusBadRequest)
+		return
+	}
+	email := req.NewOwnerEmail
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		writeError(w, fmt.Sprintf("Invalid email address %q", email), http.StatusBadRequest)
+		return
+	}
+
+	srv, err := s.driveService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Drive service: %v", err)
+		writeError(w, "Failed to connect to Google Drive", http.StatusInternalServerError)
+		return
+	}
+
+	file, err := srv.Files.Get(req.FileId).
+		SupportsAllDrives(true).
+		Fields("id, name, driveId").
+		Context(r.Context()).
+		Do()
+	if err != nil {
+		log.Printf("Failed to get file: %v", err)
+		writeError(w, fmt.Sprintf("Failed to get file: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if file.DriveId != "" {
+		writeError(w, "Files in a Shared Drive are owned by the drive; ownership cannot be transferred", http.StatusBadRequest)
+		return
+	}
+	if !s.requireInGrantsTree(w, r, srv, "TransferOwnership", file.Id) {
+		return
+	}
+
+	perms, err := listAllPermissions(r.Context(), srv, req.FileId)
+	if err != nil {
+		log.Printf("Failed to list permissions: %v", err)
+		writeError(w, fmt.Sprintf("Failed to list permissions: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	// Promote an existing grant for the user, or create one, with transferOwnership set
+	var existing *drive.Permission
+	for _, p := range perms {
+		if p.Type == "user" && strings.EqualFold(p.EmailAddress, email) {
+			existing = p
+			break
+		}
+	}
+	if existing != nil && existing.Role == "owner" {
+		writeError(w, fmt.Sprintf("%s already owns this file", email), http.StatusBadRequest)
+		return
+	}
+
+	if existing != nil {
+		_, err = srv.Permissions.Update(req.FileId, existing.Id, &drive.Permission{Role: "owner"}).
+			TransferOwnership(true).
+			Context(r.Context()).
+			Do()
+	} else {
+		_, err = srv.Permissions.Create(req.FileId, &drive.Permission{
+			Type:         "user",
+			Role:         "owner",
+			EmailAddress: email,
+		}).
+			TransferOwnership(true).
+			Context(r.Context()).
+			Do()
+	}
+	if err != nil {
+		log.Printf("Failed to transfer ownership: %v", err)
+		writeError(w, fmt.Sprintf("Failed to transfer ownership: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	s.audit(r, AuditEvent{
+		Action:   "transfer_ownership",
+		Resource: req.FileId,
+		Target:   email,
+		Detail:   fmt.Sprintf("transferred ownership of %s (%s) to %s", file.Name, req.FileId, email),
+	})
+
+	writeJSON(w, SuccessResponse{Success: true})
+}
+
+// ShareFile gives a user reader or writer access to a file or folder below
+// the Grants folder, e.g. a reviewer on one grant's folder
+func (s *Server) ShareFile(w http.ResponseWriter, r *http.Request) {
+	var req ShareFileRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if strings.TrimSpace(req.FileId) == "" {
+		writeError(w, "fileId is required", http.StatusBadRequest)
+		return
+	}
+	email := req.EmailAddress
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		writeError(w, fmt.Sprintf("Invalid email address %q", email), http.StatusBadRequest)
+		return
+	}
+	switch req.Role {
+	case Reader, Writer:
+	default:
+		writeError(w, fmt.Sprintf("Unknown role %q (want reader or writer)", req.Role), http.StatusBadRequest)
+		return
+	}
+	role := string(req.Role)
+
+	srv, err := s.driveService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Drive service: %v", err)
+		writeError(w, "Failed to connect to Google Drive", http.StatusInternalServerError)
+		return
+	}
+
+	file, err := srv.Files.Get(req.FileId).
+		Fields("id, name, parents").
+		SupportsAllDrives(true).
+		Context(r.Context()).
+		Do()
+	var apiErr *googleapi.Error
+	switch {
+	case isCancelled(err):
+		writeCancelled(w, "ShareFile")
+		return
+	case errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound:
+		writeError(w, fmt.Sprintf("File %s not found", req.FileId), http.StatusNotFound)
+		return
+	case err != nil:
+		log.Printf("Failed to get file: %v", err)
+		writeError(w, fmt.Sprintf("Failed to get file: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	// Sharing the Grants folder itself, or anything above it, would expose
+	// every grant, so only its descendants qualify
+	place, err := s.locateInTree(r.Context(), srv, file.Parents)
+	if isCancelled(err) {
+		writeCancelled(w, "ShareFile")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to check file location: %v", err)
+		writeError(w, fmt.Sprintf("Failed to check file location: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if !place.grants {
+		writeError(w, fmt.Sprintf("File %s is not inside the Grants folder", req.FileId), http.StatusForbidden)
+		return
+	}
+
+	perms, err := listAllPermissions(r.Context(), srv, file.Id)
+	if isCancelled(err) {
+		writeCancelled(w, "ShareFile")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to list permissions: %v", err)
+		writeError(w, fmt.Sprintf("Failed to list permissions: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	// Change an existing grant for the user rather than stacking a second one
+	var existing *drive.Permission
+	for _, p := range perms {
+		if p.Type == "user" && strings.EqualFold(p.EmailAddress, email) {
+			existing = p
+			break
+		}
+	}
+	if existing != nil && existing.Role != "reader" && existing.Role != "commenter" && existing.Role != "writer" {
+		writeError(w, fmt.Sprintf("%s already has %s access; change it in Drive", email, existing.Role), http.StatusBadRequest)
+		return
+	}
+
+	result := ShareFileResponse{Role: role}
+	var verb string
+	switch {
+	case existing != nil && existing.Role == role:
+		result.PermissionId = existing.Id
+		verb = "kept"
+	case existing != nil:
+		_, err = srv.Permissions.Update(file.Id, existing.Id, &drive.Permission{Role: role}).
+			SupportsAllDrives(true).
+			Context(r.Context()).
+			Do()
+		result.PermissionId = existing.Id
+		verb = fmt.Sprintf("changed from %s to", existing.Role)
+	default:
+		var perm *drive.Permission
+		perm, err = srv.Permissions.Create(file.Id, &drive.Permission{
+			Type:         "user",
+			Role:         role,
+			EmailAddress: email,
+		}).
+			SendNotificationEmail(req.SendNotificationEmail).
+			SupportsAllDrives(true).
+			Context(r.Context()).
+			Do()
+		if err == nil {
+			result.PermissionId = perm.Id
+			result.Created = true
+		}
+		verb = "granted"
+	}
+	if isCancelled(err) {
+		writeCancelled(w, "ShareFile")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to share file: %v", err)
+		writeError(w, fmt.Sprintf("Failed to share file: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	s.audit(r, AuditEvent{
+		Action:   "share_file",
+		Resource: file.Id,
+		Target:   email,
+		Detail:   fmt.Sprintf("%s %s access on %s (%s) for %s", verb, role, file.Name, file.Id, email),
+	})
+
+	writeJSON(w, result)
+}
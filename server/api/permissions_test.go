@@ -0,0 +1,160 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+)
+
+// servePermissionPages fakes a file's permissions split over two pages
+func servePermissionPages(f *fakeGoogle, fileID string) {
+	f.handle(http.MethodGet, "/files/"+fileID+"/permissions", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("pageToken") == "" {
+			writeFakeJSON(w, &drive.PermissionList{
+				Permissions: []*drive.Permission{
+					{Id: "p-1", Role: "owner", Type: "user", EmailAddress: "jane@example.org", DisplayName: "Jane"},
+					{Id: "p-2", Role: "writer", Type: "group", EmailAddress: "team@example.org"},
+				},
+				NextPageToken: "page-2",
+			})
+			return
+		}
+		writeFakeJSON(w, &drive.PermissionList{
+			Permissions: []*drive.Permission{{Id: "p-3", Role: "reader", Type: "domain", Domain: "example.org"}},
+		})
+	})
+}
+
+func TestListPermissions(t *testing.T) {
+	tests := []struct {
+		name       string
+		expose     string
+		wantEmails []string
+	}{
+		{name: "masked by default", wantEmails: []string{"j***@example.org", "t***@example.org"}},
+		{name: "EXPOSE_PERMISSION_EMAILS=true", expose: "true", wantEmails: []string{"jane@example.org", "team@example.org"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("EXPOSE_PERMISSION_EMAILS", tt.expose)
+			f := newFakeGoogle(t)
+			servePermissionPages(f, "folder-1")
+			s := newTestServer(t, f)
+
+			w := callHandler(t, s.ListPermissions, "admin@example.org", ListPermissionsRequest{FileId: "folder-1"})
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", w.Code, w.Body)
+			}
+			var resp ListPermissionsResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if len(resp.Permissions) != 3 {
+				t.Fatalf("permissions = %+v, want both pages", resp.Permissions)
+			}
+			var emails []string
+			for _, p := range resp.Permissions {
+				if p.EmailAddress != nil {
+					emails = append(emails, *p.EmailAddress)
+				}
+			}
+			if !reflect.DeepEqual(emails, tt.wantEmails) {
+				t.Errorf("emails = %v, want %v", emails, tt.wantEmails)
+			}
+			domain := resp.Permissions[2]
+			if domain.Role != "reader" || domain.Type != "domain" || domain.Domain == nil || *domain.Domain != "example.org" {
+				t.Errorf("domain permission = %+v", domain)
+			}
+			if calls := f.calls(http.MethodGet, "/files/folder-1/permissions"); len(calls) != 2 {
+				t.Errorf("listed %d pages, want 2", len(calls))
+			}
+		})
+	}
+}
+
+func TestMaskEmail(t *testing.T) {
+	for in, want := range map[string]string{
+		"jane@example.org": "j***@example.org",
+		"j@example.org":    "j***@example.org",
+		"not-an-email":     maskString("not-an-email"),
+	} {
+		if got := maskEmail(in); got != want {
+			t.Errorf("maskEmail(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestTransferOwnership(t *testing.T) {
+	tests := []struct {
+		name        string
+		newOwner    string
+		driveID     string
+		wantCode    int
+		wantMethod  string
+		wantPath    string
+		wantRequest drive.Permission
+	}{
+		{
+			name: "new grantee", newOwner: "new@example.org", wantCode: http.StatusOK,
+			wantMethod: http.MethodPost, wantPath: "/files/file-1/permissions",
+			wantRequest: drive.Permission{Type: "user", Role: "owner", EmailAddress: "new@example.org"},
+		},
+		{
+			name: "existing writer is promoted", newOwner: "Writer@example.org", wantCode: http.StatusOK,
+			wantMethod: http.MethodPatch, wantPath: "/files/file-1/permissions/p-2",
+			wantRequest: drive.Permission{Role: "owner"},
+		},
+		{name: "already the owner", newOwner: "owner@example.org", wantCode: http.StatusBadRequest},
+		{name: "invalid email", newOwner: "Someone <new@example.org>", wantCode: http.StatusBadRequest},
+		{name: "file on a Shared Drive", newOwner: "new@example.org", driveID: "drive-1", wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeGoogle(t)
+			f.reply(http.MethodGet, "/files/file-1", &drive.File{Id: "file-1", Name: "Budget", DriveId: tt.driveID, Parents: []string{"grants"}})
+			f.reply(http.MethodGet, "/files/file-1/permissions", &drive.PermissionList{Permissions: []*drive.Permission{
+				{Id: "p-1", Role: "owner", Type: "user", EmailAddress: "owner@example.org"},
+				{Id: "p-2", Role: "writer", Type: "user", EmailAddress: "writer@example.org"},
+			}})
+			f.replyFolder("grants", "Grants", "root")
+			transfer := func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("transferOwnership") != "true" {
+					t.Errorf("%s %s without transferOwnership=true", r.Method, r.URL.Path)
+				}
+				writeFakeJSON(w, &drive.Permission{Id: "p-3"})
+			}
+			f.handle(http.MethodPost, "/files/file-1/permissions", transfer)
+			f.handle(http.MethodPatch, "/files/file-1/permissions/p-2", transfer)
+			s := newTestServer(t, f)
+			s.grantsFolderID = "grants"
+
+			w := callHandler(t, s.TransferOwnership, "admin@example.org", TransferOwnershipRequest{FileId: "file-1", NewOwnerEmail: tt.newOwner})
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body)
+			}
+			created := f.calls(http.MethodPost, "/files/file-1/permissions")
+			updated := f.calls(http.MethodPatch, "/files/file-1/permissions/p-2")
+			if tt.wantCode != http.StatusOK {
+				if len(created)+len(updated) != 0 {
+					t.Errorf("changed permissions on a rejected transfer")
+				}
+				return
+			}
+
+			calls := f.calls(tt.wantMethod, tt.wantPath)
+			if len(calls) != 1 || len(created)+len(updated) != 1 {
+				t.Fatalf("sent %d creates and %d updates, want one %s %s", len(created), len(updated), tt.wantMethod, tt.wantPath)
+			}
+			var got drive.Permission
+			if err := json.Unmarshal(calls[0].body, &got); err != nil {
+				t.Fatalf("decode permission: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.wantRequest) {
+				t.Errorf("permission = %+v, want %+v", got, tt.wantRequest)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// pivotCell accumulates the values aggregated into one pivot cell
+type pivotCell struct {
+	count    int // Rows in the cell
+	numeric  int // Rows with a numeric value
+	sum      float64
+	min, max float64
+}
+
+func (c *pivotCell) add(v interface{}) {
+	c.count++
+	n, ok := numericValue(v)
+	if !ok {
+		return
+	}
+	if c.numeric == 0 || n < c.min {
+		c.min = n
+	}
+	if c.numeric == 0 || n > c.max {
+		c.max = n
+	}
+	c.numeric++
+	c.sum += n
+}
+
+// result returns the cell's aggregate, or nil if agg has nothing to work with
+func (c *pivotCell) result(agg PivotRequestAgg) *float64 {
+	var v float64
+	switch agg {
+	case Count:
+		v = float64(c.count)
+	case Sum:
+		if c.numeric == 0 {
+			return nil
+		}
+		v = c.sum
+	case Avg:
+		if c.numeric == 0 {
+			return nil
+		}
+		v = c.sum / float64(c.numeric)
+	case Min:
+		if c.numeric == 0 {
+			return nil
+		}
+		v = c.min
+	case Max:
+		if c.numeric == 0 {
+			return nil
+		}
+		v = c.max
+	}
+	return &v
+}
+
+// numericValue reads a cell as a number, accepting formatted text like "$1,200"
+func numericValue(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case string:
+		clean := strings.NewReplacer("$", "", ",", "", " ", "").Replace(n)
+		if clean == "" {
+			return 0, false
+		}
+		f, err := strconv.ParseFloat(clean, 64)
+		return f, err == nil && !math.IsNaN(f) && !math.IsInf(f, 0)
+	}
+	return 0, false
+}
+
+// pivotKeySep joins a group's column values joined with a separator that can't appear in cell text
+const pivotKeySep = "\x00"
+
+// Pivot aggregates a sheet along two sets of columns
+func (s *Server) Pivot(w http.ResponseWriter, r *http.Request) {
+	var req PivotRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.Sheet == "" || len(req.Rows) == 0 {
+		writeError(w, "Sheet and rows are required", http.StatusBadRequest)
+		return
+	}
+	if refuseInternalSheet(w, req.Sheet) {
+		return
+	}
+	switch req.Agg {
+	case Count, Sum, Avg, Min, Max:
+	default:
+		writeError(w, fmt.Sprintf("Unknown agg %q (want count, sum, avg, min, or max)", req.Agg), http.StatusBadRequest)
+		return
+	}
+	var cols []string
+	if req.Cols != nil {
+		cols = *req.Cols
+	}
+	value := ""
+	if req.Value != nil {
+		value = *req.Value
+	}
+	if value == "" && req.Agg != Count {
+		writeError(w, fmt.Sprintf("value is required for %s", req.Agg), http.StatusBadRequest)
+		return
+	}
+
+	spreadsheetID, err := s.spreadsheetFor(r)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	srv, err := s.sheetsService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Sheets service: %v", err)
+		writeError(w, "Failed to connect to Google Sheets", http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := withRetry(r.Context(), s.retryAttempts, true, func() (*sheets.ValueRange, error) {
+		return srv.Spreadsheets.Values.Get(spreadsheetID, req.Sheet).
+			ValueRenderOption("UNFORMATTED_VALUE").Context(r.Context()).Do()
+	})
+	if isCancelled(err) {
+		writeCancelled(w, "Pivot")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to read sheet: %v", err)
+		writeError(w, "Failed to read sheet", http.StatusInternalServerError)
+		return
+	}
+	table := splitTable(resp.Values, s.headerRow(req.Sheet))
+
+	rowIdx, status, err := s.readableColumns(r, table, req.Rows)
+	if err != nil {
+		writeError(w, err.Error(), status)
+		return
+	}
+	colIdx, status, err := s.readableColumns(r, table, cols)
+	if err != nil {
+		writeError(w, err.Error(), status)
+		return
+	}
+	valueIdx := -1
+	if value != "" {
+		idx, status, err := s.readableColumns(r, table, []string{value})
+		if err != nil {
+			writeError(w, err.Error(), status)
+			return
+		}
+		valueIdx = idx[0]
+	}
+
+	writeJSON(w, pivotTable(table.rows, rowIdx, colIdx, valueIdx, req.Agg))
+}
+
+// readableColumns resolves column names to indices, refusing unknown columns
+// (400) and sensitive ones the requesting user may not see (403)
+func (s *Server) readableColumns(r *http.Request, table sheetTable, names []string) ([]int, int, error) {
+	idx := make([]int, len(names))
+	for i, name := range names {
+		if idx[i] = table.indexOf(name); idx[i] == -1 {
+			return nil, http.StatusBadRequest, fmt.Errorf("Column %s not found", name)
+		}
+		if s.sensitiveColumns[name] && !s.canSeeSensitive(r) {
+			return nil, http.StatusForbidden, fmt.Errorf("insufficient permission to read column %s", name)
+		}
+	}
+	return idx, 0, nil
+}
+
+// pivotTable groups rows by the rowIdx and colIdx columns and aggregates valueIdx
+// (-1 for count) per group. Keys are sorted; missing combinations are nil.
+func pivotTable(rows [][]interface{}, rowIdx, colIdx []int, valueIdx int, agg PivotRequestAgg) PivotResponse {
+	keyOf := func(row []interface{}, idx []int) []string {
+		key := make([]string, len(idx))
+		for i, c := range idx {
+			if c < len(row) {
+				key[i] = cellString(row[c])
+			}
+		}
+		return key
+	}
+
+	cells := make(map[string]map[string]*pivotCell)
+	rowKeys := make(map[string][]string)
+	colKeys := make(map[string][]string)
+	for _, row := range rows {
+		if len(row) == 0 {
+			continue
+		}
+		rk, ck := keyOf(row, rowIdx), keyOf(row, colIdx)
+		rj, cj := strings.Join(rk, pivotKeySep), strings.Join(ck, pivotKeySep)
+		rowKeys[rj], colKeys[cj] = rk, ck
+		if cells[rj] == nil {
+			cells[rj] = make(map[string]*pivotCell)
+		}
+		cell := cells[rj][cj]
+		if cell == nil {
+			cell = &pivotCell{}
+			cells[rj][cj] = cell
+		}
+		var v interface{}
+		if valueIdx != -1 && valueIdx < len(row) {
+			v = row[valueIdx]
+		}
+		cell.add(v)
+	}
+
+	sortedKeys := func(keys map[string][]string) []string {
+		joined := make([]string, 0, len(keys))
+		for k := range keys {
+			joined = append(joined, k)
+		}
+		sort.Strings(joined)
+		return joined
+	}
+	rowOrder, colOrder := sortedKeys(rowKeys), sortedKeys(colKeys)
+
+	result := PivotResponse{
+		RowKeys: make([][]string, len(rowOrder)),
+		ColKeys: make([][]string, len(colOrder)),
+		Values:  make([][]*float64, len(rowOrder)),
+	}
+	for j, ck := range colOrder {
+		result.ColKeys[j] = colKeys[ck]
+	}
+	for i, rk := range rowOrder {
+		result.RowKeys[i] = rowKeys[rk]
+		result.Values[i] = make([]*float64, len(colOrder))
+		for j, ck := range colOrder {
+			if cell := cells[rk][ck]; cell != nil {
+				result.Values[i][j] = cell.result(agg)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// pivotGrants has amounts by status and year, with no Closed grants in 2026
+var pivotGrants = [][]interface{}{
+	{"grant_id", "status", "year", "amount"},
+	{"G-1", "Active", float64(2025), float64(1000)},
+	{"G-2", "Active", float64(2025), "$1,200"},
+	{"G-3", "Active", float64(2026), float64(500)},
+	{"G-4", "Closed", float64(2025), float64(300)},
+	{"G-5", "Closed", float64(2025), "n/a"},
+}
+
+// pivot calls Pivot as a user holding role and decodes a successful response
+func pivot(t *testing.T, s *Server, role string, req PivotRequest) (PivotResponse, int) {
+	t.Helper()
+	w := callHandlerAs(t, s.Pivot, "po@example.org", role, req)
+	var resp PivotResponse
+	if w.Code == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+	}
+	return resp, w.Code
+}
+
+func TestPivotStatusByYear(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: pivotGrants})
+	s := newTestServer(t, f)
+
+	num := func(v float64) *float64 { return &v }
+	cols := []string{"year"}
+	value := "amount"
+	tests := []struct {
+		agg  PivotRequestAgg
+		want [][]*float64
+	}{
+		{agg: Sum, want: [][]*float64{{num(2200), num(500)}, {num(300), nil}}},
+		{agg: Count, want: [][]*float64{{num(2), num(1)}, {num(2), nil}}},
+		{agg: Avg, want: [][]*float64{{num(1100), num(500)}, {num(300), nil}}},
+		{agg: Max, want: [][]*float64{{num(1200), num(500)}, {num(300), nil}}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.agg), func(t *testing.T) {
+			resp, code := pivot(t, s, "", PivotRequest{Sheet: "Grants", Rows: []string{"status"}, Cols: &cols, Value: &value, Agg: tt.agg})
+			if code != http.StatusOK {
+				t.Fatalf("status = %d", code)
+			}
+			if want := [][]string{{"Active"}, {"Closed"}}; !reflect.DeepEqual(resp.RowKeys, want) {
+				t.Errorf("row keys = %v, want %v", resp.RowKeys, want)
+			}
+			if want := [][]string{{"2025"}, {"2026"}}; !reflect.DeepEqual(resp.ColKeys, want) {
+				t.Errorf("col keys = %v, want %v", resp.ColKeys, want)
+			}
+			if !reflect.DeepEqual(resp.Values, tt.want) {
+				t.Errorf("values = %v, want %v", printPivot(resp.Values), printPivot(tt.want))
+			}
+		})
+	}
+}
+
+// printPivot renders pivot values for failure messages
+func printPivot(values [][]*float64) [][]interface{} {
+	out := make([][]interface{}, len(values))
+	for i, row := range values {
+		for _, v := range row {
+			if v == nil {
+				out[i] = append(out[i], nil)
+			} else {
+				out[i] = append(out[i], *v)
+			}
+		}
+	}
+	return out
+}
+
+func TestPivotRejects(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: pivotGrants})
+	s := newTestServer(t, f)
+	s.sensitiveColumns = map[string]bool{"amount": true}
+	s.sensitiveMinRole = "organizer"
+
+	value := "amount"
+	missing := "budget"
+	tests := []struct {
+		name string
+		role string
+		req  PivotRequest
+		want int
+	}{
+		{name: "unknown agg", req: PivotRequest{Sheet: "Grants", Rows: []string{"status"}, Agg: "median"}, want: http.StatusBadRequest},
+		{name: "sum without a value", req: PivotRequest{Sheet: "Grants", Rows: []string{"status"}, Agg: Sum}, want: http.StatusBadRequest},
+		{name: "unknown column", req: PivotRequest{Sheet: "Grants", Rows: []string{"status"}, Value: &missing, Agg: Sum}, want: http.StatusBadRequest},
+		{name: "hidden value column", role: "writer", req: PivotRequest{Sheet: "Grants", Rows: []string{"status"}, Value: &value, Agg: Sum}, want: http.StatusForbidden},
+		{name: "organizer sees the value column", role: "organizer", req: PivotRequest{Sheet: "Grants", Rows: []string{"status"}, Value: &value, Agg: Sum}, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, code := pivot(t, s, tt.role, tt.req); code != tt.want {
+				t.Errorf("status = %d, want %d", code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+)
+
+// docMimeType is the Drive MIME type of a Google Doc
+const docMimeType = "application/vnd.google-apps.document"
+
+// createTrackerDoc creates a grant's tracker doc in folderID, copying the
+// configured template when there is one
+func (s *Server) createTrackerDoc(ctx context.Context, srv *drive.Service, name, folderID string, appProperties map[string]string) (*drive.File, error) {
+	doc := &drive.File{
+		Name:          name,
+		MimeType:      docMimeType,
+		Parents:       []string{folderID},
+		AppProperties: appProperties,
+	}
+	return withRetry(ctx, s.retryAttempts, false, func() (*drive.File, error) {
+		if s.trackerTemplateID != "" {
+			return srv.Files.Copy(s.trackerTemplateID, doc).
+				Fields("id, webViewLink").
+				SupportsAllDrives(true).
+				Context(ctx).
+				Do()
+		}
+		return srv.Files.Create(doc).
+			Fields("id, webViewLink").
+			SupportsAllDrives(true).
+			Context(ctx).
+			Do()
+	})
+}
+
+// ProvisionGrantFolder sets up a new grant in one call: its folder under the
+// Grants folder, the subfolder layout, and an initialized tracker doc
+func (s *Server) ProvisionGrantFolder(w http.ResponseWriter, r *http.Request) {
+	var req ProvisionGrantFolderRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	req.GrantName = strings.TrimSpace(req.GrantName)
+	if req.GrantName == "" {
+		writeError(w, "grantName is required", http.StatusBadRequest)
+		return
+	}
+
+	spreadsheetID, err := s.spreadsheetFor(r)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	grantsFolderID := s.discoveredGrantsFolderID()
+	if grantsFolderID == "" {
+		writeError(w, "Grants folder not yet discovered", http.StatusServiceUnavailable)
+		return
+	}
+
+	srv, err := s.driveService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Drive service: %v", err)
+		writeError(w, "Failed to connect to Google Drive", http.StatusInternalServerError)
+		return
+	}
+
+	docsSrv, err := s.docsService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Docs service: %v", err)
+		writeError(w, "Failed to connect to Google Docs", http.StatusInternalServerError)
+		return
+	}
+
+	// The grant name is usually its ID, so it fills in for one the caller left out
+	grant := map[string]string{"ID": req.GrantName}
+	for k, v := range req.Grant {
+		grant[k] = v
+	}
+
+	subfolders := req.Subfolders
+	if len(subfolders) == 0 {
+		subfolders = s.grantSubfolders(r.Context(), spreadsheetID)
+	}
+
+	grantFolder, created, err := s.ensureGrantFolders(r, srv, req.GrantName, grant["ID"], grantsFolderID, subfolders)
+	if err != nil {
+		writeGrantFoldersError(w, grantFolder, err)
+		return
+	}
+
+	result := ProvisionGrantFolderResponse{
+		FolderId:   grantFolder.Id,
+		FolderUrl:  grantFolder.WebViewLink,
+		Subfolders: created,
+	}
+
+	doc, err := s.createTrackerDoc(r.Context(), srv, req.GrantName+" Tracker", grantFolder.Id, s.withCreator(r, nil))
+	if err != nil {
+		log.Printf("Failed to create tracker doc: %v", err)
+		writeError(w, fmt.Sprintf("Created grant folder %s but failed to create its tracker doc: %v", grantFolder.Id, err), http.StatusInternalServerError)
+		return
+	}
+	result.TrackerDocId = doc.Id
+	result.TrackerDocUrl = doc.WebViewLink
+
+	// Forced, so a section copied over from the template is replaced rather than kept
+	if _, err := initializeTrackerDoc(r.Context(), docsSrv, doc.Id, grant, nil, true); err != nil {
+		log.Printf("Failed to initialize tracker doc: %v", err)
+		writeError(w, fmt.Sprintf("Created grant folder %s and tracker doc %s but failed to initialize it: %v", grantFolder.Id, doc.Id, err), http.StatusInternalServerError)
+		return
+	}
+
+	s.audit(r, AuditEvent{
+		Action:   "provision_grant_folder",
+		Resource: grantFolder.Id,
+		Target:   req.GrantName,
+		Detail:   fmt.Sprintf("provisioned %s (%s) with %d subfolders and tracker doc %s", req.GrantName, grantFolder.Id, len(result.Subfolders), doc.Id),
+	})
+
+	writeJSON(w, result)
+}
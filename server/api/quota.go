@@ -0,0 +1,89 @@
+package api
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// usageWindowSeconds is how far back GetQuota reports Google API calls
+const usageWindowSeconds = 60
+
+// usageBucket counts the calls made during one second
+type usageBucket struct {
+	second      int64 // Unix second the counts belong to
+	calls       int
+	quotaErrors int
+}
+
+// apiUsage keeps a rolling per-second count of outgoing Google API calls and
+// the 429s they got back
+type apiUsage struct {
+	mu             sync.Mutex
+	buckets        [usageWindowSeconds]usageBucket
+	lastQuotaError time.Time
+}
+
+// record counts one call that finished at now with the given HTTP status
+func (u *apiUsage) record(status int, now time.Time) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	sec := now.Unix()
+	b := &u.buckets[sec%usageWindowSeconds]
+	if b.second != sec {
+		*b = usageBucket{second: sec}
+	}
+	b.calls++
+	if status == http.StatusTooManyRequests {
+		b.quotaErrors++
+		u.lastQuotaError = now
+	}
+}
+
+// totals returns the calls and quota errors in the window ending at now
+func (u *apiUsage) totals(now time.Time) (calls, quotaErrors int, lastQuotaError time.Time) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	oldest := now.Unix() - usageWindowSeconds + 1
+	for _, b := range u.buckets {
+		if b.second >= oldest {
+			calls += b.calls
+			quotaErrors += b.quotaErrors
+		}
+	}
+	return calls, quotaErrors, u.lastQuotaError
+}
+
+// usageTransport records every request the Google API clients send
+type usageTransport struct {
+	base  http.RoundTripper
+	usage *apiUsage
+}
+
+func (t *usageTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	t.usage.record(status, time.Now())
+	return resp, err
+}
+
+// GetQuota reports this instance's recent Google API call rate and quota
+// errors, so clients can slow down before requests start failing
+func (s *Server) GetQuota(w http.ResponseWriter, r *http.Request) {
+	calls, quotaErrors, last := s.apiUsage.totals(time.Now())
+
+	result := QuotaResponse{
+		WindowSeconds: usageWindowSeconds,
+		Calls:         calls,
+		QuotaErrors:   quotaErrors,
+	}
+	if !last.IsZero() {
+		result.LastQuotaErrorAt = &last
+	}
+	writeJSON(w, result)
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetQuotaCountsCallsThroughTransport(t *testing.T) {
+	status := http.StatusOK
+	google := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer google.Close()
+
+	s := newTestServer(t, nil)
+	client := &http.Client{Transport: &usageTransport{base: http.DefaultTransport, usage: &s.apiUsage}}
+	for i := 0; i < 5; i++ {
+		if i == 3 {
+			status = http.StatusTooManyRequests
+		}
+		resp, err := client.Get(google.URL)
+		if err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+		resp.Body.Close()
+	}
+
+	w := callHandler(t, s.GetQuota, "po@example.org", struct{}{})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var quota QuotaResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &quota); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if quota.Calls != 5 || quota.QuotaErrors != 2 {
+		t.Errorf("calls = %d, quotaErrors = %d, want 5 and 2", quota.Calls, quota.QuotaErrors)
+	}
+	if quota.WindowSeconds != usageWindowSeconds {
+		t.Errorf("windowSeconds = %d, want %d", quota.WindowSeconds, usageWindowSeconds)
+	}
+	if quota.LastQuotaErrorAt == nil {
+		t.Error("lastQuotaErrorAt not set after a 429")
+	}
+}
+
+func TestAPIUsageWindow(t *testing.T) {
+	var u apiUsage
+	now := time.Unix(1_700_000_000, 0)
+
+	u.record(http.StatusOK, now.Add(-2*usageWindowSeconds*time.Second))
+	u.record(http.StatusTooManyRequests, now.Add(-usageWindowSeconds*time.Second))
+	u.record(http.StatusOK, now.Add(-(usageWindowSeconds-1)*time.Second))
+	u.record(http.StatusOK, now)
+	u.record(http.StatusTooManyRequests, now)
+
+	calls, quotaErrors, last := u.totals(now)
+	if calls != 3 || quotaErrors != 1 {
+		t.Errorf("calls = %d, quotaErrors = %d, want 3 and 1", calls, quotaErrors)
+	}
+	if !last.Equal(now) {
+		t.Errorf("last quota error at %v, want %v", last, now)
+	}
+
+	if calls, _, _ := u.totals(now.Add(usageWindowSeconds * time.Second)); calls != 0 {
+		t.Errorf("calls a full window later = %d, want 0", calls)
+	}
+}
+
+func TestGetQuotaWithoutCalls(t *testing.T) {
+	s := newTestServer(t, nil)
+	w := callHandler(t, s.GetQuota, "po@example.org", struct{}{})
+
+	var quota QuotaResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &quota); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if quota.Calls != 0 || quota.QuotaErrors != 0 || quota.LastQuotaErrorAt != nil {
+		t.Errorf("got %+v, want no calls", quota)
+	}
+}
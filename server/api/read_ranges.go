@@ -0,0 +1,215 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// maxReadRanges caps how many ranges one ReadSheet call may batch
+const maxReadRanges = 50
+
+// sheetData splits values at headerRow and applies the same ID formatting as
+// every other read, dropping the hidden sheet columns (see hiddenSheetColumns).
+// colOffset is the sheet column of values[*][0], used to line values up with
+// hidden and to report each header's column letter.
+func (s *Server) sheetData(values [][]interface{}, headerRow, colOffset int, hidden map[int]bool) ([]string, [][]interface{}, map[string]string) {
+	// An empty tab reads as no headers and no rows, not null
+	headers := []string{}
+	rows := [][]interface{}{}
+	columns := make(map[string]string)
+
+	if table := splitTable(values, headerRow); table.headers != nil {
+		for _, v := range table.headers {
+			headers = append(headers, cellString(v))
+		}
+		rows = table.rows
+		s.stringifyIDColumns(headers, rows)
+
+		hidden = shiftColumns(hidden, colOffset)
+		for i, h := range headers {
+			if _, seen := columns[h]; h != "" && !seen && !hidden[i] {
+				columns[h] = columnLetter(colOffset + i)
+			}
+		}
+		headers, rows = dropColumns(headers, rows, hidden)
+	}
+	return headers, rows, columns
+}
+
+// rowObjects turns rows into objects keyed by header, filling cells missing from
+// short rows with "". Empty headers are skipped and a repeated header keeps the
+// first column's value.
+func rowObjects(headers []string, rows [][]interface{}) []map[string]interface{} {
+	objects := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
+		obj := make(map[string]interface{}, len(headers))
+		for i, h := range headers {
+			if _, seen := obj[h]; h == "" || seen {
+				continue
+			}
+			if i < len(row) && row[i] != nil {
+				obj[h] = row[i]
+			} else {
+				obj[h] = ""
+			}
+		}
+		objects = append(objects, obj)
+	}
+	return objects
+}
+
+// renderOptions maps ReadSheet's rendering and dateTimeRendering to the Sheets
+// API's ValueRenderOption and DateTimeRenderOption. The defaults return raw values.
+func renderOptions(req ReadSheetRequest) (valueRender, dateTimeRender string, err error) {
+	valueRender, dateTimeRender = "UNFORMATTED_VALUE", "SERIAL_NUMBER"
+	if req.Rendering != nil {
+		switch *req.Rendering {
+		case UNFORMATTED:
+		case FORMATTED:
+			valueRender = "FORMATTED_VALUE"
+		case FORMULA:
+			valueRender = "FORMULA"
+		default:
+			return "", "", fmt.Errorf("Unknown rendering %q (want UNFORMATTED, FORMATTED, or FORMULA)", *req.Rendering)
+		}
+	}
+	if req.DateTimeRendering != nil {
+		switch *req.DateTimeRendering {
+		case SERIALNUMBER, FORMATTEDSTRING:
+			dateTimeRender = string(*req.DateTimeRendering)
+		default:
+			return "", "", fmt.Errorf("Unknown dateTimeRendering %q (want SERIAL_NUMBER or FORMATTED_STRING)", *req.DateTimeRendering)
+		}
+	}
+	return valueRender, dateTimeRender, nil
+}
+
+// splitSheetRange splits "Sheet!A1:C" into the unquoted sheet name and the
+// cell range ("" for a whole-sheet range such as "Grants")
+func splitSheetRange(rangeStr string) (string, string) {
+	sheet, cells, _ := strings.Cut(rangeStr, "!")
+	if len(sheet) >= 2 && strings.HasPrefix(sheet, "'") && strings.HasSuffix(sheet, "'") {
+		sheet = strings.ReplaceAll(sheet[1:len(sheet)-1], "''", "'")
+	}
+	return sheet, cells
+}
+
+// firstRow returns the 1-based first row of a range like "Grants!A15:F15".
+// Append reports the whole area it wrote; if that ever spans several rows,
+// the first one is where the new row starts.
+func firstRow(rangeStr string) (int, error) {
+	_, cells := splitSheetRange(rangeStr)
+	b, err := parseA1Range(cells)
+	if err != nil {
+		return 0, err
+	}
+	if b.startRow == -1 {
+		return 0, fmt.Errorf("range %q has no row", rangeStr)
+	}
+	return b.startRow + 1, nil
+}
+
+// readRanges serves a multi-range ReadSheet with a single BatchGet. Each range
+// names its own sheet and is split into headers and rows like a single read;
+// paging, merge normalization, and the replica fallback don't apply.
+func (s *Server) readRanges(w http.ResponseWriter, r *http.Request, ranges []string, valueRender, dateTimeRender string) {
+	if len(ranges) > maxReadRanges {
+		writeError(w, fmt.Sprintf("At most %d ranges can be read at once", maxReadRanges), http.StatusBadRequest)
+		return
+	}
+
+	spreadsheetID, err := s.spreadsheetFor(r)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	srv, err := s.sheetsService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Sheets service: %v", err)
+		writeError(w, "Failed to connect to Google Sheets", http.StatusInternalServerError)
+		return
+	}
+
+	for _, rangeStr := range ranges {
+		sheet, cells := splitSheetRange(rangeStr)
+		if sheet == "" {
+			writeError(w, fmt.Sprintf("Range %q must name a sheet", rangeStr), http.StatusBadRequest)
+			return
+		}
+		if refuseInternalSheet(w, sheet) {
+			return
+		}
+		if cells == "" {
+			continue
+		}
+		if err := s.validateRanges(r.Context(), srv, spreadsheetID, sheet, []string{cells}); err != nil {
+			writeRangeError(w, err)
+			return
+		}
+	}
+
+	resp, err := srv.Spreadsheets.Values.BatchGet(spreadsheetID).
+		Ranges(ranges...).
+		ValueRenderOption(valueRender).
+		DateTimeRenderOption(dateTimeRender).
+		Context(r.Context()).
+		Do()
+	if isCancelled(err) {
+		writeCancelled(w, "ReadSheet")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to read ranges %v: %v", ranges, err)
+		writeError(w, fmt.Sprintf("Failed to read ranges: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	// Sheets returns value ranges in request order, so key them by what the client sent
+	results := make(map[string]RangeData, len(ranges))
+	total := 0
+	for i, rangeStr := range ranges {
+		var values [][]interface{}
+		if i < len(resp.ValueRanges) {
+			values = resp.ValueRanges[i].Values
+		}
+
+		sheet, cells := splitSheetRange(rangeStr)
+		headerRow, colOffset := s.headerRow(sheet), 0
+		if cells != "" {
+			headerRow = 1
+			_, colOffset, _ = a1Start(cells)
+		}
+		hidden, err := s.hiddenSheetColumns(r, srv, spreadsheetID, sheet)
+		if err != nil {
+			log.Printf("Failed to find sensitive columns: %v", err)
+			writeError(w, "Failed to read sheet headers", http.StatusInternalServerError)
+			return
+		}
+		headers, rows, columns := s.sheetData(values, headerRow, colOffset, hidden)
+		if headers == nil {
+			headers = []string{}
+		}
+		if rows == nil {
+			rows = [][]interface{}{}
+		}
+		results[rangeStr] = RangeData{Headers: headers, Rows: rows, Columns: columns}
+		total += len(rows)
+	}
+
+	s.auditRead(r, AuditEvent{
+		Action:   "read_sheet",
+		Resource: strings.Join(ranges, ","),
+		Detail:   fmt.Sprintf("read %d ranges (%d rows)", len(ranges), total),
+	})
+
+	_, pageInfo := pageRows(nil, 0, 0)
+	writeJSON(w, ReadSheetResponse{
+		Headers:  []string{},
+		Rows:     [][]interface{}{},
+		PageInfo: pageInfo,
+		Ranges:   &results,
+	})
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// unavailable answers like an overloaded Sheets backend, asking for an immediate retry
+func unavailable(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Retry-After", "0")
+	http.Error(w, `{"error": {"code": 503, "message": "The service is currently unavailable."}}`, http.StatusServiceUnavailable)
+}
+
+func TestReadSheetReplicaFallbackRetries(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.handle(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", unavailable)
+	var replicaReads atomic.Int32
+	f.handle(http.MethodGet, "/v4/spreadsheets/replica-1/values/Grants", func(w http.ResponseWriter, r *http.Request) {
+		if replicaReads.Add(1) == 1 {
+			unavailable(w, r)
+			return
+		}
+		writeFakeJSON(w, &sheets.ValueRange{Values: envelopeGrants})
+	})
+	s := newTestServer(t, f)
+	s.replicaSpreadsheetID = "replica-1"
+
+	w := callHandler(t, s.ReadSheet, "po@example.org", ReadSheetRequest{Sheet: "Grants"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var resp ReadSheetResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Stale == nil || !*resp.Stale {
+		t.Error("replica read not marked stale")
+	}
+	if got := len(f.calls(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants")); got != s.retryAttempts {
+		t.Errorf("%d primary reads, want %d", got, s.retryAttempts)
+	}
+	if got := replicaReads.Load(); got != 2 {
+		t.Errorf("%d replica reads, want a retry after the first failure", got)
+	}
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// fakeTokenInfo points tokenInfoURL at a server that vouches for every token
+// as belonging to email, starting from an empty token cache
+func fakeTokenInfo(t *testing.T, email string) {
+	t.Helper()
+	clearTokenInfoCache := func() {
+		tokenInfoCacheMu.Lock()
+		tokenInfoCache = make(map[string]tokenInfoEntry)
+		tokenInfoCacheMu.Unlock()
+	}
+	clearTokenInfoCache()
+	t.Cleanup(clearTokenInfoCache)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeFakeJSON(w, tokenInfo{Email: email, EmailVerified: "true", ExpiresIn: "3600"})
+	}))
+	t.Cleanup(srv.Close)
+
+	oldURL, oldSecret := tokenInfoURL, CookieSecret
+	tokenInfoURL = srv.URL
+	CookieSecret = []byte("test-cookie-secret-of-at-least-32-chars")
+	t.Cleanup(func() { tokenInfoURL, CookieSecret = oldURL, oldSecret })
+}
+
+// withSessionRefresh installs refresh and nearExpiry as main would, restoring them afterwards
+func withSessionRefresh(t *testing.T, refresh SessionRefresher, nearExpiry func(*http.Request) bool) {
+	t.Helper()
+	oldRefresh, oldNear := RefreshSession, AccessNearExpiry
+	RefreshSession, AccessNearExpiry = refresh, nearExpiry
+	t.Cleanup(func() { RefreshSession, AccessNearExpiry = oldRefresh, oldNear })
+}
+
+// authRequest builds a request from a signed-in user, with accessToken as its
+// access cookie (none when empty) and a server-side session
+func authRequest(t *testing.T, accessToken string) *http.Request {
+	t.Helper()
+	user, _ := json.Marshal(UserInfo{Email: "po@example.org"})
+	r := httptest.NewRequest(http.MethodPost, "/api/sheets/read", nil)
+	r.AddCookie(&http.Cookie{Name: "gt_user", Value: SignUserCookie(user)})
+	r.AddCookie(&http.Cookie{Name: "gt_session", Value: "session-1"})
+	if accessToken != "" {
+		r.AddCookie(&http.Cookie{Name: "gt_access_token", Value: accessToken})
+	}
+	return r
+}
+
+func TestRequireAuthRefreshHeaders(t *testing.T) {
+	tests := []struct {
+		name          string
+		accessToken   string
+		nearExpiry    bool
+		refreshErr    error
+		wantRefreshed bool
+		wantToken     string
+	}{
+		{name: "access cookie expired", wantRefreshed: true, wantToken: "refreshed-token"},
+		{name: "access token about to expire", accessToken: "old-token", nearExpiry: true, wantRefreshed: true, wantToken: "refreshed-token"},
+		{name: "access token still fresh", accessToken: "old-token", wantToken: "old-token"},
+		{name: "refresh of an expiring token fails", accessToken: "old-token", nearExpiry: true, refreshErr: errors.New("token endpoint down"), wantToken: "old-token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeTokenInfo(t, "po@example.org")
+			refreshes := 0
+			withSessionRefresh(t, func(w http.ResponseWriter, r *http.Request) (string, int, error) {
+				refreshes++
+				if tt.refreshErr != nil {
+					return "", 0, tt.refreshErr
+				}
+				return "refreshed-token", 3600, nil
+			}, func(*http.Request) bool { return tt.nearExpiry })
+
+			var gotToken string
+			handler := RequireAuth(func(w http.ResponseWriter, r *http.Request) {
+				gotToken = r.Header.Get("X-Access-Token")
+				writeJSON(w, SuccessResponse{Success: true})
+			})
+			w := httptest.NewRecorder()
+			handler(w, authRequest(t, tt.accessToken))
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", w.Code, w.Body)
+			}
+			if gotToken != tt.wantToken {
+				t.Errorf("handler saw token %q, want %q", gotToken, tt.wantToken)
+			}
+			refreshed := w.Header().Get("X-Token-Refreshed") == "true"
+			if refreshed != tt.wantRefreshed {
+				t.Errorf("X-Token-Refreshed = %q, want refreshed %v", w.Header().Get("X-Token-Refreshed"), tt.wantRefreshed)
+			}
+			if expiresIn := w.Header().Get("X-Token-Expires-In"); tt.wantRefreshed && expiresIn != "3600" {
+				t.Errorf("X-Token-Expires-In = %q, want 3600", expiresIn)
+			} else if !tt.wantRefreshed && expiresIn != "" {
+				t.Errorf("X-Token-Expires-In = %q on a response that wasn't refreshed", expiresIn)
+			}
+			if wantRefreshes := tt.wantRefreshed || tt.refreshErr != nil; (refreshes == 1) != wantRefreshes {
+				t.Errorf("session refreshed %d times", refreshes)
+			}
+		})
+	}
+}
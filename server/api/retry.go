@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+// Defaults for retrying Google API calls (see GOOGLE_API_RETRY_ATTEMPTS)
+const (
+	defaultRetryAttempts = 3
+	retryBaseDelay       = 500 * time.Millisecond
+	retryMaxDelay        = 8 * time.Second
+	retryMaxRetryAfter   = 30 * time.Second
+)
+
+// retryable reports whether a failed call is worth repeating. Only idempotent
+// calls are: any error, even a 429 or 503, may come after the change was
+// applied, and re-sending an append, create, or delete-by-row-index would
+// repeat its effect.
+func retryable(err error, idempotent bool) bool {
+	if !idempotent {
+		return false
+	}
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.Code {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusInternalServerError:
+		return true
+	}
+	return false
+}
+
+// retryDelay returns how long to wait before retry n (0-based): Retry-After when
+// Google sends one, otherwise exponential backoff with jitter
+func retryDelay(err error, n int) time.Duration {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) && apiErr.Header != nil {
+		if secs, err := strconv.Atoi(apiErr.Header.Get("Retry-After")); err == nil && secs >= 0 {
+			return min(time.Duration(secs)*time.Second, retryMaxRetryAfter)
+		}
+	}
+	backoff := retryMaxDelay
+	if n < 5 {
+		backoff = min(retryBaseDelay<<n, retryMaxDelay)
+	}
+	return time.Duration(rand.Int63n(int64(backoff))) + backoff/2
+}
+
+// withRetry runs call up to attempts times, backing off between tries while
+// the error is retryable. A call that isn't idempotent runs once. It stops
+// early when ctx ends.
+func withRetry[T any](ctx context.Context, attempts int, idempotent bool, call func() (T, error)) (T, error) {
+	for n := 0; ; n++ {
+		result, err := call()
+		if err == nil || n+1 >= attempts || !retryable(err, idempotent) {
+			return result, err
+		}
+
+		delay := retryDelay(err, n)
+		log.Printf("[API] Google API call failed (attempt %d of %d), retrying in %s: %v", n+1, attempts, delay, err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return result, ctx.Err()
+		}
+	}
+}
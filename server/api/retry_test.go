@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// replyAfterOutage fails the first call to path with a 503, then answers v
+func (f *fakeGoogle) replyAfterOutage(method, path string, v interface{}) {
+	failed := false
+	f.handle(method, path, func(w http.ResponseWriter, r *http.Request) {
+		if !failed {
+			failed = true
+			unavailable(w, r)
+			return
+		}
+		writeFakeJSON(w, v)
+	})
+}
+
+// retriedGrants is a Grants tab with enough columns for every handler below
+var retriedGrants = [][]interface{}{
+	{"ID", "Title", "status"},
+	{"G-1", "Packaging", "Active"},
+	{"G-2", "", "Draft"},
+}
+
+func TestSheetReadsRetryTransientFailures(t *testing.T) {
+	cols := []string{"ID"}
+	endpoints := []struct {
+		name    string
+		handler func(s *Server) http.HandlerFunc
+		body    interface{}
+	}{
+		{"pivot", func(s *Server) http.HandlerFunc { return s.Pivot }, PivotRequest{Sheet: "Grants", Rows: []string{"status"}, Agg: Count}},
+		{"completeness", func(s *Server) http.HandlerFunc { return s.Completeness }, CompletenessRequest{IdColumn: "ID", RequiredColumns: &cols}},
+		{"preview import", func(s *Server) http.HandlerFunc { return s.PreviewImport }, PreviewImportRequest{
+			Sheet: "Grants", KeyColumn: "ID", Rows: []map[string]interface{}{{"ID": "G-3", "Title": "Docs"}},
+		}},
+	}
+	for _, e := range endpoints {
+		t.Run(e.name, func(t *testing.T) {
+			f := newFakeGoogle(t)
+			path := "/v4/spreadsheets/" + testSpreadsheetID + "/values/Grants"
+			f.replyAfterOutage(http.MethodGet, path, &sheets.ValueRange{Values: retriedGrants})
+			s := newTestServer(t, f)
+
+			w := callHandler(t, e.handler(s), "po@example.org", e.body)
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", w.Code, w.Body)
+			}
+			if got := len(f.calls(http.MethodGet, path)); got != 2 {
+				t.Errorf("%d reads, want the failed one retried once", got)
+			}
+		})
+	}
+}
+
+func TestGrantsSummaryRetries(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.replyAfterOutage(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: retriedGrants})
+	s := newTestServer(t, f)
+
+	summary, err := s.grantsSummary(context.Background(), testSpreadsheetID)
+	if err != nil {
+		t.Fatalf("grantsSummary: %v", err)
+	}
+	if summary.Total != 2 || summary.ByStatus["Active"] != 1 {
+		t.Errorf("summary = %+v", summary)
+	}
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// roleRank orders Drive permission roles from least to most privileged
+var roleRank = map[string]int{
+	"reader":        1,
+	"commenter":     2,
+	"writer":        3,
+	"fileOrganizer": 4,
+	"organizer":     5,
+	"owner":         6,
+}
+
+// defaultSensitiveMinRole is the lowest role that may see sensitive columns
+const defaultSensitiveMinRole = "writer"
+
+// higherRole returns whichever of two Drive roles grants more access
+func higherRole(a, b string) string {
+	if roleRank[b] > roleRank[a] {
+		return b
+	}
+	return a
+}
+
+// canSeeSensitive reports whether the requesting user's role meets the sensitive column threshold
+func (s *Server) canSeeSensitive(r *http.Request) bool {
+	return roleRank[r.Header.Get("X-User-Role")] >= roleRank[s.sensitiveMinRole]
+}
+
+// trackerSpreadsheet reports whether fileID is a spreadsheet the API serves
+// rows from: the discovered one, its replica, or an allowed alternate. Their
+// raw contents include every sensitive column.
+func (s *Server) trackerSpreadsheet(fileID string) bool {
+	return fileID != "" && (fileID == s.discoveredSpreadsheetID() || fileID == s.replicaSpreadsheetID || s.allowedSpreadsheets[fileID])
+}
+
+// hiddenColumns returns the header indices the requesting user may not see
+func (s *Server) hiddenColumns(r *http.Request, headers []string) map[int]bool {
+	if len(s.sensitiveColumns) == 0 || s.canSeeSensitive(r) {
+		return nil
+	}
+	hidden := make(map[int]bool)
+	for i, h := range headers {
+		if s.sensitiveColumns[h] {
+			hidden[i] = true
+		}
+	}
+	return hidden
+}
+
+// hiddenSheetColumns returns the sheet columns (0-based from column A) the
+// requesting user may not see. They are found in the sheet's real header row,
+// so a read of a range that starts below or right of the header is redacted
+// the same as a whole-sheet read.
+func (s *Server) hiddenSheetColumns(r *http.Request, srv *sheets.Service, spreadsheetID, sheet string) (map[int]bool, error) {
+	if len(s.sensitiveColumns) == 0 || s.canSeeSensitive(r) {
+		return nil, nil
+	}
+	resp, err := withRetry(r.Context(), s.retryAttempts, true, func() (*sheets.ValueRange, error) {
+		return srv.Spreadsheets.Values.Get(spreadsheetID, s.headerRange(sheet)).Context(r.Context()).Do()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read %s headers: %w", sheet, err)
+	}
+	hidden := make(map[int]bool)
+	if len(resp.Values) > 0 {
+		for i, h := range resp.Values[0] {
+			if s.sensitiveColumns[cellString(h)] {
+				hidden[i] = true
+			}
+		}
+	}
+	return hidden, nil
+}
+
+// shiftColumns turns sheet column indices into indices into values read from
+// colOffset, dropping columns left of the read
+func shiftColumns(hidden map[int]bool, colOffset int) map[int]bool {
+	if len(hidden) == 0 || colOffset == 0 {
+		return hidden
+	}
+	shifted := make(map[int]bool, len(hidden))
+	for col := range hidden {
+		if col >= colOffset {
+			shifted[col-colOffset] = true
+		}
+	}
+	return shifted
+}
+
+// redactColumns drops sensitive columns from headers and rows for users below the threshold role
+func (s *Server) redactColumns(r *http.Request, headers []string, rows [][]interface{}) ([]string, [][]interface{}) {
+	return dropColumns(headers, rows, s.hiddenColumns(r, headers))
+}
+
+// visiblePayload removes the sensitive columns from a row payload for users
+// below the threshold role
+func (s *Server) visiblePayload(r *http.Request, payload map[string]interface{}) map[string]interface{} {
+	if len(s.sensitiveColumns) == 0 || s.canSeeSensitive(r) {
+		return payload
+	}
+	for column := range payload {
+		if s.sensitiveColumns[column] {
+			delete(payload, column)
+		}
+	}
+	return payload
+}
+
+// dropColumns removes the hidden column indices from headers and rows
+func dropColumns(headers []string, rows [][]interface{}, hidden map[int]bool) ([]string, [][]interface{}) {
+	if len(hidden) == 0 {
+		return headers, rows
+	}
+
+	var keptHeaders []string
+	for i, h := range headers {
+		if !hidden[i] {
+			keptHeaders = append(keptHeaders, h)
+		}
+	}
+
+	keptRows := make([][]interface{}, len(rows))
+	for ri, row := range rows {
+		kept := make([]interface{}, 0, len(row))
+		for i, v := range row {
+			if !hidden[i] {
+				kept = append(kept, v)
+			}
+		}
+		keptRows[ri] = kept
+	}
+	return keptHeaders, keptRows
+}
+
+// checkSensitiveWrite returns an error naming the first sensitive column in
+// columns when the requesting user is below the threshold role
+func (s *Server) checkSensitiveWrite(r *http.Request, columns map[string]interface{}) error {
+	if len(s.sensitiveColumns) == 0 || s.canSeeSensitive(r) {
+		return nil
+	}
+	for key := range columns {
+		if col, _ := splitColumnKey(key); s.sensitiveColumns[col] {
+			return fmt.Errorf("insufficient permission to modify column %s", col)
+		}
+	}
+	return nil
+}
+
+// defaultAdminMinRole is the lowest role allowed to call admin endpoints
+const defaultAdminMinRole = "organizer"
+
+// RequireAdmin wraps a handler with access verification plus an admin role check
+func (s *Server) RequireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return s.RequireAccess(func(w http.ResponseWriter, r *http.Request) {
+		if roleRank[r.Header.Get("X-User-Role")] < roleRank[s.adminMinRole] {
+			writeError(w, "Access denied. Administrator role required.", http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	})
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// budgetGrants is a Grants tab with a Budget column only organizers may see
+var budgetGrants = [][]interface{}{
+	{"ID", "Budget", "Title"},
+	{"G-1", float64(5000), "Packaging"},
+}
+
+func TestReadSheetRedactsSensitiveColumns(t *testing.T) {
+	tests := []struct {
+		role        string
+		wantHeaders []string
+		wantRows    [][]interface{}
+	}{
+		{role: "writer", wantHeaders: []string{"ID", "Title"}, wantRows: [][]interface{}{{"G-1", "Packaging"}}},
+		{role: "", wantHeaders: []string{"ID", "Title"}, wantRows: [][]interface{}{{"G-1", "Packaging"}}},
+		{role: "organizer", wantHeaders: []string{"ID", "Budget", "Title"}, wantRows: [][]interface{}{{"G-1", float64(5000), "Packaging"}}},
+	}
+	for _, tt := range tests {
+		t.Run("role "+tt.role, func(t *testing.T) {
+			t.Setenv("SENSITIVE_COLUMNS", "Budget")
+			t.Setenv("SENSITIVE_MIN_ROLE", "organizer")
+			f := newFakeGoogle(t)
+			f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: budgetGrants})
+			f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants!1:1", &sheets.ValueRange{Values: budgetGrants[:1]})
+			s := newTestServer(t, f)
+
+			w := callHandlerAs(t, s.ReadSheet, "po@example.org", tt.role, ReadSheetRequest{Sheet: "Grants"})
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", w.Code, w.Body)
+			}
+			var resp ReadSheetResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if !reflect.DeepEqual(resp.Headers, tt.wantHeaders) {
+				t.Errorf("headers = %v, want %v", resp.Headers, tt.wantHeaders)
+			}
+			if !reflect.DeepEqual(resp.Rows, tt.wantRows) {
+				t.Errorf("rows = %v, want %v", resp.Rows, tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestCheckSensitiveWrite(t *testing.T) {
+	s := newTestServer(t, nil)
+	s.sensitiveColumns = map[string]bool{"Budget": true}
+	s.sensitiveMinRole = "organizer"
+
+	tests := []struct {
+		name    string
+		role    string
+		columns map[string]interface{}
+		wantErr bool
+	}{
+		{name: "writer changing a visible column", role: "writer", columns: map[string]interface{}{"Title": "New"}},
+		{name: "writer changing a hidden column", role: "writer", columns: map[string]interface{}{"Budget": 1}, wantErr: true},
+		{name: "organizer changing a hidden column", role: "organizer", columns: map[string]interface{}{"Budget": 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := http.NewRequest(http.MethodPost, "/api/sheets/update", nil)
+			r.Header.Set("X-User-Role", tt.role)
+			if err := s.checkSensitiveWrite(r, tt.columns); (err != nil) != tt.wantErr {
+				t.Errorf("checkSensitiveWrite() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
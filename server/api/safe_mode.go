@@ -0,0 +1,16 @@
+package api
+
+import "net/http"
+
+// Destructive wraps a handler that deletes, moves, or gives away data. Under
+// SAFE_MODE (demo and training instances) these are refused with 403 while
+// creates, reads, and updates keep working.
+func (s *Server) Destructive(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.safeMode {
+			writeError(w, "This instance is in safe mode: deleting, moving, and transferring files or rows is disabled.", http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	}
+}
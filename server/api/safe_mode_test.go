@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestSafeModeRefusesDestructiveEndpoints(t *testing.T) {
+	t.Setenv("SAFE_MODE", "true")
+	f := newFakeGoogle(t)
+	s := newTestServer(t, f)
+
+	endpoints := []struct {
+		name    string
+		handler http.HandlerFunc
+		body    interface{}
+	}{
+		{"delete row", s.DeleteRow, DeleteRowRequest{Sheet: "Grants", IdColumn: "ID", Id: "G-1"}},
+		{"delete rows where", s.DeleteRowsWhere, DeleteRowsWhereRequest{Sheet: "Grants", Where: map[string]interface{}{"Status": "Closed"}}},
+		{"move file", s.MoveFile, MoveFileRequest{FileId: "file-1", NewParentId: "folder-2"}},
+		{"trash file", s.TrashFile, TrashFileRequest{FileId: "file-1", Permanent: true}},
+		{"transfer ownership", s.TransferOwnership, TransferOwnershipRequest{FileId: "folder-1", NewOwnerEmail: "pi@example.org"}},
+	}
+	for _, e := range endpoints {
+		t.Run(e.name, func(t *testing.T) {
+			// The fake has no handlers, so any Google call would also fail the test
+			w := callHandler(t, s.Destructive(e.handler), "po@example.org", e.body)
+			if w.Code != http.StatusForbidden {
+				t.Errorf("status = %d, want 403: %s", w.Code, w.Body)
+			}
+		})
+	}
+}
+
+func TestSafeModeKeepsReads(t *testing.T) {
+	t.Setenv("SAFE_MODE", "true")
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: envelopeGrants})
+	s := newTestServer(t, f)
+
+	if w := callHandler(t, s.ReadSheet, "po@example.org", ReadSheetRequest{Sheet: "Grants"}); w.Code != http.StatusOK {
+		t.Errorf("read status = %d: %s", w.Code, w.Body)
+	}
+	if config := s.buildConfig(); config.SafeMode == nil || !*config.SafeMode {
+		t.Error("config does not report safe mode")
+	}
+}
+
+func TestDestructiveOutsideSafeMode(t *testing.T) {
+	s := newTestServer(t, nil)
+	called := false
+	w := callHandler(t, s.Destructive(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		writeJSON(w, SuccessResponse{Success: true})
+	}), "po@example.org", struct{}{})
+
+	if w.Code != http.StatusOK || !called {
+		t.Errorf("status = %d, handler called %v; want the operation to run", w.Code, called)
+	}
+	if config := s.buildConfig(); config.SafeMode != nil {
+		t.Error("config reports safe mode when it is off")
+	}
+}
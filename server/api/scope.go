@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/googleapi"
+)
+
+// outOfScopeCode marks errors for files outside the configured Shared Drive
+const outOfScopeCode = "OUT_OF_SCOPE"
+
+// outOfScopeError names a file the server refuses to operate on
+type outOfScopeError struct {
+	fileID string
+}
+
+func (e *outOfScopeError) Error() string {
+	return fmt.Sprintf("File %s is not in the Grant Tracker Shared Drive", e.fileID)
+}
+
+// inScope reports whether a file on the given drive belongs to this instance.
+// Everything is in scope when the root folder isn't on a Shared Drive.
+func (s *Server) inScope(driveID string) bool {
+	shared := s.discoveredSharedDriveID()
+	return shared == "" || driveID == shared
+}
+
+// checkInScope returns an *outOfScopeError for the first file outside the
+// Shared Drive. Drive answers 404 for files the service account can't see,
+// which for a caller-supplied ID means the same thing.
+func (s *Server) checkInScope(ctx context.Context, srv *drive.Service, fileIDs ...string) error {
+	if s.discoveredSharedDriveID() == "" {
+		return nil
+	}
+	for _, id := range fileIDs {
+		file, err := srv.Files.Get(id).
+			Fields("id, driveId").
+			SupportsAllDrives(true).
+			Context(ctx).
+			Do()
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound {
+			return &outOfScopeError{fileID: id}
+		}
+		if err != nil {
+			return err
+		}
+		if !s.inScope(file.DriveId) {
+			return &outOfScopeError{fileID: id}
+		}
+	}
+	return nil
+}
+
+// requireInScope runs checkInScope and writes the error response if it fails;
+// handlers stop when it returns false
+func (s *Server) requireInScope(w http.ResponseWriter, r *http.Request, srv *drive.Service, op string, fileIDs ...string) bool {
+	err := s.checkInScope(r.Context(), srv, fileIDs...)
+	var scopeErr *outOfScopeError
+	switch {
+	case err == nil:
+		return true
+	case isCancelled(err):
+		writeCancelled(w, op)
+	case errors.As(err, &scopeErr):
+		writeErrorCode(w, scopeErr.Error(), outOfScopeCode, http.StatusForbidden)
+	default:
+		log.Printf("Failed to check file scope: %v", err)
+		writeError(w, fmt.Sprintf("Failed to get file info: %v", err), http.StatusInternalServerError)
+	}
+	return false
+}
+
+// requireInGrantsTree checks that every file is within the Grants or root
+// folder tree before a handler changes it, writing a 403 for the first that
+// isn't; handlers stop when it returns false
+func (s *Server) requireInGrantsTree(w http.ResponseWriter, r *http.Request, srv *drive.Service, op string, fileIDs ...string) bool {
+	for _, id := range fileIDs {
+		within, err := s.fileInTree(r.Context(), srv, id)
+		switch {
+		case isCancelled(err):
+			writeCancelled(w, op)
+			return false
+		case err != nil:
+			log.Printf("Failed to check file location: %v", err)
+			writeError(w, fmt.Sprintf("Failed to check file location: %v", err), http.StatusInternalServerError)
+			return false
+		case !within:
+			writeErrorCode(w, fmt.Sprintf("File %s is not inside the Grant Tracker folder", id), outOfScopeCode, http.StatusForbidden)
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+)
+
+// replyDriveFile fakes a file that lives on driveID ("" for My Drive)
+func (f *fakeGoogle) replyDriveFile(id, driveID string) {
+	f.reply(http.MethodGet, "/files/"+id, &drive.File{Id: id, DriveId: driveID})
+}
+
+// replyMissingFile fakes a file Drive doesn't know or won't show the service account
+func (f *fakeGoogle) replyMissingFile(id string) {
+	f.handle(http.MethodGet, "/files/"+id, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": {"code": 404, "message": "File not found"}}`, http.StatusNotFound)
+	})
+}
+
+func TestCheckInScope(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.replyDriveFile("in-drive", "drive-1")
+	f.replyDriveFile("other-drive", "drive-2")
+	f.replyDriveFile("my-drive", "")
+	f.replyMissingFile("missing")
+	f.handle(http.MethodGet, "/files/broken", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": {"code": 500, "message": "Backend Error"}}`, http.StatusInternalServerError)
+	})
+	s := newTestServer(t, f)
+	s.sharedDriveID = "drive-1"
+
+	tests := []struct {
+		name       string
+		ids        []string
+		outOfScope bool
+		wantErr    bool
+	}{
+		{name: "file on the Shared Drive", ids: []string{"in-drive"}},
+		{name: "file on another Shared Drive", ids: []string{"other-drive"}, outOfScope: true},
+		{name: "file in a My Drive", ids: []string{"my-drive"}, outOfScope: true},
+		{name: "file Drive answers 404 for", ids: []string{"missing"}, outOfScope: true},
+		{name: "second of two files out of scope", ids: []string{"in-drive", "other-drive"}, outOfScope: true},
+		{name: "Drive failure", ids: []string{"broken"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.checkInScope(context.Background(), s.driveClient, tt.ids...)
+			var scopeErr *outOfScopeError
+			if got := errors.As(err, &scopeErr); got != tt.outOfScope {
+				t.Errorf("checkInScope() = %v, want out of scope %v", err, tt.outOfScope)
+			}
+			if tt.wantErr && (err == nil || scopeErr != nil) {
+				t.Errorf("checkInScope() = %v, want a plain failure", err)
+			}
+			if !tt.outOfScope && !tt.wantErr && err != nil {
+				t.Errorf("checkInScope() = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestCheckInScopeWithoutSharedDrive(t *testing.T) {
+	f := newFakeGoogle(t)
+	s := newTestServer(t, f)
+
+	if err := s.checkInScope(context.Background(), s.driveClient, "anything"); err != nil {
+		t.Errorf("checkInScope() = %v, want everything in scope off a Shared Drive", err)
+	}
+	if calls := f.calls(http.MethodGet, "/files/anything"); len(calls) != 0 {
+		t.Errorf("looked up the file %d times, want no lookups", len(calls))
+	}
+}
+
+func TestListPermissionsScope(t *testing.T) {
+	tests := []struct {
+		name   string
+		fileID string
+		status int
+	}{
+		{name: "in-scope file", fileID: "in-drive", status: http.StatusOK},
+		{name: "file on another drive", fileID: "other-drive", status: http.StatusForbidden},
+		{name: "file Drive answers 404 for", fileID: "missing", status: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeGoogle(t)
+			f.replyDriveFile("in-drive", "drive-1")
+			f.replyDriveFile("other-drive", "drive-2")
+			f.replyMissingFile("missing")
+			permissionsPath := "/files/" + tt.fileID + "/permissions"
+			f.reply(http.MethodGet, permissionsPath, &drive.PermissionList{
+				Permissions: []*drive.Permission{{Id: "p-1", Role: "writer", Type: "user", EmailAddress: "po@example.org"}},
+			})
+			s := newTestServer(t, f)
+			s.sharedDriveID = "drive-1"
+
+			w := callHandler(t, s.ListPermissions, "po@example.org", ListPermissionsRequest{FileId: tt.fileID})
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+			if tt.status == http.StatusOK {
+				return
+			}
+
+			var resp Error
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if resp.Code == nil || *resp.Code != outOfScopeCode {
+				t.Errorf("code = %v, want %s", resp.Code, outOfScopeCode)
+			}
+			if calls := f.calls(http.MethodGet, permissionsPath); len(calls) != 0 {
+				t.Errorf("listed permissions of an out-of-scope file %d times", len(calls))
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+)
+
+// SearchFiles finds files by name (or content) anywhere under the Grants or
+// root folder, so a grant doc can be found without walking the folder tree
+func (s *Server) SearchFiles(w http.ResponseWriter, r *http.Request) {
+	var req SearchFilesRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	req.Query = strings.TrimSpace(req.Query)
+	if req.Query == "" {
+		writeError(w, "query is required", http.StatusBadRequest)
+		return
+	}
+
+	pageSize := s.listPageSize
+	if req.PageSize != 0 {
+		if req.PageSize < 1 || req.PageSize > maxListPageSize {
+			writeError(w, fmt.Sprintf("pageSize must be between 1 and %d", maxListPageSize), http.StatusBadRequest)
+			return
+		}
+		pageSize = req.PageSize
+	}
+
+	srv, err := s.driveService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Drive service: %v", err)
+		writeError(w, "Failed to connect to Google Drive", http.StatusInternalServerError)
+		return
+	}
+
+	field := "name"
+	if req.FullText {
+		field = "fullText"
+	}
+	query := fmt.Sprintf("%s contains '%s' and trashed = false", field, driveQuoted(req.Query))
+
+	call := srv.Files.List().
+		Q(query).
+		Fields("nextPageToken, files(id, name, mimeType, modifiedTime, webViewLink, shortcutDetails, appProperties, parents)").
+		PageSize(int64(pageSize)).
+		SupportsAllDrives(true).
+		IncludeItemsFromAllDrives(true)
+	// Drive ranks full-text matches by relevance and won't sort them otherwise
+	if !req.FullText {
+		call = call.OrderBy("name")
+	}
+	if driveID := s.discoveredSharedDriveID(); driveID != "" {
+		call = call.Corpora("drive").DriveId(driveID)
+	}
+	if req.PageToken != "" {
+		call = call.PageToken(req.PageToken)
+	}
+	resp, err := withRetry(r.Context(), s.retryAttempts, true, func() (*drive.FileList, error) {
+		return call.Context(r.Context()).Do()
+	})
+	if isCancelled(err) {
+		writeCancelled(w, "SearchFiles")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to search files: %v", err)
+		writeError(w, fmt.Sprintf("Failed to search files: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	files := []FileInfo{}
+	for _, f := range resp.Files {
+		// Full-text matches on the tracker would reveal what is in its sensitive columns
+		if s.trackerSpreadsheet(f.Id) {
+			continue
+		}
+		place, err := s.locateInTree(r.Context(), srv, f.Parents)
+		if isCancelled(err) {
+			writeCancelled(w, "SearchFiles")
+			return
+		}
+		if err != nil {
+			log.Printf("Failed to resolve path for %s: %v", f.Id, err)
+			writeError(w, fmt.Sprintf("Failed to resolve file path: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if !place.inTree() {
+			continue
+		}
+		s.noteTreeFile(f.Id)
+		fi := fileInfoFromDrive(f)
+		fi.Path = &place.path
+		files = append(files, fi)
+	}
+
+	s.auditRead(r, AuditEvent{
+		Action: "search_files",
+		Target: req.Query,
+		Detail: fmt.Sprintf("searched %s for %q (%d matches)", field, req.Query, len(files)),
+	})
+
+	writeJSON(w, SearchFilesResponse{Files: files, PageInfo: tokenPageInfo(pageSize, resp.NextPageToken)})
+}
@@ -2,22 +2,28 @@ package api
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"math"
 	"net/http"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/docs/v1"
 	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 	"google.golang.org/api/sheets/v4"
+	htransport "google.golang.org/api/transport/http"
 )
 
 // Server implements the generated ServerInterface
@@ -26,9 +32,165 @@ type Server struct {
 	rootFolderID string // Shared Drive root folder
 	credentials  []byte // Service account credentials (nil = use default)
 
-	// Discovered from root folder
+	// Workspace user the service account acts as via domain-wide delegation ("" = itself)
+	impersonateSubject string
+
+	// Columns whose values are returned as exact strings (nil = use naming heuristic)
+	idColumns map[string]bool
+
+	// Discovered from root folder in the background (guarded by discoveryMu)
+	discoveryMu    sync.RWMutex
+	discoveryRunMu sync.Mutex // Serializes discoverResources
+	discovering    bool
+	discoveryDone  chan struct{} // Closed when the current discovery run finishes
 	spreadsheetID  string
 	grantsFolderID string
+	sharedDriveID  string
+	branding       Branding // envBranding with any Config tab overrides
+
+	// Instance name, logo, and theme color from INSTANCE_* env vars
+	envBranding Branding
+
+	// Public origin of the app for building links (PUBLIC_URL, no trailing slash)
+	publicURL string
+
+	// Origins links may be built from when PUBLIC_URL is unset (ALLOWED_ORIGIN)
+	linkOrigins map[string]bool
+
+	// How long requests wait for discovery before giving up with 503 (0 = don't wait)
+	discoveryWaitTimeout time.Duration
+
+	// Return the full Shared Drive ID in config instead of a masked one
+	exposeSharedDriveID bool
+
+	// Return full grantee emails from ListPermissions instead of masked ones
+	exposePermissionEmails bool
+
+	// Optional read-only snapshot used when the primary spreadsheet is failing
+	replicaSpreadsheetID string
+
+	// Other spreadsheets a request may select with X-Spreadsheet-ID
+	allowedSpreadsheets map[string]bool
+
+	// 1-based header row per sheet, for tabs with banner rows above the header
+	headerRows map[string]int
+
+	// Columns hidden from users below sensitiveMinRole (nil = none)
+	sensitiveColumns map[string]bool
+	sensitiveMinRole string
+
+	// Lowest Drive role allowed to call admin endpoints
+	adminMinRole string
+
+	// Also check request ranges against the sheet's grid (costs an extra read)
+	checkRangeBounds bool
+
+	// Where create handlers look for a parent folder, in order
+	parentOrder []string
+
+	// Subfolders created in each new grant folder (nil = defaultGrantSubfolders)
+	grantSubfolderTemplate []string
+
+	// Google Doc copied as each provisioned grant's tracker doc ("" = start blank)
+	trackerTemplateID string
+
+	// MIME types CreateDoc may create
+	createDocMimeTypes map[string]bool
+
+	// Audit logging
+	auditLogger  AuditLogger
+	auditHistory *auditHistory // Recent events for GrantHistory (also in auditLogger)
+	auditToSheet bool          // AUDIT_SINK=sheet: events are also appended to the AuditLog tab
+	auditReads   bool          // Also audit reads (off by default to avoid noise)
+	auditLevel   AuditLevel
+	auditLevels  map[string]AuditLevel // Per-action overrides of auditLevel
+
+	// Optional write coalescing (0 = write immediately)
+	writeCoalesceWindow time.Duration
+	writeQueues         map[string]*writeQueue
+	writeQueuesMu       sync.Mutex
+
+	// Columns Completeness checks when neither the request nor the Config tab names any
+	requiredColumns []string
+
+	// Column ReadSheet's mine filter and owner notifications match against the user's email
+	ownerColumn string
+
+	// Tells row owners when someone else updates their row (nil = disabled)
+	ownerNotifier Notifier
+
+	// Per-sheet field rules writes are validated against when the Config tab has none
+	fieldSchemas fieldSchemas
+
+	// Recent field_schema reads from each spreadsheet's Config tab
+	schemaCache   map[string]schemaCacheEntry
+	schemaCacheMu sync.Mutex
+
+	// How writes naming a duplicated header are handled (duplicateHeadersError or duplicateHeadersIndex)
+	duplicateHeaders string
+
+	// Refuse destructive operations (see Destructive)
+	safeMode bool
+
+	// Copy rows to a timestamped backup tab before deleting them
+	backupBeforeDelete bool
+
+	// Record the requesting user in appProperties on files the API creates
+	recordCreator bool
+
+	// Maximum permission listings run at once by checkFoldersAccess
+	accessCheckConcurrency int
+
+	// Maximum grant folders walked at once by ListGrantManifests
+	manifestConcurrency int
+
+	// Signs access capabilities so requests can skip the Drive permission check
+	capabilitySecret []byte
+	capabilityTTL    time.Duration
+
+	// ENV=production: cookies are always marked Secure
+	production bool
+
+	// Per-email (or "" for everyone) Unix milliseconds at or before which capabilities are revoked
+	capabilityRevoked   map[string]int64
+	capabilityRevokedMu sync.Mutex
+
+	// Wrap every JSON API response in {data, meta, error}
+	responseEnvelope bool
+
+	// Serializes conditional updates made through this instance. It is not a
+	// transaction: other instances, plain writes, and edits in Sheets can still
+	// land between the check and the write.
+	conditionalMu sync.Mutex
+
+	// Recent Drive access decisions, keyed by email:folderId (see AUTH_CACHE_TTL)
+	authCache    map[string]*authCacheEntry
+	authCacheMu  sync.RWMutex
+	authCacheTTL time.Duration
+
+	// Recently looked-up file parents, for the Grants tree check
+	parentCache   map[string]parentCacheEntry
+	parentCacheMu sync.Mutex
+
+	// Files seen inside the Grants or root tree, so ListChanges can report
+	// their removal once Drive no longer says where they were
+	treeFiles   map[string]bool
+	treeFilesMu sync.Mutex
+
+	// Largest number of ranges sent in one Sheets BatchUpdate call
+	batchUpdateMaxRanges int
+
+	// Split larger BatchUpdateCells requests into several calls; when false they are rejected
+	batchUpdateAutoSplit bool
+
+	// Recent Google API calls, for GetQuota
+	apiUsage apiUsage
+
+	// How many times a Google API call is tried before its error is returned
+	retryAttempts int
+
+	// Drive page size for ListFiles when the client doesn't pass one
+	listPageSize int
 
 	// Cached service clients
 	sheetsClient *sheets.Service
@@ -40,35 +202,359 @@ type Server struct {
 // NewServer creates a new API server
 func NewServer(clientID string) (*Server, error) {
 	s := &Server{
-		clientID:     clientID,
-		rootFolderID: os.Getenv("ROOT_FOLDER_ID"),
+		clientID:               clientID,
+		rootFolderID:           os.Getenv("ROOT_FOLDER_ID"),
+		replicaSpreadsheetID:   os.Getenv("REPLICA_SPREADSHEET_ID"),
+		auditReads:             os.Getenv("AUDIT_READS") == "true",
+		auditLevel:             AuditNormal,
+		sensitiveMinRole:       defaultSensitiveMinRole,
+		adminMinRole:           defaultAdminMinRole,
+		writeQueues:            make(map[string]*writeQueue),
+		authCache:              make(map[string]*authCacheEntry),
+		authCacheTTL:           defaultAuthCacheTTL,
+		parentCache:            make(map[string]parentCacheEntry),
+		treeFiles:              make(map[string]bool),
+		schemaCache:            make(map[string]schemaCacheEntry),
+		batchUpdateMaxRanges:   defaultBatchUpdateMaxRanges,
+		retryAttempts:          defaultRetryAttempts,
+		listPageSize:           defaultListPageSize,
+		batchUpdateAutoSplit:   os.Getenv("BATCH_UPDATE_AUTO_SPLIT") != "false",
+		createDocMimeTypes:     defaultCreateDocMimeTypes,
+		parentOrder:            defaultParentOrder,
+		capabilityTTL:          defaultCapabilityTTL,
+		discoveryWaitTimeout:   defaultDiscoveryWaitTimeout,
+		manifestConcurrency:    defaultManifestConcurrency,
+		accessCheckConcurrency: defaultAccessCheckConcurrency,
+		duplicateHeaders:       duplicateHeadersError,
+		ownerColumn:            defaultOwnerColumn,
+		recordCreator:          os.Getenv("CREATED_BY_ATTRIBUTION") != "false",
+		safeMode:               os.Getenv("SAFE_MODE") == "true",
+		production:             Production,
+		backupBeforeDelete:     os.Getenv("DELETE_BACKUP") == "true",
+		publicURL:              strings.TrimRight(os.Getenv("PUBLIC_URL"), "/"),
+		linkOrigins:            make(map[string]bool),
+		responseEnvelope:       os.Getenv("RESPONSE_ENVELOPE") == "true",
+		checkRangeBounds:       os.Getenv("RANGE_BOUNDS_CHECK") == "true",
+		exposeSharedDriveID:    os.Getenv("EXPOSE_SHARED_DRIVE_ID") == "true",
+		exposePermissionEmails: os.Getenv("EXPOSE_PERMISSION_EMAILS") == "true",
+	}
+	for _, origin := range strings.Split(os.Getenv("ALLOWED_ORIGIN"), ",") {
+		if origin = strings.TrimRight(strings.TrimSpace(origin), "/"); origin != "" {
+			s.linkOrigins[origin] = true
+		}
 	}
 
 	log.Printf("[API] Initializing server...")
 	log.Printf("[API]   Client ID: %s", maskString(clientID))
 	log.Printf("[API]   Root Folder ID: %s", maskString(s.rootFolderID))
+	if s.replicaSpreadsheetID != "" {
+		log.Printf("[API]   Replica Spreadsheet ID: %s", maskString(s.replicaSpreadsheetID))
+	}
+	if s.auditReads {
+		log.Printf("[API]   Read auditing: enabled")
+	}
+	if s.safeMode {
+		log.Printf("[API]   Safe mode: destructive operations disabled")
+	}
+	if s.backupBeforeDelete {
+		log.Printf("[API]   Delete backup: deleted rows are copied to backup tabs")
+	}
 
-	// Load service account credentials
-	if keyJSON := os.Getenv("GOOGLE_SERVICE_ACCOUNT_KEY"); keyJSON != "" {
-		s.credentials = []byte(keyJSON)
-		log.Printf("[API]   Service account: loaded from GOOGLE_SERVICE_ACCOUNT_KEY (%d bytes)", len(keyJSON))
-	} else if keyPath := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"); keyPath != "" {
-		var err error
-		s.credentials, err = os.ReadFile(keyPath)
+	branding, err := brandingFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	s.envBranding = branding
+	s.branding = branding
+	if branding.Name != "" {
+		log.Printf("[API]   Instance name: %s", branding.Name)
+	}
+
+	if ids := os.Getenv("SPREADSHEET_ALLOWLIST"); ids != "" {
+		s.allowedSpreadsheets = make(map[string]bool)
+		for _, id := range strings.Split(ids, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				s.allowedSpreadsheets[id] = true
+			}
+		}
+		log.Printf("[API]   Spreadsheet allowlist: %d entries", len(s.allowedSpreadsheets))
+	}
+
+	if cols := os.Getenv("ID_COLUMNS"); cols != "" {
+		s.idColumns = make(map[string]bool)
+		for _, col := range strings.Split(cols, ",") {
+			if col = strings.TrimSpace(col); col != "" {
+				s.idColumns[col] = true
+			}
+		}
+		log.Printf("[API]   ID columns: %s", cols)
+	}
+
+	historySize := defaultAuditHistorySize
+	if size := os.Getenv("AUDIT_HISTORY_SIZE"); size != "" {
+		n, err := strconv.Atoi(size)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid AUDIT_HISTORY_SIZE %q", size)
+		}
+		historySize = n
+	}
+	s.auditHistory = newAuditHistory(historySize)
+	loggers := multiAuditLogger{logAuditLogger{}, s.auditHistory}
+	switch sink := os.Getenv("AUDIT_SINK"); sink {
+	case "", "log":
+	case "sheet":
+		loggers = append(loggers, newSheetAuditLogger(s))
+		s.auditToSheet = true
+		log.Printf("[API]   Audit sink: %s tab", auditSheet)
+	default:
+		return nil, fmt.Errorf("invalid AUDIT_SINK %q (want log or sheet)", sink)
+	}
+	s.auditLogger = loggers
+
+	if name := os.Getenv("AUDIT_DETAIL_LEVEL"); name != "" {
+		level, err := parseAuditLevel(name)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read service account key file: %w", err)
+			return nil, fmt.Errorf("invalid AUDIT_DETAIL_LEVEL: %w", err)
+		}
+		s.auditLevel = level
+		log.Printf("[API]   Audit detail level: %s", level)
+	}
+	if spec := os.Getenv("AUDIT_DETAIL_LEVELS"); spec != "" {
+		levels, err := parseAuditLevels(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid AUDIT_DETAIL_LEVELS: %w", err)
+		}
+		s.auditLevels = levels
+		log.Printf("[API]   Audit detail overrides: %s", spec)
+	}
+
+	if spec := os.Getenv("HEADER_ROWS"); spec != "" {
+		s.headerRows = make(map[string]int)
+		for _, entry := range strings.Split(spec, ",") {
+			sheet, rowStr, ok := strings.Cut(strings.TrimSpace(entry), "=")
+			row, err := strconv.Atoi(rowStr)
+			if !ok || err != nil || row < 1 {
+				return nil, fmt.Errorf("invalid HEADER_ROWS entry %q (want Sheet=row)", entry)
+			}
+			s.headerRows[sheet] = row
+		}
+		log.Printf("[API]   Header rows: %s", spec)
+	}
+
+	if secret := os.Getenv("CAPABILITY_SECRET"); secret != "" {
+		if len(secret) < 32 {
+			return nil, fmt.Errorf("CAPABILITY_SECRET must be at least 32 characters")
+		}
+		s.capabilitySecret = []byte(secret)
+		log.Printf("[API]   Access capabilities: signed with CAPABILITY_SECRET")
+	} else {
+		s.capabilitySecret = randomCapabilitySecret()
+	}
+	if ttl := os.Getenv("CAPABILITY_TTL"); ttl != "" {
+		d, err := time.ParseDuration(ttl)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid CAPABILITY_TTL %q", ttl)
+		}
+		s.capabilityTTL = d
+		log.Printf("[API]   Access capability TTL: %s", d)
+	}
+
+	if mode := os.Getenv("DUPLICATE_HEADERS"); mode != "" {
+		if mode != duplicateHeadersError && mode != duplicateHeadersIndex {
+			return nil, fmt.Errorf("invalid DUPLICATE_HEADERS %q (want error or index)", mode)
+		}
+		s.duplicateHeaders = mode
+		log.Printf("[API]   Duplicate headers: %s", mode)
+	}
+
+	if wait := os.Getenv("DISCOVERY_WAIT_TIMEOUT"); wait != "" {
+		d, err := time.ParseDuration(wait)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid DISCOVERY_WAIT_TIMEOUT %q", wait)
+		}
+		s.discoveryWaitTimeout = d
+		log.Printf("[API]   Discovery wait timeout: %s", d)
+	}
+
+	if window := os.Getenv("WRITE_COALESCE_WINDOW"); window != "" {
+		d, err := time.ParseDuration(window)
+		if err != nil {
+			return nil, fmt.Errorf("invalid WRITE_COALESCE_WINDOW: %w", err)
+		}
+		s.writeCoalesceWindow = d
+		log.Printf("[API]   Write coalescing window: %s", d)
+	}
+
+	if max := os.Getenv("BATCH_UPDATE_MAX_RANGES"); max != "" {
+		n, err := strconv.Atoi(max)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid BATCH_UPDATE_MAX_RANGES %q", max)
+		}
+		s.batchUpdateMaxRanges = n
+		log.Printf("[API]   Batch update max ranges: %d", n)
+	}
+	if !s.batchUpdateAutoSplit {
+		log.Printf("[API]   Batch update auto-split: disabled (larger batches are rejected)")
+	}
+
+	if attempts := os.Getenv("GOOGLE_API_RETRY_ATTEMPTS"); attempts != "" {
+		n, err := strconv.Atoi(attempts)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid GOOGLE_API_RETRY_ATTEMPTS %q", attempts)
+		}
+		s.retryAttempts = n
+		log.Printf("[API]   Google API retry attempts: %d", n)
+	}
+
+	if ttl := os.Getenv("AUTH_CACHE_TTL"); ttl != "" {
+		d, err := time.ParseDuration(ttl)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid AUTH_CACHE_TTL %q", ttl)
+		}
+		s.authCacheTTL = d
+	}
+	log.Printf("[API]   Auth cache TTL: %s", s.authCacheTTL)
+
+	if size := os.Getenv("DRIVE_LIST_PAGE_SIZE"); size != "" {
+		n, err := strconv.Atoi(size)
+		if err != nil || n < 1 || n > maxListPageSize {
+			return nil, fmt.Errorf("invalid DRIVE_LIST_PAGE_SIZE %q (want 1-%d)", size, maxListPageSize)
+		}
+		s.listPageSize = n
+	}
+	log.Printf("[API]   Drive list page size: %d", s.listPageSize)
+
+	if max := os.Getenv("ACCESS_CHECK_CONCURRENCY"); max != "" {
+		n, err := strconv.Atoi(max)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid ACCESS_CHECK_CONCURRENCY %q", max)
+		}
+		s.accessCheckConcurrency = n
+		log.Printf("[API]   Access check concurrency: %d", n)
+	}
+
+	if max := os.Getenv("MANIFEST_CONCURRENCY"); max != "" {
+		n, err := strconv.Atoi(max)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid MANIFEST_CONCURRENCY %q", max)
+		}
+		s.manifestConcurrency = n
+		log.Printf("[API]   Manifest concurrency: %d", n)
+	}
+
+	if spec := os.Getenv("PARENT_RESOLUTION_ORDER"); spec != "" {
+		order, err := parseParentOrder(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid PARENT_RESOLUTION_ORDER: %w", err)
+		}
+		s.parentOrder = order
+		log.Printf("[API]   Parent resolution order: %s", spec)
+	}
+
+	if cols := os.Getenv("REQUIRED_COLUMNS"); cols != "" {
+		for _, col := range strings.Split(cols, ",") {
+			if col = strings.TrimSpace(col); col != "" {
+				s.requiredColumns = append(s.requiredColumns, col)
+			}
+		}
+		log.Printf("[API]   Required columns: %s", cols)
+	}
+
+	if col := os.Getenv("OWNER_COLUMN"); col != "" {
+		s.ownerColumn = col
+		log.Printf("[API]   Owner column: %s", col)
+	}
+
+	if hook := os.Getenv("OWNER_NOTIFY_WEBHOOK"); hook != "" {
+		if !strings.HasPrefix(hook, "https://") && !strings.HasPrefix(hook, "http://") {
+			return nil, fmt.Errorf("invalid OWNER_NOTIFY_WEBHOOK %q (want an http(s) URL)", hook)
+		}
+		s.ownerNotifier = webhookNotifier{url: hook, client: &http.Client{Timeout: ownerNotifyTimeout}}
+		log.Printf("[API]   Owner notifications: enabled")
+	}
+
+	if spec := os.Getenv("FIELD_SCHEMA"); spec != "" {
+		schemas, err := parseFieldSchemas(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid FIELD_SCHEMA: %w", err)
+		}
+		s.fieldSchemas = schemas
+		log.Printf("[API]   Field schema: %d sheet(s)", len(schemas))
+	}
+
+	if spec := os.Getenv("GRANT_SUBFOLDERS"); spec != "" {
+		s.grantSubfolderTemplate = parseFolderList(spec)
+		log.Printf("[API]   Grant subfolders: %s", spec)
+	}
+
+	if id := strings.TrimSpace(os.Getenv("TRACKER_TEMPLATE_ID")); id != "" {
+		s.trackerTemplateID = id
+		log.Printf("[API]   Tracker template: %s", maskString(id))
+	}
+
+	if types := os.Getenv("CREATE_DOC_MIME_TYPES"); types != "" {
+		s.createDocMimeTypes = make(map[string]bool)
+		for _, t := range strings.Split(types, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				s.createDocMimeTypes[t] = true
+			}
+		}
+		log.Printf("[API]   CreateDoc MIME types: %s", types)
+	}
+
+	if role := os.Getenv("ADMIN_MIN_ROLE"); role != "" {
+		if _, ok := roleRank[role]; !ok {
+			return nil, fmt.Errorf("invalid ADMIN_MIN_ROLE %q", role)
+		}
+		s.adminMinRole = role
+		log.Printf("[API]   Admin min role: %s", role)
+	}
+
+	if cols := os.Getenv("SENSITIVE_COLUMNS"); cols != "" {
+		s.sensitiveColumns = make(map[string]bool)
+		for _, col := range strings.Split(cols, ",") {
+			if col = strings.TrimSpace(col); col != "" {
+				s.sensitiveColumns[col] = true
+			}
+		}
+		if role := os.Getenv("SENSITIVE_MIN_ROLE"); role != "" {
+			if _, ok := roleRank[role]; !ok {
+				return nil, fmt.Errorf("invalid SENSITIVE_MIN_ROLE %q", role)
+			}
+			s.sensitiveMinRole = role
 		}
-		log.Printf("[API]   Service account: loaded from file %s (%d bytes)", keyPath, len(s.credentials))
+		log.Printf("[API]   Sensitive columns: %s (min role: %s)", cols, s.sensitiveMinRole)
+	}
+
+	// Load service account credentials
+	creds, source, err := loadCredentials()
+	if err != nil {
+		return nil, err
+	}
+	s.credentials = creds
+	if creds != nil {
+		log.Printf("[API]   Service account: loaded from %s (%d bytes)", source, len(creds))
 	} else {
 		log.Printf("[API]   Service account: NOT CONFIGURED")
 	}
 
-	// Discover spreadsheet and Grants folder from root folder
-	if s.rootFolderID != "" && s.credentials != nil {
-		if err := s.discoverResources(); err != nil {
-			log.Printf("[API]   Discovery failed: %v", err)
-			// Don't fail server startup - just log the error
+	if subject := strings.TrimSpace(os.Getenv("IMPERSONATE_SUBJECT")); subject != "" {
+		if !validSubject(subject) {
+			return nil, fmt.Errorf("invalid IMPERSONATE_SUBJECT %q (want a Workspace user's email)", subject)
+		}
+		if creds == nil {
+			return nil, fmt.Errorf("IMPERSONATE_SUBJECT requires a service account key (GOOGLE_SERVICE_ACCOUNT_KEY or GOOGLE_APPLICATION_CREDENTIALS)")
 		}
+		s.impersonateSubject = subject
+		log.Printf("[API]   Impersonating: %s (domain-wide delegation)", subject)
+	}
+
+	// Discover spreadsheet and Grants folder from root folder without
+	// holding up startup; GetConfig tells clients to retry until it finishes
+	if s.rootFolderID != "" && s.credentials != nil {
+		s.discovering = true
+		s.discoveryDone = make(chan struct{})
+		go s.runDiscovery()
 	}
 
 	log.Printf("[API]   IsConfigured: %v", s.IsConfigured())
@@ -78,8 +564,16 @@ func NewServer(clientID string) (*Server, error) {
 
 // discoverResources finds the spreadsheet and Grants folder in the root folder
 func (s *Server) discoverResources() error {
+	// One discovery at a time, so a reload racing startup can't create two Grants folders
+	s.discoveryRunMu.Lock()
+	defer s.discoveryRunMu.Unlock()
+
 	ctx := context.Background()
 
+	if err := s.checkImpersonation(ctx); err != nil {
+		return err
+	}
+
 	srv, err := s.driveService(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get drive service: %w", err)
@@ -117,43 +611,31 @@ func (s *Server) discoverResources() error {
 		return fmt.Errorf("no spreadsheet found in root folder")
 	}
 
-	s.spreadsheetID = spreadsheetResp.Files[0].Id
-	log.Printf("[API]   Discovered spreadsheet: %s (%s)", spreadsheetResp.Files[0].Name, maskString(s.spreadsheetID))
+	spreadsheetID := spreadsheetResp.Files[0].Id
+	log.Printf("[API]   Discovered spreadsheet: %s (%s)", spreadsheetResp.Files[0].Name, maskString(spreadsheetID))
 
-	// Find Grants folder in root folder
-	grantsFolderQuery := fmt.Sprintf("'%s' in parents and mimeType = 'application/vnd.google-apps.folder' and name = 'Grants' and trashed = false", s.rootFolderID)
-	grantsFolderResp, err := srv.Files.List().
-		Q(grantsFolderQuery).
-		SupportsAllDrives(true).
-		IncludeItemsFromAllDrives(true).
-		Fields("files(id, name)").
-		PageSize(1).
-		Do()
+	// Find Grants folder in root folder, creating it if this is a new instance
+	grantsFolderID, err := s.findGrantsFolder(ctx, srv)
 	if err != nil {
-		return fmt.Errorf("failed to search for Grants folder: %w", err)
+		return err
 	}
-
-	if len(grantsFolderResp.Files) == 0 {
-		// Create Grants folder if it doesn't exist
-		grantsFolder := &drive.File{
-			Name:     "Grants",
-			MimeType: "application/vnd.google-apps.folder",
-			Parents:  []string{s.rootFolderID},
-		}
-		created, err := srv.Files.Create(grantsFolder).
-			SupportsAllDrives(true).
-			Fields("id").
-			Do()
-		if err != nil {
-			return fmt.Errorf("failed to create Grants folder: %w", err)
+	if grantsFolderID == "" {
+		if grantsFolderID, err = s.createGrantsFolder(ctx, srv); err != nil {
+			return err
 		}
-		s.grantsFolderID = created.Id
-		log.Printf("[API]   Created Grants folder: %s", maskString(s.grantsFolderID))
 	} else {
-		s.grantsFolderID = grantsFolderResp.Files[0].Id
-		log.Printf("[API]   Discovered Grants folder: %s", maskString(s.grantsFolderID))
+		log.Printf("[API]   Discovered Grants folder: %s", maskString(grantsFolderID))
 	}
 
+	branding := s.loadBranding(ctx, spreadsheetID)
+
+	s.discoveryMu.Lock()
+	s.spreadsheetID = spreadsheetID
+	s.grantsFolderID = grantsFolderID
+	s.sharedDriveID = rootFolder.DriveId
+	s.branding = branding
+	s.discoveryMu.Unlock()
+
 	return nil
 }
 
@@ -170,9 +652,36 @@ func maskString(s string) string {
 
 // IsConfigured returns true if the server has service account credentials
 func (s *Server) IsConfigured() bool {
+	s.clientMu.Lock()
+	defer s.clientMu.Unlock()
 	return s.credentials != nil || os.Getenv("GOOGLE_APPLICATION_CREDENTIALS") != ""
 }
 
+// clientOptions authenticates a Google API client with the service account
+// (or application default credentials) and counts its calls in s.apiUsage
+func (s *Server) clientOptions(ctx context.Context, scope string) ([]option.ClientOption, error) {
+	opts := []option.ClientOption{option.WithScopes(scope)}
+
+	if s.credentials != nil {
+		config, err := google.JWTConfigFromJSON(s.credentials, scope)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse service account credentials: %w", err)
+		}
+		config.Subject = s.impersonateSubject
+		var ts oauth2.TokenSource = config.TokenSource(ctx)
+		if s.impersonateSubject != "" {
+			ts = impersonationTokenSource{base: ts, subject: s.impersonateSubject, clientID: serviceAccountClientID(s.credentials), scope: scope}
+		}
+		opts = append(opts, option.WithTokenSource(ts))
+	}
+
+	transport, err := htransport.NewTransport(ctx, &usageTransport{base: http.DefaultTransport, usage: &s.apiUsage}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return []option.ClientOption{option.WithHTTPClient(&http.Client{Transport: transport})}, nil
+}
+
 // sheetsService returns an authenticated Sheets API service (cached)
 func (s *Server) sheetsService(ctx context.Context) (*sheets.Service, error) {
 	s.clientMu.Lock()
@@ -182,14 +691,9 @@ func (s *Server) sheetsService(ctx context.Context) (*sheets.Service, error) {
 		return s.sheetsClient, nil
 	}
 
-	var opts []option.ClientOption
-
-	if s.credentials != nil {
-		config, err := google.JWTConfigFromJSON(s.credentials, sheets.SpreadsheetsScope)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse service account credentials: %w", err)
-		}
-		opts = append(opts, option.WithTokenSource(config.TokenSource(ctx)))
+	opts, err := s.clientOptions(ctx, sheets.SpreadsheetsScope)
+	if err != nil {
+		return nil, err
 	}
 
 	srv, err := sheets.NewService(ctx, opts...)
@@ -209,14 +713,9 @@ func (s *Server) driveService(ctx context.Context) (*drive.Service, error) {
 		return s.driveClient, nil
 	}
 
-	var opts []option.ClientOption
-
-	if s.credentials != nil {
-		config, err := google.JWTConfigFromJSON(s.credentials, drive.DriveScope)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse service account credentials: %w", err)
-		}
-		opts = append(opts, option.WithTokenSource(config.TokenSource(ctx)))
+	opts, err := s.clientOptions(ctx, drive.DriveScope)
+	if err != nil {
+		return nil, err
 	}
 
 	srv, err := drive.NewService(ctx, opts...)
@@ -236,14 +735,9 @@ func (s *Server) docsService(ctx context.Context) (*docs.Service, error) {
 		return s.docsClient, nil
 	}
 
-	var opts []option.ClientOption
-
-	if s.credentials != nil {
-		config, err := google.JWTConfigFromJSON(s.credentials, docs.DocumentsScope)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse service account credentials: %w", err)
-		}
-		opts = append(opts, option.WithTokenSource(config.TokenSource(ctx)))
+	opts, err := s.clientOptions(ctx, docs.DocumentsScope)
+	if err != nil {
+		return nil, err
 	}
 
 	srv, err := docs.NewService(ctx, opts...)
@@ -261,14 +755,12 @@ func (s *Server) docsService(ctx context.Context) (*docs.Service, error) {
 // authCacheEntry stores cached authorization results
 type authCacheEntry struct {
 	hasAccess bool
+	role      string // Drive permission role, empty when unknown
 	expires   time.Time
 }
 
-var (
-	authCache     = make(map[string]*authCacheEntry)
-	authCacheMu   sync.RWMutex
-	cacheDuration = 5 * time.Minute
-)
+// defaultAuthCacheTTL is how long a Drive access check is trusted (see AUTH_CACHE_TTL)
+const defaultAuthCacheTTL = 5 * time.Minute
 
 // UserInfo contains authenticated user information
 type UserInfo struct {
@@ -277,11 +769,52 @@ type UserInfo struct {
 	Picture string `json:"picture"`
 }
 
-// RequireAuth wraps a handler with authentication check
+// SessionRefresher exchanges the refresh token stored for the request's session for a new access
+// token, setting the updated cookie on w. It returns the new token and its lifetime
+// in seconds. Set by main when server-side OAuth is configured.
+type SessionRefresher func(w http.ResponseWriter, r *http.Request) (accessToken string, expiresIn int, err error)
+
+// RefreshSession enables auto-refresh in RequireAuth when set (nil = disabled)
+var RefreshSession SessionRefresher
+
+// Production is set by main when ENV=production, so cookies are always marked Secure
+var Production bool
+
+// AccessNearExpiry reports whether the request's access token is about to
+// expire, so RequireAuth refreshes it before it lapses mid-session. Set by
+// main, which tracks token lifetimes in the session (nil = only refresh once
+// the cookie is gone).
+var AccessNearExpiry func(r *http.Request) bool
+
+// RequireAuth wraps a handler with authentication check. The access token is
+// verified with Google (see verifiedTokenEmail) and must belong to the user in
+// the gt_user cookie. If the access token cookie has expired, or is about to
+// (see AccessNearExpiry), and a session cookie is present, the session is
+// refreshed in place and the response carries X-Token-Refreshed and
+// X-Token-Expires-In so the client can reset its refresh timer.
 func RequireAuth(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		accessCookie, err := r.Cookie("gt_access_token")
-		if err != nil || accessCookie.Value == "" {
+		accessToken := ""
+		if accessCookie, err := r.Cookie("gt_access_token"); err == nil {
+			accessToken = accessCookie.Value
+		}
+
+		expiring := accessToken != "" && AccessNearExpiry != nil && AccessNearExpiry(r)
+		if (accessToken == "" || expiring) && RefreshSession != nil {
+			if _, err := r.Cookie("gt_session"); err == nil {
+				token, expiresIn, err := RefreshSession(w, r)
+				if err != nil {
+					// A token that's only expiring is still good for this request
+					log.Printf("[API] Auto-refresh failed: %v", err)
+				} else {
+					accessToken = token
+					w.Header().Set("X-Token-Refreshed", "true")
+					w.Header().Set("X-Token-Expires-In", strconv.Itoa(expiresIn))
+				}
+			}
+		}
+
+		if accessToken == "" {
 			writeError(w, "Unauthorized: No access token", http.StatusUnauthorized)
 			return
 		}
@@ -292,8 +825,9 @@ func RequireAuth(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
-		decoded, err := base64.StdEncoding.DecodeString(userCookie.Value)
+		decoded, err := VerifyUserCookie(userCookie.Value)
 		if err != nil {
+			log.Printf("[API] Rejected user cookie: %v", err)
 			writeError(w, "Unauthorized: Invalid user info", http.StatusUnauthorized)
 			return
 		}
@@ -304,17 +838,37 @@ func RequireAuth(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
+		// The cookies are readable and writable by the client, so the identity
+		// comes from Google's view of the token, not from gt_user
+		email, err := verifiedTokenEmail(r.Context(), accessToken)
+		if errors.Is(err, errInvalidToken) {
+			log.Printf("[API] Rejected access token for %s: %v", user.Email, err)
+			writeError(w, "Unauthorized: Invalid access token", http.StatusUnauthorized)
+			return
+		}
+		if err != nil {
+			log.Printf("[API] Failed to verify access token: %v", err)
+			writeError(w, "Failed to verify access token", http.StatusInternalServerError)
+			return
+		}
+		if !strings.EqualFold(email, user.Email) {
+			log.Printf("[API] User cookie claims %s but access token belongs to %s", user.Email, email)
+			writeError(w, "Unauthorized: User info does not match access token", http.StatusUnauthorized)
+			return
+		}
+		user.Email = email
+
 		// Store in request context via headers
 		r.Header.Set("X-User-Email", user.Email)
 		r.Header.Set("X-User-Name", user.Name)
-		r.Header.Set("X-Access-Token", accessCookie.Value)
+		r.Header.Set("X-Access-Token", accessToken)
 
 		next(w, r)
 	}
 }
 
 // RequireDriveAccess wraps a handler with Drive access verification (legacy, uses user token)
-func RequireDriveAccess(folderId string, next http.HandlerFunc) http.HandlerFunc {
+func (s *Server) RequireDriveAccess(folderId string, next http.HandlerFunc) http.HandlerFunc {
 	return RequireAuth(func(w http.ResponseWriter, r *http.Request) {
 		userEmail := r.Header.Get("X-User-Email")
 		userToken := r.Header.Get("X-Access-Token")
@@ -325,9 +879,9 @@ func RequireDriveAccess(folderId string, next http.HandlerFunc) http.HandlerFunc
 		}
 
 		// Check cache
-		hasAccess, cacheHit := checkAuthCache(userEmail, folderId)
+		entry, cacheHit := s.checkAuthCache(userEmail, folderId)
 		if cacheHit {
-			if !hasAccess {
+			if !entry.hasAccess {
 				writeError(w, "Access denied. You do not have permission to this Grant Tracker instance.", http.StatusForbidden)
 				return
 			}
@@ -343,7 +897,7 @@ func RequireDriveAccess(folderId string, next http.HandlerFunc) http.HandlerFunc
 			return
 		}
 
-		setAuthCache(userEmail, folderId, hasAccess)
+		s.setAuthCache(userEmail, folderId, hasAccess, "")
 
 		if !hasAccess {
 			writeError(w, "Access denied. You do not have permission to this Grant Tracker instance.", http.StatusForbidden)
@@ -359,39 +913,78 @@ func RequireDriveAccess(folderId string, next http.HandlerFunc) http.HandlerFunc
 func (s *Server) RequireAccess(next http.HandlerFunc) http.HandlerFunc {
 	return RequireAuth(func(w http.ResponseWriter, r *http.Request) {
 		userEmail := r.Header.Get("X-User-Email")
-		folderId := s.grantsFolderID
+		if err := s.awaitDiscovery(r.Context()); err != nil {
+			if isCancelled(err) {
+				writeCancelled(w, "RequireAccess")
+				return
+			}
+			w.Header().Set("Retry-After", strconv.Itoa(discoveryRetryAfterSeconds))
+			writeError(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		folderId := s.discoveredGrantsFolderID()
 
 		if folderId == "" {
 			writeError(w, "Server configuration error: GRANTS_FOLDER_ID not set", http.StatusInternalServerError)
 			return
 		}
 
+		// A handler that finds the user's Drive access gone says so, and the
+		// next request checks Drive again. Other 403s (roles, sensitive
+		// columns, scope) leave the cached decision alone.
+		next := func(w http.ResponseWriter, r *http.Request) {
+			watcher := &accessWatcher{ResponseWriter: w}
+			next(watcher, r)
+			if watcher.lost {
+				s.evictAuthCache(userEmail, folderId)
+				s.revokeCapabilities(userEmail, time.Now())
+			}
+		}
+
+		// A valid capability proves a recent successful check; a bad one just
+		// falls through to verifying again
+		if token := capabilityFromRequest(r); token != "" && s.capabilityTTL > 0 {
+			if role, err := s.verifyCapability(token, userEmail, folderId, time.Now()); err == nil {
+				r.Header.Set("X-User-Role", role)
+				next(w, r)
+				return
+			}
+		}
+
 		// Check cache
-		hasAccess, cacheHit := checkAuthCache(userEmail, folderId)
+		entry, cacheHit := s.checkAuthCache(userEmail, folderId)
 		if cacheHit {
-			if !hasAccess {
+			if !entry.hasAccess {
 				writeError(w, "Access denied. You do not have permission to this Grant Tracker instance.", http.StatusForbidden)
 				return
 			}
+			r.Header.Set("X-User-Role", entry.role)
 			next(w, r)
 			return
 		}
 
 		// Verify access using service account
-		hasAccess, err := s.verifyDriveAccessWithServiceAccount(r.Context(), userEmail, folderId)
+		role, err := s.verifyDriveAccessWithServiceAccount(r.Context(), userEmail, folderId)
 		if err != nil {
 			log.Printf("Error verifying drive access for %s: %v", userEmail, err)
 			writeError(w, "Failed to verify access permissions", http.StatusInternalServerError)
 			return
 		}
 
-		setAuthCache(userEmail, folderId, hasAccess)
+		hasAccess := role != ""
+		s.setAuthCache(userEmail, folderId, hasAccess, role)
 
 		if !hasAccess {
+			// Capabilities issued while the user still had access are now void
+			s.revokeCapabilities(userEmail, time.Now())
 			writeError(w, "Access denied. You do not have permission to this Grant Tracker instance.", http.StatusForbidden)
 			return
 		}
 
+		if s.capabilityTTL > 0 {
+			s.grantCapability(w, r, userEmail, folderId, role)
+		}
+		r.Header.Set("X-User-Role", role)
 		next(w, r)
 	})
 }
@@ -424,43 +1017,42 @@ func verifyDriveAccessWithToken(token, folderId string) (bool, error) {
 }
 
 // verifyDriveAccessWithServiceAccount checks if a user has access to a folder
-// by listing the folder's permissions using the service account
-func (s *Server) verifyDriveAccessWithServiceAccount(ctx context.Context, userEmail, folderId string) (bool, error) {
+// by listing the folder's permissions using the service account. It returns the
+// highest role the user holds, or an empty string if they have no access.
+func (s *Server) verifyDriveAccessWithServiceAccount(ctx context.Context, userEmail, folderId string) (string, error) {
 	srv, err := s.driveService(ctx)
 	if err != nil {
-		return false, fmt.Errorf("failed to get drive service: %w", err)
+		return "", fmt.Errorf("failed to get drive service: %w", err)
 	}
 
-	// List permissions on the folder
-	perms, err := srv.Permissions.List(folderId).
-		SupportsAllDrives(true).
-		Fields("permissions(emailAddress,role,type)").
-		Do()
+	// List permissions on the folder (all pages, so large ACLs aren't cut off)
+	perms, err := listAllPermissions(ctx, srv, folderId)
 	if err != nil {
-		return false, fmt.Errorf("failed to list permissions: %w", err)
+		return "", fmt.Errorf("failed to list permissions: %w", err)
 	}
 
-	// Check if user's email is in the permissions
-	for _, perm := range perms.Permissions {
+	// Collect the highest role across every permission that covers the user
+	role := ""
+	for _, perm := range perms {
 		// Check direct user permission
 		if perm.Type == "user" && perm.EmailAddress == userEmail {
-			return true, nil
+			role = higherRole(role, perm.Role)
 		}
 		// Check domain-wide permission (anyone in the domain)
 		if perm.Type == "domain" {
 			// Extract domain from user email
 			parts := splitEmail(userEmail)
 			if len(parts) == 2 && perm.Domain == parts[1] {
-				return true, nil
+				role = higherRole(role, perm.Role)
 			}
 		}
 		// "anyone" type means public access
 		if perm.Type == "anyone" {
-			return true, nil
+			role = higherRole(role, perm.Role)
 		}
 	}
 
-	return false, nil
+	return role, nil
 }
 
 // splitEmail splits an email into local and domain parts
@@ -473,26 +1065,27 @@ func splitEmail(email string) []string {
 	return []string{email}
 }
 
-func checkAuthCache(email, folderId string) (bool, bool) {
+func (s *Server) checkAuthCache(email, folderId string) (authCacheEntry, bool) {
 	key := email + ":" + folderId
-	authCacheMu.RLock()
-	entry, exists := authCache[key]
-	authCacheMu.RUnlock()
+	s.authCacheMu.RLock()
+	entry, exists := s.authCache[key]
+	s.authCacheMu.RUnlock()
 
 	if !exists || time.Now().After(entry.expires) {
-		return false, false
+		return authCacheEntry{}, false
 	}
-	return entry.hasAccess, true
+	return *entry, true
 }
 
-func setAuthCache(email, folderId string, hasAccess bool) {
+func (s *Server) setAuthCache(email, folderId string, hasAccess bool, role string) {
 	key := email + ":" + folderId
-	authCacheMu.Lock()
-	authCache[key] = &authCacheEntry{
+	s.authCacheMu.Lock()
+	s.authCache[key] = &authCacheEntry{
 		hasAccess: hasAccess,
-		expires:   time.Now().Add(cacheDuration),
+		role:      role,
+		expires:   time.Now().Add(s.authCacheTTL),
 	}
-	authCacheMu.Unlock()
+	s.authCacheMu.Unlock()
 }
 
 // ============================================
@@ -505,41 +1098,153 @@ func writeError(w http.ResponseWriter, message string, status int) {
 	json.NewEncoder(w).Encode(Error{Error: message})
 }
 
+// writeErrorCode writes an error with a machine-readable code clients can branch on
+func writeErrorCode(w http.ResponseWriter, message, code string, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(Error{Error: message, Code: &code})
+}
+
 func writeJSON(w http.ResponseWriter, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(data)
 }
 
-func decodeBody(r *http.Request, v interface{}) error {
-	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
-		return fmt.Errorf("invalid request body: %w", err)
-	}
-	return nil
+func writeJSONStatus(w http.ResponseWriter, data interface{}, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
 }
 
-// ============================================
-// Config endpoint
-// ============================================
+// statusClientClosedRequest is the non-standard status logged when the client
+// disconnects before a long operation finishes
+const statusClientClosedRequest = 499
 
-func (s *Server) GetConfig(w http.ResponseWriter, r *http.Request) {
-	config := Config{
-		ClientId:              s.clientID,
-		ServiceAccountEnabled: s.IsConfigured(),
-	}
+// isCancelled reports whether err comes from the client cancelling the request.
+// A deadline running out is an upstream timeout, not a disconnect.
+func isCancelled(err error) bool {
+	return errors.Is(err, context.Canceled)
+}
 
-	if s.IsConfigured() {
-		if s.spreadsheetID != "" {
-			config.SpreadsheetId = &s.spreadsheetID
-		}
-		if s.grantsFolderID != "" {
-			config.GrantsFolderId = &s.grantsFolderID
+// writeCancelled ends a request whose client went away mid-operation
+func writeCancelled(w http.ResponseWriter, op string) {
+	log.Printf("[API] %s cancelled by client", op)
+	writeError(w, "Request cancelled", statusClientClosedRequest)
+}
+
+// isServerError reports whether a Google API error is a 5xx (transient upstream failure)
+func isServerError(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code >= 500
+}
+
+// cellString formats a cell value the way it appears in the sheet. Whole numbers
+// are printed without exponent or fraction so large numeric IDs compare exactly.
+func cellString(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		if val == math.Trunc(val) && math.Abs(val) < 1<<53 {
+			return strconv.FormatInt(int64(val), 10)
 		}
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case json.Number:
+		return val.String()
+	default:
+		return fmt.Sprintf("%v", val)
 	}
+}
+
+// isIDColumn reports whether a column holds identifiers that must survive the
+// JSON round trip unchanged. ID_COLUMNS overrides the default naming heuristic.
+func (s *Server) isIDColumn(header string) bool {
+	if s.idColumns != nil {
+		return s.idColumns[header]
+	}
+	lower := strings.ToLower(header)
+	return lower == "id" || strings.HasSuffix(lower, "_id")
+}
+
+// stringifyIDColumns converts numeric values in ID columns to strings so
+// clients see IDs the same way whatever the cell holds. Writes store IDs as
+// text (see idCellValue); this covers cells entered before that or by hand in
+// Sheets, which are exact up to 2^53.
+func (s *Server) stringifyIDColumns(headers []string, rows [][]interface{}) {
+	for colIdx, header := range headers {
+		if !s.isIDColumn(header) {
+			continue
+		}
+		for _, row := range rows {
+			if colIdx < len(row) {
+				if _, isString := row[colIdx].(string); !isString {
+					row[colIdx] = cellString(row[colIdx])
+				}
+			}
+		}
+	}
+}
 
-	log.Printf("[API] GetConfig: serviceAccountEnabled=%v, spreadsheetId=%v, grantsFolderId=%v",
+func decodeBody(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
+
+// ============================================
+// Config endpoint
+// ============================================
+
+// buildConfig assembles the client configuration
+func (s *Server) buildConfig() Config {
+	config := Config{
+		ClientId:              s.clientID,
+		ServiceAccountEnabled: s.IsConfigured(),
+	}
+
+	if s.IsConfigured() {
+		if id := s.discoveredSpreadsheetID(); id != "" {
+			config.SpreadsheetId = &id
+		}
+		if id := s.discoveredGrantsFolderID(); id != "" {
+			config.GrantsFolderId = &id
+		}
+		if id := s.discoveredSharedDriveID(); id != "" {
+			if !s.exposeSharedDriveID {
+				id = maskString(id)
+			}
+			config.SharedDriveId = &id
+		}
+	}
+
+	if s.safeMode {
+		safeMode := true
+		config.SafeMode = &safeMode
+	}
+
+	config.Branding = s.currentBranding()
+
+	if s.isDiscovering() {
+		discovering := true
+		config.Discovering = &discovering
+	}
+	return config
+}
+
+func (s *Server) GetConfig(w http.ResponseWriter, r *http.Request) {
+	config := s.buildConfig()
+	if config.Discovering != nil {
+		w.Header().Set("Retry-After", strconv.Itoa(discoveryRetryAfterSeconds))
+	}
+
+	log.Printf("[API] GetConfig: serviceAccountEnabled=%v, spreadsheetId=%v, grantsFolderId=%v, discovering=%v",
 		config.ServiceAccountEnabled,
 		config.SpreadsheetId != nil,
-		config.GrantsFolderId != nil)
+		config.GrantsFolderId != nil,
+		config.Discovering != nil)
 
 	writeJSON(w, config)
 }
@@ -555,12 +1260,48 @@ func (s *Server) ReadSheet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	valueRender, dateTimeRender, err := renderOptions(req)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	mine := req.Mine != nil && *req.Mine
+	asObjects := req.AsObjects != nil && *req.AsObjects
+	if req.Ranges != nil && len(*req.Ranges) > 0 {
+		if mine {
+			writeError(w, "mine can't be combined with ranges", http.StatusBadRequest)
+			return
+		}
+		if asObjects {
+			writeError(w, "asObjects can't be combined with ranges", http.StatusBadRequest)
+			return
+		}
+		s.readRanges(w, r, *req.Ranges, valueRender, dateTimeRender)
+		return
+	}
+
 	if req.Sheet == "" {
 		writeError(w, "Sheet name is required", http.StatusBadRequest)
 		return
 	}
+	if refuseInternalSheet(w, req.Sheet) {
+		return
+	}
 
-	log.Printf("[API] ReadSheet: %s (spreadsheet: %s)", req.Sheet, maskString(s.spreadsheetID))
+	offset, limit, err := pageWindow(req.Offset, req.Limit, req.PageToken)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	spreadsheetID, err := s.spreadsheetFor(r)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	log.Printf("[API] ReadSheet: %s (spreadsheet: %s)", req.Sheet, maskString(spreadsheetID))
 
 	rangeStr := req.Sheet
 	if req.Range != nil && *req.Range != "" {
@@ -574,32 +1315,98 @@ func (s *Server) ReadSheet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := srv.Spreadsheets.Values.Get(s.spreadsheetID, rangeStr).
-		ValueRenderOption("UNFORMATTED_VALUE").Do()
+	if req.Range != nil && *req.Range != "" {
+		if err := s.validateRanges(r.Context(), srv, spreadsheetID, req.Sheet, []string{*req.Range}); err != nil {
+			writeRangeError(w, err)
+			return
+		}
+	}
+
+	stale := false
+	sourceID := spreadsheetID
+	read := func() (*sheets.ValueRange, error) {
+		return srv.Spreadsheets.Values.Get(sourceID, rangeStr).
+			ValueRenderOption(valueRender).DateTimeRenderOption(dateTimeRender).Context(r.Context()).Do()
+	}
+	resp, err := withRetry(r.Context(), s.retryAttempts, true, read)
+	if err != nil && spreadsheetID == s.discoveredSpreadsheetID() && s.replicaSpreadsheetID != "" && isServerError(err) {
+		log.Printf("[API] ReadSheet: primary failed (%v), falling back to replica", err)
+		sourceID = s.replicaSpreadsheetID
+		resp, err = withRetry(r.Context(), s.retryAttempts, true, read)
+		stale = err == nil
+	}
 	if err != nil {
+		if sheetMissing(r.Context(), srv, sourceID, req.Sheet, err) {
+			writeError(w, fmt.Sprintf("Sheet %s not found", req.Sheet), http.StatusNotFound)
+			return
+		}
 		log.Printf("Failed to read sheet %s: %v", req.Sheet, err)
 		writeError(w, fmt.Sprintf("Failed to read sheet: %v", err), http.StatusInternalServerError)
 		return
 	}
 
-	var headers []string
-	var rows [][]interface{}
+	// Sheet position of the first value, for ranges that don't start at A1
+	rowOffset, colOffset := 0, 0
+	if req.Range != nil && *req.Range != "" {
+		var ok bool
+		if rowOffset, colOffset, ok = a1Start(*req.Range); !ok {
+			writeError(w, fmt.Sprintf("Invalid range %s", *req.Range), http.StatusBadRequest)
+			return
+		}
+	}
 
-	if len(resp.Values) > 0 {
-		for _, v := range resp.Values[0] {
-			headers = append(headers, fmt.Sprintf("%v", v))
+	if req.NormalizeMergedCells != nil && *req.NormalizeMergedCells {
+		merges, err := mergedRanges(srv, sourceID, req.Sheet)
+		if err != nil {
+			log.Printf("Failed to get merged cells for %s: %v", req.Sheet, err)
+			writeError(w, "Failed to get merged cells", http.StatusInternalServerError)
+			return
 		}
-		if len(resp.Values) > 1 {
-			rows = resp.Values[1:]
+		fillMerges(resp.Values, merges, rowOffset, colOffset)
+	}
+
+	// An explicit range starts at its own header; whole-sheet reads honor the sheet's header row
+	headerRow := s.headerRow(req.Sheet)
+	if req.Range != nil && *req.Range != "" {
+		headerRow = 1
+	}
+	hidden, err := s.hiddenSheetColumns(r, srv, sourceID, req.Sheet)
+	if err != nil {
+		log.Printf("Failed to find sensitive columns: %v", err)
+		writeError(w, "Failed to read sheet headers", http.StatusInternalServerError)
+		return
+	}
+	values := resp.Values
+	if mine {
+		if values, err = ownedValues(values, headerRow, s.ownerColumn, r.Header.Get("X-User-Email")); err != nil {
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
 		}
 	}
+	headers, rows, columns := s.sheetData(values, headerRow, colOffset, hidden)
+
+	rows, pageInfo := pageRows(rows, offset, limit)
 
 	log.Printf("[API] ReadSheet %s: %d headers, %d rows", req.Sheet, len(headers), len(rows))
+	s.auditRead(r, AuditEvent{
+		Action:   "read_sheet",
+		Resource: req.Sheet,
+		Detail:   fmt.Sprintf("read %s (%d rows)", rangeStr, len(rows)),
+	})
 	if len(headers) > 0 {
 		log.Printf("[API]   Headers: %v", headers)
 	}
 
-	writeJSON(w, ReadSheetResponse{Headers: headers, Rows: rows})
+	result := ReadSheetResponse{Headers: headers, Rows: rows, Columns: &columns, PageInfo: pageInfo}
+	if asObjects {
+		objects := rowObjects(headers, rows)
+		result.Objects = &objects
+		result.Rows = [][]interface{}{}
+	}
+	if stale {
+		result.Stale = &stale
+	}
+	writeJSON(w, result)
 }
 
 func (s *Server) AppendRow(w http.ResponseWriter, r *http.Request) {
@@ -613,6 +1420,20 @@ func (s *Server) AppendRow(w http.ResponseWriter, r *http.Request) {
 		writeError(w, "Sheet name is required", http.StatusBadRequest)
 		return
 	}
+	if refuseInternalSheet(w, req.Sheet) {
+		return
+	}
+
+	if err := s.checkSensitiveWrite(r, req.Row); err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	spreadsheetID, err := s.spreadsheetFor(r)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
 
 	srv, err := s.sheetsService(r.Context())
 	if err != nil {
@@ -621,9 +1442,24 @@ func (s *Server) AppendRow(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	rules, ok := s.fieldRules(w, r, srv, spreadsheetID, req.Sheet, "AppendRow")
+	if !ok {
+		return
+	}
+	if problems := validateFields(rules, req.Row, false); len(problems) > 0 {
+		writeValidationError(w, problems)
+		return
+	}
+
 	// Get headers
-	headersResp, err := srv.Spreadsheets.Values.Get(s.spreadsheetID, req.Sheet+"!1:1").Do()
+	headersResp, err := withRetry(r.Context(), s.retryAttempts, true, func() (*sheets.ValueRange, error) {
+		return srv.Spreadsheets.Values.Get(spreadsheetID, s.headerRange(req.Sheet)).Context(r.Context()).Do()
+	})
 	if err != nil {
+		if sheetMissing(r.Context(), srv, spreadsheetID, req.Sheet, err) {
+			writeError(w, fmt.Sprintf("Sheet %s not found", req.Sheet), http.StatusNotFound)
+			return
+		}
 		log.Printf("Failed to get headers: %v", err)
 		writeError(w, "Failed to get sheet headers", http.StatusInternalServerError)
 		return
@@ -634,11 +1470,16 @@ func (s *Server) AppendRow(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	columns, err := s.columnsForData(req.Sheet, headersResp.Values[0], req.Row)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Build row in header order
 	var rowValues []interface{}
-	for _, header := range headersResp.Values[0] {
-		headerStr := fmt.Sprintf("%v", header)
-		if val, ok := req.Row[headerStr]; ok {
+	for colIdx := range headersResp.Values[0] {
+		if val, ok := columns[colIdx]; ok {
 			rowValues = append(rowValues, val)
 		} else {
 			rowValues = append(rowValues, "")
@@ -646,10 +1487,15 @@ func (s *Server) AppendRow(w http.ResponseWriter, r *http.Request) {
 	}
 
 	valueRange := &sheets.ValueRange{Values: [][]interface{}{rowValues}}
-	_, err = srv.Spreadsheets.Values.Append(s.spreadsheetID, req.Sheet, valueRange).
-		ValueInputOption("USER_ENTERED").
-		InsertDataOption("INSERT_ROWS").
-		Do()
+	// Anchor table detection at the header so banner rows are not mistaken for the table
+	appendRange := fmt.Sprintf("%s!A%d", req.Sheet, s.headerRow(req.Sheet))
+	appendResp, err := withRetry(r.Context(), s.retryAttempts, false, func() (*sheets.AppendValuesResponse, error) {
+		return srv.Spreadsheets.Values.Append(spreadsheetID, appendRange, valueRange).
+			ValueInputOption("USER_ENTERED").
+			InsertDataOption("INSERT_ROWS").
+			Context(r.Context()).
+			Do()
+	})
 
 	if err != nil {
 		log.Printf("Failed to append row: %v", err)
@@ -657,10 +1503,44 @@ func (s *Server) AppendRow(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userEmail := r.Header.Get("X-User-Email")
-	log.Printf("AUDIT: %s appended row to %s", userEmail, req.Sheet)
+	var mismatches []string
+	if req.Verify != nil && *req.Verify && appendResp.Updates != nil {
+		got, err := readBackRow(r.Context(), srv, spreadsheetID, appendResp.Updates.UpdatedRange)
+		if err != nil {
+			log.Printf("Failed to read back appended row: %v", err)
+			writeError(w, fmt.Sprintf("Row appended but could not be verified: %v", err), http.StatusInternalServerError)
+			return
+		}
+		written := make([]int, len(rowValues))
+		for i := range rowValues {
+			written[i] = i
+		}
+		mismatches = rowMismatches(headersResp.Values[0], rowValues, got, written)
+	}
+
+	s.audit(r, AuditEvent{
+		Action:   "append_row",
+		Resource: req.Sheet,
+		Detail:   fmt.Sprintf("appended row to %s", req.Sheet),
+		After:    rowPayload(headersResp.Values[0], rowValues),
+	})
 
-	writeJSON(w, SuccessResponse{Success: true})
+	if len(mismatches) > 0 {
+		writeErrorCode(w, verifyError(mismatches), verifyFailedCode, http.StatusUnprocessableEntity)
+		return
+	}
+
+	result := AppendRowResponse{Success: true}
+	if appendResp.Updates != nil && appendResp.Updates.UpdatedRange != "" {
+		updated := appendResp.Updates.UpdatedRange
+		result.UpdatedRange = &updated
+		if row, err := firstRow(updated); err != nil {
+			log.Printf("[API] AppendRow: could not parse updated range %q: %v", updated, err)
+		} else {
+			result.RowNumber = &row
+		}
+	}
+	writeJSON(w, result)
 }
 
 func (s *Server) UpdateRow(w http.ResponseWriter, r *http.Request) {
@@ -669,11 +1549,63 @@ func (s *Server) UpdateRow(w http.ResponseWriter, r *http.Request) {
 		writeError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	s.updateRow(w, r, req, nil)
+}
+
+// ConditionalUpdate updates a row only if a column currently holds an expected value
+func (s *Server) ConditionalUpdate(w http.ResponseWriter, r *http.Request) {
+	var req ConditionalUpdateRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Condition.Column == "" {
+		writeError(w, "condition.column is required", http.StatusBadRequest)
+		return
+	}
+	if s.sensitiveColumns[req.Condition.Column] && !s.canSeeSensitive(r) {
+		writeError(w, fmt.Sprintf("insufficient permission to read column %s", req.Condition.Column), http.StatusForbidden)
+		return
+	}
+
+	s.updateRow(w, r, UpdateRowRequest{
+		Sheet:    req.Sheet,
+		IdColumn: req.IdColumn,
+		Id:       req.Id,
+		Data:     req.Data,
+	}, &req.Condition)
+}
+
+// updateRow applies an UpdateRow request. When cond is set the write only
+// happens if the row's cond.Column equals cond.Equals, otherwise it is a 409;
+// req.ExpectedValues does the same for several columns at once.
+func (s *Server) updateRow(w http.ResponseWriter, r *http.Request, req UpdateRowRequest, cond *UpdateCondition) {
+	// Keeps this instance's own check-then-writes from interleaving. Writes
+	// from anywhere else can still slip in between, so the check narrows the
+	// race rather than closing it.
+	if cond != nil || req.ExpectedValues != nil {
+		s.conditionalMu.Lock()
+		defer s.conditionalMu.Unlock()
+	}
 
 	if req.Sheet == "" || req.IdColumn == "" || req.Id == "" {
 		writeError(w, "Sheet, idColumn, and id are required", http.StatusBadRequest)
 		return
 	}
+	if refuseInternalSheet(w, req.Sheet) {
+		return
+	}
+
+	if err := s.checkSensitiveWrite(r, req.Data); err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	spreadsheetID, err := s.spreadsheetFor(r)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
 
 	srv, err := s.sheetsService(r.Context())
 	if err != nil {
@@ -681,163 +1613,561 @@ func (s *Server) UpdateRow(w http.ResponseWriter, r *http.Request) {
 		writeError(w, "Failed to connect to Google Sheets", http.StatusInternalServerError)
 		return
 	}
-
-	resp, err := srv.Spreadsheets.Values.Get(s.spreadsheetID, req.Sheet).Do()
+
+	rules, ok := s.fieldRules(w, r, srv, spreadsheetID, req.Sheet, "UpdateRow")
+	if !ok {
+		return
+	}
+	if problems := validateFields(rules, req.Data, true); len(problems) > 0 {
+		writeValidationError(w, problems)
+		return
+	}
+
+	resp, err := withRetry(r.Context(), s.retryAttempts, true, func() (*sheets.ValueRange, error) {
+		return srv.Spreadsheets.Values.Get(spreadsheetID, req.Sheet).
+			ValueRenderOption("UNFORMATTED_VALUE").Context(r.Context()).Do()
+	})
+	if err != nil {
+		if sheetMissing(r.Context(), srv, spreadsheetID, req.Sheet, err) {
+			writeError(w, fmt.Sprintf("Sheet %s not found", req.Sheet), http.StatusNotFound)
+			return
+		}
+		log.Printf("Failed to read sheet: %v", err)
+		writeError(w, "Failed to read sheet", http.StatusInternalServerError)
+		return
+	}
+
+	table := splitTable(resp.Values, s.headerRow(req.Sheet))
+	if len(table.rows) == 0 {
+		writeError(w, "Sheet has no data rows", http.StatusNotFound)
+		return
+	}
+
+	// Find ID column
+	headers := table.headers
+	idColIdx := table.indexOf(req.IdColumn)
+	if idColIdx == -1 {
+		writeError(w, fmt.Sprintf("Column %s not found", req.IdColumn), http.StatusBadRequest)
+		return
+	}
+
+	// Find row (1-based sheet row number)
+	rowIdx := -1
+	if i := table.findRow(idColIdx, req.Id); i != -1 {
+		rowIdx = table.sheetRow(i)
+	}
+
+	if rowIdx == -1 {
+		writeError(w, fmt.Sprintf("Row with %s=%s not found", req.IdColumn, req.Id), http.StatusNotFound)
+		return
+	}
+
+	existingRow := resp.Values[rowIdx-1]
+
+	// Compare-and-set: check the predicate against the row as it is now
+	if cond != nil {
+		changed, err := expectedMismatches(table, existingRow, map[string]interface{}{cond.Column: cond.Equals})
+		if err != nil {
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(changed) > 0 {
+			writeConflict(w, changed, s.visiblePayload(r, rowPayload(headers, existingRow)))
+			return
+		}
+	}
+
+	// Optimistic concurrency: reject if anything the client read has changed
+	if req.ExpectedValues != nil {
+		// Comparing against a column the caller can't read would leak its value
+		expected := make([]string, 0, len(*req.ExpectedValues))
+		for column := range *req.ExpectedValues {
+			expected = append(expected, column)
+		}
+		if _, status, err := s.readableColumns(r, table, expected); err != nil {
+			writeError(w, err.Error(), status)
+			return
+		}
+		changed, err := expectedMismatches(table, existingRow, *req.ExpectedValues)
+		if err != nil {
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(changed) > 0 {
+			writeConflict(w, changed, s.visiblePayload(r, rowPayload(headers, existingRow)))
+			return
+		}
+	}
+
+	columns, err := s.columnsForData(req.Sheet, headers, req.Data)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Update row
+	before := rowPayload(headers, existingRow)
+	for colIdx, val := range columns {
+		for len(existingRow) <= colIdx {
+			existingRow = append(existingRow, "")
+		}
+		existingRow[colIdx] = val
+	}
+
+	rangeStr := fmt.Sprintf("%s!A%d", req.Sheet, rowIdx)
+	valueRange := &sheets.ValueRange{Range: rangeStr, Values: [][]interface{}{existingRow}}
+	err = s.writeValues(r.Context(), srv, spreadsheetID, []*sheets.ValueRange{valueRange})
+
+	if err != nil {
+		log.Printf("Failed to update row: %v", err)
+		writeError(w, fmt.Sprintf("Failed to update row: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	// Only the columns the client sent are compared; the rest were rewritten as read
+	var mismatches []string
+	if req.Verify != nil && *req.Verify {
+		written := fmt.Sprintf("%s!A%d:%s%d", req.Sheet, rowIdx, columnLetter(len(existingRow)-1), rowIdx)
+		got, err := readBackRow(r.Context(), srv, spreadsheetID, written)
+		if err != nil {
+			log.Printf("Failed to read back updated row: %v", err)
+			writeError(w, fmt.Sprintf("Row updated but could not be verified: %v", err), http.StatusInternalServerError)
+			return
+		}
+		sent := make([]int, 0, len(columns))
+		for colIdx := range columns {
+			sent = append(sent, colIdx)
+		}
+		sort.Ints(sent)
+		mismatches = rowMismatches(headers, existingRow, got, sent)
+	}
+
+	action, detail := "update_row", fmt.Sprintf("updated %s in %s (row %d)", req.Id, req.Sheet, rowIdx)
+	if cond != nil {
+		action = "conditional_update"
+		detail += fmt.Sprintf(" where %s=%s", cond.Column, cond.Equals)
+	}
+	after := rowPayload(headers, existingRow)
+	s.audit(r, AuditEvent{
+		Action:   action,
+		Resource: req.Sheet,
+		Target:   req.Id,
+		Detail:   detail,
+		Before:   before,
+		After:    after,
+	})
+	s.notifyOwner(r, req.Sheet, req.Id, before, after)
+
+	if len(mismatches) > 0 {
+		writeErrorCode(w, verifyError(mismatches), verifyFailedCode, http.StatusUnprocessableEntity)
+		return
+	}
+	writeJSON(w, SuccessResponse{Success: true})
+}
+
+// DeleteRow deletes the first row whose idColumn equals id, or every such row
+// when matchAll is set. Rows shorter than the ID column never match.
+func (s *Server) DeleteRow(w http.ResponseWriter, r *http.Request) {
+	var req DeleteRowRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.Sheet == "" || req.IdColumn == "" || req.Id == "" {
+		writeError(w, "Sheet, idColumn, and id are required", http.StatusBadRequest)
+		return
+	}
+	if refuseInternalSheet(w, req.Sheet) {
+		return
+	}
+
+	spreadsheetID, err := s.spreadsheetFor(r)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	srv, err := s.sheetsService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Sheets service: %v", err)
+		writeError(w, "Failed to connect to Google Sheets", http.StatusInternalServerError)
+		return
+	}
+
+	// Get spreadsheet to find sheet ID
+	sheetID, err := s.sheetIDByTitle(r.Context(), srv, spreadsheetID, req.Sheet)
+	if err != nil {
+		log.Printf("Failed to get spreadsheet: %v", err)
+		writeError(w, "Failed to get spreadsheet", http.StatusInternalServerError)
+		return
+	}
+
+	if sheetID == -1 {
+		writeError(w, fmt.Sprintf("Sheet %s not found", req.Sheet), http.StatusNotFound)
+		return
+	}
+
+	// Read data to find row
+	resp, err := withRetry(r.Context(), s.retryAttempts, true, func() (*sheets.ValueRange, error) {
+		return srv.Spreadsheets.Values.Get(spreadsheetID, req.Sheet).
+			ValueRenderOption("UNFORMATTED_VALUE").Context(r.Context()).Do()
+	})
+	if err != nil {
+		log.Printf("Failed to read sheet: %v", err)
+		writeError(w, "Failed to read sheet", http.StatusInternalServerError)
+		return
+	}
+
+	table := splitTable(resp.Values, s.headerRow(req.Sheet))
+	if len(table.rows) == 0 {
+		writeError(w, "Sheet has no data rows", http.StatusNotFound)
+		return
+	}
+
+	// Find ID column
+	idColIdx := table.indexOf(req.IdColumn)
+	if idColIdx == -1 {
+		writeError(w, fmt.Sprintf("Column %s not found", req.IdColumn), http.StatusBadRequest)
+		return
+	}
+
+	// Find the first matching row, or every one with matchAll
+	var matches []int
+	if req.MatchAll {
+		matches = table.findRows(idColIdx, req.Id)
+	} else if i := table.findRow(idColIdx, req.Id); i != -1 {
+		matches = []int{i}
+	}
+
+	if len(matches) == 0 {
+		writeError(w, fmt.Sprintf("Row with %s=%s not found", req.IdColumn, req.Id), http.StatusNotFound)
+		return
+	}
+
+	rowIndices := make([]int, len(matches))
+	matched := make([][]interface{}, len(matches))
+	for n, i := range matches {
+		rowIndices[n] = table.rowIndex(i)
+		matched[n] = table.rows[i]
+	}
+
+	// Only a single row's old values fit in one audit event
+	var before map[string]interface{}
+	detail := fmt.Sprintf("deleted %s from %s", req.Id, req.Sheet)
+	if len(matches) == 1 {
+		before = rowPayload(table.headers, matched[0])
+	} else {
+		detail = fmt.Sprintf("deleted %d rows with %s=%s from %s", len(matches), req.IdColumn, req.Id, req.Sheet)
+	}
+	if s.backupBeforeDelete {
+		backup, err := backupRows(r.Context(), srv, spreadsheetID, req.Sheet, table.headers, matched, time.Now())
+		if err != nil {
+			log.Printf("Failed to back up row: %v", err)
+			writeError(w, fmt.Sprintf("Row not deleted: backup failed: %v", err), http.StatusInternalServerError)
+			return
+		}
+		detail += fmt.Sprintf(" (backed up to %s)", backup)
+	}
+
+	// Delete rows in one batch; deleteRowsRequest orders them bottom-to-top
+	_, err = withRetry(r.Context(), s.retryAttempts, false, func() (*sheets.BatchUpdateSpreadsheetResponse, error) {
+		return srv.Spreadsheets.BatchUpdate(spreadsheetID, deleteRowsRequest(sheetID, rowIndices)).Context(r.Context()).Do()
+	})
+	if err != nil {
+		log.Printf("Failed to delete row: %v", err)
+		writeError(w, fmt.Sprintf("Failed to delete row: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	s.audit(r, AuditEvent{
+		Action:   "delete_row",
+		Resource: req.Sheet,
+		Target:   req.Id,
+		Detail:   detail,
+		Before:   before,
+	})
+
+	writeJSON(w, DeleteRowResponse{Success: true, Deleted: len(rowIndices)})
+}
+
+// DeleteRowsWhere deletes every row matching all of the given column values
+func (s *Server) DeleteRowsWhere(w http.ResponseWriter, r *http.Request) {
+	var req DeleteRowsWhereRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.Sheet == "" || len(req.Where) == 0 {
+		writeError(w, "Sheet and where are required", http.StatusBadRequest)
+		return
+	}
+	if refuseInternalSheet(w, req.Sheet) {
+		return
+	}
+
+	// Filtering on a hidden column would reveal its values
+	if err := s.checkSensitiveWrite(r, req.Where); err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	spreadsheetID, err := s.spreadsheetFor(r)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	srv, err := s.sheetsService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Sheets service: %v", err)
+		writeError(w, "Failed to connect to Google Sheets", http.StatusInternalServerError)
+		return
+	}
+
+	sheetID, err := s.sheetIDByTitle(r.Context(), srv, spreadsheetID, req.Sheet)
+	if isCancelled(err) {
+		writeCancelled(w, "DeleteRowsWhere")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to get spreadsheet: %v", err)
+		writeError(w, "Failed to get spreadsheet", http.StatusInternalServerError)
+		return
+	}
+
+	if sheetID == -1 {
+		writeError(w, fmt.Sprintf("Sheet %s not found", req.Sheet), http.StatusNotFound)
+		return
+	}
+
+	resp, err := srv.Spreadsheets.Values.Get(spreadsheetID, req.Sheet).
+		ValueRenderOption("UNFORMATTED_VALUE").Context(r.Context()).Do()
+	if isCancelled(err) {
+		writeCancelled(w, "DeleteRowsWhere")
+		return
+	}
 	if err != nil {
 		log.Printf("Failed to read sheet: %v", err)
 		writeError(w, "Failed to read sheet", http.StatusInternalServerError)
 		return
 	}
 
-	if len(resp.Values) < 2 {
-		writeError(w, "Sheet has no data rows", http.StatusNotFound)
+	table := splitTable(resp.Values, s.headerRow(req.Sheet))
+	if len(table.rows) == 0 {
+		writeJSON(w, DeleteRowsResponse{Deleted: 0})
 		return
 	}
 
-	// Find ID column
-	headers := resp.Values[0]
-	idColIdx := -1
-	for i, h := range headers {
-		if fmt.Sprintf("%v", h) == req.IdColumn {
-			idColIdx = i
-			break
+	// Resolve filter columns to indices
+	filterCols := make(map[int]string, len(req.Where))
+	for column, want := range req.Where {
+		colIdx := table.indexOf(column)
+		if colIdx == -1 {
+			writeError(w, fmt.Sprintf("Column %s not found", column), http.StatusBadRequest)
+			return
+		}
+		filterCols[colIdx] = cellString(want)
+	}
+
+	// Collect matching rows (0-based sheet indices)
+	var rowIndices []int
+	var matched [][]interface{}
+	for i, row := range table.rows {
+		matches := true
+		for colIdx, want := range filterCols {
+			got := ""
+			if colIdx < len(row) {
+				got = cellString(row[colIdx])
+			}
+			if got != want {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			rowIndices = append(rowIndices, table.rowIndex(i))
+			matched = append(matched, row)
 		}
 	}
 
-	if idColIdx == -1 {
-		writeError(w, fmt.Sprintf("Column %s not found", req.IdColumn), http.StatusBadRequest)
+	if len(rowIndices) == 0 {
+		writeJSON(w, DeleteRowsResponse{Deleted: 0})
 		return
 	}
 
-	// Find row
-	rowIdx := -1
-	for i, row := range resp.Values[1:] {
-		if len(row) > idColIdx && fmt.Sprintf("%v", row[idColIdx]) == req.Id {
-			rowIdx = i + 2
-			break
-		}
-	}
-
-	if rowIdx == -1 {
-		writeError(w, fmt.Sprintf("Row with %s=%s not found", req.IdColumn, req.Id), http.StatusNotFound)
+	// Last chance to back out before anything is deleted
+	if isCancelled(r.Context().Err()) {
+		writeCancelled(w, "DeleteRowsWhere")
 		return
 	}
 
-	// Update row
-	existingRow := resp.Values[rowIdx-1]
-	for colIdx, header := range headers {
-		headerStr := fmt.Sprintf("%v", header)
-		if val, ok := req.Data[headerStr]; ok {
-			for len(existingRow) <= colIdx {
-				existingRow = append(existingRow, "")
-			}
-			existingRow[colIdx] = val
+	result := DeleteRowsResponse{Deleted: len(rowIndices)}
+	detail := fmt.Sprintf("deleted %d rows from %s where %v", len(rowIndices), req.Sheet, req.Where)
+	if s.backupBeforeDelete {
+		backup, err := backupRows(r.Context(), srv, spreadsheetID, req.Sheet, table.headers, matched, time.Now())
+		if isCancelled(err) {
+			writeCancelled(w, "DeleteRowsWhere")
+			return
+		}
+		if err != nil {
+			log.Printf("Failed to back up rows: %v", err)
+			writeError(w, fmt.Sprintf("Rows not deleted: backup failed: %v", err), http.StatusInternalServerError)
+			return
 		}
+		result.BackupSheet = &backup
+		detail += fmt.Sprintf(" (backed up to %s)", backup)
 	}
 
-	rangeStr := fmt.Sprintf("%s!A%d", req.Sheet, rowIdx)
-	valueRange := &sheets.ValueRange{Values: [][]interface{}{existingRow}}
-	_, err = srv.Spreadsheets.Values.Update(s.spreadsheetID, rangeStr, valueRange).
-		ValueInputOption("USER_ENTERED").
-		Do()
-
+	_, err = withRetry(r.Context(), s.retryAttempts, false, func() (*sheets.BatchUpdateSpreadsheetResponse, error) {
+		return srv.Spreadsheets.BatchUpdate(spreadsheetID, deleteRowsRequest(sheetID, rowIndices)).Context(r.Context()).Do()
+	})
 	if err != nil {
-		log.Printf("Failed to update row: %v", err)
-		writeError(w, fmt.Sprintf("Failed to update row: %v", err), http.StatusInternalServerError)
+		log.Printf("Failed to delete rows: %v", err)
+		writeError(w, fmt.Sprintf("Failed to delete rows: %v", err), http.StatusInternalServerError)
 		return
 	}
 
-	userEmail := r.Header.Get("X-User-Email")
-	log.Printf("AUDIT: %s updated %s in %s (row %d)", userEmail, req.Id, req.Sheet, rowIdx)
+	s.audit(r, AuditEvent{
+		Action:   "delete_rows",
+		Resource: req.Sheet,
+		Detail:   detail,
+		Before:   rowsPayload(table.headers, matched, rowIndices),
+	})
 
-	writeJSON(w, SuccessResponse{Success: true})
+	writeJSON(w, result)
 }
 
-func (s *Server) DeleteRow(w http.ResponseWriter, r *http.Request) {
-	var req DeleteRowRequest
+// PreviewImport classifies incoming rows as inserts, updates, or no-ops without writing
+func (s *Server) PreviewImport(w http.ResponseWriter, r *http.Request) {
+	var req PreviewImportRequest
 	if err := decodeBody(r, &req); err != nil {
 		writeError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	if req.Sheet == "" || req.IdColumn == "" || req.Id == "" {
-		writeError(w, "Sheet, idColumn, and id are required", http.StatusBadRequest)
+	if req.Sheet == "" || req.KeyColumn == "" {
+		writeError(w, "Sheet and keyColumn are required", http.StatusBadRequest)
 		return
 	}
-
-	srv, err := s.sheetsService(r.Context())
-	if err != nil {
-		log.Printf("Failed to create Sheets service: %v", err)
-		writeError(w, "Failed to connect to Google Sheets", http.StatusInternalServerError)
+	if refuseInternalSheet(w, req.Sheet) {
 		return
 	}
 
-	// Get spreadsheet to find sheet ID
-	spreadsheet, err := srv.Spreadsheets.Get(s.spreadsheetID).Do()
-	if err != nil {
-		log.Printf("Failed to get spreadsheet: %v", err)
-		writeError(w, "Failed to get spreadsheet", http.StatusInternalServerError)
-		return
+	for _, row := range req.Rows {
+		if err := s.checkSensitiveWrite(r, row); err != nil {
+			writeError(w, err.Error(), http.StatusForbidden)
+			return
+		}
 	}
 
-	var sheetID int64 = -1
-	for _, sheet := range spreadsheet.Sheets {
-		if sheet.Properties.Title == req.Sheet {
-			sheetID = sheet.Properties.SheetId
-			break
-		}
+	spreadsheetID, err := s.spreadsheetFor(r)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
 	}
 
-	if sheetID == -1 {
-		writeError(w, fmt.Sprintf("Sheet %s not found", req.Sheet), http.StatusNotFound)
+	srv, err := s.sheetsService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Sheets service: %v", err)
+		writeError(w, "Failed to connect to Google Sheets", http.StatusInternalServerError)
 		return
 	}
 
-	// Read data to find row
-	resp, err := srv.Spreadsheets.Values.Get(s.spreadsheetID, req.Sheet).Do()
+	resp, err := withRetry(r.Context(), s.retryAttempts, true, func() (*sheets.ValueRange, error) {
+		return srv.Spreadsheets.Values.Get(spreadsheetID, req.Sheet).
+			ValueRenderOption("UNFORMATTED_VALUE").Context(r.Context()).Do()
+	})
 	if err != nil {
 		log.Printf("Failed to read sheet: %v", err)
 		writeError(w, "Failed to read sheet", http.StatusInternalServerError)
 		return
 	}
 
-	if len(resp.Values) < 2 {
-		writeError(w, "Sheet has no data rows", http.StatusNotFound)
+	table := splitTable(resp.Values, s.headerRow(req.Sheet))
+	keyColIdx := table.indexOf(req.KeyColumn)
+	if keyColIdx == -1 {
+		writeError(w, fmt.Sprintf("Column %s not found", req.KeyColumn), http.StatusBadRequest)
 		return
 	}
 
-	// Find ID column
-	headers := resp.Values[0]
-	idColIdx := -1
-	for i, h := range headers {
-		if fmt.Sprintf("%v", h) == req.IdColumn {
-			idColIdx = i
-			break
+	result := PreviewImportResponse{Rows: make([]ImportRowPreview, 0, len(req.Rows))}
+	for n, incoming := range req.Rows {
+		keyVal, ok := incoming[req.KeyColumn]
+		if !ok || cellString(keyVal) == "" {
+			writeError(w, fmt.Sprintf("Row %d is missing %s", n+1, req.KeyColumn), http.StatusBadRequest)
+			return
+		}
+		key := cellString(keyVal)
+
+		i := table.findRow(keyColIdx, key)
+		if i == -1 {
+			result.Rows = append(result.Rows, ImportRowPreview{Key: key, Action: Insert})
+			result.Inserts++
+			continue
+		}
+
+		// Compare only columns present in both the sheet and the incoming row
+		existing := table.rows[i]
+		var changed []string
+		for colIdx, header := range table.headers {
+			headerStr := cellString(header)
+			val, ok := incoming[headerStr]
+			if !ok {
+				continue
+			}
+			current := ""
+			if colIdx < len(existing) {
+				current = cellString(existing[colIdx])
+			}
+			if cellString(val) != current {
+				changed = append(changed, headerStr)
+			}
+		}
+
+		if len(changed) == 0 {
+			result.Rows = append(result.Rows, ImportRowPreview{Key: key, Action: Unchanged})
+			result.Unchanged++
+		} else {
+			result.Rows = append(result.Rows, ImportRowPreview{Key: key, Action: Update, ChangedColumns: &changed})
+			result.Updates++
 		}
 	}
 
-	if idColIdx == -1 {
-		writeError(w, fmt.Sprintf("Column %s not found", req.IdColumn), http.StatusBadRequest)
-		return
+	log.Printf("[API] PreviewImport %s: %d inserts, %d updates, %d unchanged",
+		req.Sheet, result.Inserts, result.Updates, result.Unchanged)
+
+	writeJSON(w, result)
+}
+
+// sheetIDByTitle looks up the numeric sheet ID for a tab name, returning -1 if absent
+func (s *Server) sheetIDByTitle(ctx context.Context, srv *sheets.Service, spreadsheetID, title string) (int64, error) {
+	spreadsheet, err := srv.Spreadsheets.Get(spreadsheetID).
+		Fields("sheets.properties(sheetId,title)").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return -1, err
 	}
 
-	// Find row
-	rowIdx := -1
-	for i, row := range resp.Values[1:] {
-		if len(row) > idColIdx && fmt.Sprintf("%v", row[idColIdx]) == req.Id {
-			rowIdx = i + 1 // 0-based for delete
-			break
+	for _, sheet := range spreadsheet.Sheets {
+		if sheet.Properties.Title == title {
+			return sheet.Properties.SheetId, nil
 		}
 	}
+	return -1, nil
+}
 
-	if rowIdx == -1 {
-		writeError(w, fmt.Sprintf("Row with %s=%s not found", req.IdColumn, req.Id), http.StatusNotFound)
-		return
-	}
+// deleteRowsRequest builds a batch of row deletions for 0-based sheet row indices.
+// Deletions are ordered bottom-to-top so earlier deletes don't shift later ones.
+func deleteRowsRequest(sheetID int64, rowIndices []int) *sheets.BatchUpdateSpreadsheetRequest {
+	sorted := append([]int(nil), rowIndices...)
+	sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
 
-	// Delete row
-	deleteReq := &sheets.BatchUpdateSpreadsheetRequest{
-		Requests: []*sheets.Request{{
+	requests := make([]*sheets.Request, 0, len(sorted))
+	for _, rowIdx := range sorted {
+		requests = append(requests, &sheets.Request{
 			DeleteDimension: &sheets.DeleteDimensionRequest{
 				Range: &sheets.DimensionRange{
 					SheetId:    sheetID,
@@ -846,20 +2176,9 @@ func (s *Server) DeleteRow(w http.ResponseWriter, r *http.Request) {
 					EndIndex:   int64(rowIdx + 1),
 				},
 			},
-		}},
-	}
-
-	_, err = srv.Spreadsheets.BatchUpdate(s.spreadsheetID, deleteReq).Do()
-	if err != nil {
-		log.Printf("Failed to delete row: %v", err)
-		writeError(w, fmt.Sprintf("Failed to delete row: %v", err), http.StatusInternalServerError)
-		return
+		})
 	}
-
-	userEmail := r.Header.Get("X-User-Email")
-	log.Printf("AUDIT: %s deleted %s from %s", userEmail, req.Id, req.Sheet)
-
-	writeJSON(w, SuccessResponse{Success: true})
+	return &sheets.BatchUpdateSpreadsheetRequest{Requests: requests}
 }
 
 func (s *Server) BatchUpdateCells(w http.ResponseWriter, r *http.Request) {
@@ -873,6 +2192,21 @@ func (s *Server) BatchUpdateCells(w http.ResponseWriter, r *http.Request) {
 		writeError(w, "Sheet and updates are required", http.StatusBadRequest)
 		return
 	}
+	if refuseInternalSheet(w, req.Sheet) {
+		return
+	}
+
+	// Without auto-split a large batch would go out as one call and risk timing out
+	if !s.batchUpdateAutoSplit && len(req.Updates) > s.batchUpdateMaxRanges {
+		writeBatchTooLarge(w, len(req.Updates), s.batchUpdateMaxRanges)
+		return
+	}
+
+	spreadsheetID, err := s.spreadsheetFor(r)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
 
 	srv, err := s.sheetsService(r.Context())
 	if err != nil {
@@ -881,6 +2215,49 @@ func (s *Server) BatchUpdateCells(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ranges := make([]string, len(req.Updates))
+	for i, update := range req.Updates {
+		ranges[i] = update.Range
+	}
+	if err := s.validateRanges(r.Context(), srv, spreadsheetID, req.Sheet, ranges); err != nil {
+		writeRangeError(w, err)
+		return
+	}
+
+	if len(s.sensitiveColumns) > 0 && !s.canSeeSensitive(r) {
+		headersResp, err := withRetry(r.Context(), s.retryAttempts, true, func() (*sheets.ValueRange, error) {
+			return srv.Spreadsheets.Values.Get(spreadsheetID, s.headerRange(req.Sheet)).Context(r.Context()).Do()
+		})
+		if err != nil {
+			log.Printf("Failed to get headers: %v", err)
+			writeError(w, "Failed to get sheet headers", http.StatusInternalServerError)
+			return
+		}
+		var headers []string
+		if len(headersResp.Values) > 0 {
+			for _, h := range headersResp.Values[0] {
+				headers = append(headers, cellString(h))
+			}
+		}
+		hidden := s.hiddenColumns(r, headers)
+		for _, update := range req.Updates {
+			first, last, ok := a1Columns(update.Range)
+			if !ok {
+				writeError(w, fmt.Sprintf("Invalid range %s", update.Range), http.StatusBadRequest)
+				return
+			}
+			if end := first + len(update.Values) - 1; end > last {
+				last = end
+			}
+			for col := first; col <= last; col++ {
+				if hidden[col] {
+					writeError(w, fmt.Sprintf("insufficient permission to modify column %s", headers[col]), http.StatusForbidden)
+					return
+				}
+			}
+		}
+	}
+
 	var data []*sheets.ValueRange
 	for _, update := range req.Updates {
 		data = append(data, &sheets.ValueRange{
@@ -889,22 +2266,59 @@ func (s *Server) BatchUpdateCells(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
-	batchReq := &sheets.BatchUpdateValuesRequest{
-		ValueInputOption: "USER_ENTERED",
-		Data:             data,
+	result := BatchUpdateResponse{Success: true}
+	chunks := splitValueRanges(data, s.batchUpdateMaxRanges)
+	for i, chunk := range chunks {
+		err = s.writeValues(r.Context(), srv, spreadsheetID, chunk)
+		if err == nil {
+			result.Batches++
+			result.UpdatedRanges += len(chunk)
+			continue
+		}
+
+		log.Printf("Failed to batch update (batch %d of %d): %v", i+1, len(chunks), err)
+		if result.UpdatedRanges == 0 {
+			writeError(w, fmt.Sprintf("Failed to batch update: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		// Earlier batches are already in the sheet, so report exactly what was not written
+		var failed []string
+		for _, rest := range chunks[i:] {
+			for _, vr := range rest {
+				failed = append(failed, vr.Range)
+			}
+		}
+		errMsg := err.Error()
+		result.Success = false
+		result.FailedRanges = &failed
+		result.Error = &errMsg
+		break
+	}
+
+	after := make(map[string]interface{}, result.UpdatedRanges)
+	for _, vr := range data[:result.UpdatedRanges] {
+		after[vr.Range] = vr.Values[0]
+	}
+	s.audit(r, AuditEvent{
+		Action:   "batch_update",
+		Resource: req.Sheet,
+		Detail:   fmt.Sprintf("batch updated %d of %d cells in %s (%d batches)", result.UpdatedRanges, len(data), req.Sheet, result.Batches),
+		After:    after,
+	})
+
+	// Column widths are cosmetic, so a failed resize doesn't fail the write
+	if req.AutoResize != nil && *req.AutoResize && result.UpdatedRanges > 0 {
+		if err := autoResizeColumns(r.Context(), srv, spreadsheetID, req.Sheet); err != nil {
+			log.Printf("Failed to resize columns of %s after batch update: %v", req.Sheet, err)
+		}
 	}
 
-	_, err = srv.Spreadsheets.Values.BatchUpdate(s.spreadsheetID, batchReq).Do()
-	if err != nil {
-		log.Printf("Failed to batch update: %v", err)
-		writeError(w, fmt.Sprintf("Failed to batch update: %v", err), http.StatusInternalServerError)
+	if !result.Success {
+		writeJSONStatus(w, result, http.StatusMultiStatus)
 		return
 	}
-
-	userEmail := r.Header.Get("X-User-Email")
-	log.Printf("AUDIT: %s batch updated %d cells in %s", userEmail, len(data), req.Sheet)
-
-	writeJSON(w, SuccessResponse{Success: true})
+	writeJSON(w, result)
 }
 
 // ============================================
@@ -918,7 +2332,7 @@ func (s *Server) ListFiles(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	folderId := s.grantsFolderID
+	folderId := s.discoveredGrantsFolderID()
 	if req.FolderId != nil && *req.FolderId != "" {
 		folderId = *req.FolderId
 	}
@@ -935,49 +2349,88 @@ func (s *Server) ListFiles(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	query := fmt.Sprintf("'%s' in parents and trashed = false", folderId)
-	if req.Query != nil && *req.Query != "" {
-		query = query + " and " + *req.Query
+	if req.FolderId != nil && *req.FolderId != "" && !s.requireInScope(w, r, srv, "ListFiles", folderId) {
+		return
+	}
+
+	// Filters are quoted into the query here; clients never write Drive query syntax
+	query := fmt.Sprintf("'%s' in parents and trashed = false", driveQuoted(folderId))
+	if req.Name != nil && *req.Name != "" {
+		query += fmt.Sprintf(" and name = '%s'", driveQuoted(*req.Name))
+	}
+	if req.NameContains != nil && *req.NameContains != "" {
+		query += fmt.Sprintf(" and name contains '%s'", driveQuoted(*req.NameContains))
+	}
+	if req.MimeType != nil && *req.MimeType != "" {
+		query += fmt.Sprintf(" and mimeType = '%s'", driveQuoted(*req.MimeType))
+	}
+
+	pageSize := s.listPageSize
+	if req.PageSize != nil {
+		if *req.PageSize < 1 || *req.PageSize > maxListPageSize {
+			writeError(w, fmt.Sprintf("pageSize must be between 1 and %d", maxListPageSize), http.StatusBadRequest)
+			return
+		}
+		pageSize = *req.PageSize
 	}
 
-	resp, err := srv.Files.List().
+	call := srv.Files.List().
 		Q(query).
-		Fields("files(id, name, mimeType, modifiedTime, webViewLink, shortcutDetails)").
+		Fields("nextPageToken, files(id, name, mimeType, modifiedTime, webViewLink, shortcutDetails, appProperties)").
 		OrderBy("name").
-		PageSize(1000).
+		PageSize(int64(pageSize)).
 		SupportsAllDrives(true).
-		IncludeItemsFromAllDrives(true).
-		Do()
-
+		IncludeItemsFromAllDrives(true)
+	if req.PageToken != nil && *req.PageToken != "" {
+		call = call.PageToken(*req.PageToken)
+	}
+	resp, err := withRetry(r.Context(), s.retryAttempts, true, func() (*drive.FileList, error) {
+		return call.Context(r.Context()).Do()
+	})
+	if isCancelled(err) {
+		writeCancelled(w, "ListFiles")
+		return
+	}
 	if err != nil {
 		log.Printf("Failed to list files: %v", err)
 		writeError(w, fmt.Sprintf("Failed to list files: %v", err), http.StatusInternalServerError)
 		return
 	}
 
-	files := make([]FileInfo, 0, len(resp.Files))
-	for _, f := range resp.Files {
-		fi := FileInfo{
-			Id:          f.Id,
-			Name:        f.Name,
-			MimeType:    f.MimeType,
-			WebViewLink: &f.WebViewLink,
-		}
-		if f.ModifiedTime != "" {
-			if t, err := time.Parse(time.RFC3339, f.ModifiedTime); err == nil {
-				fi.ModifiedTime = &t
-			}
+	files := filesFromDrive(resp.Files)
+
+	s.auditRead(r, AuditEvent{
+		Action:   "list_files",
+		Resource: folderId,
+		Detail:   fmt.Sprintf("listed folder %s (%d files)", folderId, len(files)),
+	})
+
+	writeJSON(w, ListFilesResponse{Files: files, PageInfo: tokenPageInfo(pageSize, resp.NextPageToken)})
+}
+
+// fileInfoFromDrive converts a Drive file listing entry to the API's FileInfo
+func fileInfoFromDrive(f *drive.File) FileInfo {
+	fi := FileInfo{
+		Id:          f.Id,
+		Name:        f.Name,
+		MimeType:    f.MimeType,
+		WebViewLink: &f.WebViewLink,
+	}
+	if f.ModifiedTime != "" {
+		if t, err := time.Parse(time.RFC3339, f.ModifiedTime); err == nil {
+			fi.ModifiedTime = &t
 		}
-		if f.ShortcutDetails != nil {
-			fi.ShortcutDetails = &ShortcutDetails{
-				TargetId:       &f.ShortcutDetails.TargetId,
-				TargetMimeType: &f.ShortcutDetails.TargetMimeType,
-			}
+	}
+	if creator := f.AppProperties[createdByProperty]; creator != "" {
+		fi.CreatedBy = &creator
+	}
+	if f.ShortcutDetails != nil {
+		fi.ShortcutDetails = &ShortcutDetails{
+			TargetId:       &f.ShortcutDetails.TargetId,
+			TargetMimeType: &f.ShortcutDetails.TargetMimeType,
 		}
-		files = append(files, fi)
 	}
-
-	writeJSON(w, ListFilesResponse{Files: files})
+	return fi
 }
 
 func (s *Server) CreateFolder(w http.ResponseWriter, r *http.Request) {
@@ -992,9 +2445,10 @@ func (s *Server) CreateFolder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	parentID := s.grantsFolderID
-	if req.ParentId != nil && *req.ParentId != "" {
-		parentID = *req.ParentId
+	parentID, err := s.resolveParent(r, req.ParentId)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	srv, err := s.driveService(r.Context())
@@ -1004,29 +2458,40 @@ func (s *Server) CreateFolder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	folder := &drive.File{
-		Name:     req.Name,
-		MimeType: "application/vnd.google-apps.folder",
-		Parents:  []string{parentID},
+	if !s.requireInScope(w, r, srv, "CreateFolder", parentID) || !s.requireInGrantsTree(w, r, srv, "CreateFolder", parentID) {
+		return
 	}
 
-	created, err := srv.Files.Create(folder).
-		Fields("id, webViewLink").
-		SupportsAllDrives(true).
-		Do()
+	var appProperties map[string]string
+	if req.GrantId != nil && *req.GrantId != "" {
+		appProperties = map[string]string{grantIDProperty: *req.GrantId}
+	}
 
+	created, err := s.createFolder(r.Context(), srv, req.Name, parentID, s.withCreator(r, appProperties))
 	if err != nil {
 		log.Printf("Failed to create folder: %v", err)
 		writeError(w, fmt.Sprintf("Failed to create folder: %v", err), http.StatusInternalServerError)
 		return
 	}
 
-	userEmail := r.Header.Get("X-User-Email")
-	log.Printf("AUDIT: %s created folder %s (%s)", userEmail, req.Name, created.Id)
+	s.audit(r, AuditEvent{
+		Action:   "create_folder",
+		Resource: created.Id,
+		Target:   req.Name,
+		Detail:   fmt.Sprintf("created folder %s (%s)", req.Name, created.Id),
+	})
 
 	writeJSON(w, CreateFolderResponse{Id: created.Id, Url: created.WebViewLink})
 }
 
+// defaultCreateDocMimeTypes are the file types CreateDoc allows unless
+// CREATE_DOC_MIME_TYPES overrides them
+var defaultCreateDocMimeTypes = map[string]bool{
+	"application/vnd.google-apps.document":     true,
+	"application/vnd.google-apps.spreadsheet":  true,
+	"application/vnd.google-apps.presentation": true,
+}
+
 func (s *Server) CreateDoc(w http.ResponseWriter, r *http.Request) {
 	var req CreateDocRequest
 	if err := decodeBody(r, &req); err != nil {
@@ -1039,9 +2504,15 @@ func (s *Server) CreateDoc(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	parentID := s.grantsFolderID
-	if req.ParentId != nil && *req.ParentId != "" {
-		parentID = *req.ParentId
+	if !s.createDocMimeTypes[req.MimeType] {
+		writeError(w, fmt.Sprintf("MIME type %q is not allowed", req.MimeType), http.StatusBadRequest)
+		return
+	}
+
+	parentID, err := s.resolveParent(r, req.ParentId)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	srv, err := s.driveService(r.Context())
@@ -1051,16 +2522,24 @@ func (s *Server) CreateDoc(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.requireInScope(w, r, srv, "CreateDoc", parentID) || !s.requireInGrantsTree(w, r, srv, "CreateDoc", parentID) {
+		return
+	}
+
 	doc := &drive.File{
-		Name:     req.Name,
-		MimeType: string(req.MimeType),
-		Parents:  []string{parentID},
+		Name:          req.Name,
+		MimeType:      req.MimeType,
+		Parents:       []string{parentID},
+		AppProperties: s.withCreator(r, nil),
 	}
 
-	created, err := srv.Files.Create(doc).
-		Fields("id, webViewLink").
-		SupportsAllDrives(true).
-		Do()
+	created, err := withRetry(r.Context(), s.retryAttempts, false, func() (*drive.File, error) {
+		return srv.Files.Create(doc).
+			Fields("id, webViewLink").
+			SupportsAllDrives(true).
+			Context(r.Context()).
+			Do()
+	})
 
 	if err != nil {
 		log.Printf("Failed to create document: %v", err)
@@ -1068,8 +2547,12 @@ func (s *Server) CreateDoc(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userEmail := r.Header.Get("X-User-Email")
-	log.Printf("AUDIT: %s created doc %s (%s) type %s", userEmail, req.Name, created.Id, req.MimeType)
+	s.audit(r, AuditEvent{
+		Action:   "create_doc",
+		Resource: created.Id,
+		Target:   req.Name,
+		Detail:   fmt.Sprintf("created doc %s (%s) type %s", req.Name, created.Id, req.MimeType),
+	})
 
 	writeJSON(w, CreateDocResponse{Id: created.Id, Url: created.WebViewLink})
 }
@@ -1093,6 +2576,11 @@ func (s *Server) CreateShortcut(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.requireInScope(w, r, srv, "CreateShortcut", req.TargetId, req.ParentId) ||
+		!s.requireInGrantsTree(w, r, srv, "CreateShortcut", req.TargetId, req.ParentId) {
+		return
+	}
+
 	name := ""
 	if req.Name != nil {
 		name = *req.Name
@@ -1112,11 +2600,12 @@ func (s *Server) CreateShortcut(w http.ResponseWriter, r *http.Request) {
 
 	shortcut := &drive.File{
 		Name:     name,
-		MimeType: "application/vnd.google-apps.shortcut",
+		MimeType: shortcutMimeType,
 		Parents:  []string{req.ParentId},
 		ShortcutDetails: &drive.FileShortcutDetails{
 			TargetId: req.TargetId,
 		},
+		AppProperties: s.withCreator(r, nil),
 	}
 
 	created, err := srv.Files.Create(shortcut).
@@ -1130,8 +2619,12 @@ func (s *Server) CreateShortcut(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userEmail := r.Header.Get("X-User-Email")
-	log.Printf("AUDIT: %s created shortcut to %s in %s", userEmail, req.TargetId, req.ParentId)
+	s.audit(r, AuditEvent{
+		Action:   "create_shortcut",
+		Resource: created.Id,
+		Target:   req.TargetId,
+		Detail:   fmt.Sprintf("created shortcut to %s in %s", req.TargetId, req.ParentId),
+	})
 
 	writeJSON(w, CreateShortcutResponse{Id: created.Id})
 }
@@ -1155,6 +2648,20 @@ func (s *Server) MoveFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.requireInScope(w, r, srv, "MoveFile", req.FileId, req.NewParentId) ||
+		!s.requireInGrantsTree(w, r, srv, "MoveFile", req.FileId, req.NewParentId) {
+		return
+	}
+	if req.FileId == s.rootFolderID || req.FileId == s.discoveredGrantsFolderID() {
+		writeError(w, "The root and Grants folders can't be moved", http.StatusForbidden)
+		return
+	}
+	// Discovery finds the spreadsheet by where it lives
+	if s.trackerSpreadsheet(req.FileId) {
+		writeError(w, "The tracker spreadsheets can't be moved", http.StatusForbidden)
+		return
+	}
+
 	prevParent := ""
 	if req.PrevParentId != nil {
 		prevParent = *req.PrevParentId
@@ -1185,9 +2692,13 @@ func (s *Server) MoveFile(w http.ResponseWriter, r *http.Request) {
 		writeError(w, fmt.Sprintf("Failed to move file: %v", err), http.StatusInternalServerError)
 		return
 	}
+	s.forgetParents(req.FileId)
 
-	userEmail := r.Header.Get("X-User-Email")
-	log.Printf("AUDIT: %s moved file %s to %s", userEmail, req.FileId, req.NewParentId)
+	s.audit(r, AuditEvent{
+		Action:   "move_file",
+		Resource: req.FileId,
+		Detail:   fmt.Sprintf("moved file %s to %s", req.FileId, req.NewParentId),
+	})
 
 	writeJSON(w, SuccessResponse{Success: true})
 }
@@ -1212,34 +2723,55 @@ func (s *Server) GetFile(w http.ResponseWriter, r *http.Request) {
 	}
 
 	file, err := srv.Files.Get(req.FileId).
-		Fields("id, name, mimeType, modifiedTime, webViewLink, shortcutDetails").
+		Fields("id, name, mimeType, modifiedTime, webViewLink, shortcutDetails, appProperties, parents, driveId").
 		SupportsAllDrives(true).
 		Do()
 
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound && s.discoveredSharedDriveID() != "" {
+		err = &outOfScopeError{fileID: req.FileId}
+	} else if err == nil && !s.inScope(file.DriveId) {
+		err = &outOfScopeError{fileID: req.FileId}
+	}
+	var scopeErr *outOfScopeError
+	if errors.As(err, &scopeErr) {
+		writeErrorCode(w, scopeErr.Error(), outOfScopeCode, http.StatusForbidden)
+		return
+	}
 	if err != nil {
 		log.Printf("Failed to get file: %v", err)
 		writeError(w, fmt.Sprintf("Failed to get file: %v", err), http.StatusInternalServerError)
 		return
 	}
 
-	fi := FileInfo{
-		Id:          file.Id,
-		Name:        file.Name,
-		MimeType:    file.MimeType,
-		WebViewLink: &file.WebViewLink,
-	}
-	if file.ModifiedTime != "" {
-		if t, err := time.Parse(time.RFC3339, file.ModifiedTime); err == nil {
-			fi.ModifiedTime = &t
+	fi := fileInfoFromDrive(file)
+
+	if req.IncludePath != nil && *req.IncludePath {
+		place, err := s.locateInTree(r.Context(), srv, file.Parents)
+		if isCancelled(err) {
+			writeCancelled(w, "GetFile")
+			return
 		}
-	}
-	if file.ShortcutDetails != nil {
-		fi.ShortcutDetails = &ShortcutDetails{
-			TargetId:       &file.ShortcutDetails.TargetId,
-			TargetMimeType: &file.ShortcutDetails.TargetMimeType,
+		if err != nil {
+			log.Printf("Failed to resolve file path: %v", err)
+			writeError(w, fmt.Sprintf("Failed to resolve file path: %v", err), http.StatusInternalServerError)
+			return
 		}
+		// Files outside both trees have no path
+		path := []Breadcrumb{}
+		if place.inTree() {
+			path = place.path
+		}
+		fi.Path = &path
 	}
 
+	s.auditRead(r, AuditEvent{
+		Action:   "get_file",
+		Resource: file.Id,
+		Target:   file.Name,
+		Detail:   fmt.Sprintf("viewed file %s (%s)", file.Name, file.Id),
+	})
+
 	writeJSON(w, fi)
 }
 
@@ -1252,6 +2784,7 @@ type InitializeTrackerDocRequest struct {
 	DocumentId string            `json:"documentId"`
 	Grant      map[string]string `json:"grant"`
 	Approvers  []string          `json:"approvers,omitempty"`
+	Force      bool              `json:"force,omitempty"` // Replace a section written by an earlier call
 }
 
 // InitializeTrackerDoc populates a tracker doc with grant metadata
@@ -1267,6 +2800,17 @@ func (s *Server) InitializeTrackerDoc(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	driveSrv, err := s.driveService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Drive service: %v", err)
+		writeError(w, "Failed to connect to Google Drive", http.StatusInternalServerError)
+		return
+	}
+	if !s.requireInScope(w, r, driveSrv, "InitializeTrackerDoc", req.DocumentId) ||
+		!s.requireInGrantsTree(w, r, driveSrv, "InitializeTrackerDoc", req.DocumentId) {
+		return
+	}
+
 	srv, err := s.docsService(r.Context())
 	if err != nil {
 		log.Printf("Failed to create Docs service: %v", err)
@@ -1274,80 +2818,32 @@ func (s *Server) InitializeTrackerDoc(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Build the document content as a single text block
-	// We'll use simple text formatting instead of complex tables
-	var content strings.Builder
-
-	// Status section (heading will be formatted separately)
-	content.WriteString("Status\n\n")
-
-	// Project Metadata section
-	if len(req.Grant) > 0 {
-		content.WriteString("Project Metadata\n")
-
-		// Add metadata fields
-		fieldOrder := []string{"ID", "Title", "Organization", "Amount", "Status", "Year"}
-		for _, field := range fieldOrder {
-			if val, ok := req.Grant[field]; ok && val != "" {
-				content.WriteString(fmt.Sprintf("%s: %s\n", field, val))
-			}
-		}
-		content.WriteString("\n")
-	}
-
-	// Insert all content at once
-	var requests []*docs.Request
-	requests = append(requests, &docs.Request{
-		InsertText: &docs.InsertTextRequest{
-			Location: &docs.Location{Index: 1},
-			Text:     content.String(),
-		},
-	})
-
-	// Format "Status" as Heading 1 (characters 1-7, plus newline at 8)
-	requests = append(requests, &docs.Request{
-		UpdateParagraphStyle: &docs.UpdateParagraphStyleRequest{
-			Range: &docs.Range{
-				StartIndex: 1,
-				EndIndex:   8,
-			},
-			ParagraphStyle: &docs.ParagraphStyle{
-				NamedStyleType: "HEADING_1",
-			},
-			Fields: "namedStyleType",
-		},
-	})
-
-	// Format "Project Metadata" as Heading 2 if we have grant data
-	if len(req.Grant) > 0 {
-		// After "Status\n\n" (8 chars), "Project Metadata\n" starts at index 9
-		requests = append(requests, &docs.Request{
-			UpdateParagraphStyle: &docs.UpdateParagraphStyleRequest{
-				Range: &docs.Range{
-					StartIndex: 9,
-					EndIndex:   26, // "Project Metadata\n" is 17 chars, so 9+17=26
-				},
-				ParagraphStyle: &docs.ParagraphStyle{
-					NamedStyleType: "HEADING_2",
-				},
-				Fields: "namedStyleType",
-			},
-		})
+	skipped, err := initializeTrackerDoc(r.Context(), srv, req.DocumentId, req.Grant, req.Approvers, req.Force)
+	if errors.Is(err, errTrackerUnmarked) {
+		writeError(w, err.Error(), http.StatusConflict)
+		return
 	}
-
-	// Execute batch update
-	_, err = srv.Documents.BatchUpdate(req.DocumentId, &docs.BatchUpdateDocumentRequest{
-		Requests: requests,
-	}).Do()
-
 	if err != nil {
 		log.Printf("Failed to initialize tracker doc: %v", err)
 		writeError(w, fmt.Sprintf("Failed to initialize document: %v", err), http.StatusInternalServerError)
 		return
 	}
 
-	userEmail := r.Header.Get("X-User-Email")
-	log.Printf("AUDIT: %s initialized tracker doc %s", userEmail, req.DocumentId)
+	// A retry or double-click finds the doc already initialized; nothing changed
+	if skipped {
+		writeJSON(w, map[string]bool{"success": true, "skipped": true})
+		return
+	}
+
+	detail := fmt.Sprintf("initialized tracker doc %s", req.DocumentId)
+	if req.Force {
+		detail = fmt.Sprintf("reinitialized tracker doc %s", req.DocumentId)
+	}
+	s.audit(r, AuditEvent{
+		Action:   "initialize_tracker_doc",
+		Resource: req.DocumentId,
+		Detail:   detail,
+	})
 
 	writeJSON(w, map[string]bool{"success": true})
 }
@@ -0,0 +1,420 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestCellString(t *testing.T) {
+	tests := []struct {
+		name string
+		in   interface{}
+		want string
+	}{
+		{"empty cell", nil, ""},
+		{"text", "G-1", "G-1"},
+		{"large whole number", float64(1234567890123), "1234567890123"},
+		{"fraction", 12.5, "12.5"},
+		{"json number", json.Number("12345678901234567890"), "12345678901234567890"},
+		{"boolean", true, "true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cellString(tt.in); got != tt.want {
+				t.Errorf("cellString(%v) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsIDColumn(t *testing.T) {
+	s := newTestServer(t, nil)
+	for header, want := range map[string]bool{"ID": true, "grant_id": true, "Grant_ID": true, "Title": false, "Budget": false} {
+		if got := s.isIDColumn(header); got != want {
+			t.Errorf("isIDColumn(%q) = %v, want %v", header, got, want)
+		}
+	}
+
+	t.Setenv("ID_COLUMNS", "Ref, Code")
+	configured := newTestServer(t, nil)
+	for header, want := range map[string]bool{"Ref": true, "Code": true, "ID": false} {
+		if got := configured.isIDColumn(header); got != want {
+			t.Errorf("with ID_COLUMNS, isIDColumn(%q) = %v, want %v", header, got, want)
+		}
+	}
+}
+
+func TestReadSheetKeepsNumericIDsExact(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: [][]interface{}{
+		{"ID", "Budget"},
+		{float64(1234567890123), float64(5000)},
+	}})
+	s := newTestServer(t, f)
+
+	w := callHandler(t, s.ReadSheet, "po@example.org", ReadSheetRequest{Sheet: "Grants"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var resp ReadSheetResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	want := [][]interface{}{{"1234567890123", float64(5000)}}
+	if !reflect.DeepEqual(resp.Rows, want) {
+		t.Errorf("rows = %v, want the ID as text and the budget as a number", resp.Rows)
+	}
+}
+
+func TestIDCellValue(t *testing.T) {
+	if got := idCellValue(float64(1234567890123)); got != "'1234567890123" {
+		t.Errorf("idCellValue(number) = %v, want it stored as text", got)
+	}
+	if got := idCellValue(""); got != "" {
+		t.Errorf("idCellValue(\"\") = %v, want it left empty", got)
+	}
+}
+
+func TestReadSheetColumnLetters(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: [][]interface{}{
+		{"ID", "Budget", "Title"},
+		{"G-1", float64(5000), "Packaging"},
+	}})
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants!1:1", &sheets.ValueRange{Values: [][]interface{}{{"ID", "Budget", "Title"}}})
+	s := newTestServer(t, f)
+	s.sensitiveColumns = map[string]bool{"Budget": true}
+	s.sensitiveMinRole = "organizer"
+
+	w := callHandlerAs(t, s.ReadSheet, "po@example.org", "writer", ReadSheetRequest{Sheet: "Grants"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var resp ReadSheetResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	// Letters are the sheet's, so Title stays C with Budget hidden
+	want := map[string]string{"ID": "A", "Title": "C"}
+	if resp.Columns == nil || !reflect.DeepEqual(*resp.Columns, want) {
+		t.Errorf("columns = %v, want %v", resp.Columns, want)
+	}
+}
+
+func TestPreviewImport(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: [][]interface{}{
+		{"ID", "Title", "Budget"},
+		{"G-1", "Packaging", float64(5000)},
+		{"G-2", "Docs", float64(1000)},
+	}})
+	s := newTestServer(t, f)
+
+	w := callHandler(t, s.PreviewImport, "po@example.org", PreviewImportRequest{Sheet: "Grants", KeyColumn: "ID", Rows: []map[string]interface{}{
+		{"ID": "G-1", "Title": "Packaging", "Budget": "5000"},
+		{"ID": "G-2", "Title": "Documentation", "Budget": 1000, "Notes": "not a column"},
+		{"ID": "G-3", "Title": "Security"},
+	}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var resp PreviewImportResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	changed := []string{"Title"}
+	want := PreviewImportResponse{
+		Inserts: 1, Updates: 1, Unchanged: 1,
+		Rows: []ImportRowPreview{
+			{Key: "G-1", Action: Unchanged},
+			{Key: "G-2", Action: Update, ChangedColumns: &changed},
+			{Key: "G-3", Action: Insert},
+		},
+	}
+	if !reflect.DeepEqual(resp, want) {
+		t.Errorf("got %+v, want %+v", resp, want)
+	}
+	if calls := f.calls(http.MethodPost, "/v4/spreadsheets/"+testSpreadsheetID+"/values:batchUpdate"); len(calls) != 0 {
+		t.Error("preview wrote to the sheet")
+	}
+}
+
+func TestPreviewImportMissingKey(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: [][]interface{}{{"ID", "Title"}}})
+	s := newTestServer(t, f)
+
+	w := callHandler(t, s.PreviewImport, "po@example.org", PreviewImportRequest{Sheet: "Grants", KeyColumn: "ID", Rows: []map[string]interface{}{
+		{"Title": "No key"},
+	}})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400: %s", w.Code, w.Body)
+	}
+}
+
+// cellUpdates builds a Grants batch of n single-cell updates down column B
+func cellUpdates(n int) BatchUpdateRequest {
+	req := BatchUpdateRequest{Sheet: "Grants"}
+	for i := 0; i < n; i++ {
+		req.Updates = append(req.Updates, struct {
+			Range  string        `json:"range"`
+			Values []interface{} `json:"values"`
+		}{Range: fmt.Sprintf("B%d", i+2), Values: []interface{}{"Closed"}})
+	}
+	return req
+}
+
+func TestBatchUpdateCellsSplitsLargeBatches(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodPost, valuesBatchUpdatePath, &sheets.BatchUpdateValuesResponse{})
+	s := newTestServer(t, f)
+	s.checkRangeBounds = false
+	s.batchUpdateMaxRanges = 2
+
+	w := callHandler(t, s.BatchUpdateCells, "po@example.org", cellUpdates(5))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var resp BatchUpdateResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if !resp.Success || resp.Batches != 3 || resp.UpdatedRanges != 5 {
+		t.Errorf("response = %+v, want 5 ranges in 3 batches", resp)
+	}
+	if calls := f.calls(http.MethodPost, valuesBatchUpdatePath); len(calls) != 3 {
+		t.Errorf("BatchUpdate called %d times, want 3", len(calls))
+	}
+}
+
+func TestBatchUpdateCellsReportsPartialFailure(t *testing.T) {
+	f := newFakeGoogle(t)
+	var mu sync.Mutex
+	batches := 0
+	f.handle(http.MethodPost, valuesBatchUpdatePath, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		batches++
+		n := batches
+		mu.Unlock()
+		if n > 1 {
+			http.Error(w, `{"error": {"code": 400, "message": "bad range"}}`, http.StatusBadRequest)
+			return
+		}
+		writeFakeJSON(w, &sheets.BatchUpdateValuesResponse{})
+	})
+	s := newTestServer(t, f)
+	s.checkRangeBounds = false
+	s.batchUpdateMaxRanges = 2
+
+	w := callHandler(t, s.BatchUpdateCells, "po@example.org", cellUpdates(5))
+	if w.Code != http.StatusMultiStatus {
+		t.Fatalf("status = %d, want 207: %s", w.Code, w.Body)
+	}
+	var resp BatchUpdateResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Success || resp.UpdatedRanges != 2 || resp.Error == nil {
+		t.Errorf("response = %+v, want the first batch written and an error", resp)
+	}
+	want := []string{"Grants!B4", "Grants!B5", "Grants!B6"}
+	if resp.FailedRanges == nil || !reflect.DeepEqual(*resp.FailedRanges, want) {
+		t.Errorf("failed ranges = %v, want %v", resp.FailedRanges, want)
+	}
+	if calls := f.calls(http.MethodPost, valuesBatchUpdatePath); len(calls) != 2 {
+		t.Errorf("BatchUpdate called %d times, want it to stop after the failure", len(calls))
+	}
+}
+
+func TestCreateDocMimeTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      string
+		mimeType string
+		wantCode int
+	}{
+		{name: "Google Doc", mimeType: "application/vnd.google-apps.document", wantCode: http.StatusOK},
+		{name: "Google Slides", mimeType: "application/vnd.google-apps.presentation", wantCode: http.StatusOK},
+		{name: "script", mimeType: "application/vnd.google-apps.script", wantCode: http.StatusBadRequest},
+		{name: "missing type", mimeType: "", wantCode: http.StatusBadRequest},
+		{name: "type added by CREATE_DOC_MIME_TYPES", env: "text/plain, application/vnd.google-apps.document", mimeType: "text/plain", wantCode: http.StatusOK},
+		{name: "default left out of CREATE_DOC_MIME_TYPES", env: "text/plain", mimeType: "application/vnd.google-apps.spreadsheet", wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CREATE_DOC_MIME_TYPES", tt.env)
+			f := newFakeGoogle(t)
+			f.reply(http.MethodPost, "/files", &drive.File{Id: "doc-1", WebViewLink: "https://docs.example/doc-1"})
+			s := newTestServer(t, f)
+			s.grantsFolderID = "grants"
+
+			parent := "grants"
+			w := callHandler(t, s.CreateDoc, "po@example.org", CreateDocRequest{Name: "Proposal", MimeType: tt.mimeType, ParentId: &parent})
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body)
+			}
+			created := len(f.calls(http.MethodPost, "/files"))
+			if tt.wantCode == http.StatusOK && created != 1 {
+				t.Errorf("created %d files, want 1", created)
+			}
+			if tt.wantCode != http.StatusOK && created != 0 {
+				t.Errorf("created %d files of a disallowed type", created)
+			}
+		})
+	}
+}
+
+func TestBatchUpdateCellsValidatesRanges(t *testing.T) {
+	tests := []struct {
+		name        string
+		checkBounds bool
+		rangeStr    string
+		wantCode    int
+		wantMsg     string
+	}{
+		{name: "malformed range", rangeStr: "B0", wantCode: http.StatusBadRequest, wantMsg: "rows start at 1"},
+		{name: "sheet name in range", rangeStr: "Other!B2", wantCode: http.StatusBadRequest, wantMsg: "must not include a sheet name"},
+		{name: "past the grid", checkBounds: true, rangeStr: "B20", wantCode: http.StatusBadRequest, wantMsg: "past the last row"},
+		{name: "past the grid without bounds checks", rangeStr: "B20", wantCode: http.StatusOK},
+		{name: "inside the grid", checkBounds: true, rangeStr: "B2", wantCode: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeGoogle(t)
+			f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID, &sheets.Spreadsheet{Sheets: []*sheets.Sheet{{Properties: &sheets.SheetProperties{
+				Title:          "Grants",
+				GridProperties: &sheets.GridProperties{RowCount: 10, ColumnCount: 5},
+			}}}})
+			f.reply(http.MethodPost, valuesBatchUpdatePath, &sheets.BatchUpdateValuesResponse{})
+			s := newTestServer(t, f)
+			s.checkRangeBounds = tt.checkBounds
+
+			req := cellUpdates(1)
+			req.Updates[0].Range = tt.rangeStr
+			w := callHandler(t, s.BatchUpdateCells, "po@example.org", req)
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantMsg) {
+				t.Errorf("body = %s, want it to mention %q", w.Body, tt.wantMsg)
+			}
+			writes := len(f.calls(http.MethodPost, valuesBatchUpdatePath))
+			if tt.wantCode == http.StatusBadRequest && writes != 0 {
+				t.Errorf("sent %d writes for an invalid range", writes)
+			}
+		})
+	}
+}
+
+func TestListFilesFollowsPageTokens(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.handle(http.MethodGet, "/files", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("pageSize"); got != "2" {
+			t.Errorf("pageSize = %q, want 2", got)
+		}
+		switch r.URL.Query().Get("pageToken") {
+		case "":
+			writeFakeJSON(w, &drive.FileList{Files: []*drive.File{{Id: "doc-1"}, {Id: "doc-2"}}, NextPageToken: "cursor-2"})
+		case "cursor-2":
+			writeFakeJSON(w, &drive.FileList{Files: []*drive.File{{Id: "doc-3"}}})
+		default:
+			http.Error(w, `{"error": {"code": 400, "message": "Invalid page token"}}`, http.StatusBadRequest)
+		}
+	})
+	s := newTestServer(t, f)
+	s.grantsFolderID = "grants"
+
+	var ids []string
+	pageSize := 2
+	req := ListFilesRequest{PageSize: &pageSize}
+	for page := 0; page < 3; page++ {
+		w := callHandler(t, s.ListFiles, "po@example.org", req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d: %s", w.Code, w.Body)
+		}
+		var resp ListFilesResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		for _, fi := range resp.Files {
+			ids = append(ids, fi.Id)
+		}
+		if resp.PageInfo.NextPageToken == nil {
+			break
+		}
+		req.PageToken = resp.PageInfo.NextPageToken
+	}
+	if want := []string{"doc-1", "doc-2", "doc-3"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("listed %v across pages, want %v", ids, want)
+	}
+	if calls := f.calls(http.MethodGet, "/files"); len(calls) != 2 {
+		t.Errorf("listed %d pages, want 2", len(calls))
+	}
+}
+
+func TestListFilesRejectsPageSize(t *testing.T) {
+	for _, pageSize := range []int{0, maxListPageSize + 1} {
+		s := newTestServer(t, newFakeGoogle(t))
+		s.grantsFolderID = "grants"
+
+		size := pageSize
+		w := callHandler(t, s.ListFiles, "po@example.org", ListFilesRequest{PageSize: &size})
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("pageSize %d: status = %d, want 400", pageSize, w.Code)
+		}
+	}
+}
+
+func TestBatchUpdateCellsRejectsOversizedBatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		updates   int
+		wantCode  int
+		wantCalls int
+	}{
+		{name: "over the limit", updates: 3, wantCode: http.StatusBadRequest},
+		{name: "at the limit", updates: 2, wantCode: http.StatusOK, wantCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("BATCH_UPDATE_AUTO_SPLIT", "false")
+			f := newFakeGoogle(t)
+			f.reply(http.MethodPost, valuesBatchUpdatePath, &sheets.BatchUpdateValuesResponse{})
+			s := newTestServer(t, f)
+			s.checkRangeBounds = false
+			s.batchUpdateMaxRanges = 2
+
+			w := callHandler(t, s.BatchUpdateCells, "po@example.org", cellUpdates(tt.updates))
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body)
+			}
+			if calls := f.calls(http.MethodPost, valuesBatchUpdatePath); len(calls) != tt.wantCalls {
+				t.Errorf("BatchUpdate called %d times, want %d", len(calls), tt.wantCalls)
+			}
+			if tt.wantCode == http.StatusOK {
+				return
+			}
+			var resp Error
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if resp.Code == nil || *resp.Code != batchTooLargeCode {
+				t.Errorf("code = %v, want %s", resp.Code, batchTooLargeCode)
+			}
+			if resp.Limit == nil || *resp.Limit != 2 {
+				t.Errorf("limit = %v, want 2", resp.Limit)
+			}
+			if !strings.Contains(resp.Error, "batches of at most 2") {
+				t.Errorf("error = %q, want guidance on the batch size", resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+)
+
+// GetSheetMetadata lists the spreadsheet's tabs with their grid sizes, so
+// clients can build tab switchers and check sheet names before reading
+func (s *Server) GetSheetMetadata(w http.ResponseWriter, r *http.Request) {
+	spreadsheetID, err := s.spreadsheetFor(r)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	srv, err := s.sheetsService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Sheets service: %v", err)
+		writeError(w, "Failed to connect to Google Sheets", http.StatusInternalServerError)
+		return
+	}
+
+	spreadsheet, err := srv.Spreadsheets.Get(spreadsheetID).
+		Fields("sheets.properties(sheetId,title,index,gridProperties)").
+		Context(r.Context()).
+		Do()
+	if isCancelled(err) {
+		writeCancelled(w, "GetSheetMetadata")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to get spreadsheet: %v", err)
+		writeError(w, fmt.Sprintf("Failed to get spreadsheet: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	result := SheetMetadataResponse{Sheets: []SheetInfo{}}
+	for _, sh := range spreadsheet.Sheets {
+		props := sh.Properties
+		info := SheetInfo{
+			SheetId:   props.SheetId,
+			Title:     props.Title,
+			HeaderRow: s.headerRow(props.Title),
+		}
+		if grid := props.GridProperties; grid != nil {
+			info.RowCount = grid.RowCount
+			info.ColumnCount = grid.ColumnCount
+			info.FrozenRowCount = grid.FrozenRowCount
+		}
+		result.Sheets = append(result.Sheets, info)
+	}
+
+	writeJSON(w, result)
+}
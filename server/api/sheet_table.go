@@ -0,0 +1,299 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/sheets/v4"
+)
+
+// sheetTable is a sheet's raw values split into its header row and data rows
+type sheetTable struct {
+	headerRow int // 1-based sheet row holding the headers
+	headers   []interface{}
+	rows      [][]interface{}
+}
+
+// splitTable splits values read from the top of a sheet at the given 1-based
+// header row. Rows above the header (titles, banners) are discarded.
+func splitTable(values [][]interface{}, headerRow int) sheetTable {
+	t := sheetTable{headerRow: headerRow}
+	if len(values) >= headerRow {
+		t.headers = values[headerRow-1]
+		t.rows = values[headerRow:]
+	}
+	return t
+}
+
+// headerRow returns the 1-based header row for a sheet (default 1)
+func (s *Server) headerRow(sheet string) int {
+	if row, ok := s.headerRows[sheet]; ok {
+		return row
+	}
+	return 1
+}
+
+// headerRange returns the A1 range covering just a sheet's header row
+func (s *Server) headerRange(sheet string) string {
+	row := s.headerRow(sheet)
+	return fmt.Sprintf("%s!%d:%d", sheet, row, row)
+}
+
+// indexOf returns the index of the named column, or -1 if absent
+func (t sheetTable) indexOf(column string) int {
+	for i, h := range t.headers {
+		if cellString(h) == column {
+			return i
+		}
+	}
+	return -1
+}
+
+// Duplicate header handling for writes. With duplicateHeadersError, writing to
+// a header that appears more than once is rejected; with duplicateHeadersIndex,
+// clients address a specific occurrence as "Name#N" (1-based, left to right).
+const (
+	duplicateHeadersError = "error"
+	duplicateHeadersIndex = "index"
+)
+
+// splitColumnKey splits a "Name#N" write key into the header name and its
+// 1-based occurrence; plain names return occurrence 0
+func splitColumnKey(key string) (string, int) {
+	name, suffix, ok := strings.Cut(key, "#")
+	if !ok {
+		return key, 0
+	}
+	n, err := strconv.Atoi(suffix)
+	if err != nil || n < 1 {
+		return key, 0
+	}
+	return name, n
+}
+
+// columnsForData maps write keys to column indexes. Keys matching no header
+// are ignored, as they always have been; keys matching a duplicated header
+// are resolved or rejected according to s.duplicateHeaders.
+func (s *Server) columnsForData(sheet string, headers []interface{}, data map[string]interface{}) (map[int]interface{}, error) {
+	occurrences := make(map[string][]int)
+	for i, h := range headers {
+		if name := cellString(h); name != "" {
+			occurrences[name] = append(occurrences[name], i)
+		}
+	}
+
+	columns := make(map[int]interface{}, len(data))
+	for key, val := range data {
+		switch cols := occurrences[key]; {
+		case len(cols) == 1:
+			columns[cols[0]] = val
+			continue
+		case len(cols) > 1:
+			letters := make([]string, len(cols))
+			for i, c := range cols {
+				letters[i] = columnLetter(c)
+			}
+			msg := fmt.Sprintf("column %s appears %d times in the %s header row (columns %s)", key, len(cols), sheet, strings.Join(letters, ", "))
+			if s.duplicateHeaders == duplicateHeadersIndex {
+				return nil, fmt.Errorf("%s; address one as %s#1 to %s#%d", msg, key, key, len(cols))
+			}
+			return nil, fmt.Errorf("%s; rename one so writes go to the intended column", msg)
+		}
+
+		name, n := splitColumnKey(key)
+		if n == 0 || s.duplicateHeaders != duplicateHeadersIndex {
+			continue
+		}
+		cols := occurrences[name]
+		if len(cols) < 2 {
+			continue
+		}
+		if n > len(cols) {
+			return nil, fmt.Errorf("column %s appears only %d times in the %s header row", name, len(cols), sheet)
+		}
+		columns[cols[n-1]] = val
+	}
+
+	for i, val := range columns {
+		if s.isIDColumn(cellString(headers[i])) {
+			columns[i] = idCellValue(val)
+		}
+	}
+	return columns, nil
+}
+
+// idCellValue makes a value bound for an ID column store as text. Sent as is,
+// USER_ENTERED turns a numeric ID into a number, and one past 2^53 is rounded
+// before anything can read it back; a leading ' keeps it as typed.
+func idCellValue(val interface{}) interface{} {
+	str := cellString(val)
+	if str == "" {
+		return val
+	}
+	return "'" + str
+}
+
+// findRow returns the index of the first data row whose value in column colIdx is id, or -1
+func (t sheetTable) findRow(colIdx int, id string) int {
+	for i, row := range t.rows {
+		if len(row) > colIdx && cellString(row[colIdx]) == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// findRows returns the indices of every data row whose value in column colIdx is id
+func (t sheetTable) findRows(colIdx int, id string) []int {
+	var matches []int
+	for i, row := range t.rows {
+		if len(row) > colIdx && cellString(row[colIdx]) == id {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// sheetRow returns the 1-based sheet row number of data row i
+func (t sheetTable) sheetRow(i int) int {
+	return t.headerRow + 1 + i
+}
+
+// rowIndex returns the 0-based sheet row index of data row i, as DeleteDimension
+// ranges expect. With the header in row 1, data row 0 is sheet row 2, index 1.
+func (t sheetTable) rowIndex(i int) int {
+	return t.sheetRow(i) - 1
+}
+
+// mergedRanges returns the merged cell ranges of a sheet
+func mergedRanges(srv *sheets.Service, spreadsheetID, sheet string) ([]*sheets.GridRange, error) {
+	spreadsheet, err := srv.Spreadsheets.Get(spreadsheetID).
+		Ranges(sheet).
+		Fields("sheets(merges)").
+		Do()
+	if err != nil {
+		return nil, err
+	}
+	var merges []*sheets.GridRange
+	for _, sh := range spreadsheet.Sheets {
+		merges = append(merges, sh.Merges...)
+	}
+	return merges, nil
+}
+
+// fillMerges copies each merge's top-left value into the rest of its cells.
+// Sheets only returns a value for the first cell of a merge, which leaves blanks
+// that shift data away from its header. rowOffset and colOffset give the sheet
+// position of values[0][0] when the read did not start at A1.
+func fillMerges(values [][]interface{}, merges []*sheets.GridRange, rowOffset, colOffset int) {
+	for _, m := range merges {
+		top := int(m.StartRowIndex) - rowOffset
+		left := int(m.StartColumnIndex) - colOffset
+		if top < 0 || top >= len(values) || left < 0 || left >= len(values[top]) {
+			continue
+		}
+		value := values[top][left]
+
+		for row := top; row < int(m.EndRowIndex)-rowOffset && row < len(values); row++ {
+			for col := left; col < int(m.EndColumnIndex)-colOffset; col++ {
+				for len(values[row]) <= col {
+					values[row] = append(values[row], "")
+				}
+				values[row][col] = value
+			}
+		}
+	}
+}
+
+// sheetProperties returns the properties of the sheet with the given title,
+// or nil if the spreadsheet has no such sheet
+func sheetProperties(ctx context.Context, srv *sheets.Service, spreadsheetID, title string) (*sheets.SheetProperties, error) {
+	spreadsheet, err := srv.Spreadsheets.Get(spreadsheetID).
+		Fields("sheets.properties(sheetId,title,gridProperties)").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, err
+	}
+	for _, sh := range spreadsheet.Sheets {
+		if sh.Properties.Title == title {
+			return sh.Properties, nil
+		}
+	}
+	return nil, nil
+}
+
+// sheetMissing reports whether a failed values read was for a tab the
+// spreadsheet doesn't have. Sheets answers an unknown tab with a generic 400
+// ("Unable to parse range"), so that is confirmed against the sheet list.
+func sheetMissing(ctx context.Context, srv *sheets.Service, spreadsheetID, sheet string, err error) bool {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+		return false
+	}
+	props, err := sheetProperties(ctx, srv, spreadsheetID, sheet)
+	return err == nil && props == nil
+}
+
+// sheetGridSize returns the row and column count of a sheet's grid; found is
+// false if the spreadsheet has no sheet with that title
+func sheetGridSize(ctx context.Context, srv *sheets.Service, spreadsheetID, title string) (rows, cols int, found bool, err error) {
+	props, err := sheetProperties(ctx, srv, spreadsheetID, title)
+	if err != nil || props == nil || props.GridProperties == nil {
+		return 0, 0, false, err
+	}
+	return int(props.GridProperties.RowCount), int(props.GridProperties.ColumnCount), true, nil
+}
+
+// invalidRangeError is a range problem the caller should report as a 400
+type invalidRangeError struct{ err error }
+
+func (e invalidRangeError) Error() string { return e.err.Error() }
+
+// validateRanges checks the syntax of each A1 range and, when bounds checking
+// is enabled, that it fits within the sheet's grid. Problems with the ranges
+// themselves are returned as invalidRangeError.
+func (s *Server) validateRanges(ctx context.Context, srv *sheets.Service, spreadsheetID, sheet string, ranges []string) error {
+	bounds := make([]a1Bounds, len(ranges))
+	for i, rangeStr := range ranges {
+		b, err := parseA1Range(rangeStr)
+		if err != nil {
+			return invalidRangeError{err}
+		}
+		bounds[i] = b
+	}
+
+	if !s.checkRangeBounds {
+		return nil
+	}
+	rowCount, colCount, found, err := sheetGridSize(ctx, srv, spreadsheetID, sheet)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return invalidRangeError{fmt.Errorf("sheet %s not found", sheet)}
+	}
+	for i, b := range bounds {
+		if err := b.checkWithin(ranges[i], rowCount, colCount); err != nil {
+			return invalidRangeError{err}
+		}
+	}
+	return nil
+}
+
+// writeRangeError reports a validateRanges failure
+func writeRangeError(w http.ResponseWriter, err error) {
+	var invalid invalidRangeError
+	if errors.As(err, &invalid) {
+		writeError(w, invalid.Error(), http.StatusBadRequest)
+		return
+	}
+	log.Printf("Failed to check range bounds: %v", err)
+	writeError(w, "Failed to get sheet dimensions", http.StatusInternalServerError)
+}
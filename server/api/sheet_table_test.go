@@ -0,0 +1,455 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestRowIndex(t *testing.T) {
+	tests := []struct {
+		name      string
+		headerRow int
+		row       int
+		want      int
+	}{
+		{"first data row", 1, 0, 1},
+		{"third data row", 1, 2, 3},
+		{"first row under a banner", 3, 0, 3},
+		{"last of five rows under a banner", 3, 4, 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := sheetTable{headerRow: tt.headerRow}
+			if got := table.rowIndex(tt.row); got != tt.want {
+				t.Errorf("rowIndex(%d) with header row %d = %d, want %d", tt.row, tt.headerRow, got, tt.want)
+			}
+			if got := table.sheetRow(tt.row); got != tt.want+1 {
+				t.Errorf("sheetRow(%d) with header row %d = %d, want %d", tt.row, tt.headerRow, got, tt.want+1)
+			}
+		})
+	}
+}
+
+func TestDeleteRowsRequestOrdersBottomUp(t *testing.T) {
+	req := deleteRowsRequest(7, []int{2, 9, 4})
+
+	var got []int64
+	for _, r := range req.Requests {
+		rng := r.DeleteDimension.Range
+		if rng.SheetId != 7 || rng.Dimension != "ROWS" || rng.EndIndex != rng.StartIndex+1 {
+			t.Errorf("unexpected range %+v", rng)
+		}
+		got = append(got, rng.StartIndex)
+	}
+	if want := []int64{9, 4, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("start indices = %v, want %v", got, want)
+	}
+}
+
+func TestDeleteRow(t *testing.T) {
+	values := [][]interface{}{
+		{"ID", "Title", "Amount"},
+		{"G-1", "First", 100},
+		{"G-2", "Short"},
+		{"G-3", "Dup A", 300},
+		{"G-4", "Middle", 400},
+		{"G-3", "Dup B", 500},
+		{"G-5", "Last", 600},
+	}
+
+	tests := []struct {
+		name     string
+		id       string
+		matchAll bool
+		status   int
+		want     []int64 // 0-based row indices deleted, in request order
+	}{
+		{name: "first data row", id: "G-1", status: http.StatusOK, want: []int64{1}},
+		{name: "last row", id: "G-5", status: http.StatusOK, want: []int64{6}},
+		{name: "row shorter than the headers", id: "G-2", status: http.StatusOK, want: []int64{2}},
+		{name: "duplicate id deletes the first match only", id: "G-3", status: http.StatusOK, want: []int64{3}},
+		{name: "duplicate id with matchAll", id: "G-3", matchAll: true, status: http.StatusOK, want: []int64{5, 3}},
+		{name: "missing id", id: "G-9", status: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeGoogle(t)
+			f.replySheet("Grants", 42, values)
+			batchPath := "/v4/spreadsheets/" + testSpreadsheetID + ":batchUpdate"
+			f.reply(http.MethodPost, batchPath, &sheets.BatchUpdateSpreadsheetResponse{})
+			s := newTestServer(t, f)
+
+			w := callHandler(t, s.DeleteRow, "po@example.org", DeleteRowRequest{
+				Sheet: "Grants", IdColumn: "ID", Id: tt.id, MatchAll: tt.matchAll,
+			})
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+
+			sent := f.sent(http.MethodPost, batchPath)
+			if tt.want == nil {
+				if len(sent) != 0 {
+					t.Fatalf("deleted rows for a missing id: %s", sent)
+				}
+				return
+			}
+			if len(sent) != 1 {
+				t.Fatalf("got %d batch updates, want 1", len(sent))
+			}
+			var batch sheets.BatchUpdateSpreadsheetRequest
+			if err := json.Unmarshal(sent[0], &batch); err != nil {
+				t.Fatalf("decode batch update: %v", err)
+			}
+			var got []int64
+			for _, r := range batch.Requests {
+				if r.DeleteDimension.Range.SheetId != 42 {
+					t.Errorf("deleted from sheet %d, want 42", r.DeleteDimension.Range.SheetId)
+				}
+				got = append(got, r.DeleteDimension.Range.StartIndex)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("deleted row indices %v, want %v", got, tt.want)
+			}
+
+			var resp DeleteRowResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if resp.Deleted != len(tt.want) {
+				t.Errorf("deleted = %d, want %d", resp.Deleted, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestDeleteRowsWhere(t *testing.T) {
+	values := [][]interface{}{
+		{"ID", "Status", "Year"},
+		{"G-1", "Closed", float64(2024)},
+		{"G-2", "Active", float64(2024)},
+		{"G-3", "Closed", float64(2025)},
+		{"G-4", "Closed", float64(2024)},
+	}
+
+	tests := []struct {
+		name   string
+		where  map[string]interface{}
+		status int
+		want   []int64 // 0-based row indices deleted, in request order
+	}{
+		{name: "one column", where: map[string]interface{}{"Status": "Closed"}, status: http.StatusOK, want: []int64{4, 3, 1}},
+		{name: "every column must match", where: map[string]interface{}{"Status": "Closed", "Year": 2024}, status: http.StatusOK, want: []int64{4, 1}},
+		{name: "no matching rows", where: map[string]interface{}{"Status": "Draft"}, status: http.StatusOK},
+		{name: "unknown column", where: map[string]interface{}{"Stage": "Closed"}, status: http.StatusBadRequest},
+		{name: "no filter", where: map[string]interface{}{}, status: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeGoogle(t)
+			f.replySheet("Grants", 42, values)
+			batchPath := "/v4/spreadsheets/" + testSpreadsheetID + ":batchUpdate"
+			f.reply(http.MethodPost, batchPath, &sheets.BatchUpdateSpreadsheetResponse{})
+			s := newTestServer(t, f)
+
+			w := callHandler(t, s.DeleteRowsWhere, "po@example.org", DeleteRowsWhereRequest{Sheet: "Grants", Where: tt.where})
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+
+			sent := f.sent(http.MethodPost, batchPath)
+			if tt.want == nil {
+				if len(sent) != 0 {
+					t.Fatalf("deleted rows without a match: %s", sent)
+				}
+				return
+			}
+			if len(sent) != 1 {
+				t.Fatalf("got %d batch updates, want 1", len(sent))
+			}
+			var batch sheets.BatchUpdateSpreadsheetRequest
+			if err := json.Unmarshal(sent[0], &batch); err != nil {
+				t.Fatalf("decode batch update: %v", err)
+			}
+			var got []int64
+			for _, r := range batch.Requests {
+				got = append(got, r.DeleteDimension.Range.StartIndex)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("deleted row indices %v, want %v", got, tt.want)
+			}
+
+			var resp DeleteRowsResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if resp.Deleted != len(tt.want) {
+				t.Errorf("deleted = %d, want %d", resp.Deleted, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestHeaderRowsConfig(t *testing.T) {
+	t.Setenv("HEADER_ROWS", "Grants=3, Reports=2")
+	s := newTestServer(t, nil)
+	for sheet, want := range map[string]int{"Grants": 3, "Reports": 2, "Config": 1} {
+		if got := s.headerRow(sheet); got != want {
+			t.Errorf("headerRow(%s) = %d, want %d", sheet, got, want)
+		}
+	}
+	if got := s.headerRange("Grants"); got != "Grants!3:3" {
+		t.Errorf("headerRange(Grants) = %q, want Grants!3:3", got)
+	}
+
+	for _, spec := range []string{"Grants", "Grants=0", "Grants=top"} {
+		t.Setenv("HEADER_ROWS", spec)
+		if _, err := NewServer("test-client"); err == nil {
+			t.Errorf("HEADER_ROWS=%q accepted", spec)
+		}
+	}
+}
+
+func TestReadSheetBelowBanner(t *testing.T) {
+	t.Setenv("HEADER_ROWS", "Grants=3")
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: [][]interface{}{
+		{"Grant Tracker FY26"},
+		{},
+		{"ID", "Title"},
+		{"G-1", "Packaging"},
+	}})
+	s := newTestServer(t, f)
+
+	w := callHandler(t, s.ReadSheet, "po@example.org", ReadSheetRequest{Sheet: "Grants"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var resp ReadSheetResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if want := []string{"ID", "Title"}; !reflect.DeepEqual(resp.Headers, want) {
+		t.Errorf("headers = %v, want %v", resp.Headers, want)
+	}
+	if want := [][]interface{}{{"G-1", "Packaging"}}; !reflect.DeepEqual(resp.Rows, want) {
+		t.Errorf("rows = %v, want %v", resp.Rows, want)
+	}
+}
+
+func TestFillMerges(t *testing.T) {
+	merge := func(top, bottom, left, right int64) *sheets.GridRange {
+		return &sheets.GridRange{StartRowIndex: top, EndRowIndex: bottom, StartColumnIndex: left, EndColumnIndex: right}
+	}
+	tests := []struct {
+		name      string
+		values    [][]interface{}
+		merges    []*sheets.GridRange
+		rowOffset int
+		colOffset int
+		want      [][]interface{}
+	}{
+		{
+			name:   "vertical merge",
+			values: [][]interface{}{{"ID", "Program"}, {"G-1", "Open Source"}, {"G-2"}},
+			merges: []*sheets.GridRange{merge(1, 3, 1, 2)},
+			want:   [][]interface{}{{"ID", "Program"}, {"G-1", "Open Source"}, {"G-2", "Open Source"}},
+		},
+		{
+			name:   "horizontal merge",
+			values: [][]interface{}{{"ID", "Notes", "", "Status"}, {"G-1", "Long note", "", "Active"}},
+			merges: []*sheets.GridRange{merge(1, 2, 1, 3)},
+			want:   [][]interface{}{{"ID", "Notes", "", "Status"}, {"G-1", "Long note", "Long note", "Active"}},
+		},
+		{
+			name:      "read starting below and right of A1",
+			values:    [][]interface{}{{"Open Source"}, {}},
+			merges:    []*sheets.GridRange{merge(4, 6, 2, 3)},
+			rowOffset: 4,
+			colOffset: 2,
+			want:      [][]interface{}{{"Open Source"}, {"Open Source"}},
+		},
+		{
+			name:   "merge outside the values read",
+			values: [][]interface{}{{"ID"}, {"G-1"}},
+			merges: []*sheets.GridRange{merge(10, 12, 0, 1)},
+			want:   [][]interface{}{{"ID"}, {"G-1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fillMerges(tt.values, tt.merges, tt.rowOffset, tt.colOffset)
+			if !reflect.DeepEqual(tt.values, tt.want) {
+				t.Errorf("got %v, want %v", tt.values, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadSheetNormalizeMergedCells(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: [][]interface{}{
+		{"ID", "Program"},
+		{"G-1", "Open Source"},
+		{"G-2"},
+	}})
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID, &sheets.Spreadsheet{Sheets: []*sheets.Sheet{{
+		Merges: []*sheets.GridRange{{StartRowIndex: 1, EndRowIndex: 3, StartColumnIndex: 1, EndColumnIndex: 2}},
+	}}})
+	s := newTestServer(t, f)
+
+	normalize := true
+	w := callHandler(t, s.ReadSheet, "po@example.org", ReadSheetRequest{Sheet: "Grants", NormalizeMergedCells: &normalize})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var resp ReadSheetResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if want := [][]interface{}{{"G-1", "Open Source"}, {"G-2", "Open Source"}}; !reflect.DeepEqual(resp.Rows, want) {
+		t.Errorf("rows = %v, want %v", resp.Rows, want)
+	}
+}
+
+func TestColumnsForData(t *testing.T) {
+	headers := []interface{}{"ID", "Notes", "Title", "Notes"}
+	tests := []struct {
+		name    string
+		mode    string
+		data    map[string]interface{}
+		want    map[int]interface{}
+		wantErr string
+	}{
+		{name: "unique columns", mode: duplicateHeadersError, data: map[string]interface{}{"ID": "G-1", "Title": "Docs", "Missing": "x"}, want: map[int]interface{}{0: "'G-1", 2: "Docs"}},
+		{name: "duplicate rejected", mode: duplicateHeadersError, data: map[string]interface{}{"Notes": "x"}, wantErr: "column Notes appears 2 times in the Grants header row (columns B, D); rename one"},
+		{name: "occurrence ignored in error mode", mode: duplicateHeadersError, data: map[string]interface{}{"Notes#2": "x"}, want: map[int]interface{}{}},
+		{name: "plain duplicate in index mode", mode: duplicateHeadersIndex, data: map[string]interface{}{"Notes": "x"}, wantErr: "address one as Notes#1 to Notes#2"},
+		{name: "second occurrence", mode: duplicateHeadersIndex, data: map[string]interface{}{"Notes#2": "x", "Notes#1": "y"}, want: map[int]interface{}{1: "y", 3: "x"}},
+		{name: "occurrence out of range", mode: duplicateHeadersIndex, data: map[string]interface{}{"Notes#3": "x"}, wantErr: "appears only 2 times"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t, nil)
+			s.duplicateHeaders = tt.mode
+			got, err := s.columnsForData("Grants", headers, tt.data)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("columnsForData: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("columns = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendRowDuplicateHeaders(t *testing.T) {
+	tests := []struct {
+		mode     string
+		row      map[string]interface{}
+		wantCode int
+		wantRow  []interface{}
+	}{
+		{mode: "", row: map[string]interface{}{"ID": "G-3", "Notes": "x"}, wantCode: http.StatusBadRequest},
+		{mode: "index", row: map[string]interface{}{"ID": "G-3", "Notes#2": "second"}, wantCode: http.StatusOK, wantRow: []interface{}{"'G-3", "", "", "second"}},
+	}
+	for _, tt := range tests {
+		t.Run("mode "+tt.mode, func(t *testing.T) {
+			t.Setenv("DUPLICATE_HEADERS", tt.mode)
+			f := newFakeGoogle(t)
+			f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Config!A:B", &sheets.ValueRange{})
+			f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants!1:1", &sheets.ValueRange{Values: [][]interface{}{{"ID", "Notes", "Title", "Notes"}}})
+			appendPath := "/v4/spreadsheets/" + testSpreadsheetID + "/values/Grants!A1:append"
+			f.reply(http.MethodPost, appendPath, &sheets.AppendValuesResponse{})
+			s := newTestServer(t, f)
+
+			w := callHandler(t, s.AppendRow, "po@example.org", AppendRowRequest{Sheet: "Grants", Row: tt.row})
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body)
+			}
+			sent := f.sent(http.MethodPost, appendPath)
+			if tt.wantRow == nil {
+				if len(sent) != 0 {
+					t.Errorf("appended a row despite the ambiguous column")
+				}
+				return
+			}
+			if len(sent) != 1 {
+				t.Fatalf("appended %d times, want once", len(sent))
+			}
+			var vr sheets.ValueRange
+			if err := json.Unmarshal(sent[0], &vr); err != nil {
+				t.Fatalf("decode append: %v", err)
+			}
+			if len(vr.Values) != 1 || !reflect.DeepEqual(vr.Values[0], tt.wantRow) {
+				t.Errorf("appended %v, want %v", vr.Values, tt.wantRow)
+			}
+		})
+	}
+}
+
+func TestReadSheetMissingAndEmptyTabs(t *testing.T) {
+	tests := []struct {
+		name     string
+		sheet    string
+		wantCode int
+	}{
+		{name: "empty tab", sheet: "Budget", wantCode: http.StatusOK},
+		{name: "missing tab", sheet: "Reports", wantCode: http.StatusNotFound},
+		// The tab exists, so a 400 from Sheets is some other failure
+		{name: "bad read of an existing tab", sheet: "Grants", wantCode: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeGoogle(t)
+			f.replySheetGrids()
+			f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Budget", &sheets.ValueRange{Range: "Budget!A1:Z1000"})
+			for _, sheet := range []string{"Reports", "Grants"} {
+				f.handle(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/"+sheet, func(w http.ResponseWriter, r *http.Request) {
+					http.Error(w, `{"error": {"code": 400, "message": "Unable to parse range"}}`, http.StatusBadRequest)
+				})
+			}
+			s := newTestServer(t, f)
+
+			w := callHandler(t, s.ReadSheet, "po@example.org", ReadSheetRequest{Sheet: tt.sheet})
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body)
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var resp map[string]json.RawMessage
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if string(resp["headers"]) != "[]" || string(resp["rows"]) != "[]" {
+				t.Errorf("headers %s, rows %s, want empty arrays", resp["headers"], resp["rows"])
+			}
+		})
+	}
+}
+
+func TestAppendRowMissingTab(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.replySheetGrids()
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Config!A:B", &sheets.ValueRange{})
+	f.handle(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Reports!1:1", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": {"code": 400, "message": "Unable to parse range"}}`, http.StatusBadRequest)
+	})
+	s := newTestServer(t, f)
+
+	w := callHandler(t, s.AppendRow, "po@example.org", AppendRowRequest{Sheet: "Reports", Row: map[string]interface{}{"ID": "R-1"}})
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,21 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// spreadsheetFor returns the spreadsheet a request targets: the discovered one by
+// default, or the one named in X-Spreadsheet-ID when it is allowlisted. Access is
+// still checked against this instance's Grants folder, so only allowlist
+// spreadsheets that share its audience.
+func (s *Server) spreadsheetFor(r *http.Request) (string, error) {
+	id := r.Header.Get("X-Spreadsheet-ID")
+	if primary := s.discoveredSpreadsheetID(); id == "" || id == primary {
+		return primary, nil
+	}
+	if !s.allowedSpreadsheets[id] {
+		return "", fmt.Errorf("spreadsheet %s is not allowed", maskString(id))
+	}
+	return id, nil
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestSpreadsheetFor(t *testing.T) {
+	t.Setenv("SPREADSHEET_ALLOWLIST", " sheet-2 ,sheet-3")
+	s := newTestServer(t, nil)
+
+	tests := []struct {
+		name    string
+		header  string
+		want    string
+		wantErr bool
+	}{
+		{name: "no override", want: testSpreadsheetID},
+		{name: "override naming the default", header: testSpreadsheetID, want: testSpreadsheetID},
+		{name: "allowlisted override", header: "sheet-2", want: "sheet-2"},
+		{name: "another allowlisted override", header: "sheet-3", want: "sheet-3"},
+		{name: "override not on the allowlist", header: "sheet-9", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/api/sheets/read", nil)
+			if tt.header != "" {
+				r.Header.Set("X-Spreadsheet-ID", tt.header)
+			}
+			got, err := s.spreadsheetFor(r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("spreadsheetFor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("spreadsheetFor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadSheetSpreadsheetOverride(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    string
+		status    int
+		wantReads map[string]int // Sheets value reads per spreadsheet
+	}{
+		{name: "allowlisted override is read", header: "sheet-2", status: http.StatusOK, wantReads: map[string]int{"sheet-2": 1}},
+		{name: "non-allowlisted override is refused", header: "sheet-9", status: http.StatusForbidden, wantReads: map[string]int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SPREADSHEET_ALLOWLIST", "sheet-2")
+			f := newFakeGoogle(t)
+			for _, id := range []string{testSpreadsheetID, "sheet-2"} {
+				f.reply(http.MethodGet, "/v4/spreadsheets/"+id+"/values/Grants", &sheets.ValueRange{Values: envelopeGrants})
+			}
+			s := newTestServer(t, f)
+
+			header := http.Header{"X-User-Email": {"po@example.org"}, "X-Spreadsheet-Id": {tt.header}}
+			w := callHandlerWith(t, s.ReadSheet, header, ReadSheetRequest{Sheet: "Grants"})
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+			for _, id := range []string{testSpreadsheetID, "sheet-2", "sheet-9"} {
+				if got := len(f.calls(http.MethodGet, "/v4/spreadsheets/"+id+"/values/Grants")); got != tt.wantReads[id] {
+					t.Errorf("%d reads of %s, want %d", got, id, tt.wantReads[id])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// TokenAudience is the OAuth client ID access tokens must have been issued to.
+// Set by main; when empty any Google-issued token is accepted.
+var TokenAudience string
+
+// tokenInfoURL is Google's access token introspection endpoint
+var tokenInfoURL = "https://oauth2.googleapis.com/tokeninfo"
+
+// tokenInfoCacheDuration bounds how long a verified token is trusted without
+// asking Google again, so a revoked token stops working soon after
+const tokenInfoCacheDuration = 2 * time.Minute
+
+// tokenInfoSweepSize is the cache size above which expired entries are swept
+const tokenInfoSweepSize = 1000
+
+// errInvalidToken means Google doesn't recognize the token, or it wasn't issued
+// to this app for a verified email
+var errInvalidToken = errors.New("invalid access token")
+
+// tokenInfo is the part of Google's tokeninfo response we check
+type tokenInfo struct {
+	Email         string `json:"email"`
+	EmailVerified string `json:"email_verified"`
+	Aud           string `json:"aud"`
+	Azp           string `json:"azp"`
+	ExpiresIn     string `json:"expires_in"`
+}
+
+type tokenInfoEntry struct {
+	email   string
+	expires time.Time
+}
+
+var (
+	tokenInfoCache   = make(map[string]tokenInfoEntry)
+	tokenInfoCacheMu sync.Mutex
+)
+
+// tokenCacheKey hashes a token so the cache never holds usable credentials
+func tokenCacheKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// verifiedTokenEmail returns the email Google says the access token belongs to.
+// Results are cached for tokenInfoCacheDuration, or until the token expires if
+// that is sooner.
+func verifiedTokenEmail(ctx context.Context, token string) (string, error) {
+	key := tokenCacheKey(token)
+	now := time.Now()
+
+	tokenInfoCacheMu.Lock()
+	entry, ok := tokenInfoCache[key]
+	tokenInfoCacheMu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.email, nil
+	}
+
+	info, err := fetchTokenInfo(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	if TokenAudience != "" && info.Aud != TokenAudience && info.Azp != TokenAudience {
+		return "", fmt.Errorf("%w: issued to another client", errInvalidToken)
+	}
+	if info.Email == "" || info.EmailVerified != "true" {
+		return "", fmt.Errorf("%w: no verified email", errInvalidToken)
+	}
+
+	expires := now.Add(tokenInfoCacheDuration)
+	if secs, err := strconv.Atoi(info.ExpiresIn); err == nil {
+		if tokenExpiry := now.Add(time.Duration(secs) * time.Second); tokenExpiry.Before(expires) {
+			expires = tokenExpiry
+		}
+	}
+
+	tokenInfoCacheMu.Lock()
+	if len(tokenInfoCache) >= tokenInfoSweepSize {
+		for k, e := range tokenInfoCache {
+			if !now.Before(e.expires) {
+				delete(tokenInfoCache, k)
+			}
+		}
+	}
+	tokenInfoCache[key] = tokenInfoEntry{email: info.Email, expires: expires}
+	tokenInfoCacheMu.Unlock()
+
+	return info.Email, nil
+}
+
+// fetchTokenInfo asks Google about an access token. A 400 means the token is
+// expired, revoked, or was never valid.
+func fetchTokenInfo(ctx context.Context, token string) (*tokenInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenInfoURL+"?access_token="+url.QueryEscape(token), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusBadRequest {
+		return nil, errInvalidToken
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("tokeninfo returned %d: %s", resp.StatusCode, string(body))
+	}
+
+	var info tokenInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("decode tokeninfo: %w", err)
+	}
+	return &info, nil
+}
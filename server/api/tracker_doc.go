@@ -0,0 +1,316 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"google.golang.org/api/docs/v1"
+)
+
+// trackerFields are the grant fields listed in a tracker doc's metadata table, in order
+var trackerFields = []string{"ID", "Title", "Organization", "Amount", "Status", "Year"}
+
+const (
+	trackerStatusHeading    = "Status\n"
+	trackerMetadataHeading  = "Project Metadata\n"
+	trackerApprovalsHeading = "Approvals\n"
+)
+
+// docLen is the length of s in Docs indices, which count UTF-16 code units
+func docLen(s string) int64 {
+	return int64(len(utf16.Encode([]rune(s))))
+}
+
+// trackerSection is a heading followed by a table, whose first row is its header
+type trackerSection struct {
+	heading string
+	rows    [][]string
+}
+
+// trackerMetadataRows returns the Field/Value rows for the grant's non-empty
+// fields, headed by a Field/Value row (nil if there are none)
+func trackerMetadataRows(grant map[string]string) [][]string {
+	var rows [][]string
+	for _, field := range trackerFields {
+		if val := grant[field]; val != "" {
+			rows = append(rows, []string{field, val})
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return append([][]string{{"Field", "Value"}}, rows...)
+}
+
+// trackerApprovalRows returns a Name/Status/Date row per approver, each
+// starting out Pending, headed by a Name/Status/Date row (nil if there are none)
+func trackerApprovalRows(approvers []string) [][]string {
+	var rows [][]string
+	for _, name := range approvers {
+		if name = strings.TrimSpace(name); name != "" {
+			rows = append(rows, []string{name, "Pending", ""})
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return append([][]string{{"Name", "Status", "Date"}}, rows...)
+}
+
+// trackerSections returns the doc's table sections, leaving out empty ones
+func trackerSections(grant map[string]string, approvers []string) []trackerSection {
+	var sections []trackerSection
+	if rows := trackerMetadataRows(grant); rows != nil {
+		sections = append(sections, trackerSection{heading: trackerMetadataHeading, rows: rows})
+	}
+	if rows := trackerApprovalRows(approvers); rows != nil {
+		sections = append(sections, trackerSection{heading: trackerApprovalsHeading, rows: rows})
+	}
+	return sections
+}
+
+// headingStyle styles the paragraph spanning [start, end) as a named heading
+func headingStyle(start, end int64, style string) *docs.Request {
+	return &docs.Request{
+		UpdateParagraphStyle: &docs.UpdateParagraphStyleRequest{
+			Range:          &docs.Range{StartIndex: start, EndIndex: end},
+			ParagraphStyle: &docs.ParagraphStyle{NamedStyleType: style},
+			Fields:         "namedStyleType",
+		},
+	}
+}
+
+// trackerSkeletonRequests inserts the headings at the top of the doc, each
+// section's followed by a blank line holding an empty table, and marks them as
+// the tracker section. The marker covers the text and grows with every table
+// and cell inserted inside it. Tables are inserted last to first so the
+// indices computed from the text stay valid.
+// It returns the index the first table was inserted at; Docs adds a newline
+// there, so the table itself starts one past it.
+func trackerSkeletonRequests(sections []trackerSection) ([]*docs.Request, int64) {
+	text := trackerStatusHeading + "\n"
+	for _, sec := range sections {
+		text += sec.heading + "\n"
+	}
+
+	requests := []*docs.Request{
+		{InsertText: &docs.InsertTextRequest{Location: &docs.Location{Index: 1}, Text: text}},
+		headingStyle(1, 1+docLen(trackerStatusHeading), "HEADING_1"),
+		markTracker(1 + docLen(text)),
+	}
+
+	tableAt := make([]int64, len(sections))
+	index := 1 + docLen(trackerStatusHeading+"\n")
+	for i, sec := range sections {
+		end := index + docLen(sec.heading)
+		requests = append(requests, headingStyle(index, end, "HEADING_2"))
+		tableAt[i] = end
+		index = end + docLen("\n")
+	}
+	for i := len(sections) - 1; i >= 0; i-- {
+		requests = append(requests, &docs.Request{InsertTable: &docs.InsertTableRequest{
+			Rows:     int64(len(sections[i].rows)),
+			Columns:  int64(len(sections[i].rows[0])),
+			Location: &docs.Location{Index: tableAt[i]},
+		}})
+	}
+
+	if len(sections) == 0 {
+		return requests, 0
+	}
+	return requests, tableAt[0]
+}
+
+// findTables returns the first n top-level tables starting at or after index
+func findTables(content []*docs.StructuralElement, index int64, n int) []*docs.StructuralElement {
+	var tables []*docs.StructuralElement
+	for _, el := range content {
+		if len(tables) == n {
+			break
+		}
+		if el.Table != nil && el.StartIndex >= index {
+			tables = append(tables, el)
+		}
+	}
+	return tables
+}
+
+// tableCellRequests fills an empty table with rows, bolding the first row.
+// Cells are filled last to first so each insert leaves the indices of the
+// cells still to be filled untouched; empty values are left blank.
+func tableCellRequests(table *docs.Table, rows [][]string) ([]*docs.Request, error) {
+	if len(table.TableRows) != len(rows) {
+		return nil, fmt.Errorf("table has %d rows, expected %d", len(table.TableRows), len(rows))
+	}
+
+	var requests []*docs.Request
+	for i := len(rows) - 1; i >= 0; i-- {
+		cells := table.TableRows[i].TableCells
+		if len(cells) != len(rows[i]) {
+			return nil, fmt.Errorf("table row %d has %d cells, expected %d", i, len(cells), len(rows[i]))
+		}
+		for j := len(rows[i]) - 1; j >= 0; j-- {
+			if rows[i][j] == "" {
+				continue
+			}
+			if len(cells[j].Content) == 0 {
+				return nil, fmt.Errorf("table cell (%d, %d) has no paragraph", i, j)
+			}
+			start := cells[j].Content[0].StartIndex
+			requests = append(requests, &docs.Request{
+				InsertText: &docs.InsertTextRequest{Location: &docs.Location{Index: start}, Text: rows[i][j]},
+			})
+			if i == 0 {
+				requests = append(requests, &docs.Request{
+					UpdateTextStyle: &docs.UpdateTextStyleRequest{
+						Range:     &docs.Range{StartIndex: start, EndIndex: start + docLen(rows[i][j])},
+						TextStyle: &docs.TextStyle{Bold: true},
+						Fields:    "bold",
+					},
+				})
+			}
+		}
+	}
+	return requests, nil
+}
+
+// trackerRangeName names the range covering everything initializeTrackerDoc
+// wrote, which marks the doc as initialized and lets a forced rerun replace it
+const trackerRangeName = "grant-tracker:initialized"
+
+// errTrackerUnmarked is returned when forcing reinitialization of a doc whose
+// tracker section predates the marker, so its extent isn't known
+var errTrackerUnmarked = errors.New("doc was initialized without a marker, so its tracker section can't be replaced; remove it by hand and retry")
+
+// trackerRanges returns the ranges marked as the doc's tracker section
+func trackerRanges(doc *docs.Document) []*docs.Range {
+	var ranges []*docs.Range
+	for _, nr := range doc.NamedRanges[trackerRangeName].NamedRanges {
+		ranges = append(ranges, nr.Ranges...)
+	}
+	return ranges
+}
+
+// startsWithStatusHeading reports whether the doc's first paragraph is the
+// Status heading, which is how docs initialized before the marker look
+func startsWithStatusHeading(doc *docs.Document) bool {
+	if doc.Body == nil {
+		return false
+	}
+	for _, el := range doc.Body.Content {
+		if el.Paragraph == nil {
+			continue
+		}
+		style := el.Paragraph.ParagraphStyle
+		return style != nil && style.NamedStyleType == "HEADING_1" &&
+			paragraphText(el.Paragraph) == strings.TrimSuffix(trackerStatusHeading, "\n")
+	}
+	return false
+}
+
+// clearTrackerRequests deletes a previous tracker section and its marker,
+// last range first so earlier ranges keep their indices
+func clearTrackerRequests(ranges []*docs.Range) []*docs.Request {
+	sorted := append([]*docs.Range(nil), ranges...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].StartIndex > sorted[j].StartIndex })
+
+	var requests []*docs.Request
+	for _, rng := range sorted {
+		requests = append(requests, &docs.Request{
+			DeleteContentRange: &docs.DeleteContentRangeRequest{
+				Range: &docs.Range{StartIndex: rng.StartIndex, EndIndex: rng.EndIndex},
+			},
+		})
+	}
+	return append(requests, &docs.Request{DeleteNamedRange: &docs.DeleteNamedRangeRequest{Name: trackerRangeName}})
+}
+
+// markTracker marks [1, end) as the tracker section
+func markTracker(end int64) *docs.Request {
+	return &docs.Request{CreateNamedRange: &docs.CreateNamedRangeRequest{
+		Name:  trackerRangeName,
+		Range: &docs.Range{StartIndex: 1, EndIndex: end},
+	}}
+}
+
+// initializeTrackerDoc writes the Status heading, a Project Metadata table of
+// the grant's fields, and an Approvals table with a row per approver at the top
+// of a tracker doc. The tables are inserted empty, then the doc is re-read so
+// their cells are filled at their actual indices.
+//
+// The section is marked with a named range. A doc that already has one is left
+// alone and reported as skipped, unless force is set, in which case the old
+// section is deleted and written afresh.
+func initializeTrackerDoc(ctx context.Context, srv *docs.Service, documentID string, grant map[string]string, approvers []string, force bool) (skipped bool, err error) {
+	existing, err := srv.Documents.Get(documentID).
+		Fields("revisionId,namedRanges,body.content(paragraph(paragraphStyle(namedStyleType),elements(textRun(content))))").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return false, err
+	}
+
+	var requests []*docs.Request
+	if ranges := trackerRanges(existing); len(ranges) > 0 {
+		if !force {
+			return true, nil
+		}
+		requests = clearTrackerRequests(ranges)
+	} else if startsWithStatusHeading(existing) {
+		if !force {
+			return true, nil
+		}
+		return false, errTrackerUnmarked
+	}
+
+	sections := trackerSections(grant, approvers)
+	skeleton, tableAt := trackerSkeletonRequests(sections)
+	requests = append(requests, skeleton...)
+
+	// The old section's indices came from this read, so refuse if anyone has
+	// edited the doc since
+	_, err = srv.Documents.BatchUpdate(documentID, &docs.BatchUpdateDocumentRequest{
+		Requests:     requests,
+		WriteControl: &docs.WriteControl{RequiredRevisionId: existing.RevisionId},
+	}).Context(ctx).Do()
+	if err != nil {
+		return false, err
+	}
+	if len(sections) == 0 {
+		return false, nil
+	}
+
+	doc, err := srv.Documents.Get(documentID).
+		Fields("revisionId,body.content(startIndex,endIndex,table(tableRows(tableCells(content(startIndex)))))").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return false, fmt.Errorf("re-read doc for tracker tables: %w", err)
+	}
+	tables := findTables(doc.Body.Content, tableAt, len(sections))
+	if len(tables) != len(sections) {
+		return false, fmt.Errorf("found %d of %d tracker tables after inserting them", len(tables), len(sections))
+	}
+
+	// Later tables first, so filling them doesn't move the earlier ones
+	var cellRequests []*docs.Request
+	for i := len(sections) - 1; i >= 0; i-- {
+		reqs, err := tableCellRequests(tables[i].Table, sections[i].rows)
+		if err != nil {
+			return false, fmt.Errorf("%s table: %w", strings.TrimSpace(sections[i].heading), err)
+		}
+		cellRequests = append(cellRequests, reqs...)
+	}
+	_, err = srv.Documents.BatchUpdate(documentID, &docs.BatchUpdateDocumentRequest{
+		Requests:     cellRequests,
+		WriteControl: &docs.WriteControl{RequiredRevisionId: doc.RevisionId},
+	}).Context(ctx).Do()
+	if err != nil {
+		return false, fmt.Errorf("fill tracker tables: %w", err)
+	}
+	return false, nil
+}
@@ -0,0 +1,268 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+const (
+	testDocID     = "doc-1"
+	testDocPath   = "/v1/documents/" + testDocID
+	testBatchPath = testDocPath + ":batchUpdate"
+)
+
+// fakeTable is a top-level table starting at start whose cell (i, j)
+// paragraph starts at start+100*i+10*j+2
+func fakeTable(start int64, rows, cols int) *docs.StructuralElement {
+	table := &docs.Table{}
+	for i := 0; i < rows; i++ {
+		row := &docs.TableRow{}
+		for j := 0; j < cols; j++ {
+			row.TableCells = append(row.TableCells, &docs.TableCell{
+				Content: []*docs.StructuralElement{{StartIndex: start + int64(100*i+10*j+2)}},
+			})
+		}
+		table.TableRows = append(table.TableRows, row)
+	}
+	return &docs.StructuralElement{StartIndex: start, Table: table}
+}
+
+// fakeTrackerDoc serves first on the first Get of the doc and then (or first
+// again when nil) on every later one, and accepts every BatchUpdate
+func fakeTrackerDoc(t *testing.T, first, then *docs.Document) (*fakeGoogle, *docs.Service) {
+	t.Helper()
+	f := newFakeGoogle(t)
+	gets := 0
+	f.handle(http.MethodGet, testDocPath, func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		if gets == 1 || then == nil {
+			writeFakeJSON(w, first)
+			return
+		}
+		writeFakeJSON(w, then)
+	})
+	f.reply(http.MethodPost, testBatchPath, &docs.BatchUpdateDocumentResponse{DocumentId: testDocID})
+
+	srv, err := docs.NewService(context.Background(), f.options()...)
+	if err != nil {
+		t.Fatalf("docs client: %v", err)
+	}
+	return f, srv
+}
+
+// sentBatches decodes every BatchUpdate the fake received
+func sentBatches(t *testing.T, f *fakeGoogle) []docs.BatchUpdateDocumentRequest {
+	t.Helper()
+	var batches []docs.BatchUpdateDocumentRequest
+	for _, body := range f.sent(http.MethodPost, testBatchPath) {
+		var batch docs.BatchUpdateDocumentRequest
+		if err := json.Unmarshal(body, &batch); err != nil {
+			t.Fatalf("decode batch update: %v", err)
+		}
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// insertion is the index and text of one InsertText request
+type insertion struct {
+	index int64
+	text  string
+}
+
+// insertedText lists the InsertText requests, in order
+func insertedText(requests []*docs.Request) []insertion {
+	var got []insertion
+	for _, r := range requests {
+		if r.InsertText != nil {
+			got = append(got, insertion{r.InsertText.Location.Index, r.InsertText.Text})
+		}
+	}
+	return got
+}
+
+func TestInitializeTrackerDoc(t *testing.T) {
+	grant := map[string]string{"ID": "G-1", "Title": "Clean Water"}
+	approvers := []string{"Ann", " "}
+
+	// Status\n \n Project Metadata\n \n Approvals\n \n: the metadata table goes
+	// in after its heading at 26, the approvals table after its heading at 37
+	afterInsert := &docs.Document{RevisionId: "rev-2", Body: &docs.Body{Content: []*docs.StructuralElement{
+		{StartIndex: 1, Paragraph: &docs.Paragraph{}},
+		fakeTable(27, 3, 2),
+		{StartIndex: 400, Paragraph: &docs.Paragraph{}},
+		fakeTable(412, 2, 3),
+	}}}
+	f, srv := fakeTrackerDoc(t, &docs.Document{RevisionId: "rev-1", Body: &docs.Body{}}, afterInsert)
+
+	skipped, err := initializeTrackerDoc(context.Background(), srv, testDocID, grant, approvers, false)
+	if err != nil || skipped {
+		t.Fatalf("initializeTrackerDoc = %v, %v", skipped, err)
+	}
+
+	batches := sentBatches(t, f)
+	if len(batches) != 2 {
+		t.Fatalf("got %d batch updates, want 2", len(batches))
+	}
+
+	skeleton := batches[0]
+	if skeleton.WriteControl == nil || skeleton.WriteControl.RequiredRevisionId != "rev-1" {
+		t.Errorf("skeleton write control = %+v, want revision rev-1", skeleton.WriteControl)
+	}
+	text := "Status\n\nProject Metadata\n\nApprovals\n\n"
+	if got, want := insertedText(skeleton.Requests), []insertion{{1, text}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("skeleton text = %v, want %v", got, want)
+	}
+	var marker *docs.Range
+	var tablesAt []int64
+	var headings []docs.Range
+	for _, r := range skeleton.Requests {
+		switch {
+		case r.CreateNamedRange != nil:
+			if r.CreateNamedRange.Name != trackerRangeName {
+				t.Errorf("named range %q, want %q", r.CreateNamedRange.Name, trackerRangeName)
+			}
+			marker = r.CreateNamedRange.Range
+		case r.InsertTable != nil:
+			tablesAt = append(tablesAt, r.InsertTable.Location.Index)
+		case r.UpdateParagraphStyle != nil:
+			headings = append(headings, *r.UpdateParagraphStyle.Range)
+		}
+	}
+	if marker == nil || marker.StartIndex != 1 || marker.EndIndex != 1+docLen(text) {
+		t.Errorf("marker = %+v, want [1, %d)", marker, 1+docLen(text))
+	}
+	if want := []int64{37, 26}; !reflect.DeepEqual(tablesAt, want) {
+		t.Errorf("tables inserted at %v, want %v (last first)", tablesAt, want)
+	}
+	if want := []docs.Range{{StartIndex: 1, EndIndex: 8}, {StartIndex: 9, EndIndex: 26}, {StartIndex: 27, EndIndex: 37}}; !reflect.DeepEqual(headings, want) {
+		t.Errorf("headings styled at %v, want %v", headings, want)
+	}
+
+	cells := batches[1]
+	if cells.WriteControl == nil || cells.WriteControl.RequiredRevisionId != "rev-2" {
+		t.Errorf("cell write control = %+v, want revision rev-2", cells.WriteControl)
+	}
+	want := []insertion{
+		// Approvals table at 412, last cell first; the empty Date is skipped
+		{412 + 100 + 10 + 2, "Pending"},
+		{412 + 100 + 2, "Ann"},
+		{412 + 20 + 2, "Date"},
+		{412 + 10 + 2, "Status"},
+		{412 + 2, "Name"},
+		// Then the metadata table at 27
+		{27 + 200 + 10 + 2, "Clean Water"},
+		{27 + 200 + 2, "Title"},
+		{27 + 100 + 10 + 2, "G-1"},
+		{27 + 100 + 2, "ID"},
+		{27 + 10 + 2, "Value"},
+		{27 + 2, "Field"},
+	}
+	if got := insertedText(cells.Requests); !reflect.DeepEqual(got, want) {
+		t.Errorf("cell text =\n%v\nwant\n%v", got, want)
+	}
+
+	bold := 0
+	for _, r := range cells.Requests {
+		if r.UpdateTextStyle != nil {
+			if !r.UpdateTextStyle.TextStyle.Bold {
+				t.Errorf("non-bold text style %+v", r.UpdateTextStyle)
+			}
+			bold++
+		}
+	}
+	if bold != 5 {
+		t.Errorf("bolded %d header cells, want 5", bold)
+	}
+}
+
+func TestInitializeTrackerDocWithoutTables(t *testing.T) {
+	f, srv := fakeTrackerDoc(t, &docs.Document{RevisionId: "rev-1", Body: &docs.Body{}}, nil)
+
+	if _, err := initializeTrackerDoc(context.Background(), srv, testDocID, map[string]string{}, nil, false); err != nil {
+		t.Fatalf("initializeTrackerDoc: %v", err)
+	}
+	batches := sentBatches(t, f)
+	if len(batches) != 1 {
+		t.Fatalf("got %d batch updates, want only the skeleton", len(batches))
+	}
+	for _, r := range batches[0].Requests {
+		if r.InsertTable != nil {
+			t.Errorf("inserted a table with no rows to fill: %+v", r.InsertTable)
+		}
+	}
+	if n := len(f.calls(http.MethodGet, testDocPath)); n != 1 {
+		t.Errorf("read the doc %d times, want 1", n)
+	}
+}
+
+func TestInitializeTrackerDocMarked(t *testing.T) {
+	marked := &docs.Document{
+		RevisionId: "rev-1",
+		Body:       &docs.Body{},
+		NamedRanges: map[string]docs.NamedRanges{trackerRangeName: {NamedRanges: []*docs.NamedRange{{
+			Ranges: []*docs.Range{{StartIndex: 1, EndIndex: 50}, {StartIndex: 60, EndIndex: 80}},
+		}}}},
+	}
+
+	t.Run("skipped without force", func(t *testing.T) {
+		f, srv := fakeTrackerDoc(t, marked, nil)
+		skipped, err := initializeTrackerDoc(context.Background(), srv, testDocID, map[string]string{"ID": "G-1"}, nil, false)
+		if err != nil || !skipped {
+			t.Fatalf("initializeTrackerDoc = %v, %v; want skipped", skipped, err)
+		}
+		if n := len(sentBatches(t, f)); n != 0 {
+			t.Errorf("sent %d batch updates to an initialized doc", n)
+		}
+	})
+
+	t.Run("replaced with force", func(t *testing.T) {
+		f, srv := fakeTrackerDoc(t, marked, nil)
+		if _, err := initializeTrackerDoc(context.Background(), srv, testDocID, map[string]string{}, nil, true); err != nil {
+			t.Fatalf("initializeTrackerDoc: %v", err)
+		}
+		batches := sentBatches(t, f)
+		if len(batches) != 1 {
+			t.Fatalf("got %d batch updates, want 1", len(batches))
+		}
+		reqs := batches[0].Requests
+		if len(reqs) < 3 || reqs[0].DeleteContentRange == nil || reqs[1].DeleteContentRange == nil || reqs[2].DeleteNamedRange == nil {
+			t.Fatalf("replacement doesn't start by deleting the old section: %+v", reqs)
+		}
+		if reqs[0].DeleteContentRange.Range.StartIndex != 60 || reqs[1].DeleteContentRange.Range.StartIndex != 1 {
+			t.Errorf("old ranges deleted out of order: %+v, %+v", reqs[0].DeleteContentRange.Range, reqs[1].DeleteContentRange.Range)
+		}
+		if batches[0].WriteControl == nil || batches[0].WriteControl.RequiredRevisionId != "rev-1" {
+			t.Errorf("write control = %+v, want revision rev-1", batches[0].WriteControl)
+		}
+	})
+}
+
+func TestInitializeTrackerDocUnmarked(t *testing.T) {
+	legacy := &docs.Document{RevisionId: "rev-1", Body: &docs.Body{Content: []*docs.StructuralElement{{
+		Paragraph: &docs.Paragraph{
+			ParagraphStyle: &docs.ParagraphStyle{NamedStyleType: "HEADING_1"},
+			Elements:       []*docs.ParagraphElement{{TextRun: &docs.TextRun{Content: "Status\n"}}},
+		},
+	}}}}
+
+	f, srv := fakeTrackerDoc(t, legacy, nil)
+	skipped, err := initializeTrackerDoc(context.Background(), srv, testDocID, map[string]string{"ID": "G-1"}, nil, false)
+	if err != nil || !skipped {
+		t.Errorf("without force: %v, %v; want skipped", skipped, err)
+	}
+
+	_, err = initializeTrackerDoc(context.Background(), srv, testDocID, map[string]string{"ID": "G-1"}, nil, true)
+	if !errors.Is(err, errTrackerUnmarked) {
+		t.Errorf("with force: error %v, want errTrackerUnmarked", err)
+	}
+	if n := len(sentBatches(t, f)); n != 0 {
+		t.Errorf("sent %d batch updates to an unmarked doc", n)
+	}
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/googleapi"
+)
+
+// trashMinRole is the lowest role allowed to trash a file; deleting one
+// outright takes an admin
+const trashMinRole = "writer"
+
+// TrashFile moves a file below the Grants folder to the trash, or deletes it
+// outright when Permanent is set
+func (s *Server) TrashFile(w http.ResponseWriter, r *http.Request) {
+	var req TrashFileRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.FileId == "" {
+		writeError(w, "fileId is required", http.StatusBadRequest)
+		return
+	}
+
+	role := roleRank[r.Header.Get("X-User-Role")]
+	if req.Permanent && role < roleRank[s.adminMinRole] {
+		writeError(w, "Access denied. Administrator role required to delete permanently.", http.StatusForbidden)
+		return
+	}
+	if role < roleRank[trashMinRole] {
+		writeError(w, "Access denied. Writer role required to trash files.", http.StatusForbidden)
+		return
+	}
+
+	srv, err := s.driveService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Drive service: %v", err)
+		writeError(w, "Failed to connect to Google Drive", http.StatusInternalServerError)
+		return
+	}
+
+	file, err := srv.Files.Get(req.FileId).
+		Fields("id, name, parents").
+		SupportsAllDrives(true).
+		Context(r.Context()).
+		Do()
+	var apiErr *googleapi.Error
+	switch {
+	case isCancelled(err):
+		writeCancelled(w, "TrashFile")
+		return
+	case errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound:
+		writeError(w, fmt.Sprintf("File %s not found", req.FileId), http.StatusNotFound)
+		return
+	case err != nil:
+		log.Printf("Failed to get file: %v", err)
+		writeError(w, fmt.Sprintf("Failed to get file: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	// Verify the whole parent chain rather than trusting the Shared Drive
+	// check, which would also allow the spreadsheet and the Grants folder
+	place, err := s.locateInTree(r.Context(), srv, file.Parents)
+	if isCancelled(err) {
+		writeCancelled(w, "TrashFile")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to check file location: %v", err)
+		writeError(w, fmt.Sprintf("Failed to check file location: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if !place.grants {
+		writeError(w, fmt.Sprintf("File %s is not inside the Grants folder", req.FileId), http.StatusForbidden)
+		return
+	}
+
+	action, verb := "trash_file", "trashed"
+	if req.Permanent {
+		action, verb = "delete_file", "permanently deleted"
+		err = srv.Files.Delete(file.Id).
+			SupportsAllDrives(true).
+			Context(r.Context()).
+			Do()
+	} else {
+		_, err = srv.Files.Update(file.Id, &drive.File{Trashed: true}).
+			SupportsAllDrives(true).
+			Context(r.Context()).
+			Do()
+	}
+	if isCancelled(err) {
+		writeCancelled(w, "TrashFile")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to remove file %s: %v", file.Id, err)
+		writeError(w, fmt.Sprintf("Failed to remove file: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	s.audit(r, AuditEvent{
+		Action:   action,
+		Resource: file.Id,
+		Target:   file.Name,
+		Detail:   fmt.Sprintf("%s %s (%s)", verb, file.Name, file.Id),
+	})
+
+	writeJSON(w, SuccessResponse{Success: true})
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// CookieSecret keys the HMAC on the gt_user cookie. Set by main from
+// COOKIE_SECRET; cookies signed with another secret are rejected.
+var CookieSecret []byte
+
+// errInvalidUserCookie means a gt_user cookie is malformed or was not signed by us
+var errInvalidUserCookie = errors.New("invalid user cookie")
+
+func signUserPayload(encoded string) []byte {
+	mac := hmac.New(sha256.New, CookieSecret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+// SignUserCookie returns the gt_user cookie value for a JSON user payload, of
+// the form base64(payload).base64url(hmac). The payload part stays plain
+// base64 so the frontend can still decode it for display.
+func SignUserCookie(payload []byte) string {
+	encoded := base64.StdEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(signUserPayload(encoded))
+}
+
+// VerifyUserCookie checks a gt_user cookie's signature and returns its JSON payload
+func VerifyUserCookie(value string) ([]byte, error) {
+	if len(CookieSecret) == 0 {
+		return nil, errors.New("cookie secret not configured")
+	}
+	encoded, sigPart, ok := strings.Cut(value, ".")
+	if !ok {
+		return nil, errInvalidUserCookie
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(sigPart)
+	if err != nil || !hmac.Equal(sig, signUserPayload(encoded)) {
+		return nil, errInvalidUserCookie
+	}
+	payload, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errInvalidUserCookie
+	}
+	return payload, nil
+}
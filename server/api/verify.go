@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// verifyFailedCode marks a write whose read-back didn't match what was sent
+const verifyFailedCode = "VERIFY_FAILED"
+
+// dateLayouts are the date formats Sheets parses from USER_ENTERED input, so a
+// date read back as a serial number isn't reported as a coercion
+var dateLayouts = []string{"2006-01-02", "1/2/2006", "01/02/2006"}
+
+// sheetsEpoch is day zero of Sheets date serial numbers
+var sheetsEpoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+
+// dateSerial returns the Sheets serial number of a date
+func dateSerial(t time.Time) float64 {
+	return float64(t.Sub(sheetsEpoch) / (24 * time.Hour))
+}
+
+// sameCell reports whether a value read back from Sheets is what was sent.
+// Writes use USER_ENTERED, so numeric text may come back as a number, dates as
+// serial numbers, and a leading ' (which forces text) is not stored; anything
+// else that changes was coerced or dropped.
+func sameCell(sent, got interface{}) bool {
+	sentStr, gotStr := cellString(sent), cellString(got)
+	sentStr = strings.TrimPrefix(sentStr, "'")
+	if strings.TrimSpace(sentStr) == "" {
+		return strings.TrimSpace(gotStr) == ""
+	}
+	if sentStr == gotStr {
+		return true
+	}
+
+	if want, ok := numericValue(sent); ok {
+		have, ok := numericValue(got)
+		return ok && want == have
+	}
+	switch g := got.(type) {
+	case bool:
+		return strings.EqualFold(sentStr, fmt.Sprint(g))
+	case float64:
+		for _, layout := range dateLayouts {
+			if t, err := time.Parse(layout, strings.TrimSpace(sentStr)); err == nil {
+				return dateSerial(t) == g
+			}
+		}
+	}
+	return false
+}
+
+// readBackRow reads one written row. FORMULA rendering returns formulas as
+// written and everything else unformatted, which is what sameCell compares.
+func readBackRow(ctx context.Context, srv *sheets.Service, spreadsheetID, rangeStr string) ([]interface{}, error) {
+	resp, err := srv.Spreadsheets.Values.Get(spreadsheetID, rangeStr).
+		ValueRenderOption("FORMULA").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Values) == 0 {
+		return nil, nil
+	}
+	return resp.Values[0], nil
+}
+
+// rowMismatches compares the sent cells at the given column indexes with the
+// row read back, describing each cell Sheets changed
+func rowMismatches(headers []interface{}, sent, got []interface{}, columns []int) []string {
+	var mismatches []string
+	for _, col := range columns {
+		var want, have interface{}
+		if col < len(sent) {
+			want = sent[col]
+		}
+		if col < len(got) {
+			have = got[col]
+		}
+		if sameCell(want, have) {
+			continue
+		}
+		name := columnLetter(col)
+		if col < len(headers) && cellString(headers[col]) != "" {
+			name = cellString(headers[col])
+		}
+		mismatches = append(mismatches, fmt.Sprintf("%s: sent %q, read back %q", name, cellString(want), cellString(have)))
+	}
+	return mismatches
+}
+
+// verifyError formats rowMismatches for the client
+func verifyError(mismatches []string) string {
+	return "Write verification failed; the row was written but Sheets changed " + strings.Join(mismatches, "; ")
+}
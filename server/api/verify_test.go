@@ -0,0 +1,160 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestSameCell(t *testing.T) {
+	tests := []struct {
+		name string
+		sent interface{}
+		got  interface{}
+		want bool
+	}{
+		{name: "same text", sent: "Approved", got: "Approved", want: true},
+		{name: "forced text", sent: "'G-1", got: "G-1", want: true},
+		{name: "numeric text", sent: "5,000", got: float64(5000), want: true},
+		{name: "date as a serial number", sent: "2026-06-30", got: float64(46203), want: true},
+		{name: "boolean", sent: "true", got: true, want: true},
+		{name: "empty", sent: "", got: nil, want: true},
+		{name: "dropped value", sent: "Packaging", got: nil},
+		{name: "text coerced to a date", sent: "1/2", got: float64(46024)},
+		{name: "different number", sent: "5000", got: float64(500)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sameCell(tt.sent, tt.got); got != tt.want {
+				t.Errorf("sameCell(%v, %v) = %v, want %v", tt.sent, tt.got, got, tt.want)
+			}
+		})
+	}
+}
+
+// decodeVerifyError checks a response is a verification failure and returns its message
+func decodeVerifyError(t *testing.T, body []byte) string {
+	t.Helper()
+	var resp Error
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Code == nil || *resp.Code != verifyFailedCode {
+		t.Errorf("code = %v, want %s", resp.Code, verifyFailedCode)
+	}
+	return resp.Error
+}
+
+func TestAppendRowVerify(t *testing.T) {
+	tests := []struct {
+		name     string
+		readBack []interface{}
+		wantCode int
+		wantMsg  string
+	}{
+		{name: "stored as sent", readBack: []interface{}{"G-3", "1/2 done", float64(5000)}, wantCode: http.StatusOK},
+		{name: "value coerced", readBack: []interface{}{"G-3", float64(46024), float64(5000)}, wantCode: http.StatusUnprocessableEntity, wantMsg: `Title: sent "1/2 done"`},
+		{name: "value dropped", readBack: []interface{}{"G-3", "1/2 done"}, wantCode: http.StatusUnprocessableEntity, wantMsg: `Budget: sent "5000", read back ""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeGoogle(t)
+			f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Config!A:B", &sheets.ValueRange{})
+			f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants!1:1", &sheets.ValueRange{Values: [][]interface{}{{"ID", "Title", "Budget"}}})
+			f.reply(http.MethodPost, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants!A1:append", &sheets.AppendValuesResponse{
+				Updates: &sheets.UpdateValuesResponse{UpdatedRange: "Grants!A4:C4"},
+			})
+			readBackPath := "/v4/spreadsheets/" + testSpreadsheetID + "/values/Grants!A4:C4"
+			f.handle(http.MethodGet, readBackPath, func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.Query().Get("valueRenderOption"); got != "FORMULA" {
+					t.Errorf("valueRenderOption = %q, want FORMULA", got)
+				}
+				writeFakeJSON(w, &sheets.ValueRange{Values: [][]interface{}{tt.readBack}})
+			})
+			s := newTestServer(t, f)
+
+			verify := true
+			w := callHandler(t, s.AppendRow, "po@example.org", AppendRowRequest{
+				Sheet:  "Grants",
+				Row:    map[string]interface{}{"ID": "G-3", "Title": "1/2 done", "Budget": "5000"},
+				Verify: &verify,
+			})
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body)
+			}
+			if calls := f.calls(http.MethodGet, readBackPath); len(calls) != 1 {
+				t.Errorf("read back the written range %d times, want once", len(calls))
+			}
+			if tt.wantMsg == "" {
+				return
+			}
+			if msg := decodeVerifyError(t, w.Body.Bytes()); !strings.Contains(msg, tt.wantMsg) {
+				t.Errorf("error = %q, want it to mention %q", msg, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestAppendRowWithoutVerify(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Config!A:B", &sheets.ValueRange{})
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants!1:1", &sheets.ValueRange{Values: [][]interface{}{{"ID", "Title"}}})
+	f.reply(http.MethodPost, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants!A1:append", &sheets.AppendValuesResponse{
+		Updates: &sheets.UpdateValuesResponse{UpdatedRange: "Grants!A4:B4"},
+	})
+	s := newTestServer(t, f)
+
+	w := callHandler(t, s.AppendRow, "po@example.org", AppendRowRequest{Sheet: "Grants", Row: map[string]interface{}{"ID": "G-3", "Title": "Packaging"}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	if calls := f.calls(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants!A4:B4"); len(calls) != 0 {
+		t.Errorf("read back %d times without verify", len(calls))
+	}
+}
+
+func TestUpdateRowVerify(t *testing.T) {
+	tests := []struct {
+		name     string
+		readBack []interface{}
+		wantCode int
+	}{
+		{name: "stored as sent", readBack: []interface{}{"G-1", "Paid", float64(6000)}, wantCode: http.StatusOK},
+		// Only the sent column is compared, so a change elsewhere in the row is ignored
+		{name: "unsent column changed", readBack: []interface{}{"G-1", "Approved", float64(6000)}, wantCode: http.StatusOK},
+		{name: "sent value coerced", readBack: []interface{}{"G-1", "Paid", float64(600)}, wantCode: http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeGoogle(t)
+			f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Config!A:B", &sheets.ValueRange{})
+			f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants", &sheets.ValueRange{Values: [][]interface{}{
+				{"ID", "Status", "Budget"},
+				{"G-1", "Paid", float64(5000)},
+			}})
+			f.reply(http.MethodPost, valuesBatchUpdatePath, &sheets.BatchUpdateValuesResponse{})
+			f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Grants!A2:C2", &sheets.ValueRange{Values: [][]interface{}{tt.readBack}})
+			s := newTestServer(t, f)
+
+			verify := true
+			w := callHandler(t, s.UpdateRow, "po@example.org", UpdateRowRequest{
+				Sheet:    "Grants",
+				IdColumn: "ID",
+				Id:       "G-1",
+				Data:     map[string]interface{}{"Budget": "6,000"},
+				Verify:   &verify,
+			})
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body)
+			}
+			if tt.wantCode != http.StatusOK {
+				if msg := decodeVerifyError(t, w.Body.Bytes()); !strings.Contains(msg, "Budget") {
+					t.Errorf("error = %q, want it to name Budget", msg)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+)
+
+// grantSubfoldersKey is the Config tab key holding a JSON array of subfolder names
+const grantSubfoldersKey = "grant_subfolders"
+
+// defaultGrantSubfolders is the layout used when neither GRANT_SUBFOLDERS nor the Config tab sets one
+var defaultGrantSubfolders = []string{"Reports"}
+
+// parseFolderList splits a comma-separated list of folder names
+func parseFolderList(spec string) []string {
+	var names []string
+	for _, name := range strings.Split(spec, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// grantSubfolders returns the subfolder template for new grants. A grant_subfolders
+// entry in the Config tab wins, so teams can change the layout without a redeploy.
+func (s *Server) grantSubfolders(ctx context.Context, spreadsheetID string) []string {
+	template := s.grantSubfolderTemplate
+	if template == nil {
+		template = defaultGrantSubfolders
+	}
+
+	srv, err := s.sheetsService(ctx)
+	if err != nil {
+		log.Printf("[API] Grant subfolders: using default template (%v)", err)
+		return template
+	}
+	value, found, err := configValue(ctx, srv, spreadsheetID, grantSubfoldersKey)
+	if err != nil {
+		log.Printf("[API] Grant subfolders: could not read Config tab, using default template (%v)", err)
+		return template
+	}
+	if !found || value == "" {
+		return template
+	}
+
+	var names []string
+	if err := json.Unmarshal([]byte(value), &names); err != nil {
+		log.Printf("[API] Grant subfolders: invalid %s in Config tab, using default template (%v)", grantSubfoldersKey, err)
+		return template
+	}
+	return names
+}
+
+// createFolder creates a folder under parentID
+func (s *Server) createFolder(ctx context.Context, srv *drive.Service, name, parentID string, appProperties map[string]string) (*drive.File, error) {
+	return withRetry(ctx, s.retryAttempts, false, func() (*drive.File, error) {
+		return srv.Files.Create(&drive.File{
+			Name:          name,
+			MimeType:      folderMimeType,
+			Parents:       []string{parentID},
+			AppProperties: appProperties,
+		}).
+			Fields("id, name, webViewLink").
+			SupportsAllDrives(true).
+			Context(ctx).
+			Do()
+	})
+}
+
+// subfolderError wraps a subfolder failure from ensureGrantFolders, after the
+// grant folder itself was created
+type subfolderError struct {
+	name string
+	err  error
+}
+
+func (e *subfolderError) Error() string {
+	return fmt.Sprintf("failed to create subfolder %s: %v", e.name, e.err)
+}
+
+func (e *subfolderError) Unwrap() error { return e.err }
+
+// ensureGrantFolders creates a grant's folder under parentID, tagged with its
+// grant ID, and the subfolder layout inside it. When a subfolder fails the
+// grant folder is still returned, with an *subfolderError, so the caller can say
+// how far it got.
+func (s *Server) ensureGrantFolders(r *http.Request, srv *drive.Service, name, grantID, parentID string, subfolders []string) (*drive.File, []WorkspaceFolder, error) {
+	grantFolder, err := s.createFolder(r.Context(), srv, name, parentID, s.withCreator(r, map[string]string{grantIDProperty: grantID}))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	created := []WorkspaceFolder{}
+	for _, sub := range subfolders {
+		folder, err := s.createFolder(r.Context(), srv, sub, grantFolder.Id, s.withCreator(r, nil))
+		if err != nil {
+			return grantFolder, created, &subfolderError{name: sub, err: err}
+		}
+		created = append(created, WorkspaceFolder{Id: folder.Id, Name: folder.Name, Url: folder.WebViewLink})
+	}
+	return grantFolder, created, nil
+}
+
+// writeGrantFoldersError reports an ensureGrantFolders failure
+func writeGrantFoldersError(w http.ResponseWriter, grantFolder *drive.File, err error) {
+	var subErr *subfolderError
+	if errors.As(err, &subErr) {
+		// The grant folder exists now, so say how far we got
+		log.Printf("Failed to create subfolder %s: %v", subErr.name, subErr.err)
+		writeError(w, fmt.Sprintf("Created grant folder %s but failed to create subfolder %s: %v", grantFolder.Id, subErr.name, subErr.err), http.StatusInternalServerError)
+		return
+	}
+	log.Printf("Failed to create grant folder: %v", err)
+	writeError(w, fmt.Sprintf("Failed to create grant folder: %v", err), http.StatusInternalServerError)
+}
+
+// CreateGrantWorkspace creates a grant's folder, tagged with its grant ID, plus
+// the configured subfolder layout inside it
+func (s *Server) CreateGrantWorkspace(w http.ResponseWriter, r *http.Request) {
+	var req CreateGrantWorkspaceRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.GrantId == "" {
+		writeError(w, "grantId is required", http.StatusBadRequest)
+		return
+	}
+
+	parentID, err := s.resolveParent(r, req.ParentId)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	spreadsheetID, err := s.spreadsheetFor(r)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	srv, err := s.driveService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Drive service: %v", err)
+		writeError(w, "Failed to connect to Google Drive", http.StatusInternalServerError)
+		return
+	}
+
+	if !s.requireInScope(w, r, srv, "CreateGrantWorkspace", parentID) || !s.requireInGrantsTree(w, r, srv, "CreateGrantWorkspace", parentID) {
+		return
+	}
+
+	grantFolder, subfolders, err := s.ensureGrantFolders(r, srv, req.GrantId, req.GrantId, parentID, s.grantSubfolders(r.Context(), spreadsheetID))
+	if err != nil {
+		writeGrantFoldersError(w, grantFolder, err)
+		return
+	}
+
+	result := CreateGrantWorkspaceResponse{
+		Id:         grantFolder.Id,
+		Url:        grantFolder.WebViewLink,
+		Subfolders: subfolders,
+	}
+
+	s.audit(r, AuditEvent{
+		Action:   "create_grant_workspace",
+		Resource: grantFolder.Id,
+		Target:   req.GrantId,
+		Detail:   fmt.Sprintf("created workspace for %s (%s) with %d subfolders", req.GrantId, grantFolder.Id, len(result.Subfolders)),
+	})
+
+	writeJSON(w, result)
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/sheets/v4"
+)
+
+// createdFolder is one folder a test saw created
+type createdFolder struct {
+	name, parent string
+}
+
+// serveFolderCreates fakes Drive folder creation, giving each folder the ID
+// "id-<name>", and returns the folders created so far
+func serveFolderCreates(f *fakeGoogle) func() []createdFolder {
+	var mu sync.Mutex
+	var created []createdFolder
+	f.handle(http.MethodPost, "/files", func(w http.ResponseWriter, r *http.Request) {
+		var file drive.File
+		if err := json.NewDecoder(r.Body).Decode(&file); err != nil {
+			f.t.Errorf("decode created file: %v", err)
+		}
+		mu.Lock()
+		created = append(created, createdFolder{name: file.Name, parent: strings.Join(file.Parents, ",")})
+		mu.Unlock()
+		writeFakeJSON(w, &drive.File{Id: "id-" + file.Name, Name: file.Name, WebViewLink: "https://drive.example/id-" + file.Name})
+	})
+	return func() []createdFolder {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]createdFolder(nil), created...)
+	}
+}
+
+func TestCreateGrantWorkspace(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    string
+		config [][]interface{}
+		want   []string
+	}{
+		{name: "default layout", want: []string{"Reports"}},
+		{name: "GRANT_SUBFOLDERS", env: "Application, Reports, Financials", want: []string{"Application", "Reports", "Financials"}},
+		{
+			name:   "Config tab wins over GRANT_SUBFOLDERS",
+			env:    "Application",
+			config: [][]interface{}{{"key", "value"}, {grantSubfoldersKey, `["Contracts", "Invoices"]`}},
+			want:   []string{"Contracts", "Invoices"},
+		},
+		{
+			name:   "invalid Config tab value",
+			env:    "Application",
+			config: [][]interface{}{{"key", "value"}, {grantSubfoldersKey, "Contracts"}},
+			want:   []string{"Application"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GRANT_SUBFOLDERS", tt.env)
+			f := newFakeGoogle(t)
+			f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Config!A:B", &sheets.ValueRange{Values: tt.config})
+			created := serveFolderCreates(f)
+			s := newTestServer(t, f)
+			s.grantsFolderID = "grants"
+
+			w := callHandler(t, s.CreateGrantWorkspace, "po@example.org", CreateGrantWorkspaceRequest{GrantId: "G-1"})
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", w.Code, w.Body)
+			}
+			var resp CreateGrantWorkspaceResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if resp.Id != "id-G-1" {
+				t.Errorf("grant folder = %q, want id-G-1", resp.Id)
+			}
+
+			want := []createdFolder{{name: "G-1", parent: "grants"}}
+			var names []string
+			for _, name := range tt.want {
+				want = append(want, createdFolder{name: name, parent: "id-G-1"})
+			}
+			for _, sub := range resp.Subfolders {
+				names = append(names, sub.Name)
+			}
+			if got := created(); !reflect.DeepEqual(got, want) {
+				t.Errorf("created %v, want %v", got, want)
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("subfolders = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateGrantWorkspaceSubfolderFailure(t *testing.T) {
+	t.Setenv("GRANT_SUBFOLDERS", "Application, Reports")
+	f := newFakeGoogle(t)
+	f.reply(http.MethodGet, "/v4/spreadsheets/"+testSpreadsheetID+"/values/Config!A:B", &sheets.ValueRange{})
+	f.handle(http.MethodPost, "/files", func(w http.ResponseWriter, r *http.Request) {
+		var file drive.File
+		json.NewDecoder(r.Body).Decode(&file)
+		if file.Name == "Reports" {
+			http.Error(w, `{"error": {"code": 403, "message": "Insufficient permissions"}}`, http.StatusForbidden)
+			return
+		}
+		writeFakeJSON(w, &drive.File{Id: "id-" + file.Name, Name: file.Name})
+	})
+	s := newTestServer(t, f)
+	s.grantsFolderID = "grants"
+
+	w := callHandler(t, s.CreateGrantWorkspace, "po@example.org", CreateGrantWorkspaceRequest{GrantId: "G-1"})
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500: %s", w.Code, w.Body)
+	}
+	if body := w.Body.String(); !strings.Contains(body, "id-G-1") || !strings.Contains(body, "Reports") {
+		t.Errorf("body = %s, want it to name the created grant folder and the failed subfolder", body)
+	}
+}
@@ -0,0 +1,176 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// writeQueue serializes value writes to one spreadsheet and coalesces writes that
+// arrive within a short window into a single BatchUpdate call. Ranges are sent in
+// arrival order, so a later write to the same cell still wins.
+type writeQueue struct {
+	spreadsheetID string
+	window        time.Duration
+	service       func(context.Context) (*sheets.Service, error) // Resolved per flush, so reloaded credentials apply
+	attempts      int
+	maxRanges     int // Largest BatchUpdate sent; coalesced writes beyond it are split
+
+	mu      sync.Mutex
+	pending []*queuedWrite
+	timer   *time.Timer
+
+	flushMu sync.Mutex // Only one BatchUpdate in flight per spreadsheet
+}
+
+// queuedWrite is one caller's share of a coalesced batch
+type queuedWrite struct {
+	data []*sheets.ValueRange
+	done chan error
+}
+
+// enqueue adds data to the next batch and returns a channel that receives the batch result
+func (q *writeQueue) enqueue(data []*sheets.ValueRange) <-chan error {
+	w := &queuedWrite{data: data, done: make(chan error, 1)}
+
+	q.mu.Lock()
+	q.pending = append(q.pending, w)
+	if q.timer == nil {
+		q.timer = time.AfterFunc(q.window, q.flush)
+	}
+	q.mu.Unlock()
+
+	return w.done
+}
+
+// flush sends every pending write as one BatchUpdate, or consecutive ones of at
+// most maxRanges ranges. If the batch fails, each write is retried on its own so
+// one bad range doesn't fail every caller.
+func (q *writeQueue) flush() {
+	q.flushMu.Lock()
+	defer q.flushMu.Unlock()
+
+	q.mu.Lock()
+	batch := q.pending
+	q.pending = nil
+	q.timer = nil
+	q.mu.Unlock()
+
+	if len(batch) == 0 {
+		return
+	}
+
+	// No caller owns the flush, so retries aren't tied to any request's context
+	ctx := context.Background()
+	srv, err := q.service(ctx)
+	if err != nil {
+		for _, w := range batch {
+			w.done <- err
+		}
+		return
+	}
+
+	var data []*sheets.ValueRange
+	for _, w := range batch {
+		data = append(data, w.data...)
+	}
+	chunks := splitValueRanges(data, q.maxRanges)
+	for _, chunk := range chunks {
+		if err = q.send(ctx, srv, chunk); err != nil {
+			break
+		}
+	}
+	if err == nil || len(batch) == 1 {
+		if len(batch) > 1 {
+			log.Printf("[API] Write queue: coalesced %d writes (%d ranges) into %d BatchUpdate(s)", len(batch), len(data), len(chunks))
+		}
+		for _, w := range batch {
+			w.done <- err
+		}
+		return
+	}
+
+	// A failed BatchUpdate writes nothing, though earlier chunks may have landed;
+	// resend in arrival order so a later write to the same cell still wins
+	log.Printf("[API] Write queue: batch of %d writes failed, sending them one by one: %v", len(batch), err)
+	for _, w := range batch {
+		w.done <- q.send(ctx, srv, w.data)
+	}
+}
+
+// send writes data to the queue's spreadsheet in one BatchUpdate
+func (q *writeQueue) send(ctx context.Context, srv *sheets.Service, data []*sheets.ValueRange) error {
+	_, err := withRetry(ctx, q.attempts, true, func() (*sheets.BatchUpdateValuesResponse, error) {
+		return srv.Spreadsheets.Values.BatchUpdate(q.spreadsheetID, &sheets.BatchUpdateValuesRequest{
+			ValueInputOption: "USER_ENTERED",
+			Data:             data,
+		}).Context(ctx).Do()
+	})
+	return err
+}
+
+// defaultBatchUpdateMaxRanges keeps each BatchUpdate well under Google's request size limits
+const defaultBatchUpdateMaxRanges = 500
+
+// batchTooLargeCode marks a batch rejected for exceeding the configured size
+const batchTooLargeCode = "BATCH_TOO_LARGE"
+
+// writeBatchTooLarge rejects a batch of size updates, telling the client the
+// largest batch it should send instead
+func writeBatchTooLarge(w http.ResponseWriter, size, max int) {
+	code := batchTooLargeCode
+	writeJSONStatus(w, Error{
+		Error: fmt.Sprintf("Batch of %d updates exceeds the limit of %d; split it into batches of at most %d", size, max, max),
+		Code:  &code,
+		Limit: &max,
+	}, http.StatusBadRequest)
+}
+
+// splitValueRanges splits data into consecutive chunks of at most max ranges
+func splitValueRanges(data []*sheets.ValueRange, max int) [][]*sheets.ValueRange {
+	if max < 1 || len(data) <= max {
+		return [][]*sheets.ValueRange{data}
+	}
+	chunks := make([][]*sheets.ValueRange, 0, (len(data)+max-1)/max)
+	for len(data) > max {
+		chunks = append(chunks, data[:max])
+		data = data[max:]
+	}
+	return append(chunks, data)
+}
+
+// writeValues writes value ranges to a spreadsheet, going through the write queue
+// when coalescing is enabled
+func (s *Server) writeValues(ctx context.Context, srv *sheets.Service, spreadsheetID string, data []*sheets.ValueRange) error {
+	if s.writeCoalesceWindow <= 0 {
+		_, err := withRetry(ctx, s.retryAttempts, true, func() (*sheets.BatchUpdateValuesResponse, error) {
+			return srv.Spreadsheets.Values.BatchUpdate(spreadsheetID, &sheets.BatchUpdateValuesRequest{
+				ValueInputOption: "USER_ENTERED",
+				Data:             data,
+			}).Context(ctx).Do()
+		})
+		return err
+	}
+
+	s.writeQueuesMu.Lock()
+	q, ok := s.writeQueues[spreadsheetID]
+	if !ok {
+		q = &writeQueue{spreadsheetID: spreadsheetID, window: s.writeCoalesceWindow, service: s.sheetsService, attempts: s.retryAttempts, maxRanges: s.batchUpdateMaxRanges}
+		s.writeQueues[spreadsheetID] = q
+	}
+	s.writeQueuesMu.Unlock()
+
+	// The write is applied even if the caller goes away; we just stop waiting.
+	// Each caller gets its own write's result, so only writes that landed are audited.
+	select {
+	case err := <-q.enqueue(data):
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+const valuesBatchUpdatePath = "/v4/spreadsheets/" + testSpreadsheetID + "/values:batchUpdate"
+
+func TestWriteQueueSplitsCoalescedWrites(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodPost, valuesBatchUpdatePath, &sheets.BatchUpdateValuesResponse{})
+	s := newTestServer(t, f)
+	s.writeCoalesceWindow = 50 * time.Millisecond
+	s.batchUpdateMaxRanges = 2
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			data := []*sheets.ValueRange{{Range: fmt.Sprintf("Grants!A%d", i+2), Values: [][]interface{}{{"x"}}}}
+			if err := s.writeValues(context.Background(), s.sheetsClient, testSpreadsheetID, data); err != nil {
+				t.Errorf("write %d: %v", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	var sizes []int
+	for _, body := range f.sent(http.MethodPost, valuesBatchUpdatePath) {
+		var req sheets.BatchUpdateValuesRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		sizes = append(sizes, len(req.Data))
+	}
+	if len(sizes) != 2 || sizes[0] != 2 || sizes[1] != 1 {
+		t.Errorf("BatchUpdate sizes = %v, want [2 1]", sizes)
+	}
+}
+
+func TestWriteValuesUnqueuedUsesContext(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.reply(http.MethodPost, valuesBatchUpdatePath, &sheets.BatchUpdateValuesResponse{})
+	s := newTestServer(t, f)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	data := []*sheets.ValueRange{{Range: "Grants!A2", Values: [][]interface{}{{"x"}}}}
+	if err := s.writeValues(ctx, s.sheetsClient, testSpreadsheetID, data); err == nil {
+		t.Error("write with a cancelled context succeeded")
+	}
+	if calls := f.calls(http.MethodPost, valuesBatchUpdatePath); len(calls) != 0 {
+		t.Errorf("%d writes sent after the caller went away", len(calls))
+	}
+}
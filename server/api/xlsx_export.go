@@ -0,0 +1,93 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+const (
+	xlsxMimeType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+	// exportTooLargeCode marks an export Drive refused for exceeding its size limit
+	exportTooLargeCode = "EXPORT_TOO_LARGE"
+)
+
+// exportTooLarge reports whether Drive refused an export for exceeding its
+// 10MB export limit
+func exportTooLarge(err error) bool {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	for _, item := range apiErr.Errors {
+		if item.Reason == "exportSizeLimitExceeded" {
+			return true
+		}
+	}
+	return false
+}
+
+// ExportSpreadsheetXlsx streams the whole spreadsheet, every tab included, as an
+// Excel file via Drive's export API. The export can't hide columns, so when
+// SENSITIVE_COLUMNS is set only users allowed to see them may download it.
+func (s *Server) ExportSpreadsheetXlsx(w http.ResponseWriter, r *http.Request) {
+	if len(s.sensitiveColumns) > 0 && !s.canSeeSensitive(r) {
+		writeError(w, "insufficient permission to export sensitive columns", http.StatusForbidden)
+		return
+	}
+
+	spreadsheetID, err := s.spreadsheetFor(r)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	srv, err := s.driveService(r.Context())
+	if err != nil {
+		log.Printf("Failed to create Drive service: %v", err)
+		writeError(w, "Failed to connect to Google Drive", http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := srv.Files.Export(spreadsheetID, xlsxMimeType).Context(r.Context()).Download()
+	if isCancelled(err) {
+		writeCancelled(w, "ExportSpreadsheetXlsx")
+		return
+	}
+	if exportTooLarge(err) {
+		writeErrorCode(w, "Spreadsheet is too large to export as XLSX (Drive limits exports to 10MB); export individual sheets instead",
+			exportTooLargeCode, http.StatusUnprocessableEntity)
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to export spreadsheet: %v", err)
+		writeError(w, fmt.Sprintf("Failed to export spreadsheet: %v", err), http.StatusInternalServerError)
+		return
+	}
+	defer resp.Body.Close()
+
+	filename := fmt.Sprintf("grant-tracker-%s.xlsx", time.Now().UTC().Format("2006-01-02"))
+	w.Header().Set("Content-Type", xlsxMimeType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	if resp.ContentLength > 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(resp.ContentLength))
+	}
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		// Headers are already sent, so all we can do is log it
+		log.Printf("Failed to stream spreadsheet export after %d bytes: %v", n, err)
+		return
+	}
+
+	s.audit(r, AuditEvent{
+		Action:   "export_spreadsheet",
+		Resource: spreadsheetID,
+		Detail:   fmt.Sprintf("exported spreadsheet as XLSX (%d bytes)", n),
+	})
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grant-tracker/server/api"
+	"google.golang.org/api/idtoken"
+	"google.golang.org/api/option"
+)
+
+const testClientID = "client-1.apps.googleusercontent.com"
+
+// idTokenSigner signs ID tokens with a key that the test validator trusts as Google's
+type idTokenSigner struct {
+	key *rsa.PrivateKey
+	kid string
+}
+
+// jwksTransport answers every request with a JWKS holding the signer's public key
+type jwksTransport struct {
+	signer *idTokenSigner
+}
+
+func (j jwksTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	pub := j.signer.key.PublicKey
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(rec).Encode(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"kid": j.signer.kid,
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+	return rec.Result(), nil
+}
+
+// newIDTokenSigner points idTokenValidator at a fresh signing key and sets the
+// client ID tokens must be issued to
+func newIDTokenSigner(t *testing.T) *idTokenSigner {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	signer := &idTokenSigner{key: key, kid: "test-key"}
+
+	validator, err := idtoken.NewValidator(context.Background(),
+		option.WithHTTPClient(&http.Client{Transport: jwksTransport{signer}}))
+	if err != nil {
+		t.Fatalf("create validator: %v", err)
+	}
+	oldValidator, oldClientID := idTokenValidator, clientID
+	idTokenValidator, clientID = validator, testClientID
+	t.Cleanup(func() { idTokenValidator, clientID = oldValidator, oldClientID })
+	return signer
+}
+
+// idClaims returns valid claims for a verified po@example.org, with overrides
+// applied; a nil override removes the claim
+func idClaims(overrides map[string]interface{}) map[string]interface{} {
+	now := time.Now()
+	claims := map[string]interface{}{
+		"iss":            "https://accounts.google.com",
+		"aud":            testClientID,
+		"sub":            "1234567890",
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"email":          "po@example.org",
+		"email_verified": true,
+		"name":           "Program Officer",
+		"hd":             "example.org",
+	}
+	for name, value := range overrides {
+		if value == nil {
+			delete(claims, name)
+		} else {
+			claims[name] = value
+		}
+	}
+	return claims
+}
+
+// sign returns an RS256 JWT for claims
+func (s *idTokenSigner) sign(t *testing.T, claims map[string]interface{}) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": s.kid})
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("marshal claims: %v", err)
+	}
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	sum := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, sum[:])
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// tamper swaps a signed token's payload for one carrying claims, keeping the signature
+func tamper(token string, claims map[string]interface{}) string {
+	parts := strings.Split(token, ".")
+	payload, _ := json.Marshal(claims)
+	return parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload) + "." + parts[2]
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	signer := newIDTokenSigner(t)
+	other := &idTokenSigner{kid: signer.kid}
+	var err error
+	if other.key, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "signed token", token: signer.sign(t, idClaims(nil))},
+		{name: "issuer without scheme", token: signer.sign(t, idClaims(map[string]interface{}{"iss": "accounts.google.com"}))},
+		{name: "tampered payload", token: tamper(signer.sign(t, idClaims(nil)), idClaims(map[string]interface{}{"email": "admin@example.org"})), wantErr: true},
+		{name: "signed by another key", token: other.sign(t, idClaims(nil)), wantErr: true},
+		{name: "issued to another client", token: signer.sign(t, idClaims(map[string]interface{}{"aud": "other-client"})), wantErr: true},
+		{name: "expired", token: signer.sign(t, idClaims(map[string]interface{}{"exp": time.Now().Add(-time.Minute).Unix()})), wantErr: true},
+		{name: "not issued by Google", token: signer.sign(t, idClaims(map[string]interface{}{"iss": "https://evil.example"})), wantErr: true},
+		{name: "no email", token: signer.sign(t, idClaims(map[string]interface{}{"email": nil})), wantErr: true},
+		{name: "not a JWT", token: "not-a-token", wantErr: true},
+		{name: "missing", token: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := verifyIDToken(context.Background(), tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("verifyIDToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if claims.Email != "po@example.org" || !claims.EmailVerified || claims.Name != "Program Officer" || claims.HD != "example.org" {
+				t.Errorf("claims = %+v", claims)
+			}
+		})
+	}
+}
+
+// callback runs handleCallback for an OAuth code exchange that returns idToken
+func callback(t *testing.T, idToken string) *httptest.ResponseRecorder {
+	t.Helper()
+	fakeTokenEndpoint(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(TokenResponse{
+			AccessToken: "access-1", RefreshToken: "refresh-1", IDToken: idToken, ExpiresIn: 3600, TokenType: "Bearer",
+		})
+	})
+	oldSessions, oldSecret := sessions, api.CookieSecret
+	sessions, api.CookieSecret = newMemorySessionStore(), []byte(strongSecret)
+	t.Cleanup(func() { sessions, api.CookieSecret = oldSessions, oldSecret })
+
+	r := httptest.NewRequest(http.MethodGet, "/auth/callback?state=state-1&code=code-1", nil)
+	r.AddCookie(&http.Cookie{Name: "oauth_state", Value: "state-1"})
+	w := httptest.NewRecorder()
+	handleCallback(w, r)
+	return w
+}
+
+// signedInAs returns the email in the gt_user cookie a response sets, if any
+func signedInAs(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+	for _, c := range w.Result().Cookies() {
+		if c.Name != "gt_user" || c.MaxAge < 0 {
+			continue
+		}
+		payload, err := api.VerifyUserCookie(c.Value)
+		if err != nil {
+			t.Fatalf("gt_user cookie: %v", err)
+		}
+		var user UserInfo
+		if err := json.Unmarshal(payload, &user); err != nil {
+			t.Fatalf("decode gt_user cookie: %v", err)
+		}
+		return user.Email
+	}
+	return ""
+}
+
+func TestHandleCallbackSignedIDToken(t *testing.T) {
+	signer := newIDTokenSigner(t)
+	w := callback(t, signer.sign(t, idClaims(nil)))
+
+	if w.Code != http.StatusFound || w.Header().Get("Location") != "/" {
+		t.Fatalf("status = %d, location %q: %s", w.Code, w.Header().Get("Location"), w.Body)
+	}
+	if email := signedInAs(t, w); email != "po@example.org" {
+		t.Errorf("signed in as %q, want po@example.org", email)
+	}
+}
+
+func TestHandleCallbackTamperedIDToken(t *testing.T) {
+	signer := newIDTokenSigner(t)
+	token := tamper(signer.sign(t, idClaims(nil)), idClaims(map[string]interface{}{"email": "admin@example.org"}))
+	w := callback(t, token)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401: %s", w.Code, w.Body)
+	}
+	if email := signedInAs(t, w); email != "" {
+		t.Errorf("tampered token signed in as %q", email)
+	}
+}
+
+// withHostedDomain restricts login to domain for one test
+func withHostedDomain(t *testing.T, domain string) {
+	t.Helper()
+	old := hostedDomain
+	hostedDomain = domain
+	t.Cleanup(func() { hostedDomain = old })
+}
+
+func TestVerifyHostedDomain(t *testing.T) {
+	tests := []struct {
+		name    string
+		domain  string
+		claims  idTokenClaims
+		wantErr bool
+	}{
+		{"in-domain account", "example.org", idTokenClaims{Email: "po@example.org", EmailVerified: true, HD: "example.org"}, false},
+		{"hd in another case", "example.org", idTokenClaims{Email: "po@example.org", EmailVerified: true, HD: "Example.ORG"}, false},
+		{"other Workspace domain", "example.org", idTokenClaims{Email: "po@other.org", EmailVerified: true, HD: "other.org"}, true},
+		{"consumer account with an in-domain email", "example.org", idTokenClaims{Email: "po@example.org", EmailVerified: true}, true},
+		{"unverified email", "example.org", idTokenClaims{Email: "po@example.org", HD: "example.org"}, true},
+		{"no restriction", "", idTokenClaims{Email: "someone@gmail.com", EmailVerified: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withHostedDomain(t, tt.domain)
+			if err := verifyHostedDomain(&tt.claims); (err != nil) != tt.wantErr {
+				t.Errorf("verifyHostedDomain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHandleCallbackHostedDomain(t *testing.T) {
+	tests := []struct {
+		name      string
+		claims    map[string]interface{}
+		wantEmail string
+	}{
+		{name: "in-domain login", claims: idClaims(nil), wantEmail: "po@example.org"},
+		{name: "out-of-domain login", claims: idClaims(map[string]interface{}{"email": "po@other.org", "hd": "other.org"})},
+		{name: "consumer account", claims: idClaims(map[string]interface{}{"hd": nil})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withHostedDomain(t, "example.org")
+			signer := newIDTokenSigner(t)
+			w := callback(t, signer.sign(t, tt.claims))
+
+			if email := signedInAs(t, w); email != tt.wantEmail {
+				t.Errorf("signed in as %q, want %q", email, tt.wantEmail)
+			}
+			wantStatus := http.StatusFound
+			if tt.wantEmail == "" {
+				wantStatus = http.StatusForbidden
+			}
+			if w.Code != wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, wantStatus)
+			}
+		})
+	}
+}
+
+func TestHandleLoginHostedDomain(t *testing.T) {
+	withHostedDomain(t, "example.org")
+	w := httptest.NewRecorder()
+	handleLogin(w, httptest.NewRequest(http.MethodGet, "/auth/login", nil))
+
+	location, err := url.Parse(w.Header().Get("Location"))
+	if err != nil {
+		t.Fatalf("parse redirect: %v", err)
+	}
+	if hd := location.Query().Get("hd"); hd != "example.org" {
+		t.Errorf("auth URL hd = %q, want example.org", hd)
+	}
+}
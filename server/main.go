@@ -1,9 +1,13 @@
 package main
 
 import (
+	"context"
 	"crypto/rand"
+	"crypto/subtle"
+	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -11,10 +15,21 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/grant-tracker/server/api"
+	"google.golang.org/api/idtoken"
+)
+
+// Build information, injected at build time with
+// -ldflags "-X main.version=... -X main.gitCommit=... -X main.buildTime=..."
+var (
+	version   = "dev"
+	gitCommit = "unknown"
+	buildTime = "unknown"
 )
 
 var (
@@ -22,14 +37,22 @@ var (
 	clientSecret  string
 	redirectURI   string
 	staticDir     string
-	allowedOrigin string
+	allowedOrigin string // ALLOWED_ORIGIN: comma-separated origins allowed to call the API cross-origin
+	hostedDomain  string // Restrict login to this Google Workspace domain (empty = any account)
+	production    bool   // ENV=production: insecure configuration is fatal and cookies are always Secure
+	sessionMaxAge time.Duration
+	sessions      SessionStore = newMemorySessionStore()
 	apiServer     *api.Server
+
+	// Google's OAuth token endpoint, for code exchange and refresh
+	googleTokenURL = "https://oauth2.googleapis.com/token"
 )
 
 // TokenResponse represents the response from Google's token endpoint
 type TokenResponse struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
 	ExpiresIn    int    `json:"expires_in"`
 	TokenType    string `json:"token_type"`
 	Scope        string `json:"scope,omitempty"`
@@ -49,6 +72,9 @@ func main() {
 	redirectURI = os.Getenv("REDIRECT_URI")
 	staticDir = os.Getenv("STATIC_DIR")
 	allowedOrigin = os.Getenv("ALLOWED_ORIGIN")
+	hostedDomain = strings.ToLower(os.Getenv("HOSTED_DOMAIN"))
+	production = os.Getenv("ENV") == "production"
+	sessionMaxAge = envDuration("SESSION_MAX_AGE", defaultSessionMaxAge)
 
 	if clientID == "" || clientSecret == "" {
 		log.Fatal("GOOGLE_CLIENT_ID and GOOGLE_CLIENT_SECRET must be set")
@@ -67,9 +93,47 @@ func main() {
 		}
 	}
 	log.Printf("Using redirect URI: %s", redirectURI)
+	if production {
+		if err := validateProductionConfig(redirectURI, allowedOrigin, os.Getenv("CAPABILITY_SECRET"), os.Getenv("COOKIE_SECRET")); err != nil {
+			log.Fatalf("Refusing to start in production: %v", err)
+		}
+		log.Printf("Production mode: secure configuration verified")
+	}
+	log.Printf("Session max age: %s", sessionMaxAge)
+	if hostedDomain != "" {
+		log.Printf("Login restricted to Workspace domain: %s", hostedDomain)
+	}
 
-	// Initialize API server (service account)
+	// ID token verification (fetches and caches Google's signing keys on demand)
 	var err error
+	idTokenValidator, err = idtoken.NewValidator(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to create ID token validator: %v", err)
+	}
+
+	// Let API middleware refresh expired or expiring access tokens in place
+	api.RefreshSession = refreshSession
+	api.AccessNearExpiry = accessNearExpiry
+
+	// ENV is parsed here only; the API takes the result
+	api.Production = production
+
+	// Only accept access tokens issued to this app
+	api.TokenAudience = clientID
+
+	// Sign the gt_user cookie so its identity can't be edited client-side
+	if secret := os.Getenv("COOKIE_SECRET"); secret != "" {
+		if len(secret) < 32 {
+			log.Fatalf("COOKIE_SECRET must be at least 32 characters")
+		}
+		api.CookieSecret = []byte(secret)
+	} else {
+		api.CookieSecret = make([]byte, 32)
+		rand.Read(api.CookieSecret)
+		log.Printf("Warning: COOKIE_SECRET not set; using a random secret, so users must sign in again after a restart")
+	}
+
+	// Initialize API server (service account)
 	apiServer, err = api.NewServer(clientID)
 	if err != nil {
 		log.Printf("Warning: API server initialization failed: %v", err)
@@ -85,29 +149,71 @@ func main() {
 	mux.HandleFunc("/auth/refresh", handleRefresh)
 	mux.HandleFunc("/auth/logout", handleLogout)
 	mux.HandleFunc("/auth/status", handleStatus)
+	mux.HandleFunc("/auth/csrf", handleCSRF)
+
+	// Build info (public)
+	mux.HandleFunc("/api/version", handleVersion)
 
 	// Register API routes if service account is available
 	if apiServer != nil && apiServer.IsConfigured() {
 		// Config endpoint (public)
 		mux.HandleFunc("/api/config", apiServer.GetConfig)
 
+		// Recent Google API usage, so clients can throttle themselves
+		mux.HandleFunc("/api/quota", apiServer.RequireAccess(apiServer.GetQuota))
+
 		// Sheets endpoints (require auth + access check via service account)
 		mux.HandleFunc("/api/sheets/read", apiServer.RequireAccess(apiServer.ReadSheet))
+		mux.HandleFunc("/api/sheets/metadata", apiServer.RequireAccess(apiServer.GetSheetMetadata))
 		mux.HandleFunc("/api/sheets/append", apiServer.RequireAccess(apiServer.AppendRow))
+		mux.HandleFunc("/api/sheets/batch-append", apiServer.RequireAccess(apiServer.BatchAppendRows))
 		mux.HandleFunc("/api/sheets/update", apiServer.RequireAccess(apiServer.UpdateRow))
-		mux.HandleFunc("/api/sheets/delete", apiServer.RequireAccess(apiServer.DeleteRow))
+		mux.HandleFunc("/api/sheets/conditional-update", apiServer.RequireAccess(apiServer.ConditionalUpdate))
+		mux.HandleFunc("/api/sheets/delete", apiServer.RequireAccess(apiServer.Destructive(apiServer.DeleteRow)))
+		mux.HandleFunc("/api/sheets/delete-where", apiServer.RequireAccess(apiServer.Destructive(apiServer.DeleteRowsWhere)))
+		mux.HandleFunc("/api/sheets/pivot", apiServer.RequireAccess(apiServer.Pivot))
+		mux.HandleFunc("/api/sheets/completeness", apiServer.RequireAccess(apiServer.Completeness))
 		mux.HandleFunc("/api/sheets/batch-update", apiServer.RequireAccess(apiServer.BatchUpdateCells))
+		mux.HandleFunc("/api/sheets/auto-resize", apiServer.RequireAccess(apiServer.AutoResizeColumns))
+		mux.HandleFunc("/api/sheets/preview-import", apiServer.RequireAccess(apiServer.PreviewImport))
+		mux.HandleFunc("/api/sheets/duplicates", apiServer.RequireAccess(apiServer.FindDuplicates))
+		mux.HandleFunc("/api/sheets/find", apiServer.RequireAccess(apiServer.FindRows))
+		mux.HandleFunc("/api/sheets/export-xlsx", apiServer.RequireAccess(apiServer.ExportSpreadsheetXlsx))
+		mux.HandleFunc("/api/dashboard", apiServer.RequireAccess(apiServer.GetDashboard))
+		mux.HandleFunc("/api/grants/history", apiServer.RequireAccess(apiServer.GrantHistory))
+		mux.HandleFunc("/api/audit/list", apiServer.RequireAdmin(apiServer.ListAuditEvents))
+		mux.HandleFunc("/api/grants/export", apiServer.RequireAccess(apiServer.ExportGrant))
+		mux.HandleFunc("/api/grants/permalink", apiServer.RequireAccess(apiServer.GetGrantPermalink))
+		mux.HandleFunc("/api/grants/workspace", apiServer.RequireAccess(apiServer.CreateGrantWorkspace))
+		mux.HandleFunc("/api/grants/provision", apiServer.RequireAccess(apiServer.ProvisionGrantFolder))
 
 		// Drive endpoints (require auth + access check via service account)
 		mux.HandleFunc("/api/drive/list", apiServer.RequireAccess(apiServer.ListFiles))
 		mux.HandleFunc("/api/drive/create-folder", apiServer.RequireAccess(apiServer.CreateFolder))
 		mux.HandleFunc("/api/drive/create-doc", apiServer.RequireAccess(apiServer.CreateDoc))
 		mux.HandleFunc("/api/drive/create-shortcut", apiServer.RequireAccess(apiServer.CreateShortcut))
-		mux.HandleFunc("/api/drive/move", apiServer.RequireAccess(apiServer.MoveFile))
+		mux.HandleFunc("/api/drive/move", apiServer.RequireAccess(apiServer.Destructive(apiServer.MoveFile)))
+		mux.HandleFunc("/api/drive/trash", apiServer.RequireAccess(apiServer.Destructive(apiServer.TrashFile)))
+		mux.HandleFunc("/api/drive/path", apiServer.RequireAccess(apiServer.GetFilePath))
+		mux.HandleFunc("/api/drive/search", apiServer.RequireAccess(apiServer.SearchFiles))
+		mux.HandleFunc("/api/drive/changes", apiServer.RequireAccess(apiServer.ListChanges))
 		mux.HandleFunc("/api/drive/get", apiServer.RequireAccess(apiServer.GetFile))
+		mux.HandleFunc("/api/drive/download", apiServer.RequireAccess(apiServer.DownloadFile))
+		mux.HandleFunc("/api/drive/access", apiServer.RequireAccess(apiServer.CheckFolderAccess))
 
 		// Docs endpoints (require auth + access check via service account)
 		mux.HandleFunc("/api/docs/initialize-tracker", apiServer.RequireAccess(apiServer.InitializeTrackerDoc))
+		mux.HandleFunc("/api/docs/structure", apiServer.RequireAccess(apiServer.GetDocStructure))
+
+		// Admin endpoints
+		mux.HandleFunc("/api/admin/bootstrap", apiServer.RequireAdmin(apiServer.BootstrapSpreadsheet))
+		mux.HandleFunc("/api/admin/sheets/create-tab", apiServer.RequireAdmin(apiServer.CreateSpreadsheetTab))
+		mux.HandleFunc("/api/admin/grant-manifests", apiServer.RequireAdmin(apiServer.ListGrantManifests))
+		mux.HandleFunc("/api/admin/permissions", apiServer.RequireAdmin(apiServer.ListPermissions))
+		mux.HandleFunc("/api/admin/auth-cache/purge", apiServer.RequireAdmin(apiServer.PurgeAuthCacheHandler))
+		mux.HandleFunc("/api/admin/reload-credentials", apiServer.RequireAdmin(apiServer.ReloadCredentials))
+		mux.HandleFunc("/api/admin/share", apiServer.RequireAdmin(apiServer.ShareFile))
+		mux.HandleFunc("/api/admin/transfer-ownership", apiServer.RequireAdmin(apiServer.Destructive(apiServer.TransferOwnership)))
 
 		log.Printf("Service account API routes registered")
 	} else {
@@ -116,29 +222,302 @@ func main() {
 		log.Printf("Running without service account - client-side auth only")
 	}
 
-	// Static files and SPA routing
-	mux.HandleFunc("/", handleStatic)
+	// Static files and SPA routing, bounded so slow clients can't pile up
+	staticTimeout := envDuration("STATIC_TIMEOUT", defaultStaticTimeout)
+	staticMaxConcurrent := envInt("STATIC_MAX_CONCURRENT", defaultStaticMaxConcurrent)
+	mux.Handle("/", limitConcurrency(staticMaxConcurrent,
+		http.TimeoutHandler(http.HandlerFunc(handleStatic), staticTimeout, "Request timed out")))
+	log.Printf("Static serving: timeout %s, max %d concurrent", staticTimeout, staticMaxConcurrent)
 
-	// Wrap with logging and CORS
-	handler := logRequests(mux)
+	// Wrap with logging, CSRF checks, and CORS
+	var handler http.Handler = mux
+	if apiServer != nil {
+		handler = apiServer.Envelope(handler)
+	}
+	handler = csrfProtect(handler)
+	if origins := parseOrigins(allowedOrigin); len(origins) > 0 {
+		handler = cors(origins, handler)
+		log.Printf("CORS allowed origins: %s", strings.Join(origins, ", "))
+	}
+	handler = securityHeaders(handler)
+	handler = logRequests(handler)
 
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
 	}
 
-	log.Printf("Server starting on :%s", port)
+	log.Printf("Server starting on :%s (version %s, commit %s)", port, version, gitCommit)
 	log.Printf("Static files from: %s", staticDir)
 	log.Printf("Redirect URI: %s", redirectURI)
-	log.Fatal(http.ListenAndServe(":"+port, handler))
+	srv := &http.Server{
+		Addr:              ":" + port,
+		Handler:           handler,
+		ReadHeaderTimeout: envDuration("READ_HEADER_TIMEOUT", defaultReadHeaderTimeout),
+		ReadTimeout:       envDuration("READ_TIMEOUT", defaultReadTimeout),
+		WriteTimeout:      envDuration("WRITE_TIMEOUT", defaultWriteTimeout),
+		IdleTimeout:       envDuration("IDLE_TIMEOUT", defaultIdleTimeout),
+		TLSConfig:         &tls.Config{MinVersion: tls.VersionTLS12},
+	}
+	// Usually TLS ends at the load balancer; TLS_CERT_FILE and TLS_KEY_FILE serve it directly
+	if certFile, keyFile := os.Getenv("TLS_CERT_FILE"), os.Getenv("TLS_KEY_FILE"); certFile != "" && keyFile != "" {
+		log.Fatal(srv.ListenAndServeTLS(certFile, keyFile))
+	}
+	log.Fatal(srv.ListenAndServe())
+}
+
+// Defaults for serving limits and sessions (see envDuration/envInt for overrides)
+const (
+	defaultReadHeaderTimeout   = 10 * time.Second
+	defaultReadTimeout         = time.Minute
+	defaultWriteTimeout        = 5 * time.Minute // Covers the slowest exports and downloads
+	defaultIdleTimeout         = 2 * time.Minute
+	defaultStaticTimeout       = 30 * time.Second
+	defaultStaticMaxConcurrent = 64
+	defaultSessionMaxAge       = 7 * 24 * time.Hour
+)
+
+// envDuration reads a duration such as "30s" from the environment
+func envDuration(name string, def time.Duration) time.Duration {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		log.Fatalf("Invalid %s %q: want a positive duration like 30s", name, v)
+	}
+	return d
+}
+
+// envInt reads a positive integer from the environment
+func envInt(name string, def int) int {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		log.Fatalf("Invalid %s %q: want a positive integer", name, v)
+	}
+	return n
+}
+
+// validateProductionConfig rejects settings that are only safe for local
+// development: plain-http OAuth redirects or origins, and no stable secrets
+// for signing the access capability and user cookies
+func validateProductionConfig(redirectURI, allowedOrigin, capabilitySecret, cookieSecret string) error {
+	if !strings.HasPrefix(redirectURI, "https://") {
+		return fmt.Errorf("REDIRECT_URI %q must use https", redirectURI)
+	}
+	for _, origin := range parseOrigins(allowedOrigin) {
+		if !strings.HasPrefix(origin, "https://") {
+			return fmt.Errorf("ALLOWED_ORIGIN %q must use https", origin)
+		}
+	}
+	if capabilitySecret == "" {
+		return errors.New("CAPABILITY_SECRET must be set")
+	}
+	if cookieSecret == "" {
+		return errors.New("COOKIE_SECRET must be set")
+	}
+	return nil
+}
+
+// secureCookies reports whether cookies for r must be marked Secure
+func secureCookies(r *http.Request) bool {
+	return production || r.TLS != nil || strings.HasPrefix(redirectURI, "https")
+}
+
+// securityHeaders adds HSTS in production so browsers only ever use https
+func securityHeaders(next http.Handler) http.Handler {
+	if !production {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Strict-Transport-Security", "max-age=31536000; includeSubDomains")
+		next.ServeHTTP(w, r)
+	})
+}
+
+// CORS settings for cross-origin frontends (see cors)
+const (
+	corsAllowMethods  = "GET, POST, OPTIONS"
+	corsAllowHeaders  = "Authorization, Content-Type, Accept, X-Spreadsheet-ID, X-Operation-ID, X-Response-Envelope, X-CSRF-Token"
+	corsExposeHeaders = "X-Token-Refreshed, X-Token-Expires-In, X-Response-Envelope, Retry-After, Content-Disposition"
+	corsMaxAge        = "600"
+)
+
+// parseOrigins splits a comma-separated origin list, dropping trailing slashes
+func parseOrigins(spec string) []string {
+	var origins []string
+	for _, origin := range strings.Split(spec, ",") {
+		if origin = strings.TrimRight(strings.TrimSpace(origin), "/"); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
+
+// cors lets the listed origins call the API with credentials. Only an exact
+// match is echoed back; other origins get no CORS headers, and their preflights
+// are refused. Preflights from allowed origins are answered here with 204.
+func cors(origins []string, next http.Handler) http.Handler {
+	allowed := make(map[string]bool, len(origins))
+	for _, origin := range origins {
+		allowed[origin] = true
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+		w.Header().Add("Vary", "Origin")
+
+		if origin == "" || !allowed[origin] {
+			if preflight {
+				http.Error(w, "Origin not allowed", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+		if preflight {
+			w.Header().Set("Access-Control-Allow-Methods", corsAllowMethods)
+			w.Header().Set("Access-Control-Allow-Headers", corsAllowHeaders)
+			w.Header().Set("Access-Control-Max-Age", corsMaxAge)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Access-Control-Expose-Headers", corsExposeHeaders)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Double-submit CSRF token: a JS-readable cookie whose value every
+// state-changing request must echo in a header. A cross-site form or fetch
+// can make the browser send the cookie, but can't read it to set the header.
+const (
+	csrfCookieName = "gt_csrf"
+	csrfHeaderName = "X-CSRF-Token"
+	csrfFailedCode = "CSRF_FAILED" // Error code for requests rejected by csrfProtect
+)
+
+// setCSRFCookie issues a fresh CSRF token and returns it
+func setCSRFCookie(w http.ResponseWriter, r *http.Request) string {
+	token := generateState()
+	http.SetCookie(w, &http.Cookie{
+		Name:     csrfCookieName,
+		Value:    token,
+		Path:     "/",
+		MaxAge:   int(sessionMaxAge.Seconds()),
+		Secure:   secureCookies(r),
+		HttpOnly: false, // JS reads it to echo in the header
+		SameSite: http.SameSiteLaxMode,
+	})
+	return token
+}
+
+// handleCSRF returns the caller's CSRF token, issuing one if needed. Frontends
+// on another origin can't read the cookie, so they get the token from here.
+func handleCSRF(w http.ResponseWriter, r *http.Request) {
+	token := ""
+	if cookie, err := r.Cookie(csrfCookieName); err == nil {
+		token = cookie.Value
+	}
+	if token == "" {
+		token = setCSRFCookie(w, r)
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"token": token})
+}
+
+// csrfProtect rejects state-changing API and auth requests whose CSRF header is
+// missing or doesn't match the cookie. Safe methods and the OAuth callback (a
+// GET from Google) pass through.
+func csrfProtect(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !strings.HasPrefix(r.URL.Path, "/api/") && !strings.HasPrefix(r.URL.Path, "/auth/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		header := r.Header.Get(csrfHeaderName)
+		cookie, err := r.Cookie(csrfCookieName)
+		if err != nil || cookie.Value == "" || header == "" ||
+			subtle.ConstantTimeCompare([]byte(header), []byte(cookie.Value)) != 1 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(map[string]string{
+				"error": "Missing or invalid CSRF token; fetch one from /auth/csrf and send it in the " + csrfHeaderName + " header",
+				"code":  csrfFailedCode,
+			})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// limitConcurrency rejects requests with 503 once max are already in flight
+func limitConcurrency(max int, next http.Handler) http.Handler {
+	slots := make(chan struct{}, max)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+			next.ServeHTTP(w, r)
+		default:
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "Server busy", http.StatusServiceUnavailable)
+		}
+	})
+}
+
+// statusRecorder remembers the status code a handler writes
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (rec *statusRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+// Write implies a 200 if the handler never called WriteHeader
+func (rec *statusRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	return rec.ResponseWriter.Write(b)
+}
+
+// Flush keeps streaming responses working through the recorder
+func (rec *statusRecorder) Flush() {
+	if flusher, ok := rec.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
 }
 
 // logRequests is a simple logging middleware
 func logRequests(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
-		next.ServeHTTP(w, r)
-		log.Printf("%s %s %s", r.Method, r.URL.Path, time.Since(start))
+		rec := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+		if rec.status == 0 {
+			rec.status = http.StatusOK
+		}
+		log.Printf("%s %s %d %s", r.Method, r.URL.Path, rec.status, time.Since(start))
 	})
 }
 
@@ -159,7 +538,7 @@ func handleLogin(w http.ResponseWriter, r *http.Request) {
 		Value:    state,
 		Path:     "/",
 		MaxAge:   600, // 10 minutes
-		Secure:   r.TLS != nil || strings.HasPrefix(redirectURI, "https"),
+		Secure:   secureCookies(r),
 		HttpOnly: true,
 		SameSite: http.SameSiteLaxMode,
 	})
@@ -175,7 +554,7 @@ func handleLogin(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Build Google OAuth URL
-	authURL := "https://accounts.google.com/o/oauth2/v2/auth?" + url.Values{
+	params := url.Values{
 		"client_id":     {clientID},
 		"redirect_uri":  {redirectURI},
 		"response_type": {"code"},
@@ -183,7 +562,12 @@ func handleLogin(w http.ResponseWriter, r *http.Request) {
 		"access_type":   {"offline"},
 		"prompt":        {"consent"},
 		"state":         {state},
-	}.Encode()
+	}
+	// hd only narrows the account chooser; the callback still verifies the domain
+	if hostedDomain != "" {
+		params.Set("hd", hostedDomain)
+	}
+	authURL := "https://accounts.google.com/o/oauth2/v2/auth?" + params.Encode()
 
 	http.Redirect(w, r, authURL, http.StatusFound)
 }
@@ -225,28 +609,50 @@ func handleCallback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get user info
-	userInfo, err := getUserInfo(tokens.AccessToken)
+	// Identify the user from the verified ID token
+	claims, err := verifyIDToken(r.Context(), tokens.IDToken)
 	if err != nil {
-		log.Printf("Get user info error: %v", err)
-		http.Error(w, "Failed to get user info", http.StatusInternalServerError)
+		log.Printf("ID token verification error: %v", err)
+		http.Error(w, "Failed to verify identity", http.StatusUnauthorized)
 		return
 	}
 
-	// Set cookies with tokens
-	secure := r.TLS != nil || strings.HasPrefix(redirectURI, "https")
-	maxAge := 7 * 24 * 60 * 60 // 7 days
+	if err := verifyHostedDomain(claims); err != nil {
+		log.Printf("Rejected login for %s: %v", claims.Email, err)
+		http.Error(w, "This account is not allowed to sign in to this instance", http.StatusForbidden)
+		return
+	}
+
+	userInfo := &UserInfo{Email: claims.Email, Name: claims.Name, Picture: claims.Picture}
 
-	// Refresh token cookie
+	// Set cookies with tokens
+	secure := secureCookies(r)
+	maxAge := int(sessionMaxAge.Seconds())
+
+	// The refresh token stays server-side; the cookie only carries a session id
+	endSession(r)
+	id := generateState()
+	now := time.Now()
+	sess := Session{
+		RefreshToken:  tokens.RefreshToken,
+		AccessIssued:  now,
+		AccessExpires: now.Add(time.Duration(tokens.ExpiresIn) * time.Second),
+	}
+	if err := sessions.Put(r.Context(), id, sess, sessionMaxAge); err != nil {
+		log.Printf("Failed to store session: %v", err)
+		http.Error(w, "Failed to start session", http.StatusInternalServerError)
+		return
+	}
 	http.SetCookie(w, &http.Cookie{
-		Name:     "gt_refresh_token",
-		Value:    tokens.RefreshToken,
+		Name:     sessionCookieName,
+		Value:    id,
 		Path:     "/",
 		MaxAge:   maxAge,
 		Secure:   secure,
 		HttpOnly: true, // Not accessible to JS - only sent to our server
 		SameSite: http.SameSiteLaxMode,
 	})
+	expireCookie(w, legacyRefreshCookieName)
 
 	// Access token cookie (JS needs to read this for direct Google API calls)
 	http.SetCookie(w, &http.Cookie{
@@ -259,11 +665,11 @@ func handleCallback(w http.ResponseWriter, r *http.Request) {
 		SameSite: http.SameSiteLaxMode,
 	})
 
-	// User info cookie (JS readable for display)
+	// User info cookie (JS readable for display, signed against tampering)
 	userJSON, _ := json.Marshal(userInfo)
 	http.SetCookie(w, &http.Cookie{
 		Name:     "gt_user",
-		Value:    base64.StdEncoding.EncodeToString(userJSON),
+		Value:    api.SignUserCookie(userJSON),
 		Path:     "/",
 		MaxAge:   maxAge,
 		Secure:   secure,
@@ -271,6 +677,9 @@ func handleCallback(w http.ResponseWriter, r *http.Request) {
 		SameSite: http.SameSiteLaxMode,
 	})
 
+	// New session, new CSRF token
+	setCSRFCookie(w, r)
+
 	// Redirect to app
 	http.Redirect(w, r, "/", http.StatusFound)
 }
@@ -282,23 +691,68 @@ func handleRefresh(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get refresh token from cookie
-	refreshCookie, err := r.Cookie("gt_refresh_token")
-	if err != nil {
-		http.Error(w, "No refresh token", http.StatusUnauthorized)
+	// Refresh the token
+	tokens, err := refreshAccessCookie(w, r)
+	if errors.Is(err, errSessionNotFound) {
+		http.Error(w, "No session", http.StatusUnauthorized)
+		return
+	}
+	if errors.Is(err, errInvalidGrant) {
+		// The refresh token is dead; retrying won't help, so end the session
+		log.Printf("Token refresh rejected, re-auth required: %v", err)
+		endSession(r)
+		clearAuthCookies(w)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{
+			"error": "Session expired. Please sign in again.",
+			"code":  reauthRequiredCode,
+		})
 		return
 	}
-
-	// Refresh the token
-	tokens, err := refreshToken(refreshCookie.Value)
 	if err != nil {
 		log.Printf("Token refresh error: %v", err)
 		http.Error(w, "Failed to refresh token", http.StatusUnauthorized)
 		return
 	}
 
-	// Update access token cookie
-	secure := r.TLS != nil || strings.HasPrefix(redirectURI, "https")
+	// Return token info
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token": tokens.AccessToken,
+		"expires_in":   tokens.ExpiresIn,
+	})
+}
+
+// refreshAccessCookie exchanges the session's refresh token, records the new
+// access token's lifetime in the session, and sets the access token cookie
+func refreshAccessCookie(w http.ResponseWriter, r *http.Request) (*TokenResponse, error) {
+	id, sess, err := requestSession(r)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := refreshOnce(id, func() (*TokenResponse, error) {
+		tokens, err := refreshToken(sess.RefreshToken)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		sess.AccessIssued = now
+		sess.AccessExpires = now.Add(time.Duration(tokens.ExpiresIn) * time.Second)
+		// Not tied to this request's context: other requests are waiting on it
+		if err := sessions.Update(context.Background(), id, sess); err != nil {
+			// The token is still good for this response; the next request will
+			// just find no session to refresh from
+			log.Printf("Failed to record refreshed session: %v", err)
+		}
+		return tokens, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	secure := secureCookies(r)
 	http.SetCookie(w, &http.Cookie{
 		Name:     "gt_access_token",
 		Value:    tokens.AccessToken,
@@ -308,26 +762,49 @@ func handleRefresh(w http.ResponseWriter, r *http.Request) {
 		HttpOnly: false,
 		SameSite: http.SameSiteLaxMode,
 	})
+	return tokens, nil
+}
 
-	// Return token info
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"access_token": tokens.AccessToken,
-		"expires_in":   tokens.ExpiresIn,
+// refreshSession lets RequireAuth transparently refresh an expired or
+// nearly expired access token
+func refreshSession(w http.ResponseWriter, r *http.Request) (string, int, error) {
+	tokens, err := refreshAccessCookie(w, r)
+	if errors.Is(err, errInvalidGrant) {
+		endSession(r)
+		clearAuthCookies(w)
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	return tokens.AccessToken, tokens.ExpiresIn, nil
+}
+
+// legacyRefreshCookieName held the refresh token itself before sessions moved
+// server-side; it is only ever expired now
+const legacyRefreshCookieName = "gt_refresh_token"
+
+// expireCookie tells the browser to drop a cookie
+func expireCookie(w http.ResponseWriter, name string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:   name,
+		Value:  "",
+		Path:   "/",
+		MaxAge: -1,
 	})
 }
 
-// handleLogout clears all auth cookies
-func handleLogout(w http.ResponseWriter, r *http.Request) {
-	cookies := []string{"gt_refresh_token", "gt_access_token", "gt_user"}
+// clearAuthCookies expires every auth cookie
+func clearAuthCookies(w http.ResponseWriter) {
+	cookies := []string{sessionCookieName, legacyRefreshCookieName, "gt_access_token", "gt_user", csrfCookieName}
 	for _, name := range cookies {
-		http.SetCookie(w, &http.Cookie{
-			Name:   name,
-			Value:  "",
-			Path:   "/",
-			MaxAge: -1,
-		})
+		expireCookie(w, name)
 	}
+}
+
+// handleLogout ends the session and clears all auth cookies
+func handleLogout(w http.ResponseWriter, r *http.Request) {
+	endSession(r)
+	clearAuthCookies(w)
 
 	if r.Method == http.MethodPost {
 		w.Header().Set("Content-Type", "application/json")
@@ -346,6 +823,17 @@ func handleConfigFallback(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// handleVersion returns the build information of the running server
+func handleVersion(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(api.VersionInfo{
+		Version:   version,
+		GitCommit: gitCommit,
+		BuildTime: buildTime,
+		GoVersion: runtime.Version(),
+	})
+}
+
 // handleStatus returns current auth status
 func handleStatus(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -358,13 +846,19 @@ func handleStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Decode user info from cookie
+	// Decode user info from cookie, treating a tampered one as signed out
 	var userInfo *UserInfo
 	if userCookie, err := r.Cookie("gt_user"); err == nil {
-		if decoded, err := base64.StdEncoding.DecodeString(userCookie.Value); err == nil {
-			userInfo = &UserInfo{}
-			json.Unmarshal(decoded, userInfo)
+		decoded, err := api.VerifyUserCookie(userCookie.Value)
+		if err != nil {
+			log.Printf("Rejected user cookie: %v", err)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"authenticated": false,
+			})
+			return
 		}
+		userInfo = &UserInfo{}
+		json.Unmarshal(decoded, userInfo)
 	}
 
 	json.NewEncoder(w).Encode(map[string]interface{}{
@@ -400,7 +894,7 @@ func handleStatic(w http.ResponseWriter, r *http.Request) {
 
 // exchangeCode exchanges an authorization code for tokens
 func exchangeCode(code string) (*TokenResponse, error) {
-	resp, err := http.PostForm("https://oauth2.googleapis.com/token", url.Values{
+	resp, err := http.PostForm(googleTokenURL, url.Values{
 		"client_id":     {clientID},
 		"client_secret": {clientSecret},
 		"code":          {code},
@@ -426,9 +920,21 @@ func exchangeCode(code string) (*TokenResponse, error) {
 	return &tokens, nil
 }
 
+// errInvalidGrant means Google rejected the refresh token (revoked or expired)
+var errInvalidGrant = errors.New("invalid_grant")
+
+// reauthRequiredCode tells the client to send the user back through login
+const reauthRequiredCode = "REAUTH_REQUIRED"
+
+// tokenErrorResponse is the error body of Google's token endpoint
+type tokenErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
 // refreshToken uses a refresh token to get a new access token
 func refreshToken(token string) (*TokenResponse, error) {
-	resp, err := http.PostForm("https://oauth2.googleapis.com/token", url.Values{
+	resp, err := http.PostForm(googleTokenURL, url.Values{
 		"client_id":     {clientID},
 		"client_secret": {clientSecret},
 		"refresh_token": {token},
@@ -442,6 +948,10 @@ func refreshToken(token string) (*TokenResponse, error) {
 	body, _ := io.ReadAll(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
+		var tokenErr tokenErrorResponse
+		if json.Unmarshal(body, &tokenErr) == nil && tokenErr.Error == "invalid_grant" {
+			return nil, fmt.Errorf("token refresh failed: %w (%s)", errInvalidGrant, tokenErr.ErrorDescription)
+		}
 		return nil, fmt.Errorf("token refresh failed: %s", string(body))
 	}
 
@@ -453,26 +963,72 @@ func refreshToken(token string) (*TokenResponse, error) {
 	return &tokens, nil
 }
 
-// getUserInfo fetches user profile information
-func getUserInfo(accessToken string) (*UserInfo, error) {
-	req, _ := http.NewRequest("GET", "https://www.googleapis.com/oauth2/v2/userinfo", nil)
-	req.Header.Set("Authorization", "Bearer "+accessToken)
+// googleIssuers are the valid "iss" values for Google ID tokens
+var googleIssuers = map[string]bool{
+	"accounts.google.com":         true,
+	"https://accounts.google.com": true,
+}
+
+// idTokenValidator verifies ID token signatures against Google's JWKS.
+// It caches the signing keys according to the certs endpoint's Cache-Control.
+var idTokenValidator *idtoken.Validator
+
+// idTokenClaims are the verified ID token fields used to identify the user
+type idTokenClaims struct {
+	Email         string
+	EmailVerified bool
+	Name          string
+	Picture       string
+	HD            string
+}
 
-	resp, err := http.DefaultClient.Do(req)
+// verifyIDToken checks the ID token's signature, audience, issuer, and expiry
+// and returns its identity claims
+func verifyIDToken(ctx context.Context, idToken string) (*idTokenClaims, error) {
+	if idToken == "" {
+		return nil, fmt.Errorf("token response has no ID token")
+	}
+
+	// Validate checks the signature, aud, and exp
+	payload, err := idTokenValidator.Validate(ctx, idToken, clientID)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("get user info failed: %s", string(body))
+	if !googleIssuers[payload.Issuer] {
+		return nil, fmt.Errorf("unexpected issuer %q", payload.Issuer)
 	}
 
-	var userInfo UserInfo
-	if err := json.NewDecoder(resp.Body).Decode(&userInfo); err != nil {
-		return nil, err
+	claim := func(name string) string {
+		v, _ := payload.Claims[name].(string)
+		return v
+	}
+	verified, _ := payload.Claims["email_verified"].(bool)
+
+	claims := &idTokenClaims{
+		Email:         claim("email"),
+		EmailVerified: verified,
+		Name:          claim("name"),
+		Picture:       claim("picture"),
+		HD:            claim("hd"),
 	}
+	if claims.Email == "" {
+		return nil, fmt.Errorf("ID token has no email claim")
+	}
+	return claims, nil
+}
 
-	return &userInfo, nil
+// verifyHostedDomain checks that a verified account belongs to the configured
+// Workspace domain. The hd claim is used rather than the email suffix because
+// consumer Google accounts can be registered with any email address.
+func verifyHostedDomain(claims *idTokenClaims) error {
+	if hostedDomain == "" {
+		return nil
+	}
+	if !claims.EmailVerified {
+		return fmt.Errorf("email %s is not verified", claims.Email)
+	}
+	if strings.ToLower(claims.HD) != hostedDomain {
+		return fmt.Errorf("hosted domain %q is not %q", claims.HD, hostedDomain)
+	}
+	return nil
 }
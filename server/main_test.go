@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/grant-tracker/server/api"
+)
+
+// strongSecret is long enough for CAPABILITY_SECRET and COOKIE_SECRET
+const strongSecret = "0123456789abcdef0123456789abcdef"
+
+func TestValidateProductionConfig(t *testing.T) {
+	tests := []struct {
+		name             string
+		redirectURI      string
+		allowedOrigin    string
+		capabilitySecret string
+		cookieSecret     string
+		wantErr          bool
+	}{
+		{"secure config", "https://app.example/auth/callback", "https://app.example", strongSecret, strongSecret, false},
+		{"no allowed origins", "https://app.example/auth/callback", "", strongSecret, strongSecret, false},
+		{"http redirect URI", "http://app.example/auth/callback", "", strongSecret, strongSecret, true},
+		{"http allowed origin", "https://app.example/auth/callback", "https://app.example, http://other.example", strongSecret, strongSecret, true},
+		{"no capability secret", "https://app.example/auth/callback", "", "", strongSecret, true},
+		{"no cookie secret", "https://app.example/auth/callback", "", strongSecret, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateProductionConfig(tt.redirectURI, tt.allowedOrigin, tt.capabilitySecret, tt.cookieSecret)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateProductionConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// withProduction sets the production flag and redirect URI for one test
+func withProduction(t *testing.T, prod bool, redirect string) {
+	t.Helper()
+	oldProd, oldRedirect := production, redirectURI
+	production, redirectURI = prod, redirect
+	t.Cleanup(func() { production, redirectURI = oldProd, oldRedirect })
+}
+
+func TestSecureCookies(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "http://localhost:8080/auth/login", nil)
+
+	withProduction(t, false, "http://localhost:8080/auth/callback")
+	if secureCookies(r) {
+		t.Error("plain http development request got Secure cookies")
+	}
+
+	withProduction(t, true, "http://localhost:8080/auth/callback")
+	if !secureCookies(r) {
+		t.Error("production request got cookies without Secure")
+	}
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	for _, prod := range []bool{false, true} {
+		withProduction(t, prod, "https://app.example/auth/callback")
+		w := httptest.NewRecorder()
+		securityHeaders(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		if got := w.Header().Get("Strict-Transport-Security") != ""; got != prod {
+			t.Errorf("production=%v: HSTS set = %v", prod, got)
+		}
+	}
+}
+
+func TestHandleVersion(t *testing.T) {
+	oldVersion, oldCommit, oldTime := version, gitCommit, buildTime
+	version, gitCommit, buildTime = "1.4.0", "abc1234", "2026-10-01T12:00:00Z"
+	t.Cleanup(func() { version, gitCommit, buildTime = oldVersion, oldCommit, oldTime })
+
+	w := httptest.NewRecorder()
+	handleVersion(w, httptest.NewRequest(http.MethodGet, "/api/version", nil))
+
+	var info api.VersionInfo
+	if err := json.Unmarshal(w.Body.Bytes(), &info); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	want := api.VersionInfo{Version: "1.4.0", GitCommit: "abc1234", BuildTime: "2026-10-01T12:00:00Z", GoVersion: runtime.Version()}
+	if info != want {
+		t.Errorf("version info = %+v, want %+v", info, want)
+	}
+}
+
+func TestLimitConcurrency(t *testing.T) {
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	h := limitConcurrency(1, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		entered <- struct{}{}
+		<-release
+	}))
+
+	first := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		h.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/", nil))
+		close(done)
+	}()
+	<-entered
+
+	busy := httptest.NewRecorder()
+	h.ServeHTTP(busy, httptest.NewRequest(http.MethodGet, "/", nil))
+	if busy.Code != http.StatusServiceUnavailable || busy.Header().Get("Retry-After") == "" {
+		t.Errorf("request over the limit: status %d, Retry-After %q, want 503 with Retry-After", busy.Code, busy.Header().Get("Retry-After"))
+	}
+
+	close(release)
+	<-done
+	if first.Code != http.StatusOK {
+		t.Errorf("request within the limit: status %d", first.Code)
+	}
+
+	// The slot is free again once the first request finishes
+	go func() { <-entered }()
+	after := httptest.NewRecorder()
+	h.ServeHTTP(after, httptest.NewRequest(http.MethodGet, "/", nil))
+	if after.Code != http.StatusOK {
+		t.Errorf("request after the slot freed: status %d", after.Code)
+	}
+}
+
+func TestHandleStaticSPAFallback(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte("<html>app</html>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "app.js"), []byte("console.log(1)"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	oldDir := staticDir
+	staticDir = dir
+	t.Cleanup(func() { staticDir = oldDir })
+
+	h := limitConcurrency(2, http.TimeoutHandler(http.HandlerFunc(handleStatic), time.Second, "Request timed out"))
+	for path, want := range map[string]string{
+		"/":           "<html>app</html>",
+		"/app.js":     "console.log(1)",
+		"/grants/G-1": "<html>app</html>",
+	} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK || w.Body.String() != want {
+			t.Errorf("GET %s = %d %q, want %q", path, w.Code, w.Body, want)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// sessionCookieName holds the opaque session id; the refresh token itself
+// never leaves the server
+const sessionCookieName = "gt_session"
+
+// accessRefreshWindow is how close to expiry an access token is refreshed
+// ahead of time, so requests never arrive with one that's already lapsed
+const accessRefreshWindow = 5 * time.Minute
+
+// errSessionNotFound means the session id is unknown or its session has expired
+var errSessionNotFound = errors.New("session not found")
+
+// Session is what the server keeps for a signed-in browser
+type Session struct {
+	RefreshToken  string
+	AccessIssued  time.Time // When the current access token was issued
+	AccessExpires time.Time // When the current access token expires
+}
+
+// SessionStore holds sessions keyed by session id. The in-memory store only
+// works for a single instance; a shared store such as Redis can be swapped in
+// by implementing this interface.
+type SessionStore interface {
+	// Get returns a session, or errSessionNotFound
+	Get(ctx context.Context, id string) (Session, error)
+	// Put stores a session for ttl
+	Put(ctx context.Context, id string, sess Session, ttl time.Duration) error
+	// Update replaces an existing session, keeping its ttl; it returns
+	// errSessionNotFound if the session is gone
+	Update(ctx context.Context, id string, sess Session) error
+	// Delete removes a session; deleting an unknown session is not an error
+	Delete(ctx context.Context, id string) error
+}
+
+type memorySession struct {
+	Session
+	expires time.Time
+}
+
+// memorySessionStore is a SessionStore kept in process memory, so sessions
+// are lost on restart
+type memorySessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]memorySession
+}
+
+func newMemorySessionStore() *memorySessionStore {
+	return &memorySessionStore{sessions: make(map[string]memorySession)}
+}
+
+func (m *memorySessionStore) Get(ctx context.Context, id string) (Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sess, ok := m.sessions[id]
+	if !ok {
+		return Session{}, errSessionNotFound
+	}
+	if time.Now().After(sess.expires) {
+		delete(m.sessions, id)
+		return Session{}, errSessionNotFound
+	}
+	return sess.Session, nil
+}
+
+func (m *memorySessionStore) Put(ctx context.Context, id string, sess Session, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// Sweep expired sessions here so abandoned ones don't pile up
+	now := time.Now()
+	for k, s := range m.sessions {
+		if now.After(s.expires) {
+			delete(m.sessions, k)
+		}
+	}
+	m.sessions[id] = memorySession{Session: sess, expires: now.Add(ttl)}
+	return nil
+}
+
+func (m *memorySessionStore) Update(ctx context.Context, id string, sess Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	existing, ok := m.sessions[id]
+	if !ok || time.Now().After(existing.expires) {
+		return errSessionNotFound
+	}
+	m.sessions[id] = memorySession{Session: sess, expires: existing.expires}
+	return nil
+}
+
+func (m *memorySessionStore) Delete(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.sessions, id)
+	return nil
+}
+
+// refreshCall is a refresh in flight, which other requests on the same
+// session wait for instead of starting their own
+type refreshCall struct {
+	done   chan struct{}
+	tokens *TokenResponse
+	err    error
+}
+
+var (
+	refreshCallsMu sync.Mutex
+	refreshCalls   = make(map[string]*refreshCall)
+)
+
+// refreshOnce runs refresh for a session unless one is already running, in
+// which case it waits for that one and shares its result. A page firing
+// several requests as the access token nears expiry then exchanges the
+// refresh token once, rather than once per request.
+func refreshOnce(id string, refresh func() (*TokenResponse, error)) (*TokenResponse, error) {
+	refreshCallsMu.Lock()
+	if c, ok := refreshCalls[id]; ok {
+		refreshCallsMu.Unlock()
+		<-c.done
+		return c.tokens, c.err
+	}
+	c := &refreshCall{done: make(chan struct{})}
+	refreshCalls[id] = c
+	refreshCallsMu.Unlock()
+
+	c.tokens, c.err = refresh()
+
+	refreshCallsMu.Lock()
+	delete(refreshCalls, id)
+	refreshCallsMu.Unlock()
+	close(c.done)
+	return c.tokens, c.err
+}
+
+// sessionID returns the request's session id, or "" if it has none
+func sessionID(r *http.Request) string {
+	if cookie, err := r.Cookie(sessionCookieName); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+// requestSession looks up the session for the request's session cookie
+func requestSession(r *http.Request) (string, Session, error) {
+	id := sessionID(r)
+	if id == "" {
+		return "", Session{}, errSessionNotFound
+	}
+	sess, err := sessions.Get(r.Context(), id)
+	return id, sess, err
+}
+
+// accessNearExpiry reports whether the request's session has an access token
+// within accessRefreshWindow of expiring. Sessions that predate expiry
+// tracking, or that can't be found, are left to the normal 401 path.
+func accessNearExpiry(r *http.Request) bool {
+	_, sess, err := requestSession(r)
+	if err != nil || sess.AccessExpires.IsZero() {
+		return false
+	}
+	return time.Until(sess.AccessExpires) < accessRefreshWindow
+}
+
+// endSession drops the request's session from the store, if it has one
+func endSession(r *http.Request) {
+	if id := sessionID(r); id != "" {
+		if err := sessions.Delete(r.Context(), id); err != nil {
+			log.Printf("Failed to delete session: %v", err)
+		}
+	}
+}
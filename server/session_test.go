@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeTokenEndpoint points googleTokenURL at a server answering code
+// exchanges and refreshes with respond, and returns the number of requests it
+// has handled. It expects code "code-1" and refresh token "refresh-1".
+func fakeTokenEndpoint(t *testing.T, respond func(w http.ResponseWriter, r *http.Request)) *atomic.Int32 {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		switch r.FormValue("grant_type") {
+		case "refresh_token":
+			if r.FormValue("refresh_token") != "refresh-1" {
+				t.Errorf("unexpected refresh token %q", r.FormValue("refresh_token"))
+			}
+		case "authorization_code":
+			if r.FormValue("code") != "code-1" {
+				t.Errorf("unexpected authorization code %q", r.FormValue("code"))
+			}
+		default:
+			t.Errorf("unexpected token request %v", r.Form)
+		}
+		respond(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	old := googleTokenURL
+	googleTokenURL = srv.URL
+	t.Cleanup(func() { googleTokenURL = old })
+	return &calls
+}
+
+// newToken answers a refresh with a fresh hour-long access token
+func newToken(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(TokenResponse{AccessToken: "access-2", ExpiresIn: 3600, TokenType: "Bearer"})
+}
+
+// withSession stores sess in a fresh session store and returns a request carrying its cookie
+func withSession(t *testing.T, sess Session) *http.Request {
+	t.Helper()
+	old := sessions
+	sessions = newMemorySessionStore()
+	t.Cleanup(func() { sessions = old })
+
+	if err := sessions.Put(context.Background(), "session-1", sess, time.Hour); err != nil {
+		t.Fatalf("store session: %v", err)
+	}
+	r := httptest.NewRequest(http.MethodPost, "/api/sheets/read", nil)
+	r.AddCookie(&http.Cookie{Name: sessionCookieName, Value: "session-1"})
+	return r
+}
+
+func TestAccessNearExpiry(t *testing.T) {
+	tests := []struct {
+		name    string
+		expires time.Time
+		want    bool
+	}{
+		{"expires within the window", time.Now().Add(accessRefreshWindow / 2), true},
+		{"already expired", time.Now().Add(-time.Minute), true},
+		{"plenty of time left", time.Now().Add(time.Hour), false},
+		{"expiry not tracked", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := withSession(t, Session{RefreshToken: "refresh-1", AccessExpires: tt.expires})
+			if got := accessNearExpiry(r); got != tt.want {
+				t.Errorf("accessNearExpiry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("no session", func(t *testing.T) {
+		if accessNearExpiry(httptest.NewRequest(http.MethodGet, "/", nil)) {
+			t.Error("request without a session reported as near expiry")
+		}
+	})
+}
+
+func TestRefreshSessionUpdatesCookieAndExpiry(t *testing.T) {
+	calls := fakeTokenEndpoint(t, newToken)
+	r := withSession(t, Session{RefreshToken: "refresh-1", AccessExpires: time.Now().Add(time.Minute)})
+
+	w := httptest.NewRecorder()
+	token, expiresIn, err := refreshSession(w, r)
+	if err != nil {
+		t.Fatalf("refreshSession: %v", err)
+	}
+	if token != "access-2" || expiresIn != 3600 || calls.Load() != 1 {
+		t.Errorf("got %q, %d after %d refreshes", token, expiresIn, calls.Load())
+	}
+
+	var cookie *http.Cookie
+	for _, c := range w.Result().Cookies() {
+		if c.Name == "gt_access_token" {
+			cookie = c
+		}
+	}
+	if cookie == nil || cookie.Value != "access-2" || cookie.MaxAge != 3600 {
+		t.Errorf("access cookie = %+v", cookie)
+	}
+
+	sess, err := sessions.Get(context.Background(), "session-1")
+	if err != nil {
+		t.Fatalf("session lost: %v", err)
+	}
+	if left := time.Until(sess.AccessExpires); left < 59*time.Minute || left > time.Hour {
+		t.Errorf("session access expiry in %s, want about an hour", left)
+	}
+	if accessNearExpiry(r) {
+		t.Error("refreshed session still reported as near expiry")
+	}
+}
+
+func TestRefreshSessionSharedByConcurrentRequests(t *testing.T) {
+	release := make(chan struct{})
+	calls := fakeTokenEndpoint(t, func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		newToken(w, r)
+	})
+	withSession(t, Session{RefreshToken: "refresh-1", AccessExpires: time.Now().Add(time.Minute)})
+
+	const requests = 8
+	var wg sync.WaitGroup
+	tokens := make([]string, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r := httptest.NewRequest(http.MethodPost, "/api/sheets/read", nil)
+			r.AddCookie(&http.Cookie{Name: sessionCookieName, Value: "session-1"})
+			token, _, err := refreshSession(httptest.NewRecorder(), r)
+			if err != nil {
+				t.Errorf("request %d: %v", i, err)
+			}
+			tokens[i] = token
+		}(i)
+	}
+	// Let every request reach the refresh before the first one finishes
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("refresh token exchanged %d times, want once", n)
+	}
+	for i, token := range tokens {
+		if token != "access-2" {
+			t.Errorf("request %d got token %q", i, token)
+		}
+	}
+}
+
+// invalidGrant answers like Google does for a revoked or expired refresh token
+func invalidGrant(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(tokenErrorResponse{Error: "invalid_grant", ErrorDescription: "Token has been expired or revoked."})
+}
+
+// clearedCookies returns the names of the cookies a response expires
+func clearedCookies(w *httptest.ResponseRecorder) map[string]bool {
+	cleared := map[string]bool{}
+	for _, c := range w.Result().Cookies() {
+		if c.MaxAge < 0 {
+			cleared[c.Name] = true
+		}
+	}
+	return cleared
+}
+
+func TestRefreshSessionInvalidGrantEndsSession(t *testing.T) {
+	fakeTokenEndpoint(t, invalidGrant)
+	r := withSession(t, Session{RefreshToken: "refresh-1", AccessExpires: time.Now().Add(time.Minute)})
+
+	w := httptest.NewRecorder()
+	if _, _, err := refreshSession(w, r); err == nil {
+		t.Fatal("refresh with a revoked token succeeded")
+	}
+	if _, err := sessions.Get(context.Background(), "session-1"); err != errSessionNotFound {
+		t.Errorf("session after invalid_grant: %v, want it deleted", err)
+	}
+	if cleared := clearedCookies(w); !cleared[sessionCookieName] || !cleared["gt_access_token"] {
+		t.Errorf("cookies cleared: %v", cleared)
+	}
+}
+
+func TestHandleRefreshInvalidGrantRequiresReauth(t *testing.T) {
+	fakeTokenEndpoint(t, invalidGrant)
+	r := withSession(t, Session{RefreshToken: "refresh-1", AccessExpires: time.Now().Add(time.Minute)})
+
+	w := httptest.NewRecorder()
+	handleRefresh(w, r)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401", w.Code)
+	}
+	var body struct {
+		Error string `json:"error"`
+		Code  string `json:"code"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if body.Code != reauthRequiredCode {
+		t.Errorf("code = %q, want %q", body.Code, reauthRequiredCode)
+	}
+	cleared := clearedCookies(w)
+	for _, name := range []string{sessionCookieName, legacyRefreshCookieName, "gt_access_token", "gt_user", csrfCookieName} {
+		if !cleared[name] {
+			t.Errorf("cookie %s not cleared", name)
+		}
+	}
+	if _, err := sessions.Get(context.Background(), "session-1"); err != errSessionNotFound {
+		t.Errorf("session after invalid_grant: %v, want it deleted", err)
+	}
+}
+
+func TestHandleRefreshOtherFailureKeepsSession(t *testing.T) {
+	fakeTokenEndpoint(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "backend error", http.StatusInternalServerError)
+	})
+	r := withSession(t, Session{RefreshToken: "refresh-1", AccessExpires: time.Now().Add(time.Minute)})
+
+	w := httptest.NewRecorder()
+	handleRefresh(w, r)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401", w.Code)
+	}
+	if strings.Contains(w.Body.String(), reauthRequiredCode) {
+		t.Errorf("transient failure asked for re-auth: %s", w.Body)
+	}
+	if cleared := clearedCookies(w); len(cleared) != 0 {
+		t.Errorf("transient failure cleared cookies %v", cleared)
+	}
+	if _, err := sessions.Get(context.Background(), "session-1"); err != nil {
+		t.Errorf("session after a transient failure: %v, want it kept", err)
+	}
+}
@@ -2,7 +2,7 @@
  * Authentication module supporting both server-side and client-side OAuth.
  *
  * Server-side auth (Cloud Run):
- * - Uses HTTP-only cookies for refresh tokens
+ * - Keeps refresh tokens server-side; the browser holds only an HTTP-only session cookie
  * - Server handles OAuth flow with Google
  * - More secure, no popup windows
  *
@@ -13,6 +13,7 @@
  */
 
 import { configStore } from '../stores/config.svelte.js';
+import { csrfHeaders } from './csrf.js';
 
 let refreshTimeoutId = null;
 let googleAuthInitialized = false;
@@ -136,7 +137,9 @@ export function getUserFromCookie() {
   const cookieMatch = document.cookie.match(/(?:^|; )gt_user=([^;]*)/);
   if (cookieMatch) {
     try {
-      const decoded = atob(decodeURIComponent(cookieMatch[1]));
+      // The value is base64(json).signature; only the server checks the signature
+      const [payload] = decodeURIComponent(cookieMatch[1]).split('.');
+      const decoded = atob(payload);
       return JSON.parse(decoded);
     } catch {
       // Fall through
@@ -221,7 +224,7 @@ export async function signOut() {
   // Clear server cookies
   if (configStore.serverAuthAvailable) {
     try {
-      await fetch('/auth/logout', { method: 'POST' });
+      await fetch('/auth/logout', { method: 'POST', headers: await csrfHeaders() });
     } catch {
       // Ignore errors
     }
@@ -236,6 +239,12 @@ export async function signOut() {
   window.location.href = '/';
 }
 
+/**
+ * Error code returned by /auth/refresh when the session can't be refreshed
+ * and the user must sign in again.
+ */
+export const REAUTH_REQUIRED = 'REAUTH_REQUIRED';
+
 /**
  * Refresh the access token using the server.
  * The server uses the HttpOnly refresh_token cookie.
@@ -245,10 +254,17 @@ export async function refreshToken() {
   const response = await fetch('/auth/refresh', {
     method: 'POST',
     credentials: 'same-origin',
+    headers: await csrfHeaders(),
   });
 
   if (!response.ok) {
-    throw new Error('Token refresh failed');
+    const error = new Error('Token refresh failed');
+    // The server flags revoked/expired refresh tokens; retrying won't help
+    const body = await response.json().catch(() => null);
+    if (body?.code === REAUTH_REQUIRED) {
+      error.code = REAUTH_REQUIRED;
+    }
+    throw error;
   }
 
   return response.json();
@@ -15,34 +15,203 @@
  */
 
 import { OpenAPI } from './generated/index.js';
+import { addResponseHook } from './generated/core/request.js';
+import { SheetsService as GeneratedSheetsService } from './generated/services/SheetsService.js';
+import { DriveService as GeneratedDriveService } from './generated/services/DriveService.js';
+import { ConfigService as GeneratedConfigService } from './generated/services/ConfigService.js';
+import { AdminService as GeneratedAdminService } from './generated/services/AdminService.js';
+import { DashboardService as GeneratedDashboardService } from './generated/services/DashboardService.js';
+import { refreshToken, getAccessToken } from './auth.js';
+import { csrfHeaders } from './csrf.js';
+import { unwrapEnvelope } from './envelope.js';
+
+// Every backend call echoes the CSRF token the server checks on POSTs
+OpenAPI.HEADERS = () => csrfHeaders();
+
+// Services and error handlers expect the legacy shapes even when the server envelopes responses
+addResponseHook(unwrapEnvelope);
+
+let tokenRefreshListener = null;
+let pendingRefresh = null;
+
+/**
+ * Register a callback for access tokens refreshed by a backend call, either
+ * after a 401 or by the server itself mid-request.
+ * @param {function} onRefresh - Callback with new token data ({ access_token, expires_in })
+ */
+export function onBackendTokenRefresh(onRefresh) {
+  tokenRefreshListener = onRefresh;
+}
+
+/**
+ * Follow a refresh the server did while handling a request. It has already
+ * set the new gt_access_token cookie; the refresh timer still needs the new expiry.
+ * @param {Response} response - The backend response
+ * @param {any} body - The parsed response body, returned unchanged
+ * @returns {any}
+ */
+function followServerRefresh(response, body) {
+  if (response.headers.get('X-Token-Refreshed') === 'true') {
+    const expiresIn = parseInt(response.headers.get('X-Token-Expires-In'), 10);
+    if (expiresIn > 0) {
+      tokenRefreshListener?.({ access_token: getAccessToken(), expires_in: expiresIn });
+    }
+  }
+  return body;
+}
+
+addResponseHook(followServerRefresh);
+
+/**
+ * Refresh the access token once, however many calls hit a 401 together.
+ * @returns {Promise<{access_token: string, expires_in: number}>}
+ */
+function refreshOnce() {
+  if (!pendingRefresh) {
+    pendingRefresh = refreshToken()
+      .then((tokenData) => {
+        tokenRefreshListener?.(tokenData);
+        return tokenData;
+      })
+      .finally(() => {
+        pendingRefresh = null;
+      });
+  }
+  return pendingRefresh;
+}
+
+/**
+ * Wrap a generated service so a call rejected with 401 refreshes the
+ * access token and is retried once. RequireAuth rejects before the handler
+ * runs, so retrying a write can't apply it twice.
+ * @param {Object} service - Generated service class with static methods
+ * @returns {Object} - Object with the same methods
+ */
+function withAuthRetry(service) {
+  const wrapped = {};
+  for (const name of Object.getOwnPropertyNames(service)) {
+    const method = service[name];
+    if (typeof method !== 'function') continue;
+    wrapped[name] = async (...args) => {
+      try {
+        return await method.apply(service, args);
+      } catch (error) {
+        if (!isAuthError(error)) throw error;
+        await refreshOnce();
+        return method.apply(service, args);
+      }
+    };
+  }
+  return wrapped;
+}
 
 // Re-export services and types for convenient imports
-export { SheetsService } from './generated/services/SheetsService.js';
-export { DriveService } from './generated/services/DriveService.js';
-export { ConfigService } from './generated/services/ConfigService.js';
+export const SheetsService = withAuthRetry(GeneratedSheetsService);
+export const DriveService = withAuthRetry(GeneratedDriveService);
+export const ConfigService = withAuthRetry(GeneratedConfigService);
+export const AdminService = withAuthRetry(GeneratedAdminService);
+export const DashboardService = withAuthRetry(GeneratedDashboardService);
 export { ApiError } from './generated/core/ApiError.js';
 
 // Re-export types
 export * from './generated/models/AppendRowRequest.js';
+export * from './generated/models/AppendRowResponse.js';
+export * from './generated/models/AuditEntry.js';
+export * from './generated/models/AuditListRequest.js';
+export * from './generated/models/AuditListResponse.js';
+export * from './generated/models/AutoResizeRequest.js';
+export * from './generated/models/BatchAppendRowsRequest.js';
+export * from './generated/models/BatchAppendRowsResponse.js';
 export * from './generated/models/BatchUpdateRequest.js';
+export * from './generated/models/BatchUpdateResponse.js';
+export * from './generated/models/BootstrapResponse.js';
+export * from './generated/models/Branding.js';
+export * from './generated/models/Breadcrumb.js';
+export * from './generated/models/CheckFolderAccessRequest.js';
+export * from './generated/models/CheckFolderAccessResponse.js';
+export * from './generated/models/CompletenessRequest.js';
+export * from './generated/models/CompletenessResponse.js';
+export * from './generated/models/ConditionalUpdateRequest.js';
 export * from './generated/models/Config.js';
 export * from './generated/models/CreateDocRequest.js';
 export * from './generated/models/CreateDocResponse.js';
 export * from './generated/models/CreateFolderRequest.js';
 export * from './generated/models/CreateFolderResponse.js';
+export * from './generated/models/CreateGrantWorkspaceRequest.js';
+export * from './generated/models/CreateGrantWorkspaceResponse.js';
 export * from './generated/models/CreateShortcutRequest.js';
 export * from './generated/models/CreateShortcutResponse.js';
+export * from './generated/models/CreateSpreadsheetTabRequest.js';
+export * from './generated/models/CreateSpreadsheetTabResponse.js';
+export * from './generated/models/DashboardResponse.js';
 export * from './generated/models/DeleteRowRequest.js';
+export * from './generated/models/DeleteRowResponse.js';
+export * from './generated/models/DeleteRowsResponse.js';
+export * from './generated/models/DeleteRowsWhereRequest.js';
+export * from './generated/models/DownloadFileRequest.js';
+export * from './generated/models/DriveChange.js';
+export * from './generated/models/DuplicateGroup.js';
+export * from './generated/models/ExportGrantRequest.js';
+export * from './generated/models/ExportGrantResponse.js';
+export * from './generated/models/FieldError.js';
 export * from './generated/models/FileInfo.js';
+export * from './generated/models/FindDuplicatesRequest.js';
+export * from './generated/models/FindDuplicatesResponse.js';
+export * from './generated/models/FindRowsRequest.js';
+export * from './generated/models/FindRowsResponse.js';
+export * from './generated/models/FolderAccess.js';
+export * from './generated/models/FoundRow.js';
+export * from './generated/models/GetFilePathRequest.js';
+export * from './generated/models/GetFilePathResponse.js';
 export * from './generated/models/GetFileRequest.js';
+export * from './generated/models/GrantHistoryRequest.js';
+export * from './generated/models/GrantHistoryResponse.js';
+export * from './generated/models/GrantManifest.js';
+export * from './generated/models/GrantPermalinkRequest.js';
+export * from './generated/models/GrantPermalinkResponse.js';
+export * from './generated/models/GrantsSummary.js';
+export * from './generated/models/ImportRowPreview.js';
+export * from './generated/models/ListChangesRequest.js';
+export * from './generated/models/ListChangesResponse.js';
 export * from './generated/models/ListFilesRequest.js';
 export * from './generated/models/ListFilesResponse.js';
+export * from './generated/models/ListGrantManifestsRequest.js';
+export * from './generated/models/ListGrantManifestsResponse.js';
+export * from './generated/models/ListPermissionsRequest.js';
+export * from './generated/models/ListPermissionsResponse.js';
 export * from './generated/models/MoveFileRequest.js';
+export * from './generated/models/PageInfo.js';
+export * from './generated/models/Permission.js';
+export * from './generated/models/PivotRequest.js';
+export * from './generated/models/PivotResponse.js';
+export * from './generated/models/PreviewImportRequest.js';
+export * from './generated/models/PreviewImportResponse.js';
+export * from './generated/models/ProvisionGrantFolderRequest.js';
+export * from './generated/models/ProvisionGrantFolderResponse.js';
+export * from './generated/models/PurgeAuthCacheRequest.js';
+export * from './generated/models/PurgeAuthCacheResponse.js';
+export * from './generated/models/QuotaResponse.js';
+export * from './generated/models/RangeData.js';
 export * from './generated/models/ReadSheetRequest.js';
 export * from './generated/models/ReadSheetResponse.js';
+export * from './generated/models/ReloadCredentialsResponse.js';
+export * from './generated/models/RowCompleteness.js';
+export * from './generated/models/RowFilter.js';
+export * from './generated/models/SearchFilesRequest.js';
+export * from './generated/models/SearchFilesResponse.js';
+export * from './generated/models/ShareFileRequest.js';
+export * from './generated/models/ShareFileResponse.js';
+export * from './generated/models/SheetInfo.js';
+export * from './generated/models/SheetMetadataResponse.js';
 export * from './generated/models/ShortcutDetails.js';
 export * from './generated/models/SuccessResponse.js';
+export * from './generated/models/TransferOwnershipRequest.js';
+export * from './generated/models/TrashFileRequest.js';
+export * from './generated/models/UpdateCondition.js';
+export * from './generated/models/UpdateConflict.js';
 export * from './generated/models/UpdateRowRequest.js';
+export * from './generated/models/VersionInfo.js';
+export * from './generated/models/WorkspaceFolder.js';
 
 /**
  * Initialize the backend API client.
@@ -0,0 +1,64 @@
+/**
+ * CSRF token for state-changing requests to our server.
+ *
+ * The server sets a gt_csrf cookie and rejects any POST to /api or /auth that
+ * doesn't echo its value in the X-CSRF-Token header. When the cookie can't be
+ * read (not issued yet, or the frontend is on another origin), the token is
+ * fetched from /auth/csrf instead.
+ */
+
+export const CSRF_HEADER = 'X-CSRF-Token';
+const CSRF_COOKIE = 'gt_csrf';
+
+let tokenRequest = null;
+
+/**
+ * Read a cookie visible to this page.
+ * @param {string} name
+ * @returns {string|null}
+ */
+function readCookie(name) {
+  for (const part of document.cookie.split(';')) {
+    const [key, ...rest] = part.trim().split('=');
+    if (key === name) {
+      return decodeURIComponent(rest.join('='));
+    }
+  }
+  return null;
+}
+
+/**
+ * Get the CSRF token, asking the server for one if the cookie isn't readable.
+ * @returns {Promise<string>}
+ */
+export async function getCsrfToken() {
+  const fromCookie = readCookie(CSRF_COOKIE);
+  if (fromCookie) {
+    return fromCookie;
+  }
+
+  if (!tokenRequest) {
+    tokenRequest = fetch('/auth/csrf', { credentials: 'include' })
+      .then((response) => {
+        if (!response.ok) {
+          throw new Error(`CSRF token request failed (${response.status})`);
+        }
+        return response.json();
+      })
+      .then((body) => body.token)
+      .catch((err) => {
+        // Let the next request try again
+        tokenRequest = null;
+        throw err;
+      });
+  }
+  return tokenRequest;
+}
+
+/**
+ * Headers to add to a state-changing request.
+ * @returns {Promise<Record<string, string>>}
+ */
+export async function csrfHeaders() {
+  return { [CSRF_HEADER]: await getCsrfToken() };
+}
@@ -7,6 +7,8 @@
 
 import { configStore } from '../stores/config.svelte.js';
 import { DriveService } from './backend.js';
+import { csrfHeaders } from './csrf.js';
+import { unwrapEnvelope } from './envelope.js';
 import * as directDrive from './drive.js';
 
 /**
@@ -84,18 +86,25 @@ export async function createFolder(accessToken, name, parentId) {
 export async function listFiles(accessToken, folderId, options = {}) {
   if (useBackend()) {
     return withBetterErrors(async () => {
-      let query = null;
+      let mimeType = null;
       if (options.foldersOnly) {
-        query = `mimeType = 'application/vnd.google-apps.folder'`;
+        mimeType = 'application/vnd.google-apps.folder';
       } else if (options.mimeType) {
-        query = `mimeType = '${options.mimeType}'`;
+        mimeType = options.mimeType;
       }
 
-      const response = await DriveService.listFiles({
-        requestBody: { folderId, query },
-      });
-
-      return response.files || [];
+      // Follow pageInfo so folders with more than one page aren't truncated
+      const files = [];
+      let pageToken = null;
+      do {
+        const response = await DriveService.listFiles({
+          requestBody: { folderId, mimeType, pageToken },
+        });
+        files.push(...(response.files || []));
+        pageToken = response.pageInfo?.nextPageToken || null;
+      } while (pageToken);
+
+      return files;
     });
   }
 
@@ -112,9 +121,8 @@ export async function listFiles(accessToken, folderId, options = {}) {
 export async function findFolder(accessToken, parentId, name) {
   if (useBackend()) {
     return withBetterErrors(async () => {
-      const query = `name = '${name}' and mimeType = 'application/vnd.google-apps.folder'`;
       const response = await DriveService.listFiles({
-        requestBody: { folderId: parentId, query },
+        requestBody: { folderId: parentId, name, mimeType: 'application/vnd.google-apps.folder' },
       });
 
       return response.files?.[0] || null;
@@ -300,15 +308,28 @@ export async function createGrantFolderStructure(accessToken, grantsFolderId, gr
   // This is a complex operation that creates multiple resources
   // For now, implement it using the unified primitives
 
-  // Create the grant folder
-  const grantFolder = await createFolder(accessToken, grantId, grantsFolderId);
+  // Create the grant folder. The backend also creates the team's configured
+  // subfolder layout; client-side mode only creates Reports.
+  let grantFolder;
+  let reportsFolder = null;
+  if (useBackend()) {
+    grantFolder = await withBetterErrors(() =>
+      DriveService.createGrantWorkspace({
+        requestBody: { grantId, parentId: grantsFolderId },
+      })
+    );
+    reportsFolder = grantFolder.subfolders.find((f) => f.name === 'Reports') || null;
+  } else {
+    grantFolder = await createFolder(accessToken, grantId, grantsFolderId);
+  }
 
-  // Create Tracker doc, Proposal doc, and Reports folder
-  const [trackerDoc, proposalDoc, reportsFolder] = await Promise.all([
+  // Create Tracker doc, Proposal doc, and Reports folder (if not already created)
+  const [trackerDoc, proposalDoc, reports] = await Promise.all([
     createDoc(accessToken, `${grantId}-Tracker`, grantFolder.id),
     createDoc(accessToken, `${grantId}-Proposal`, grantFolder.id),
-    createFolder(accessToken, 'Reports', grantFolder.id),
+    reportsFolder || createFolder(accessToken, 'Reports', grantFolder.id),
   ]);
+  reportsFolder = reports;
 
   // Initialize Tracker doc with grant metadata if grant data provided
   if (grant) {
@@ -365,6 +386,7 @@ async function initializeTrackerDocViaBackend(documentId, grant) {
     method: 'POST',
     headers: {
       'Content-Type': 'application/json',
+      ...(await csrfHeaders()),
     },
     credentials: 'include',
     body: JSON.stringify({
@@ -381,7 +403,8 @@ async function initializeTrackerDocViaBackend(documentId, grant) {
   });
 
   if (!response.ok) {
-    const error = await response.json().catch(() => ({ error: 'Unknown error' }));
+    const body = await response.json().catch(() => null);
+    const error = unwrapEnvelope(response, body) || { error: 'Unknown error' };
     throw new Error(error.error || `HTTP ${response.status}`);
   }
 }
@@ -62,28 +62,38 @@ export async function listFiles(accessToken, folderId, options = {}) {
     query += ` and mimeType = '${options.mimeType}'`;
   }
 
-  const params = new URLSearchParams({
-    q: query,
-    fields: 'files(id, name, mimeType, modifiedTime, webViewLink, shortcutDetails)',
-    orderBy: 'name',
-    pageSize: '1000',
-    supportsAllDrives: 'true',
-    includeItemsFromAllDrives: 'true',
-  });
+  const files = [];
+  let pageToken = null;
+  do {
+    const params = new URLSearchParams({
+      q: query,
+      fields: 'nextPageToken, files(id, name, mimeType, modifiedTime, webViewLink, shortcutDetails)',
+      orderBy: 'name',
+      pageSize: '1000',
+      supportsAllDrives: 'true',
+      includeItemsFromAllDrives: 'true',
+    });
+    if (pageToken) {
+      params.set('pageToken', pageToken);
+    }
 
-  const response = await fetch(`${DRIVE_API_BASE}/files?${params}`, {
-    headers: {
-      Authorization: `Bearer ${accessToken}`,
-    },
-  });
+    const response = await fetch(`${DRIVE_API_BASE}/files?${params}`, {
+      headers: {
+        Authorization: `Bearer ${accessToken}`,
+      },
+    });
 
-  if (!response.ok) {
-    const error = await response.json().catch(() => ({}));
-    throw new Error(error.error?.message || `Failed to list files (${response.status})`);
-  }
+    if (!response.ok) {
+      const error = await response.json().catch(() => ({}));
+      throw new Error(error.error?.message || `Failed to list files (${response.status})`);
+    }
 
-  const data = await response.json();
-  return data.files || [];
+    const data = await response.json();
+    files.push(...(data.files || []));
+    pageToken = data.nextPageToken || null;
+  } while (pageToken);
+
+  return files;
 }
 
 /**
@@ -0,0 +1,25 @@
+/**
+ * Response envelope support.
+ *
+ * The server can wrap API responses as {data, meta, error} (per request, or
+ * for everyone with RESPONSE_ENVELOPE=true). The app is written against the
+ * legacy shapes, so enveloped bodies are unwrapped back to them here.
+ */
+
+/**
+ * Return the legacy body for a possibly enveloped response: `data` on
+ * success, or the error object with `message` back under `error`.
+ * @param {Response} response - The fetch response the body was read from
+ * @param {any} body - The parsed response body
+ * @returns {any} - The body as it would have been without the envelope
+ */
+export function unwrapEnvelope(response, body) {
+  if (response.headers.get('X-Response-Envelope') !== 'true' || !body || typeof body !== 'object') {
+    return body;
+  }
+  if (!body.error) {
+    return body.data;
+  }
+  const { message, ...details } = body.error;
+  return { error: message, ...details };
+}
@@ -1,7 +1,11 @@
-/* generated using openapi-typescript-codegen -- do not edit */
 /* istanbul ignore file */
 /* tslint:disable */
 /* eslint-disable */
+/*
+ * Custom request for openapi-typescript-codegen (`--request codegen/request.ts`):
+ * the stock fetch client, plus hooks that see every response before its body
+ * is returned or thrown. Edit web/codegen/request.ts, not the generated copy.
+ */
 import { ApiError } from './ApiError';
 import type { ApiRequestOptions } from './ApiRequestOptions';
 import type { ApiResult } from './ApiResult';
@@ -9,6 +13,18 @@ import { CancelablePromise } from './CancelablePromise';
 import type { OnCancel } from './CancelablePromise';
 import type { OpenAPIConfig } from './OpenAPI';
 
+export type ResponseHook = (response: Response, body: any) => any;
+
+const responseHooks: ResponseHook[] = [];
+
+/**
+ * Register a hook called with every response and its parsed body. Whatever it
+ * returns replaces the body, for both results and ApiError.body.
+ */
+export const addResponseHook = (hook: ResponseHook): void => {
+    responseHooks.push(hook);
+};
+
 export const isDefined = <T>(value: T | null | undefined): value is Exclude<T, null | undefined> => {
     return value !== undefined && value !== null;
 };
@@ -300,7 +316,10 @@ export const request = <T>(config: OpenAPIConfig, options: ApiRequestOptions): C
 
             if (!onCancel.isCancelled) {
                 const response = await sendRequest(config, options, url, body, formData, headers, onCancel);
-                const responseBody = await getResponseBody(response);
+                let responseBody = await getResponseBody(response);
+                for (const hook of responseHooks) {
+                    responseBody = hook(response, responseBody);
+                }
                 const responseHeader = getResponseHeader(response, options.responseHeader);
 
                 const result: ApiResult = {
@@ -8,27 +8,107 @@ export { OpenAPI } from './core/OpenAPI';
 export type { OpenAPIConfig } from './core/OpenAPI';
 
 export type { AppendRowRequest } from './models/AppendRowRequest';
+export type { AppendRowResponse } from './models/AppendRowResponse';
+export type { AuditEntry } from './models/AuditEntry';
+export type { AuditListRequest } from './models/AuditListRequest';
+export { AuditListResponse } from './models/AuditListResponse';
+export type { AutoResizeRequest } from './models/AutoResizeRequest';
+export type { BatchAppendRowsRequest } from './models/BatchAppendRowsRequest';
+export type { BatchAppendRowsResponse } from './models/BatchAppendRowsResponse';
 export type { BatchUpdateRequest } from './models/BatchUpdateRequest';
+export type { BatchUpdateResponse } from './models/BatchUpdateResponse';
+export type { BootstrapResponse } from './models/BootstrapResponse';
+export type { Branding } from './models/Branding';
+export type { Breadcrumb } from './models/Breadcrumb';
+export type { CheckFolderAccessRequest } from './models/CheckFolderAccessRequest';
+export type { CheckFolderAccessResponse } from './models/CheckFolderAccessResponse';
+export type { CompletenessRequest } from './models/CompletenessRequest';
+export type { CompletenessResponse } from './models/CompletenessResponse';
+export type { ConditionalUpdateRequest } from './models/ConditionalUpdateRequest';
 export type { Config } from './models/Config';
-export { CreateDocRequest } from './models/CreateDocRequest';
+export type { CreateDocRequest } from './models/CreateDocRequest';
 export type { CreateDocResponse } from './models/CreateDocResponse';
 export type { CreateFolderRequest } from './models/CreateFolderRequest';
 export type { CreateFolderResponse } from './models/CreateFolderResponse';
+export type { CreateGrantWorkspaceRequest } from './models/CreateGrantWorkspaceRequest';
+export type { CreateGrantWorkspaceResponse } from './models/CreateGrantWorkspaceResponse';
 export type { CreateShortcutRequest } from './models/CreateShortcutRequest';
 export type { CreateShortcutResponse } from './models/CreateShortcutResponse';
+export type { CreateSpreadsheetTabRequest } from './models/CreateSpreadsheetTabRequest';
+export type { CreateSpreadsheetTabResponse } from './models/CreateSpreadsheetTabResponse';
+export type { DashboardResponse } from './models/DashboardResponse';
 export type { DeleteRowRequest } from './models/DeleteRowRequest';
+export type { DeleteRowResponse } from './models/DeleteRowResponse';
+export type { DeleteRowsResponse } from './models/DeleteRowsResponse';
+export type { DeleteRowsWhereRequest } from './models/DeleteRowsWhereRequest';
+export type { DownloadFileRequest } from './models/DownloadFileRequest';
+export type { DriveChange } from './models/DriveChange';
+export type { DuplicateGroup } from './models/DuplicateGroup';
 export type { Error } from './models/Error';
+export type { ExportGrantRequest } from './models/ExportGrantRequest';
+export type { ExportGrantResponse } from './models/ExportGrantResponse';
+export type { FieldError } from './models/FieldError';
 export type { FileInfo } from './models/FileInfo';
+export type { FindDuplicatesRequest } from './models/FindDuplicatesRequest';
+export type { FindDuplicatesResponse } from './models/FindDuplicatesResponse';
+export type { FindRowsRequest } from './models/FindRowsRequest';
+export type { FindRowsResponse } from './models/FindRowsResponse';
+export type { FolderAccess } from './models/FolderAccess';
+export type { FoundRow } from './models/FoundRow';
+export type { GetFilePathRequest } from './models/GetFilePathRequest';
+export type { GetFilePathResponse } from './models/GetFilePathResponse';
 export type { GetFileRequest } from './models/GetFileRequest';
+export type { GrantHistoryRequest } from './models/GrantHistoryRequest';
+export type { GrantHistoryResponse } from './models/GrantHistoryResponse';
+export type { GrantManifest } from './models/GrantManifest';
+export type { GrantPermalinkRequest } from './models/GrantPermalinkRequest';
+export type { GrantPermalinkResponse } from './models/GrantPermalinkResponse';
+export type { GrantsSummary } from './models/GrantsSummary';
+export { ImportRowPreview } from './models/ImportRowPreview';
+export type { ListChangesRequest } from './models/ListChangesRequest';
+export type { ListChangesResponse } from './models/ListChangesResponse';
 export type { ListFilesRequest } from './models/ListFilesRequest';
 export type { ListFilesResponse } from './models/ListFilesResponse';
+export type { ListGrantManifestsRequest } from './models/ListGrantManifestsRequest';
+export type { ListGrantManifestsResponse } from './models/ListGrantManifestsResponse';
+export type { ListPermissionsRequest } from './models/ListPermissionsRequest';
+export type { ListPermissionsResponse } from './models/ListPermissionsResponse';
 export type { MoveFileRequest } from './models/MoveFileRequest';
-export type { ReadSheetRequest } from './models/ReadSheetRequest';
+export type { PageInfo } from './models/PageInfo';
+export type { Permission } from './models/Permission';
+export { PivotRequest } from './models/PivotRequest';
+export type { PivotResponse } from './models/PivotResponse';
+export type { PreviewImportRequest } from './models/PreviewImportRequest';
+export type { PreviewImportResponse } from './models/PreviewImportResponse';
+export type { ProvisionGrantFolderRequest } from './models/ProvisionGrantFolderRequest';
+export type { ProvisionGrantFolderResponse } from './models/ProvisionGrantFolderResponse';
+export type { PurgeAuthCacheRequest } from './models/PurgeAuthCacheRequest';
+export type { PurgeAuthCacheResponse } from './models/PurgeAuthCacheResponse';
+export type { QuotaResponse } from './models/QuotaResponse';
+export type { RangeData } from './models/RangeData';
+export { ReadSheetRequest } from './models/ReadSheetRequest';
 export type { ReadSheetResponse } from './models/ReadSheetResponse';
+export type { ReloadCredentialsResponse } from './models/ReloadCredentialsResponse';
+export type { RowCompleteness } from './models/RowCompleteness';
+export { RowFilter } from './models/RowFilter';
+export type { SearchFilesRequest } from './models/SearchFilesRequest';
+export type { SearchFilesResponse } from './models/SearchFilesResponse';
+export { ShareFileRequest } from './models/ShareFileRequest';
+export type { ShareFileResponse } from './models/ShareFileResponse';
+export type { SheetInfo } from './models/SheetInfo';
+export type { SheetMetadataResponse } from './models/SheetMetadataResponse';
 export type { ShortcutDetails } from './models/ShortcutDetails';
 export type { SuccessResponse } from './models/SuccessResponse';
+export type { TransferOwnershipRequest } from './models/TransferOwnershipRequest';
+export type { TrashFileRequest } from './models/TrashFileRequest';
+export type { UpdateCondition } from './models/UpdateCondition';
+export type { UpdateConflict } from './models/UpdateConflict';
 export type { UpdateRowRequest } from './models/UpdateRowRequest';
+export type { VersionInfo } from './models/VersionInfo';
+export type { WorkspaceFolder } from './models/WorkspaceFolder';
 
+export { AdminService } from './services/AdminService';
 export { ConfigService } from './services/ConfigService';
+export { DashboardService } from './services/DashboardService';
 export { DriveService } from './services/DriveService';
 export { SheetsService } from './services/SheetsService';
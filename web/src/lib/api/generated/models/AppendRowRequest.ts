@@ -8,8 +8,15 @@ export type AppendRowRequest = {
      */
     sheet: string;
     /**
-     * Row data as key-value pairs where keys match column headers
+     * Row data as key-value pairs where keys match column headers. Writing to a header
+     * that appears more than once in the sheet is rejected with 400; when the server sets
+     * DUPLICATE_HEADERS=index, address a specific occurrence as "Name#N" (1-based, left to right).
      */
     row: Record<string, any>;
+    /**
+     * Read the written row back and fail with 422 (code VERIFY_FAILED) if Sheets coerced
+     * or dropped any value. The write itself is not undone.
+     */
+    verify?: boolean;
 };
 
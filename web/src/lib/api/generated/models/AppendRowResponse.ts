@@ -0,0 +1,16 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type AppendRowResponse = {
+    success: boolean;
+    /**
+     * A1 range Sheets wrote the row to
+     */
+    updatedRange?: string;
+    /**
+     * 1-based sheet row of the new row (the first row, if Sheets reports several)
+     */
+    rowNumber?: number;
+};
+
@@ -0,0 +1,30 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type AuditEntry = {
+    time: string;
+    /**
+     * Email of the user who made the change
+     */
+    user: string;
+    action: string;
+    /**
+     * Sheet name or Drive file/folder ID
+     */
+    resource: string;
+    /**
+     * Row ID or other object acted on
+     */
+    target?: string;
+    /**
+     * Human-readable summary
+     */
+    detail?: string;
+    /**
+     * The X-Operation-ID the request carried, shared by every step of one client action.
+     * Letters, digits, and -_.: only, up to 128 characters; other values are ignored.
+     */
+    operationId?: string;
+};
+
@@ -0,0 +1,23 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type AuditListRequest = {
+    /**
+     * Only events by this email (case-insensitive)
+     */
+    user?: string;
+    /**
+     * Only events at or after this time
+     */
+    since?: string;
+    /**
+     * Only events before this time
+     */
+    until?: string;
+    /**
+     * Maximum number of events to return
+     */
+    limit?: number;
+};
+
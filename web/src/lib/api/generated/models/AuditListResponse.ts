@@ -0,0 +1,25 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { AuditEntry } from './AuditEntry';
+export type AuditListResponse = {
+    /**
+     * Matching events, newest first
+     */
+    entries: Array<AuditEntry>;
+    /**
+     * Where the events were read from (the AuditLog tab or this instance's memory)
+     */
+    source: AuditListResponse.source;
+};
+export namespace AuditListResponse {
+    /**
+     * Where the events were read from (the AuditLog tab or this instance's memory)
+     */
+    export enum source {
+        SHEET = 'sheet',
+        MEMORY = 'memory',
+    }
+}
+
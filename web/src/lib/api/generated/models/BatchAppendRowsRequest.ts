@@ -0,0 +1,16 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type BatchAppendRowsRequest = {
+    /**
+     * Sheet name
+     */
+    sheet: string;
+    /**
+     * Rows in the order they should appear, each as key-value pairs where keys match
+     * column headers (duplicate headers are handled as for appendRow)
+     */
+    rows: Array<Record<string, any>>;
+};
+
@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type BatchAppendRowsResponse = {
+    /**
+     * Number of rows appended
+     */
+    appended: number;
+    /**
+     * A1 range Sheets wrote the rows to
+     */
+    updatedRange?: string;
+};
+
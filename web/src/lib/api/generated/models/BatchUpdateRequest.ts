@@ -7,6 +7,11 @@ export type BatchUpdateRequest = {
      * Sheet name
      */
     sheet: string;
+    /**
+     * Cells to write. Batches larger than the server's BATCH_UPDATE_MAX_RANGES (default 500)
+     * are split into several Sheets calls, or rejected with 400 (code BATCH_TOO_LARGE and
+     * the accepted `limit`) when the server sets BATCH_UPDATE_AUTO_SPLIT=false.
+     */
     updates: Array<{
         /**
          * Cell range (e.g., 'A2:C2')
@@ -17,5 +22,9 @@ export type BatchUpdateRequest = {
          */
         values: Array<any>;
     }>;
+    /**
+     * Fit the sheet's column widths to their contents after writing (useful for bulk imports)
+     */
+    autoResize?: boolean;
 };
 
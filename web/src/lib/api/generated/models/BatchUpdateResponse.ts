@@ -0,0 +1,27 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type BatchUpdateResponse = {
+    /**
+     * True when every range was written
+     */
+    success: boolean;
+    /**
+     * Number of ranges written
+     */
+    updatedRanges: number;
+    /**
+     * Number of Sheets BatchUpdate calls made
+     */
+    batches: number;
+    /**
+     * Ranges that were not written (failed batch and everything after it)
+     */
+    failedRanges?: Array<string>;
+    /**
+     * Error from the failed batch
+     */
+    error?: string;
+};
+
@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type BootstrapResponse = {
+    /**
+     * Tabs that were created
+     */
+    created: Array<string>;
+    /**
+     * Tabs that already existed and were left untouched
+     */
+    existing: Array<string>;
+};
+
@@ -0,0 +1,24 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Instance branding for white-labeled deployments, from the INSTANCE_NAME,
+ * INSTANCE_LOGO_URL, and INSTANCE_THEME_COLOR env vars, overridden by the
+ * Config tab's instance_* keys. Omitted from the config when none are set.
+ */
+export type Branding = {
+    /**
+     * Instance name to show in place of "Grant Tracker"
+     */
+    name?: string;
+    /**
+     * http(s) URL of the instance logo
+     */
+    logoUrl?: string;
+    /**
+     * Theme color as a CSS hex code with 3 or 6 digits
+     */
+    themeColor?: string;
+};
+
@@ -0,0 +1,12 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { FolderAccess } from './FolderAccess';
+export type CheckFolderAccessResponse = {
+    /**
+     * Access keyed by folder ID
+     */
+    access: Record<string, FolderAccess>;
+};
+
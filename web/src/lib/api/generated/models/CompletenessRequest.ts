@@ -0,0 +1,19 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type CompletenessRequest = {
+    /**
+     * Sheet name (defaults to Grants)
+     */
+    sheet?: string;
+    /**
+     * Column identifying each row in the response
+     */
+    idColumn: string;
+    /**
+     * Columns every row should fill (defaults to the configured set)
+     */
+    requiredColumns?: Array<string>;
+};
+
@@ -0,0 +1,16 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { RowCompleteness } from './RowCompleteness';
+export type CompletenessResponse = {
+    /**
+     * The columns each row was scored against
+     */
+    requiredColumns: Array<string>;
+    /**
+     * One entry per data row, least complete first
+     */
+    rows: Array<RowCompleteness>;
+};
+
@@ -0,0 +1,25 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { UpdateCondition } from './UpdateCondition';
+export type ConditionalUpdateRequest = {
+    /**
+     * Sheet name
+     */
+    sheet: string;
+    /**
+     * Column name containing the unique ID
+     */
+    idColumn: string;
+    /**
+     * Value of the ID to match
+     */
+    id: string;
+    /**
+     * Fields to update as key-value pairs
+     */
+    data: Record<string, any>;
+    condition: UpdateCondition;
+};
+
@@ -2,6 +2,7 @@
 /* istanbul ignore file */
 /* tslint:disable */
 /* eslint-disable */
+import type { Branding } from './Branding';
 export type Config = {
     /**
      * Google OAuth client ID
@@ -19,5 +20,22 @@ export type Config = {
      * ID of the grants root folder (only when service account enabled)
      */
     grantsFolderId?: string;
+    /**
+     * ID of the Shared Drive holding the root folder (only when service account
+     * enabled). Masked unless the server sets EXPOSE_SHARED_DRIVE_ID=true.
+     */
+    sharedDriveId?: string;
+    /**
+     * True while the server is still locating the spreadsheet and Grants folder.
+     * The response also carries a Retry-After header; clients should wait and
+     * fetch the config again.
+     */
+    discovering?: boolean;
+    /**
+     * True when the server runs with SAFE_MODE=true. Deleting rows, moving files, and
+     * transferring ownership are then refused with 403, so clients should hide those actions.
+     */
+    safeMode?: boolean;
+    branding?: Branding;
 };
 
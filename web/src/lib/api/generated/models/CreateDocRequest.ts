@@ -8,22 +8,13 @@ export type CreateDocRequest = {
      */
     name: string;
     /**
-     * Document type
+     * Document type. Must be in the server's CREATE_DOC_MIME_TYPES allowlist,
+     * which defaults to Google Docs, Sheets, and Slides.
      */
-    mimeType: CreateDocRequest.mimeType;
+    mimeType: string;
     /**
      * Parent folder ID
      */
     parentId?: string;
 };
-export namespace CreateDocRequest {
-    /**
-     * Document type
-     */
-    export enum mimeType {
-        APPLICATION_VND_GOOGLE_APPS_DOCUMENT = 'application/vnd.google-apps.document',
-        APPLICATION_VND_GOOGLE_APPS_SPREADSHEET = 'application/vnd.google-apps.spreadsheet',
-        APPLICATION_VND_GOOGLE_APPS_PRESENTATION = 'application/vnd.google-apps.presentation',
-    }
-}
 
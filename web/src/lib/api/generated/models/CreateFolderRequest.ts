@@ -11,5 +11,9 @@ export type CreateFolderRequest = {
      * Parent folder ID (defaults to grants folder)
      */
     parentId?: string;
+    /**
+     * Tag the folder as this grant's folder so it can be found even if renamed
+     */
+    grantId?: string;
 };
 
@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type CreateGrantWorkspaceRequest = {
+    /**
+     * Grant ID, used as the folder name
+     */
+    grantId: string;
+    /**
+     * Parent folder ID (defaults to the Grants folder)
+     */
+    parentId?: string;
+};
+
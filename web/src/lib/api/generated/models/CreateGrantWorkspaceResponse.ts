@@ -0,0 +1,17 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { WorkspaceFolder } from './WorkspaceFolder';
+export type CreateGrantWorkspaceResponse = {
+    /**
+     * Grant folder ID
+     */
+    id: string;
+    /**
+     * URL to view the grant folder
+     */
+    url: string;
+    subfolders: Array<WorkspaceFolder>;
+};
+
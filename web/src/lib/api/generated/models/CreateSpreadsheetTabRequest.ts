@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type CreateSpreadsheetTabRequest = {
+    /**
+     * Name of the new tab
+     */
+    title: string;
+    /**
+     * Column headers written into row 1
+     */
+    headers: Array<string>;
+};
+
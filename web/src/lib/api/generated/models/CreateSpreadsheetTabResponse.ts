@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type CreateSpreadsheetTabResponse = {
+    /**
+     * Numeric ID of the new tab (the gid in its URL)
+     */
+    sheetId: number;
+    /**
+     * Name of the new tab
+     */
+    title: string;
+};
+
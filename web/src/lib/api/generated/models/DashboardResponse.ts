@@ -0,0 +1,20 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { Config } from './Config';
+import type { FileInfo } from './FileInfo';
+import type { GrantsSummary } from './GrantsSummary';
+export type DashboardResponse = {
+    config: Config;
+    grants?: GrantsSummary;
+    /**
+     * Most recently modified files in the Shared Drive
+     */
+    recentFiles?: Array<FileInfo>;
+    /**
+     * Sections that could not be loaded, and why
+     */
+    warnings: Array<string>;
+};
+
@@ -15,5 +15,9 @@ export type DeleteRowRequest = {
      * Value of the ID to match
      */
     id: string;
+    /**
+     * Delete every row with this ID rather than only the first
+     */
+    matchAll?: boolean;
 };
 
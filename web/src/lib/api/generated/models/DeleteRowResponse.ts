@@ -0,0 +1,12 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type DeleteRowResponse = {
+    success: boolean;
+    /**
+     * Number of rows deleted
+     */
+    deleted: number;
+};
+
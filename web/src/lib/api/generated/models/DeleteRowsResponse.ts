@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type DeleteRowsResponse = {
+    /**
+     * Number of rows deleted
+     */
+    deleted: number;
+    /**
+     * Tab the deleted rows were copied to first (only when DELETE_BACKUP=true)
+     */
+    backupSheet?: string;
+};
+
@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type DeleteRowsWhereRequest = {
+    /**
+     * Sheet name
+     */
+    sheet: string;
+    /**
+     * Column values a row must match (all must be equal) to be deleted
+     */
+    where: Record<string, any>;
+};
+
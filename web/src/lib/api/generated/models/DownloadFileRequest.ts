@@ -0,0 +1,19 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type DownloadFileRequest = {
+    /**
+     * ID of the file to download
+     */
+    fileId: string;
+    /**
+     * Format to export a Google Docs, Sheets, or Slides file as (required for those, ignored otherwise)
+     */
+    mimeType?: string;
+    /**
+     * Ask for the file to be shown in the browser rather than saved, when its type allows
+     */
+    inline?: boolean;
+};
+
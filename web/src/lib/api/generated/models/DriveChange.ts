@@ -0,0 +1,21 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { FileInfo } from './FileInfo';
+export type DriveChange = {
+    fileId: string;
+    /**
+     * The file was trashed, deleted, moved out of the Grants and root folder trees, or is
+     * no longer visible to the server. A removal carries no file, so it is only reported
+     * for files this server has seen inside the trees (in changes or search results).
+     * Files only seen before a server restart may go unreported; resync to catch them.
+     */
+    removed: boolean;
+    /**
+     * When the change happened
+     */
+    time?: string;
+    file?: FileInfo;
+};
+
@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type DuplicateGroup = {
+    /**
+     * The shared key column value
+     */
+    key: string;
+    /**
+     * 1-based sheet row numbers holding this key
+     */
+    rows: Array<number>;
+};
+
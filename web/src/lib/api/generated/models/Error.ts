@@ -2,10 +2,32 @@
 /* istanbul ignore file */
 /* tslint:disable */
 /* eslint-disable */
+import type { FieldError } from './FieldError';
 export type Error = {
     /**
      * Error message
      */
     error: string;
+    /**
+     * Machine-readable reason, set for errors clients are expected to handle:
+     * OUT_OF_SCOPE means a file or folder ID is not in the Grant Tracker Shared Drive
+     * (or does not exist), or, for changes, not inside the Grants or root folder, and
+     * the server refused to touch it. VALIDATION_FAILED means
+     * the data broke the sheet's field schema; see `fields`. BATCH_TOO_LARGE means the
+     * request had more items than the server accepts at once; see `limit`.
+     * EXPORT_TOO_LARGE means Drive refused an export over its 10MB limit.
+     * CSRF_FAILED means the X-CSRF-Token header was missing or didn't match the
+     * gt_csrf cookie. RESYNC_REQUIRED means a Drive changes token has expired and
+     * the client should reload everything.
+     */
+    code?: string;
+    /**
+     * The largest batch the server accepts (BATCH_TOO_LARGE only)
+     */
+    limit?: number;
+    /**
+     * Field-level problems (VALIDATION_FAILED only)
+     */
+    fields?: Array<FieldError>;
 };
 
@@ -0,0 +1,19 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type ExportGrantRequest = {
+    /**
+     * Sheet holding the grant (defaults to Grants)
+     */
+    sheet?: string;
+    /**
+     * Column name containing the unique ID
+     */
+    idColumn: string;
+    /**
+     * Grant ID, also used to locate the grant's Drive folder
+     */
+    id: string;
+};
+
@@ -0,0 +1,17 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { FileInfo } from './FileInfo';
+export type ExportGrantResponse = {
+    /**
+     * The grant's row as key-value pairs keyed by column header
+     */
+    row: Record<string, any>;
+    folder?: FileInfo;
+    /**
+     * Every file under the grant folder; path holds the subfolders between the grant folder and the file
+     */
+    files: Array<FileInfo>;
+};
+
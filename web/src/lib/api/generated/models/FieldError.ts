@@ -0,0 +1,16 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type FieldError = {
+    /**
+     * Column the problem is in, as sent
+     */
+    field: string;
+    message: string;
+    /**
+     * 1-based position of the row in the request (batch appends only)
+     */
+    row?: number;
+};
+
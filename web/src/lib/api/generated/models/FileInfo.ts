@@ -2,6 +2,7 @@
 /* istanbul ignore file */
 /* tslint:disable */
 /* eslint-disable */
+import type { Breadcrumb } from './Breadcrumb';
 import type { ShortcutDetails } from './ShortcutDetails';
 export type FileInfo = {
     /**
@@ -25,5 +26,15 @@ export type FileInfo = {
      */
     webViewLink?: string;
     shortcutDetails?: ShortcutDetails;
+    /**
+     * Email of the user who created the file through this app. Drive itself attributes
+     * these files to the service account. Absent for files created elsewhere, or when the
+     * server sets CREATED_BY_ATTRIBUTION=false.
+     */
+    createdBy?: string;
+    /**
+     * Ancestor folders, outermost first (only when includePath is set)
+     */
+    path?: Array<Breadcrumb>;
 };
 
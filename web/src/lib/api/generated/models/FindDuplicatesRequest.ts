@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type FindDuplicatesRequest = {
+    /**
+     * Sheet name
+     */
+    sheet: string;
+    /**
+     * Column whose value should be unique per row
+     */
+    keyColumn: string;
+};
+
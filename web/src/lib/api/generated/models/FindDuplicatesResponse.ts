@@ -0,0 +1,16 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { DuplicateGroup } from './DuplicateGroup';
+export type FindDuplicatesResponse = {
+    /**
+     * One entry per key held by more than one row, in sheet order
+     */
+    groups: Array<DuplicateGroup>;
+    /**
+     * Total number of rows across all groups
+     */
+    duplicateRows: number;
+};
+
@@ -0,0 +1,20 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { RowFilter } from './RowFilter';
+export type FindRowsRequest = {
+    /**
+     * Sheet name
+     */
+    sheet: string;
+    /**
+     * Conditions a row must meet, all of which must hold
+     */
+    filters: Array<RowFilter>;
+    /**
+     * Columns to return, in this order (default every column)
+     */
+    columns?: Array<string>;
+};
+
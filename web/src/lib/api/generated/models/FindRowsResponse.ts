@@ -0,0 +1,16 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { FoundRow } from './FoundRow';
+export type FindRowsResponse = {
+    /**
+     * The returned columns, in the order of each row's values
+     */
+    headers: Array<string>;
+    /**
+     * Matching rows in sheet order
+     */
+    rows: Array<FoundRow>;
+};
+
@@ -0,0 +1,12 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type FolderAccess = {
+    hasAccess: boolean;
+    /**
+     * The caller's highest Drive role on the folder, when they have access
+     */
+    role?: string;
+};
+
@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type FoundRow = {
+    /**
+     * 1-based sheet row number
+     */
+    row: number;
+    /**
+     * Cell values, one per header (missing trailing cells are null)
+     */
+    values: Array<any>;
+};
+
@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type GetFilePathRequest = {
+    /**
+     * ID of the file to place
+     */
+    fileId: string;
+    /**
+     * If the file is a shortcut, return where its target lives instead
+     */
+    followShortcut?: boolean;
+};
+
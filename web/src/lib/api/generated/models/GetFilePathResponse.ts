@@ -0,0 +1,13 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { Breadcrumb } from './Breadcrumb';
+export type GetFilePathResponse = {
+    /**
+     * Ancestor folders, outermost (the Grants or root folder) first
+     */
+    path: Array<Breadcrumb>;
+    file: Breadcrumb;
+};
+
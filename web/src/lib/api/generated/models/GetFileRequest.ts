@@ -7,5 +7,9 @@ export type GetFileRequest = {
      * ID of the file to get
      */
     fileId: string;
+    /**
+     * Also return the folder path from the Grants folder (or root) down to the file
+     */
+    includePath?: boolean;
 };
 
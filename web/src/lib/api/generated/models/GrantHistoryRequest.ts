@@ -0,0 +1,23 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type GrantHistoryRequest = {
+    /**
+     * Column name containing the grant ID
+     */
+    idColumn: string;
+    /**
+     * Grant ID
+     */
+    id: string;
+    /**
+     * Sheet the grant rows live on; defaults to Grants
+     */
+    sheet?: string;
+    /**
+     * Maximum number of entries to return
+     */
+    limit?: number;
+};
+
@@ -0,0 +1,21 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { FileInfo } from './FileInfo';
+export type GrantManifest = {
+    /**
+     * The grant ID from the folder's appProperties, or the folder name for untagged folders
+     */
+    grantId: string;
+    folder: FileInfo;
+    /**
+     * Every file under the grant folder; path holds the subfolders between the grant folder and the file
+     */
+    files: Array<FileInfo>;
+    /**
+     * Why the folder couldn't be walked; files is empty when set
+     */
+    error?: string;
+};
+
@@ -0,0 +1,19 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type GrantPermalinkResponse = {
+    /**
+     * Link to the grant's detail view in the app
+     */
+    url: string;
+    /**
+     * The grant's Drive folder, if it has one
+     */
+    folderId?: string;
+    /**
+     * webViewLink of the grant's Drive folder, if it has one
+     */
+    folderUrl?: string;
+};
+
@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type GrantsSummary = {
+    /**
+     * Number of grants
+     */
+    total: number;
+    /**
+     * Grant count per status
+     */
+    byStatus: Record<string, number>;
+};
+
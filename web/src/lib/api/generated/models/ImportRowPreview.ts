@@ -0,0 +1,29 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type ImportRowPreview = {
+    /**
+     * Key column value of the incoming row
+     */
+    key: string;
+    /**
+     * What importing this row would do
+     */
+    action: ImportRowPreview.action;
+    /**
+     * Columns whose values would change (updates only)
+     */
+    changedColumns?: Array<string>;
+};
+export namespace ImportRowPreview {
+    /**
+     * What importing this row would do
+     */
+    export enum action {
+        INSERT = 'insert',
+        UPDATE = 'update',
+        UNCHANGED = 'unchanged',
+    }
+}
+
@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type ListChangesRequest = {
+    /**
+     * Token from a previous response; omit to get a starting token without changes
+     */
+    pageToken?: string;
+    /**
+     * Maximum changes to read from Drive per call (defaults to the server's DRIVE_LIST_PAGE_SIZE)
+     */
+    pageSize?: number;
+};
+
@@ -0,0 +1,17 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { DriveChange } from './DriveChange';
+export type ListChangesResponse = {
+    changes: Array<DriveChange>;
+    /**
+     * Token to store and pass as pageToken next time
+     */
+    nextPageToken: string;
+    /**
+     * Whether more changes are waiting; call again with nextPageToken now
+     */
+    hasMore: boolean;
+};
+
@@ -8,8 +8,24 @@ export type ListFilesRequest = {
      */
     folderId?: string;
     /**
-     * Additional Drive API query filter
+     * Only files with exactly this name
      */
-    query?: string;
+    name?: string;
+    /**
+     * Only files whose name contains this text
+     */
+    nameContains?: string;
+    /**
+     * Only files of this MIME type
+     */
+    mimeType?: string;
+    /**
+     * Maximum files to return (defaults to the server's DRIVE_LIST_PAGE_SIZE, 1000 unless set)
+     */
+    pageSize?: number;
+    /**
+     * nextPageToken from a previous response
+     */
+    pageToken?: string;
 };
 
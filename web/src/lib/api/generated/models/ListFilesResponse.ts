@@ -3,7 +3,9 @@
 /* tslint:disable */
 /* eslint-disable */
 import type { FileInfo } from './FileInfo';
+import type { PageInfo } from './PageInfo';
 export type ListFilesResponse = {
     files: Array<FileInfo>;
+    pageInfo: PageInfo;
 };
 
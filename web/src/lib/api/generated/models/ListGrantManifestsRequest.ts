@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type ListGrantManifestsRequest = {
+    /**
+     * Maximum grant folders to return (default 10)
+     */
+    pageSize?: number;
+    /**
+     * nextPageToken from a previous response
+     */
+    pageToken?: string;
+};
+
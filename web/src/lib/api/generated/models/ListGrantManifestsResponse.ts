@@ -0,0 +1,11 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { GrantManifest } from './GrantManifest';
+import type { PageInfo } from './PageInfo';
+export type ListGrantManifestsResponse = {
+    grants: Array<GrantManifest>;
+    pageInfo: PageInfo;
+};
+
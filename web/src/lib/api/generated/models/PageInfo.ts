@@ -0,0 +1,30 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Pagination metadata shared by all paginated endpoints
+ */
+export type PageInfo = {
+    /**
+     * Total number of items, when known
+     */
+    total?: number;
+    /**
+     * Index of the first item in this page, when the source supports offsets
+     */
+    offset?: number;
+    /**
+     * Maximum number of items in this page
+     */
+    limit: number;
+    /**
+     * Token to pass as pageToken to fetch the next page (absent on the last page)
+     */
+    nextPageToken?: string;
+    /**
+     * Whether more items follow this page
+     */
+    hasMore: boolean;
+};
+
@@ -0,0 +1,31 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type Permission = {
+    /**
+     * Drive permission ID
+     */
+    id: string;
+    /**
+     * Drive role (reader, commenter, writer, fileOrganizer, organizer, owner)
+     */
+    role: string;
+    /**
+     * Grantee type (user, group, domain, anyone)
+     */
+    type: string;
+    /**
+     * Grantee email for user and group permissions (masked unless exposed)
+     */
+    emailAddress?: string;
+    /**
+     * Grantee domain for domain permissions
+     */
+    domain?: string;
+    /**
+     * Grantee display name
+     */
+    displayName?: string;
+};
+
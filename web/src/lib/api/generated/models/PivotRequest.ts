@@ -0,0 +1,39 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type PivotRequest = {
+    /**
+     * Sheet name
+     */
+    sheet: string;
+    /**
+     * Columns whose values label the pivot rows
+     */
+    rows: Array<string>;
+    /**
+     * Columns whose values label the pivot columns (omit for a single column)
+     */
+    cols?: Array<string>;
+    /**
+     * Column to aggregate (not needed for count)
+     */
+    value?: string;
+    /**
+     * Aggregation applied to each cell. Non-numeric values are skipped except by count.
+     */
+    agg: PivotRequest.agg;
+};
+export namespace PivotRequest {
+    /**
+     * Aggregation applied to each cell. Non-numeric values are skipped except by count.
+     */
+    export enum agg {
+        COUNT = 'count',
+        SUM = 'sum',
+        AVG = 'avg',
+        MIN = 'min',
+        MAX = 'max',
+    }
+}
+
@@ -0,0 +1,19 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type PivotResponse = {
+    /**
+     * One entry per pivot row, holding that row's values of the `rows` columns
+     */
+    rowKeys: Array<Array<string>>;
+    /**
+     * One entry per pivot column, holding that column's values of the `cols` columns
+     */
+    colKeys: Array<Array<string>>;
+    /**
+     * values[i][j] aggregates rowKeys[i] × colKeys[j] (null when no rows match)
+     */
+    values: Array<Array<number | null>>;
+};
+
@@ -0,0 +1,19 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type PreviewImportRequest = {
+    /**
+     * Sheet name
+     */
+    sheet: string;
+    /**
+     * Column used to match incoming rows to existing rows
+     */
+    keyColumn: string;
+    /**
+     * Incoming rows as key-value pairs where keys match column headers
+     */
+    rows: Array<Record<string, any>>;
+};
+
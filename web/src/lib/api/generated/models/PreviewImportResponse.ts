@@ -0,0 +1,24 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { ImportRowPreview } from './ImportRowPreview';
+export type PreviewImportResponse = {
+    /**
+     * Classification of each incoming row, in request order
+     */
+    rows: Array<ImportRowPreview>;
+    /**
+     * Number of rows that would be appended
+     */
+    inserts: number;
+    /**
+     * Number of existing rows that would change
+     */
+    updates: number;
+    /**
+     * Number of rows that already match the sheet
+     */
+    unchanged: number;
+};
+
@@ -0,0 +1,19 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type ProvisionGrantFolderRequest = {
+    /**
+     * Name of the grant folder, usually the grant ID
+     */
+    grantName: string;
+    /**
+     * Metadata written into the tracker doc (ID, Title, Organization, Amount, Status, Year)
+     */
+    grant?: Record<string, string>;
+    /**
+     * Subfolders to create instead of the configured layout
+     */
+    subfolders?: Array<string>;
+};
+
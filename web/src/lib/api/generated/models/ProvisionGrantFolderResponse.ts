@@ -0,0 +1,25 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { WorkspaceFolder } from './WorkspaceFolder';
+export type ProvisionGrantFolderResponse = {
+    /**
+     * Grant folder ID
+     */
+    folderId: string;
+    /**
+     * URL to view the grant folder
+     */
+    folderUrl: string;
+    subfolders: Array<WorkspaceFolder>;
+    /**
+     * Tracker doc ID
+     */
+    trackerDocId: string;
+    /**
+     * URL to edit the tracker doc
+     */
+    trackerDocUrl: string;
+};
+
@@ -0,0 +1,11 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type PurgeAuthCacheRequest = {
+    /**
+     * User whose cached access to clear; omit to clear everyone's
+     */
+    email?: string;
+};
+
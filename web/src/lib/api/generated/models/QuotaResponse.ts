@@ -0,0 +1,23 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type QuotaResponse = {
+    /**
+     * Length of the rolling window the counts cover
+     */
+    windowSeconds: number;
+    /**
+     * Google API calls made in the window, retries included
+     */
+    calls: number;
+    /**
+     * Calls in the window rejected with 429
+     */
+    quotaErrors: number;
+    /**
+     * When the most recent 429 was seen, however long ago
+     */
+    lastQuotaErrorAt?: string;
+};
+
@@ -0,0 +1,13 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type RangeData = {
+    headers: Array<string>;
+    rows: Array<Array<any>>;
+    /**
+     * Sheet column letter for each header
+     */
+    columns: Record<string, string>;
+};
+
@@ -4,12 +4,77 @@
 /* eslint-disable */
 export type ReadSheetRequest = {
     /**
-     * Sheet name (e.g., 'Grants', 'ActionItems')
+     * Sheet name (e.g., 'Grants', 'ActionItems'); required unless ranges is set
      */
-    sheet: string;
+    sheet?: string;
+    /**
+     * Read several ranges in one call instead of sheet/range, each naming its sheet
+     * (e.g. 'Grants', 'Orgs!A1:D'). Results are returned in `ranges`, keyed by the range
+     * as sent. Paging, normalizeMergedCells, and the replica fallback apply only to single reads.
+     */
+    ranges?: Array<string>;
     /**
      * Optional range (e.g., 'A1:Z')
      */
     range?: string;
+    /**
+     * Fill every cell of a merged range with the merge's value so rows stay aligned with headers
+     */
+    normalizeMergedCells?: boolean;
+    /**
+     * Return only rows whose owner column (Owner, or the server's OWNER_COLUMN) holds the
+     * signed-in user's email, compared case-insensitively. Applied before paging, so
+     * offset, limit, and page tokens count the user's rows only. Works even when the owner column is
+     * sensitive and hidden from the caller. Not available with ranges.
+     */
+    mine?: boolean;
+    /**
+     * Return rows in `objects`, each keyed by header, instead of as arrays in `rows`
+     * (which is then empty). Cells missing from short rows are "". Columns with an
+     * empty header are left out, and a repeated header keeps its first column's value.
+     * Not available with ranges.
+     */
+    asObjects?: boolean;
+    /**
+     * How cell values are returned: raw values for computation (UNFORMATTED), strings
+     * as displayed in the sheet, such as "$1,200.00" (FORMATTED), or formulas (FORMULA).
+     */
+    rendering?: ReadSheetRequest.rendering;
+    /**
+     * How dates and times are returned when rendering isn't FORMATTED: as spreadsheet
+     * serial numbers (days since 1899-12-30) or as strings in the cell's number format.
+     */
+    dateTimeRendering?: ReadSheetRequest.dateTimeRendering;
+    /**
+     * Number of data rows to skip
+     */
+    offset?: number;
+    /**
+     * Maximum data rows to return (default all)
+     */
+    limit?: number;
+    /**
+     * nextPageToken from a previous response (takes precedence over offset)
+     */
+    pageToken?: string;
 };
+export namespace ReadSheetRequest {
+    /**
+     * How cell values are returned: raw values for computation (UNFORMATTED), strings
+     * as displayed in the sheet, such as "$1,200.00" (FORMATTED), or formulas (FORMULA).
+     */
+    export enum rendering {
+        UNFORMATTED = 'UNFORMATTED',
+        FORMATTED = 'FORMATTED',
+        FORMULA = 'FORMULA',
+    }
+    /**
+     * How dates and times are returned when rendering isn't FORMATTED: as spreadsheet
+     * serial numbers (days since 1899-12-30) or as strings in the cell's number format.
+     */
+    export enum dateTimeRendering {
+        SERIAL_NUMBER = 'SERIAL_NUMBER',
+        FORMATTED_STRING = 'FORMATTED_STRING',
+    }
+}
 
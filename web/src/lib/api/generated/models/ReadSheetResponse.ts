@@ -2,6 +2,8 @@
 /* istanbul ignore file */
 /* tslint:disable */
 /* eslint-disable */
+import type { PageInfo } from './PageInfo';
+import type { RangeData } from './RangeData';
 export type ReadSheetResponse = {
     /**
      * Column headers from first row
@@ -11,5 +13,22 @@ export type ReadSheetResponse = {
      * Data rows (excluding header row)
      */
     rows: Array<Array<any>>;
+    /**
+     * Sheet column letter for each header, for building A1 ranges
+     */
+    columns?: Record<string, string>;
+    pageInfo: PageInfo;
+    /**
+     * True when the primary spreadsheet was unavailable and data came from the read-only replica
+     */
+    stale?: boolean;
+    /**
+     * Per-range results for a multi-range read, keyed by the requested range; headers and rows are then empty
+     */
+    ranges?: Record<string, RangeData>;
+    /**
+     * Data rows keyed by header, when the request set asObjects
+     */
+    objects?: Array<Record<string, any>>;
 };
 
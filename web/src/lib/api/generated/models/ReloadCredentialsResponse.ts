@@ -0,0 +1,19 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type ReloadCredentialsResponse = {
+    /**
+     * Where the key was read from
+     */
+    source: string;
+    /**
+     * Service account the new key belongs to
+     */
+    clientEmail: string;
+    /**
+     * Whether discovery re-ran (false when ROOT_FOLDER_ID is unset)
+     */
+    rediscovered: boolean;
+};
+
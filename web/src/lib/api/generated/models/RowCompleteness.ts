@@ -0,0 +1,27 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type RowCompleteness = {
+    /**
+     * The row's value in idColumn
+     */
+    id: string;
+    /**
+     * Required columns with a non-blank value
+     */
+    filled: number;
+    /**
+     * Number of required columns
+     */
+    required: number;
+    /**
+     * filled as a percentage of required
+     */
+    percent: number;
+    /**
+     * Required columns left blank
+     */
+    missing: Array<string>;
+};
+
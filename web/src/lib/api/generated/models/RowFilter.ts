@@ -0,0 +1,31 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type RowFilter = {
+    /**
+     * Column to test
+     */
+    column: string;
+    /**
+     * eq matches the exact cell text, contains matches a case-insensitive substring,
+     * and gt matches numeric cells greater than value (non-numeric cells never match)
+     */
+    op: RowFilter.op;
+    /**
+     * Value to compare against (a number for gt)
+     */
+    value: any;
+};
+export namespace RowFilter {
+    /**
+     * eq matches the exact cell text, contains matches a case-insensitive substring,
+     * and gt matches numeric cells greater than value (non-numeric cells never match)
+     */
+    export enum op {
+        EQ = 'eq',
+        CONTAINS = 'contains',
+        GT = 'gt',
+    }
+}
+
@@ -0,0 +1,23 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type SearchFilesRequest = {
+    /**
+     * Text to look for
+     */
+    query: string;
+    /**
+     * Match file content as well as names. The tracker spreadsheets are never returned.
+     */
+    fullText?: boolean;
+    /**
+     * Maximum files to read from Drive per page (defaults to the server's DRIVE_LIST_PAGE_SIZE)
+     */
+    pageSize?: number;
+    /**
+     * nextPageToken from a previous response
+     */
+    pageToken?: string;
+};
+
@@ -0,0 +1,14 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { FileInfo } from './FileInfo';
+import type { PageInfo } from './PageInfo';
+export type SearchFilesResponse = {
+    /**
+     * Matches, each with `path` set
+     */
+    files: Array<FileInfo>;
+    pageInfo: PageInfo;
+};
+
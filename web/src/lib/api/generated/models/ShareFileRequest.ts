@@ -0,0 +1,32 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type ShareFileRequest = {
+    /**
+     * File or folder ID
+     */
+    fileId: string;
+    /**
+     * Email of the user to share with
+     */
+    emailAddress: string;
+    /**
+     * Access to grant
+     */
+    role: ShareFileRequest.role;
+    /**
+     * Have Drive email the user about the share
+     */
+    sendNotificationEmail?: boolean;
+};
+export namespace ShareFileRequest {
+    /**
+     * Access to grant
+     */
+    export enum role {
+        READER = 'reader',
+        WRITER = 'writer',
+    }
+}
+
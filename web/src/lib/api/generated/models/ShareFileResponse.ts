@@ -0,0 +1,19 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type ShareFileResponse = {
+    /**
+     * ID of the user's permission on the file
+     */
+    permissionId: string;
+    /**
+     * The user's role now
+     */
+    role: string;
+    /**
+     * Whether a new permission was created, as opposed to an existing one updated or left as it was
+     */
+    created: boolean;
+};
+
@@ -0,0 +1,22 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type SheetInfo = {
+    /**
+     * Numeric sheet ID (stable across renames)
+     */
+    sheetId: number;
+    /**
+     * Tab name, as used in the sheet field of other requests
+     */
+    title: string;
+    rowCount: number;
+    columnCount: number;
+    frozenRowCount: number;
+    /**
+     * 1-based row the server reads headers from (see HEADER_ROWS)
+     */
+    headerRow: number;
+};
+
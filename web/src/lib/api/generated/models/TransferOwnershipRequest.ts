@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type TransferOwnershipRequest = {
+    /**
+     * File or folder ID
+     */
+    fileId: string;
+    /**
+     * Email of the user who becomes the owner
+     */
+    newOwnerEmail: string;
+};
+
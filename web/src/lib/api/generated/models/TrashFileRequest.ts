@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type TrashFileRequest = {
+    /**
+     * ID of the file to remove
+     */
+    fileId: string;
+    /**
+     * Delete the file outright instead of moving it to the trash (admins only)
+     */
+    permanent?: boolean;
+};
+
@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type UpdateCondition = {
+    /**
+     * Column to check
+     */
+    column: string;
+    /**
+     * Value the column must currently hold (compared like expectedValues, so 5000 matches 5,000.00)
+     */
+    equals: string;
+};
+
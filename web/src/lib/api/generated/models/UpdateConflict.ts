@@ -0,0 +1,20 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type UpdateConflict = {
+    error: string;
+    /**
+     * Always CONFLICT
+     */
+    code?: string;
+    /**
+     * The expected columns whose values no longer match
+     */
+    columns?: Array<string>;
+    /**
+     * The row as it is now, keyed by header, without the sensitive columns the caller may not read
+     */
+    current: Record<string, any>;
+};
+
@@ -16,8 +16,22 @@ export type UpdateRowRequest = {
      */
     id: string;
     /**
-     * Fields to update as key-value pairs
+     * Fields to update as key-value pairs. Headers that appear more than once are handled
+     * as for appendRow: rejected, or addressed as "Name#N" when DUPLICATE_HEADERS=index.
      */
     data: Record<string, any>;
+    /**
+     * Read the written row back and fail with 422 (code VERIFY_FAILED) if Sheets coerced
+     * or dropped any value. The write itself is not undone.
+     */
+    verify?: boolean;
+    /**
+     * Optimistic concurrency check. The values the client last read for some columns of
+     * the row; if any of them has changed since, nothing is written and the request fails
+     * with 409 carrying the row's current values so the client can merge and retry.
+     * Numbers match their text form (5000 matches "5000"). Sensitive columns the caller
+     * may not read are refused with 403.
+     */
+    expectedValues?: Record<string, any>;
 };
 
@@ -0,0 +1,23 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type VersionInfo = {
+    /**
+     * Release version ("dev" for local builds)
+     */
+    version: string;
+    /**
+     * Git commit the binary was built from
+     */
+    gitCommit: string;
+    /**
+     * Build timestamp
+     */
+    buildTime: string;
+    /**
+     * Go runtime version
+     */
+    goVersion: string;
+};
+
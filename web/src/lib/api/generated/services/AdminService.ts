@@ -0,0 +1,222 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { BootstrapResponse } from '../models/BootstrapResponse';
+import type { CreateSpreadsheetTabRequest } from '../models/CreateSpreadsheetTabRequest';
+import type { CreateSpreadsheetTabResponse } from '../models/CreateSpreadsheetTabResponse';
+import type { ListGrantManifestsRequest } from '../models/ListGrantManifestsRequest';
+import type { ListGrantManifestsResponse } from '../models/ListGrantManifestsResponse';
+import type { ListPermissionsRequest } from '../models/ListPermissionsRequest';
+import type { ListPermissionsResponse } from '../models/ListPermissionsResponse';
+import type { PurgeAuthCacheRequest } from '../models/PurgeAuthCacheRequest';
+import type { PurgeAuthCacheResponse } from '../models/PurgeAuthCacheResponse';
+import type { ReloadCredentialsResponse } from '../models/ReloadCredentialsResponse';
+import type { ShareFileRequest } from '../models/ShareFileRequest';
+import type { ShareFileResponse } from '../models/ShareFileResponse';
+import type { SuccessResponse } from '../models/SuccessResponse';
+import type { TransferOwnershipRequest } from '../models/TransferOwnershipRequest';
+import type { CancelablePromise } from '../core/CancelablePromise';
+import { OpenAPI } from '../core/OpenAPI';
+import { request as __request } from '../core/request';
+export class AdminService {
+    /**
+     * List every grant folder's file manifest
+     * Walks each grant folder under the Grants folder and returns its file manifest, for
+     * auditing attachments across all grants. Pages cover grant folders, not files; pass
+     * pageInfo.nextPageToken back to continue. The response is streamed, so a folder that
+     * can't be read is reported in its entry's error rather than failing the request.
+     * The server walks at most MANIFEST_CONCURRENCY folders at once (default 4).
+     * @returns ListGrantManifestsResponse One page of grant manifests
+     * @throws ApiError
+     */
+    public static listGrantManifests({
+        requestBody,
+    }: {
+        requestBody: ListGrantManifestsRequest,
+    }): CancelablePromise<ListGrantManifestsResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/admin/grant-manifests',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                500: `Server error`,
+            },
+        });
+    }
+    /**
+     * Create missing spreadsheet tabs
+     * Creates the standard tabs (Grants, Orgs, AuditLog, Config) with their headers if they don't exist. Existing tabs are left untouched, so this is safe to call repeatedly.
+     * @returns BootstrapResponse Bootstrap result
+     * @throws ApiError
+     */
+    public static bootstrapSpreadsheet(): CancelablePromise<BootstrapResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/admin/bootstrap',
+            errors: {
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                500: `Server error`,
+            },
+        });
+    }
+    /**
+     * Create a spreadsheet tab
+     * Adds a tab with the given title and writes `headers` into row 1, which is frozen.
+     * Tab names are compared case-insensitively, as Google Sheets does; a name that is
+     * already taken returns 409 and nothing is created.
+     * @returns CreateSpreadsheetTabResponse The new tab
+     * @throws ApiError
+     */
+    public static createSpreadsheetTab({
+        requestBody,
+    }: {
+        requestBody: CreateSpreadsheetTabRequest,
+    }): CancelablePromise<CreateSpreadsheetTabResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/admin/sheets/create-tab',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                409: `A tab with this name already exists`,
+                500: `Server error`,
+            },
+        });
+    }
+    /**
+     * Clear cached access decisions
+     * Access checks are cached for five minutes and vouched for by capability tokens, so a
+     * removed Drive permission would otherwise keep working for a while. This clears the
+     * cache for one email (or everyone when `email` is omitted) and revokes the matching
+     * capabilities, so the next request re-checks Drive. Applies to this instance only.
+     * @returns PurgeAuthCacheResponse Cache cleared
+     * @throws ApiError
+     */
+    public static purgeAuthCache({
+        requestBody,
+    }: {
+        requestBody?: PurgeAuthCacheRequest,
+    }): CancelablePromise<PurgeAuthCacheResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/admin/auth-cache/purge',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+            },
+        });
+    }
+    /**
+     * Reload the service account key
+     * Re-reads GOOGLE_SERVICE_ACCOUNT_KEY or the GOOGLE_APPLICATION_CREDENTIALS file, rebuilds
+     * the Google API clients with the new key, and re-runs discovery, so a rotated key takes
+     * effect without a restart. A key that doesn't parse is rejected and the old one stays in
+     * use. Applies to this instance only.
+     * @returns ReloadCredentialsResponse Credentials reloaded
+     * @throws ApiError
+     */
+    public static reloadCredentials(): CancelablePromise<ReloadCredentialsResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/admin/reload-credentials',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                500: `Server error`,
+            },
+        });
+    }
+    /**
+     * List who has access to a file
+     * Returns every permission on a file or folder, following Drive's paging.
+     * Email addresses are masked unless the server sets EXPOSE_PERMISSION_EMAILS=true.
+     * @returns ListPermissionsResponse Permissions on the file
+     * @throws ApiError
+     */
+    public static listPermissions({
+        requestBody,
+    }: {
+        requestBody: ListPermissionsRequest,
+    }): CancelablePromise<ListPermissionsResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/admin/permissions',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                500: `Server error`,
+            },
+        });
+    }
+    /**
+     * Transfer ownership of a file
+     * Makes another user the owner of a file or folder in My Drive. Files in a
+     * Shared Drive are owned by the drive itself, so they are rejected with 400;
+     * manage their access with Shared Drive membership instead.
+     * @returns SuccessResponse Ownership transferred
+     * @throws ApiError
+     */
+    public static transferOwnership({
+        requestBody,
+    }: {
+        requestBody: TransferOwnershipRequest,
+    }): CancelablePromise<SuccessResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/admin/transfer-ownership',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                500: `Server error`,
+            },
+        });
+    }
+    /**
+     * Share a file or folder with a user
+     * Gives a user reader or writer access to a file or folder somewhere below the
+     * Grants folder, e.g. a reviewer on one grant's folder. Anything else, including the
+     * Grants folder itself and the spreadsheet, is refused with 403. A user who already
+     * has access has their role changed; owners and Shared Drive managers are left
+     * alone with 400.
+     * @returns ShareFileResponse Access granted
+     * @throws ApiError
+     */
+    public static shareFile({
+        requestBody,
+    }: {
+        requestBody: ShareFileRequest,
+    }): CancelablePromise<ShareFileResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/admin/share',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                404: `Resource not found`,
+                500: `Server error`,
+            },
+        });
+    }
+}
@@ -3,6 +3,8 @@
 /* tslint:disable */
 /* eslint-disable */
 import type { Config } from '../models/Config';
+import type { QuotaResponse } from '../models/QuotaResponse';
+import type { VersionInfo } from '../models/VersionInfo';
 import type { CancelablePromise } from '../core/CancelablePromise';
 import { OpenAPI } from '../core/OpenAPI';
 import { request as __request } from '../core/request';
@@ -19,4 +21,34 @@ export class ConfigService {
             url: '/config',
         });
     }
+    /**
+     * Get server version
+     * Returns the version, git commit, and build time of the running server
+     * @returns VersionInfo Build information
+     * @throws ApiError
+     */
+    public static getVersion(): CancelablePromise<VersionInfo> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/version',
+        });
+    }
+    /**
+     * Get recent Google API usage
+     * Reports how many Google API calls this server instance made in the last minute and how
+     * many were rejected with 429 (quota exceeded), so clients can slow down before their
+     * requests start failing. Counts are per instance and reset on restart.
+     * @returns QuotaResponse Recent usage
+     * @throws ApiError
+     */
+    public static getQuota(): CancelablePromise<QuotaResponse> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/quota',
+            errors: {
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+            },
+        });
+    }
 }
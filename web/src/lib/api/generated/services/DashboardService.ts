@@ -0,0 +1,28 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { DashboardResponse } from '../models/DashboardResponse';
+import type { CancelablePromise } from '../core/CancelablePromise';
+import { OpenAPI } from '../core/OpenAPI';
+import { request as __request } from '../core/request';
+export class DashboardService {
+    /**
+     * Get the dashboard payload
+     * Returns config, a grants summary, and recent Drive activity in one call.
+     * Sections are fetched concurrently; a section that fails is omitted and
+     * explained in `warnings` rather than failing the whole request.
+     * @returns DashboardResponse Dashboard payload
+     * @throws ApiError
+     */
+    public static getDashboard(): CancelablePromise<DashboardResponse> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/dashboard',
+            errors: {
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+            },
+        });
+    }
+}
@@ -2,18 +2,34 @@
 /* istanbul ignore file */
 /* tslint:disable */
 /* eslint-disable */
+import type { CheckFolderAccessRequest } from '../models/CheckFolderAccessRequest';
+import type { CheckFolderAccessResponse } from '../models/CheckFolderAccessResponse';
 import type { CreateDocRequest } from '../models/CreateDocRequest';
 import type { CreateDocResponse } from '../models/CreateDocResponse';
 import type { CreateFolderRequest } from '../models/CreateFolderRequest';
 import type { CreateFolderResponse } from '../models/CreateFolderResponse';
+import type { CreateGrantWorkspaceRequest } from '../models/CreateGrantWorkspaceRequest';
+import type { CreateGrantWorkspaceResponse } from '../models/CreateGrantWorkspaceResponse';
 import type { CreateShortcutRequest } from '../models/CreateShortcutRequest';
 import type { CreateShortcutResponse } from '../models/CreateShortcutResponse';
+import type { DownloadFileRequest } from '../models/DownloadFileRequest';
 import type { FileInfo } from '../models/FileInfo';
+import type { GetFilePathRequest } from '../models/GetFilePathRequest';
+import type { GetFilePathResponse } from '../models/GetFilePathResponse';
 import type { GetFileRequest } from '../models/GetFileRequest';
+import type { GrantPermalinkRequest } from '../models/GrantPermalinkRequest';
+import type { GrantPermalinkResponse } from '../models/GrantPermalinkResponse';
+import type { ListChangesRequest } from '../models/ListChangesRequest';
+import type { ListChangesResponse } from '../models/ListChangesResponse';
 import type { ListFilesRequest } from '../models/ListFilesRequest';
 import type { ListFilesResponse } from '../models/ListFilesResponse';
 import type { MoveFileRequest } from '../models/MoveFileRequest';
+import type { ProvisionGrantFolderRequest } from '../models/ProvisionGrantFolderRequest';
+import type { ProvisionGrantFolderResponse } from '../models/ProvisionGrantFolderResponse';
+import type { SearchFilesRequest } from '../models/SearchFilesRequest';
+import type { SearchFilesResponse } from '../models/SearchFilesResponse';
 import type { SuccessResponse } from '../models/SuccessResponse';
+import type { TrashFileRequest } from '../models/TrashFileRequest';
 import type { CancelablePromise } from '../core/CancelablePromise';
 import { OpenAPI } from '../core/OpenAPI';
 import { request as __request } from '../core/request';
@@ -42,6 +58,63 @@ export class DriveService {
             },
         });
     }
+    /**
+     * Create a grant workspace
+     * Creates a grant's folder under the Grants folder, tagged with its grant ID,
+     * and the standard subfolders inside it. The subfolder names come from the
+     * `grant_subfolders` key in the Config tab (a JSON array), falling back to
+     * the server's GRANT_SUBFOLDERS setting.
+     * @returns CreateGrantWorkspaceResponse Workspace created
+     * @throws ApiError
+     */
+    public static createGrantWorkspace({
+        requestBody,
+    }: {
+        requestBody: CreateGrantWorkspaceRequest,
+    }): CancelablePromise<CreateGrantWorkspaceResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/grants/workspace',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                500: `Server error`,
+            },
+        });
+    }
+    /**
+     * Provision a grant folder
+     * Sets up everything a new grant needs in one call: a folder named after the grant
+     * under the Grants folder, the standard subfolders inside it, and a tracker doc
+     * populated with the grant's metadata. Subfolders default to the same layout as
+     * /grants/workspace (the `grant_subfolders` Config key, then GRANT_SUBFOLDERS) unless
+     * the request lists its own. The tracker doc is a copy of the TRACKER_TEMPLATE_ID doc
+     * when that is set, or a blank doc otherwise.
+     * @returns ProvisionGrantFolderResponse Grant folder provisioned
+     * @throws ApiError
+     */
+    public static provisionGrantFolder({
+        requestBody,
+    }: {
+        requestBody: ProvisionGrantFolderRequest,
+    }): CancelablePromise<ProvisionGrantFolderResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/grants/provision',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                500: `Server error`,
+                503: `The Grants folder hasn't been discovered yet`,
+            },
+        });
+    }
     /**
      * Create a folder
      * Creates a new folder in Google Drive
@@ -116,7 +189,7 @@ export class DriveService {
     }
     /**
      * Move a file
-     * Moves a file to a different folder
+     * Moves a file to a different folder. The root and Grants folders and the tracker spreadsheets are refused with 403.
      * @returns SuccessResponse File moved successfully
      * @throws ApiError
      */
@@ -138,6 +211,121 @@ export class DriveService {
             },
         });
     }
+    /**
+     * Trash or delete a file
+     * Moves a file to the trash, where it can be restored from Drive for 30 days. With
+     * `permanent` set it is deleted outright instead. Only files somewhere below the
+     * Grants folder can be removed; anything else, including the Grants folder itself
+     * and the spreadsheet, is refused with 403. Trashing needs at least the writer role
+     * and permanent deletion the admin role. Refused in safe mode.
+     * @returns SuccessResponse File trashed or deleted
+     * @throws ApiError
+     */
+    public static trashFile({
+        requestBody,
+    }: {
+        requestBody: TrashFileRequest,
+    }): CancelablePromise<SuccessResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/drive/trash',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                404: `Resource not found`,
+                500: `Server error`,
+            },
+        });
+    }
+    /**
+     * Get a file's breadcrumbs
+     * Returns the folders from the Grants folder (or root folder) down to a file, for
+     * breadcrumb navigation. A shortcut is placed where the shortcut itself sits unless
+     * `followShortcut` is set, in which case its target's location is returned instead.
+     * Files outside the Grants and root folder trees are refused with 403 OUT_OF_SCOPE.
+     * @returns GetFilePathResponse The file's breadcrumbs
+     * @throws ApiError
+     */
+    public static getFilePath({
+        requestBody,
+    }: {
+        requestBody: GetFilePathRequest,
+    }): CancelablePromise<GetFilePathResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/drive/path',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                404: `Resource not found`,
+                500: `Server error`,
+            },
+        });
+    }
+    /**
+     * Search files by name or content
+     * Finds files anywhere in the Grants and root folder trees whose name (or, with
+     * `fullText`, content) contains the query, each with its folder path from the
+     * Grants or root folder down. Matches outside those trees are dropped after Drive
+     * returns them, so a page can hold fewer than `pageSize` files while `hasMore`
+     * is still set.
+     * @returns SearchFilesResponse Matching files
+     * @throws ApiError
+     */
+    public static searchFiles({
+        requestBody,
+    }: {
+        requestBody: SearchFilesRequest,
+    }): CancelablePromise<SearchFilesResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/drive/search',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                500: `Server error`,
+            },
+        });
+    }
+    /**
+     * List files changed since a sync token
+     * Incremental sync over the Drive Changes API. Call without `pageToken` to get a
+     * starting token, store it, and later pass it back to get the files changed since,
+     * limited to the Grants and root folder trees. Always store the returned
+     * `nextPageToken`; when `hasMore` is set, call again right away to drain the rest.
+     * A token Drive no longer accepts answers 410 with RESYNC_REQUIRED, meaning the
+     * client should reload everything and start over without a token.
+     * @returns ListChangesResponse Changes since the token
+     * @throws ApiError
+     */
+    public static listChanges({
+        requestBody,
+    }: {
+        requestBody: ListChangesRequest,
+    }): CancelablePromise<ListChangesResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/drive/changes',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                410: `The token is no longer valid; do a full resync (code RESYNC_REQUIRED)`,
+                500: `Server error`,
+            },
+        });
+    }
     /**
      * Get file metadata
      * Gets metadata for a specific file
@@ -163,4 +351,87 @@ export class DriveService {
             },
         });
     }
+    /**
+     * Download a file's content
+     * Streams a file's content. Uploaded files are sent as stored, with their Drive MIME
+     * type and size. Google Docs, Sheets, and Slides have no content of their own, so
+     * `mimeType` must name a format to export them as (for example application/pdf);
+     * Drive caps exports at 10MB and larger ones get 422 with code EXPORT_TOO_LARGE.
+     * Files are sent as attachments unless `inline` is set and the type is safe to
+     * preview in the browser (images, PDF, plain text).
+     * @returns Blob File content
+     * @throws ApiError
+     */
+    public static downloadFile({
+        requestBody,
+    }: {
+        requestBody: DownloadFileRequest,
+    }): CancelablePromise<Blob> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/drive/download',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                404: `Resource not found`,
+                422: `Export exceeds Drive's export size limit`,
+                500: `Server error`,
+            },
+        });
+    }
+    /**
+     * Check access to several folders
+     * Reports the caller's access to each folder. Results are cached like the per-request
+     * access check, and uncached folders are checked at most ACCESS_CHECK_CONCURRENCY
+     * (default 4) at a time.
+     * @returns CheckFolderAccessResponse Access per folder
+     * @throws ApiError
+     */
+    public static checkFolderAccess({
+        requestBody,
+    }: {
+        requestBody: CheckFolderAccessRequest,
+    }): CancelablePromise<CheckFolderAccessResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/drive/access',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                500: `Server error`,
+            },
+        });
+    }
+    /**
+     * Get a shareable link to a grant
+     * Returns the app URL of the grant's detail view, built from PUBLIC_URL (or, when unset,
+     * the request's origin if ALLOWED_ORIGIN lists it; otherwise 500), plus the grant's
+     * Drive folder when one exists.
+     * @returns GrantPermalinkResponse Grant links
+     * @throws ApiError
+     */
+    public static getGrantPermalink({
+        requestBody,
+    }: {
+        requestBody: GrantPermalinkRequest,
+    }): CancelablePromise<GrantPermalinkResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/grants/permalink',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                500: `Server error`,
+            },
+        });
+    }
 }
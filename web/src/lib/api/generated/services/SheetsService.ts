@@ -3,19 +3,63 @@
 /* tslint:disable */
 /* eslint-disable */
 import type { AppendRowRequest } from '../models/AppendRowRequest';
+import type { AppendRowResponse } from '../models/AppendRowResponse';
+import type { AuditListRequest } from '../models/AuditListRequest';
+import type { AuditListResponse } from '../models/AuditListResponse';
+import type { AutoResizeRequest } from '../models/AutoResizeRequest';
+import type { BatchAppendRowsRequest } from '../models/BatchAppendRowsRequest';
+import type { BatchAppendRowsResponse } from '../models/BatchAppendRowsResponse';
 import type { BatchUpdateRequest } from '../models/BatchUpdateRequest';
+import type { BatchUpdateResponse } from '../models/BatchUpdateResponse';
+import type { CompletenessRequest } from '../models/CompletenessRequest';
+import type { CompletenessResponse } from '../models/CompletenessResponse';
+import type { ConditionalUpdateRequest } from '../models/ConditionalUpdateRequest';
 import type { DeleteRowRequest } from '../models/DeleteRowRequest';
+import type { DeleteRowResponse } from '../models/DeleteRowResponse';
+import type { DeleteRowsResponse } from '../models/DeleteRowsResponse';
+import type { DeleteRowsWhereRequest } from '../models/DeleteRowsWhereRequest';
+import type { ExportGrantRequest } from '../models/ExportGrantRequest';
+import type { ExportGrantResponse } from '../models/ExportGrantResponse';
+import type { FindDuplicatesRequest } from '../models/FindDuplicatesRequest';
+import type { FindDuplicatesResponse } from '../models/FindDuplicatesResponse';
+import type { FindRowsRequest } from '../models/FindRowsRequest';
+import type { FindRowsResponse } from '../models/FindRowsResponse';
+import type { GrantHistoryRequest } from '../models/GrantHistoryRequest';
+import type { GrantHistoryResponse } from '../models/GrantHistoryResponse';
+import type { PivotRequest } from '../models/PivotRequest';
+import type { PivotResponse } from '../models/PivotResponse';
+import type { PreviewImportRequest } from '../models/PreviewImportRequest';
+import type { PreviewImportResponse } from '../models/PreviewImportResponse';
 import type { ReadSheetRequest } from '../models/ReadSheetRequest';
 import type { ReadSheetResponse } from '../models/ReadSheetResponse';
+import type { SheetMetadataResponse } from '../models/SheetMetadataResponse';
 import type { SuccessResponse } from '../models/SuccessResponse';
 import type { UpdateRowRequest } from '../models/UpdateRowRequest';
 import type { CancelablePromise } from '../core/CancelablePromise';
 import { OpenAPI } from '../core/OpenAPI';
 import { request as __request } from '../core/request';
 export class SheetsService {
+    /**
+     * List the spreadsheet's sheets
+     * Returns every tab in the spreadsheet with its ID, grid size, and frozen rows
+     * @returns SheetMetadataResponse Sheets in tab order
+     * @throws ApiError
+     */
+    public static getSheetMetadata(): CancelablePromise<SheetMetadataResponse> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/sheets/metadata',
+            errors: {
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                500: `Server error`,
+            },
+        });
+    }
     /**
      * Read data from a sheet
-     * Reads all data from a sheet, returning headers and rows separately
+     * Reads all data from a sheet, returning headers and rows separately. A sheet that exists
+     * but is empty returns 200 with empty `headers` and `rows`; a sheet that doesn't exist is a 404.
      * @returns ReadSheetResponse Sheet data
      * @throws ApiError
      */
@@ -33,6 +77,7 @@ export class SheetsService {
                 400: `Invalid request`,
                 401: `Not authenticated`,
                 403: `Access denied (no permission to grants folder)`,
+                404: `The spreadsheet has no sheet with this name`,
                 500: `Server error`,
             },
         });
@@ -40,14 +85,14 @@ export class SheetsService {
     /**
      * Append a row to a sheet
      * Appends a new row to the end of a sheet
-     * @returns SuccessResponse Row appended successfully
+     * @returns AppendRowResponse Row appended successfully
      * @throws ApiError
      */
     public static appendRow({
         requestBody,
     }: {
         requestBody: AppendRowRequest,
-    }): CancelablePromise<SuccessResponse> {
+    }): CancelablePromise<AppendRowResponse> {
         return __request(OpenAPI, {
             method: 'POST',
             url: '/sheets/append',
@@ -57,6 +102,35 @@ export class SheetsService {
                 400: `Invalid request`,
                 401: `Not authenticated`,
                 403: `Access denied (no permission to grants folder)`,
+                404: `The spreadsheet has no sheet with this name`,
+                422: `The row was written but did not read back as sent (only with verify)`,
+                500: `Server error`,
+            },
+        });
+    }
+    /**
+     * Append several rows to a sheet
+     * Appends rows in one Sheets call, for bulk imports. All-or-nothing: every row is
+     * validated before anything is written, and the rows go in with a single append,
+     * so either all of them are added or an error is returned and none are.
+     * @returns BatchAppendRowsResponse Rows appended successfully
+     * @throws ApiError
+     */
+    public static batchAppendRows({
+        requestBody,
+    }: {
+        requestBody: BatchAppendRowsRequest,
+    }): CancelablePromise<BatchAppendRowsResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/sheets/batch-append',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                404: `The spreadsheet has no sheet with this name`,
                 500: `Server error`,
             },
         });
@@ -82,21 +156,53 @@ export class SheetsService {
                 401: `Not authenticated`,
                 403: `Access denied (no permission to grants folder)`,
                 404: `Resource not found`,
+                409: `The row no longer has the values in expectedValues; nothing was written`,
+                422: `The row was written but did not read back as sent (only with verify)`,
+                500: `Server error`,
+            },
+        });
+    }
+    /**
+     * Update a row only if a column has an expected value
+     * Compare-and-set on any column: applies the update only when the row's
+     * `condition.column` currently equals `condition.equals`, e.g. set
+     * status=Paid only if status is currently Approved. Returns 409 otherwise.
+     * @returns SuccessResponse Row updated successfully
+     * @throws ApiError
+     */
+    public static conditionalUpdate({
+        requestBody,
+    }: {
+        requestBody: ConditionalUpdateRequest,
+    }): CancelablePromise<SuccessResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/sheets/conditional-update',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                404: `Resource not found`,
+                409: `The condition column no longer holds the expected value; nothing was written`,
                 500: `Server error`,
             },
         });
     }
     /**
      * Delete a row from a sheet
-     * Deletes a row identified by an ID column value
-     * @returns SuccessResponse Row deleted successfully
+     * Deletes the first row whose ID column equals `id`. With `matchAll`, every
+     * matching row is deleted in a single batch, bottom to top so earlier
+     * deletions don't shift later ones.
+     * @returns DeleteRowResponse Row deleted successfully
      * @throws ApiError
      */
     public static deleteRow({
         requestBody,
     }: {
         requestBody: DeleteRowRequest,
-    }): CancelablePromise<SuccessResponse> {
+    }): CancelablePromise<DeleteRowResponse> {
         return __request(OpenAPI, {
             method: 'POST',
             url: '/sheets/delete',
@@ -111,17 +217,202 @@ export class SheetsService {
             },
         });
     }
+    /**
+     * Delete all rows matching a filter
+     * Deletes every row whose columns equal the given values, in a single batch
+     * @returns DeleteRowsResponse Rows deleted successfully
+     * @throws ApiError
+     */
+    public static deleteRowsWhere({
+        requestBody,
+    }: {
+        requestBody: DeleteRowsWhereRequest,
+    }): CancelablePromise<DeleteRowsResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/sheets/delete-where',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                404: `Resource not found`,
+                500: `Server error`,
+            },
+        });
+    }
+    /**
+     * Preview an import without writing
+     * Classifies each incoming row as an insert, update, or no-op against the current sheet, matched on a key column
+     * @returns PreviewImportResponse Import preview
+     * @throws ApiError
+     */
+    public static previewImport({
+        requestBody,
+    }: {
+        requestBody: PreviewImportRequest,
+    }): CancelablePromise<PreviewImportResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/sheets/preview-import',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                404: `Resource not found`,
+                500: `Server error`,
+            },
+        });
+    }
+    /**
+     * Find rows sharing a key
+     * Groups data rows by the value of `keyColumn` and returns every group with
+     * more than one row, along with their sheet row numbers, so duplicates left
+     * by an import can be cleaned up. Rows with an empty key are ignored.
+     * @returns FindDuplicatesResponse Duplicate groups
+     * @throws ApiError
+     */
+    public static findDuplicates({
+        requestBody,
+    }: {
+        requestBody: FindDuplicatesRequest,
+    }): CancelablePromise<FindDuplicatesResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/sheets/duplicates',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                404: `Resource not found`,
+                500: `Server error`,
+            },
+        });
+    }
+    /**
+     * Find rows matching filters
+     * Reads a sheet and returns only the rows matching every filter, with their
+     * sheet row numbers, optionally projected to a subset of columns. Saves
+     * clients from downloading a whole sheet to pick out a few rows.
+     * @returns FindRowsResponse Matching rows
+     * @throws ApiError
+     */
+    public static findRows({
+        requestBody,
+    }: {
+        requestBody: FindRowsRequest,
+    }): CancelablePromise<FindRowsResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/sheets/find',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                404: `Resource not found`,
+                500: `Server error`,
+            },
+        });
+    }
+    /**
+     * Aggregate a sheet along two sets of columns
+     * Groups data rows by the `rows` columns and the `cols` columns and
+     * aggregates `value` in each cell, e.g. amount by status × grant_year.
+     * Combinations with no matching rows are null.
+     * @returns PivotResponse Pivot table
+     * @throws ApiError
+     */
+    public static pivot({
+        requestBody,
+    }: {
+        requestBody: PivotRequest,
+    }): CancelablePromise<PivotResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/sheets/pivot',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                500: `Server error`,
+            },
+        });
+    }
+    /**
+     * Fit column widths to their contents
+     * Widens or narrows every column of a sheet to fit its values, e.g. after a bulk import
+     * @returns SuccessResponse Columns resized
+     * @throws ApiError
+     */
+    public static autoResizeColumns({
+        requestBody,
+    }: {
+        requestBody: AutoResizeRequest,
+    }): CancelablePromise<SuccessResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/sheets/auto-resize',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                404: `Resource not found`,
+                500: `Server error`,
+            },
+        });
+    }
+    /**
+     * Score how complete each record is
+     * Counts how many of the required columns each data row fills in and returns
+     * the rows least complete first. Required columns come from the request, then the
+     * `required_columns` Config tab entry, then the server's REQUIRED_COLUMNS.
+     * @returns CompletenessResponse Completeness per row
+     * @throws ApiError
+     */
+    public static completeness({
+        requestBody,
+    }: {
+        requestBody: CompletenessRequest,
+    }): CancelablePromise<CompletenessResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/sheets/completeness',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                500: `Server error`,
+            },
+        });
+    }
     /**
      * Batch update multiple cells
-     * Updates multiple cells in a single request
-     * @returns SuccessResponse Cells updated successfully
+     * Updates multiple cells. Large update sets are split into several Sheets
+     * BatchUpdate calls of at most BATCH_UPDATE_MAX_RANGES ranges each, sent in
+     * order. If a later call fails after earlier ones succeeded, the response is
+     * 207 and lists the ranges that were not written.
+     * @returns BatchUpdateResponse Cells updated successfully
+     * @returns BatchUpdateResponse Some batches were written before a later batch failed
      * @throws ApiError
      */
     public static batchUpdateCells({
         requestBody,
     }: {
         requestBody: BatchUpdateRequest,
-    }): CancelablePromise<SuccessResponse> {
+    }): CancelablePromise<BatchUpdateResponse> {
         return __request(OpenAPI, {
             method: 'POST',
             url: '/sheets/batch-update',
@@ -135,4 +426,104 @@ export class SheetsService {
             },
         });
     }
+    /**
+     * List recent audit events
+     * Returns the most recent audit events, newest first, optionally narrowed to one
+     * user and a time window. Events come from the AuditLog tab when the server runs
+     * with AUDIT_SINK=sheet; otherwise from this instance's in-memory history
+     * (AUDIT_HISTORY_SIZE events), which starts empty after a restart. Admins only,
+     * since it covers every user's activity; row values are never included.
+     * @returns AuditListResponse Recent events
+     * @throws ApiError
+     */
+    public static listAuditEvents({
+        requestBody,
+    }: {
+        requestBody?: AuditListRequest,
+    }): CancelablePromise<AuditListResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/audit/list',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                500: `Server error`,
+            },
+        });
+    }
+    /**
+     * Get a grant's recent history
+     * Returns the most recent audit entries for a grant, newest first. Entries
+     * match when their target is the grant ID or, at the verbose audit level,
+     * when the row payload's idColumn holds it. History is kept in memory per
+     * server instance (AUDIT_HISTORY_SIZE events), so it starts empty after a restart.
+     * @returns GrantHistoryResponse Recent entries
+     * @throws ApiError
+     */
+    public static grantHistory({
+        requestBody,
+    }: {
+        requestBody: GrantHistoryRequest,
+    }): CancelablePromise<GrantHistoryResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/grants/history',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+            },
+        });
+    }
+    /**
+     * Export a grant bundle
+     * Returns a grant's row together with a manifest of every file in its Drive folder
+     * @returns ExportGrantResponse Grant bundle
+     * @throws ApiError
+     */
+    public static exportGrant({
+        requestBody,
+    }: {
+        requestBody: ExportGrantRequest,
+    }): CancelablePromise<ExportGrantResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/grants/export',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                404: `Resource not found`,
+                500: `Server error`,
+            },
+        });
+    }
+    /**
+     * Download the spreadsheet as Excel
+     * Exports the spreadsheet, every tab included, through Drive's export API and
+     * streams it as an .xlsx attachment. Drive caps exports at 10MB; larger
+     * spreadsheets get 422 with code EXPORT_TOO_LARGE. When the server sets
+     * SENSITIVE_COLUMNS, only users allowed to read those columns may export.
+     * @returns Blob Excel workbook
+     * @throws ApiError
+     */
+    public static exportSpreadsheetXlsx(): CancelablePromise<Blob> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/sheets/export-xlsx',
+            errors: {
+                401: `Not authenticated`,
+                403: `Access denied (no permission to grants folder)`,
+                422: `Spreadsheet exceeds Drive's export size limit`,
+                500: `Server error`,
+            },
+        });
+    }
 }
@@ -3,6 +3,8 @@
  * Fetches runtime config from the server, with fallback for static hosting.
  */
 
+import { unwrapEnvelope } from '../api/envelope.js';
+
 let config = $state({
   clientId: null,
   loaded: false,
@@ -16,10 +18,38 @@ let config = $state({
   spreadsheetId: null,
   // Grants folder ID (only set when service account is enabled)
   grantsFolderId: null,
+  // Shared Drive ID (masked unless the server exposes it in full)
+  sharedDriveId: null,
+  // True when the server refuses deletes, moves, and ownership transfers
+  safeMode: false,
 });
 
 let loadPromise = null;
 
+// How many times to re-fetch config while the server is still discovering resources
+const MAX_DISCOVERY_RETRIES = 15;
+
+/**
+ * Fetch /api/config, waiting out server-side discovery.
+ * While discovery runs the server answers with discovering:true and a
+ * Retry-After header; back off for that long and ask again.
+ */
+async function fetchServerConfig() {
+  for (let attempt = 0; ; attempt++) {
+    const response = await fetch('/api/config');
+    if (!response.ok) {
+      return null;
+    }
+    const data = unwrapEnvelope(response, await response.json());
+    if (!data.discovering || attempt >= MAX_DISCOVERY_RETRIES) {
+      return data;
+    }
+    const retryAfter = parseInt(response.headers.get('Retry-After'), 10) || 2;
+    console.log(`Server is discovering resources, retrying config in ${retryAfter}s`);
+    await new Promise((resolve) => setTimeout(resolve, retryAfter * 1000));
+  }
+}
+
 /**
  * Load configuration from the server.
  * Falls back to VITE_GOOGLE_CLIENT_ID for static hosting.
@@ -36,14 +66,15 @@ export async function loadConfig() {
 
   loadPromise = (async () => {
     try {
-      const response = await fetch('/api/config');
-      if (response.ok) {
-        const data = await response.json();
+      const data = await fetchServerConfig();
+      if (data) {
         config.clientId = data.clientId;
         config.serverAuthAvailable = true;
         config.serviceAccountEnabled = data.serviceAccountEnabled || false;
         config.spreadsheetId = data.spreadsheetId || null;
         config.grantsFolderId = data.grantsFolderId || null;
+        config.sharedDriveId = data.sharedDriveId || null;
+        config.safeMode = data.safeMode || false;
         config.loaded = true;
         console.log(
           'Config loaded from server',
@@ -104,4 +135,10 @@ export const configStore = {
   get grantsFolderId() {
     return config.grantsFolderId;
   },
+  get sharedDriveId() {
+    return config.sharedDriveId;
+  },
+  get safeMode() {
+    return config.safeMode;
+  },
 };
@@ -17,7 +17,9 @@ import {
   hasExtendedScope as checkExtendedScope,
   requestExtendedAccess,
   revokeExtendedAccess,
+  REAUTH_REQUIRED,
 } from '../api/auth.js';
+import { onBackendTokenRefresh } from '../api/backend.js';
 import { configStore } from './config.svelte.js';
 
 // Reactive state
@@ -38,6 +40,12 @@ async function initialize() {
   isLoading = true;
   error = null;
 
+  // Reset the refresh timer whenever a backend call had to refresh our token
+  onBackendTokenRefresh((tokenData) => {
+    handleTokenRefresh(tokenData);
+    scheduleTokenRefresh(tokenData.expires_in, handleTokenRefresh, handleTokenRefreshError);
+  });
+
   try {
     const authState = await initializeAuth();
 
@@ -175,6 +183,11 @@ function handleTokenRefreshError(err) {
   // Clear state to trigger sign-in
   user = null;
   accessToken = null;
+
+  // The server already cleared the session cookies; go straight to login
+  if (err.code === REAUTH_REQUIRED) {
+    authSignIn();
+  }
 }
 
 /**